@@ -4,6 +4,9 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
 
 	"github.com/vostronet/lego/acme"
 )
@@ -12,6 +15,11 @@ type AccountService service
 
 // New Creates a new account.
 func (a *AccountService) New(req acme.Account) (acme.ExtendedAccount, error) {
+	err := validateContacts("new", req.Contact)
+	if err != nil {
+		return acme.ExtendedAccount{}, err
+	}
+
 	var account acme.Account
 	resp, err := a.core.post(a.core.GetDirectory().NewAccountURL, req, &account)
 	location := getLocation(resp)
@@ -27,6 +35,12 @@ func (a *AccountService) New(req acme.Account) (acme.ExtendedAccount, error) {
 	return acme.ExtendedAccount{Account: account, Location: location}, nil
 }
 
+// Lookup Looks up the existing account of the account key, without creating a new account ("onlyReturnExisting").
+// The CA returns an accountDoesNotExist error if no account is registered with the account key.
+func (a *AccountService) Lookup() (acme.ExtendedAccount, error) {
+	return a.New(acme.Account{OnlyReturnExisting: true})
+}
+
 // NewEAB Creates a new account with an External Account Binding.
 func (a *AccountService) NewEAB(accMsg acme.Account, kid string, hmacEncoded string) (acme.ExtendedAccount, error) {
 	hmac, err := base64.RawURLEncoding.DecodeString(hmacEncoded)
@@ -43,20 +57,80 @@ func (a *AccountService) NewEAB(accMsg acme.Account, kid string, hmacEncoded str
 	return a.New(accMsg)
 }
 
-// Get Retrieves an account.
+// Get Retrieves an account (status, contacts, orders URL).
 func (a *AccountService) Get(accountURL string) (acme.Account, error) {
 	if len(accountURL) == 0 {
 		return acme.Account{}, errors.New("account[get]: empty URL")
 	}
 
 	var account acme.Account
-	_, err := a.core.post(accountURL, acme.Account{}, &account)
+	_, err := a.core.postAsGet(accountURL, &account)
 	if err != nil {
 		return acme.Account{}, err
 	}
 	return account, nil
 }
 
+// ListOrders Retrieves the URLs of the orders of an account, by following the orders URL of the account (RFC 8555 section 7.1.2.1).
+// The list is paginated by the CA with Link "next" headers.
+// Many CAs don't implement the orders list: an error is returned if the account has no orders URL.
+func (a *AccountService) ListOrders(accountURL string) ([]string, error) {
+	account, err := a.Get(accountURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Orders == "" {
+		return nil, errors.New("account[orders]: unsupported by the CA: the account has no orders URL")
+	}
+
+	orders := []string{}
+	visited := map[string]bool{}
+
+	for page := account.Orders; page != "" && !visited[page]; {
+		visited[page] = true
+
+		var list ordersList
+		resp, err := a.core.postAsGet(page, &list)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, list.Orders...)
+
+		page, err = resolveLink(page, getLink(resp.Header, "next"))
+		if err != nil {
+			return nil, fmt.Errorf("account[orders]: invalid next page: %v", err)
+		}
+	}
+
+	return orders, nil
+}
+
+// ordersList a page of the orders list of an account.
+type ordersList struct {
+	Orders []string `json:"orders"`
+}
+
+// resolveLink resolves a (possibly relative) link against the URL of the current page.
+func resolveLink(base, link string) (string, error) {
+	if link == "" {
+		return "", nil
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+
+	linkURL, err := baseURL.Parse(link)
+	if err != nil {
+		return "", err
+	}
+
+	return linkURL.String(), nil
+}
+
 // Deactivate Deactivates an account.
 func (a *AccountService) Deactivate(accountURL string) error {
 	if len(accountURL) == 0 {
@@ -67,3 +141,96 @@ func (a *AccountService) Deactivate(accountURL string) error {
 	_, err := a.core.post(accountURL, req, nil)
 	return err
 }
+
+// Update Updates the contacts of an account (RFC 8555 section 7.3.2).
+// An empty list removes all the contacts of the account.
+func (a *AccountService) Update(accountURL string, contacts []string) (acme.Account, error) {
+	if len(accountURL) == 0 {
+		return acme.Account{}, errors.New("account[update]: empty URL")
+	}
+
+	err := validateContacts("update", contacts)
+	if err != nil {
+		return acme.Account{}, err
+	}
+
+	if contacts == nil {
+		contacts = []string{}
+	}
+
+	var account acme.Account
+	_, err = a.core.post(accountURL, accountUpdate{Contact: contacts}, &account)
+	if err != nil {
+		return acme.Account{}, err
+	}
+
+	return account, nil
+}
+
+// accountUpdate the payload of an account update:
+// unlike acme.Account, an empty list of contacts is sent (to remove all the contacts).
+type accountUpdate struct {
+	Contact []string `json:"contact"`
+}
+
+// validateContacts checks the format of the contacts of an account before sending them to the CA.
+// Only the clearly malformed entries are rejected:
+// the CA remains responsible for the schemes it supports (mailto, tel, ...).
+func validateContacts(operation string, contacts []string) error {
+	for _, contact := range contacts {
+		err := validateContact(contact)
+		if err != nil {
+			return fmt.Errorf("account[%s]: invalid contact %q: %v", operation, contact, err)
+		}
+	}
+
+	return nil
+}
+
+func validateContact(contact string) error {
+	u, err := url.Parse(contact)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme == "" {
+		return errors.New("missing scheme (e.g. mailto:)")
+	}
+
+	if u.Opaque == "" && u.Host == "" && u.Path == "" {
+		return errors.New("empty value")
+	}
+
+	if !strings.EqualFold(u.Scheme, "mailto") {
+		return nil
+	}
+
+	// RFC 8555 (section 7.3): the mailto URIs must not contain "hfields" (i.e. a query), nor more than one address.
+	if u.RawQuery != "" || u.ForceQuery {
+		return errors.New("mailto contact must not contain header fields")
+	}
+
+	addr, err := url.PathUnescape(u.Opaque)
+	if err != nil {
+		return err
+	}
+
+	if addr == "" {
+		return errors.New("empty email address")
+	}
+
+	if strings.Contains(addr, ",") {
+		return errors.New("mailto contact must contain only one email address")
+	}
+
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil {
+		return err
+	}
+
+	if parsed.Address != addr {
+		return errors.New("invalid email address")
+	}
+
+	return nil
+}
@@ -0,0 +1,388 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountService_Get(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, r *http.Request) {
+		body, errR := readSignedBody(r, privateKey)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// POST-as-GET: empty payload.
+		if len(body) != 0 {
+			http.Error(w, "the payload must be empty", http.StatusBadRequest)
+			return
+		}
+
+		errR = tester.WriteJSONResponse(w, acme.Account{
+			Status:  acme.StatusValid,
+			Contact: []string{"mailto:foo@example.com"},
+			Orders:  apiURL + "/account/1/orders",
+		})
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", privateKey)
+	require.NoError(t, err)
+
+	account, err := core.Accounts.Get(apiURL + "/account/1")
+	require.NoError(t, err)
+
+	expected := acme.Account{
+		Status:  acme.StatusValid,
+		Contact: []string{"mailto:foo@example.com"},
+		Orders:  apiURL + "/account/1/orders",
+	}
+	assert.Equal(t, expected, account)
+}
+
+func TestAccountService_ListOrders(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, r *http.Request) {
+		_, errR := readSignedBody(r, privateKey)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		errR = tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusValid, Orders: apiURL + "/account/1/orders"})
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/account/1/orders", func(w http.ResponseWriter, r *http.Request) {
+		body, errR := readSignedBody(r, privateKey)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// POST-as-GET: empty payload.
+		if len(body) != 0 {
+			http.Error(w, "the payload must be empty", http.StatusBadRequest)
+			return
+		}
+
+		var orders []string
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			// relative link to the next page.
+			w.Header().Add("Link", `</account/1/orders?cursor=2>;rel="next"`)
+			orders = []string{apiURL + "/order/1", apiURL + "/order/2"}
+		case "2":
+			orders = []string{apiURL + "/order/3"}
+		default:
+			http.Error(w, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+
+		errR = tester.WriteJSONResponse(w, map[string][]string{"orders": orders})
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", privateKey)
+	require.NoError(t, err)
+
+	orders, err := core.Accounts.ListOrders(apiURL + "/account/1")
+	require.NoError(t, err)
+
+	expected := []string{apiURL + "/order/1", apiURL + "/order/2", apiURL + "/order/3"}
+	assert.Equal(t, expected, orders)
+}
+
+func TestAccountService_ListOrders_unsupported(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, r *http.Request) {
+		_, errR := readSignedBody(r, privateKey)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		errR = tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusValid})
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", privateKey)
+	require.NoError(t, err)
+
+	_, err = core.Accounts.ListOrders(apiURL + "/account/1")
+	require.EqualError(t, err, "account[orders]: unsupported by the CA: the account has no orders URL")
+}
+
+func TestAccountService_Lookup(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	var request acme.Account
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		body, errR := readSignedBody(r, privateKey)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		errR = json.Unmarshal(body, &request)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Location", apiURL+"/account/1")
+		errR = tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusValid})
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	account, err := core.Accounts.Lookup()
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.Account{OnlyReturnExisting: true}, request)
+	assert.Equal(t, apiURL+"/account/1", account.Location)
+	assert.Equal(t, acme.StatusValid, account.Status)
+}
+
+func TestAccountService_Deactivate(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	var request acme.Account
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, r *http.Request) {
+		body, errR := readSignedBody(r, privateKey)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		errR = json.Unmarshal(body, &request)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		errR = tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusDeactivated})
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", privateKey)
+	require.NoError(t, err)
+
+	err = core.Accounts.Deactivate(apiURL + "/account/1")
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.Account{Status: acme.StatusDeactivated}, request)
+}
+
+func TestAccountService_Update(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		contacts []string
+		expected string
+	}{
+		{
+			desc:     "new contacts",
+			contacts: []string{"mailto:foo@example.com", "mailto:bar@example.com"},
+			expected: `{"contact":["mailto:foo@example.com","mailto:bar@example.com"]}`,
+		},
+		{
+			desc:     "remove all the contacts",
+			expected: `{"contact":[]}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+			require.NoError(t, err)
+
+			var request string
+			mux.HandleFunc("/account/1", func(w http.ResponseWriter, r *http.Request) {
+				body, errR := readSignedBody(r, privateKey)
+				if errR != nil {
+					http.Error(w, errR.Error(), http.StatusBadRequest)
+					return
+				}
+
+				request = string(body)
+
+				errR = tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusValid, Contact: test.contacts})
+				if errR != nil {
+					http.Error(w, errR.Error(), http.StatusInternalServerError)
+				}
+			})
+
+			core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", privateKey)
+			require.NoError(t, err)
+
+			account, err := core.Accounts.Update(apiURL+"/account/1", test.contacts)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, test.expected, request)
+			assert.Equal(t, acme.Account{Status: acme.StatusValid, Contact: test.contacts}, account)
+		})
+	}
+}
+
+func TestAccountService_Update_invalidContact(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "the request must not be sent", http.StatusBadRequest)
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", privateKey)
+	require.NoError(t, err)
+
+	_, err = core.Accounts.Update(apiURL+"/account/1", []string{"foo@example.com"})
+	require.EqualError(t, err, `account[update]: invalid contact "foo@example.com": missing scheme (e.g. mailto:)`)
+}
+
+func TestAccountService_New_invalidContact(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "the request must not be sent", http.StatusBadRequest)
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	_, err = core.Accounts.New(acme.Account{TermsOfServiceAgreed: true, Contact: []string{"mailto:foo"}})
+	require.EqualError(t, err, `account[new]: invalid contact "mailto:foo": mail: missing '@' or angle-addr`)
+}
+
+func Test_validateContacts(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		contacts []string
+		expected string
+	}{
+		{
+			desc: "no contact",
+		},
+		{
+			desc:     "mailto",
+			contacts: []string{"mailto:foo@example.com"},
+		},
+		{
+			desc:     "escaped mailto",
+			contacts: []string{"mailto:foo%2Bbar@example.com"},
+		},
+		{
+			desc:     "tel",
+			contacts: []string{"mailto:foo@example.com", "tel:+12025550123"},
+		},
+		{
+			desc:     "other scheme",
+			contacts: []string{"https://example.com/contact"},
+		},
+		{
+			desc:     "missing scheme",
+			contacts: []string{"foo@example.com"},
+			expected: `account[new]: invalid contact "foo@example.com": missing scheme (e.g. mailto:)`,
+		},
+		{
+			desc:     "empty value",
+			contacts: []string{"tel:"},
+			expected: `account[new]: invalid contact "tel:": empty value`,
+		},
+		{
+			desc:     "invalid escape",
+			contacts: []string{"mailto:foo@example.com", "mailto:foo%zz@example.com"},
+			expected: `account[new]: invalid contact "mailto:foo%zz@example.com": invalid URL escape "%zz"`,
+		},
+		{
+			desc:     "mailto with header fields",
+			contacts: []string{"mailto:foo@example.com?subject=lego"},
+			expected: `account[new]: invalid contact "mailto:foo@example.com?subject=lego": mailto contact must not contain header fields`,
+		},
+		{
+			desc:     "mailto with several addresses",
+			contacts: []string{"mailto:foo@example.com,bar@example.com"},
+			expected: `account[new]: invalid contact "mailto:foo@example.com,bar@example.com": mailto contact must contain only one email address`,
+		},
+		{
+			desc:     "mailto with a display name",
+			contacts: []string{"mailto:Foo <foo@example.com>"},
+			expected: `account[new]: invalid contact "mailto:Foo <foo@example.com>": invalid email address`,
+		},
+		{
+			desc:     "mailto without address",
+			contacts: []string{"mailto:"},
+			expected: `account[new]: invalid contact "mailto:": empty value`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateContacts("new", test.contacts)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/vostronet/lego/acme/api/internal/secure"
 	"github.com/vostronet/lego/acme/api/internal/sender"
 	"github.com/vostronet/lego/log"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 // Core ACME/LE core API.
@@ -24,7 +25,11 @@ type Core struct {
 	nonceManager *nonces.Manager
 	jws          *secure.JWS
 	directory    acme.Directory
+	// directoryURL the URL of the directory, also used to get the nonces if the directory has no newNonce URL.
+	directoryURL string
 	HTTPClient   *http.Client
+	// certificateAccept the Accept header of the certificate downloads (optional).
+	certificateAccept string
 
 	common         service // Reuse a single struct instead of allocating one for each service on the heap.
 	Accounts       *AccountService
@@ -34,20 +39,151 @@ type Core struct {
 	Orders         *OrderService
 }
 
+// Option an option of the Core.
+type Option func(opts *options)
+
+// options the options of the Core.
+type options struct {
+	doer              *sender.Doer
+	nonceStore        acme.NonceStore
+	certificateAccept string
+	jwsAlgorithm      string
+}
+
+// WithHeaders adds static headers to all the requests sent to the ACME server,
+// including the directory, nonce and account requests (e.g. a request ID for tracing through a proxy).
+func WithHeaders(headers http.Header) Option {
+	return func(opts *options) {
+		opts.doer.SetHeaders(headers)
+	}
+}
+
+// WithMetrics sets the receiver of the events of the client (requests and nonce errors).
+func WithMetrics(metrics acme.Metrics) Option {
+	return func(opts *options) {
+		opts.doer.SetMetrics(metrics)
+	}
+}
+
+// WithNonceStore sets an external store of the nonces, to reuse the nonces between short-lived processes.
+// A nonce rejected by the server (e.g. already used) is handled as any bad nonce: the request is retried with a new nonce.
+func WithNonceStore(store acme.NonceStore) Option {
+	return func(opts *options) {
+		opts.nonceStore = store
+	}
+}
+
+// WithCertificateAccept sets the Accept header of the certificate downloads
+// (e.g. "application/pkcs7-mime" for the CAs returning the chain in PKCS#7 by default).
+// The certificates are always returned in PEM: a PKCS#7 chain is converted.
+func WithCertificateAccept(mediaType string) Option {
+	return func(opts *options) {
+		opts.certificateAccept = mediaType
+	}
+}
+
+// WithJWSAlgorithm sets the algorithm of the signatures of the requests (e.g. "PS256" instead of "RS256" for an RSA key),
+// for the CAs accepting only specific algorithms.
+// The algorithm must be compatible with the account key: by default, it's derived from the type of the key.
+func WithJWSAlgorithm(alg string) Option {
+	return func(opts *options) {
+		opts.jwsAlgorithm = alg
+	}
+}
+
+// WithRetryTimeout sets the maximum duration of the retries of the directory and nonce requests
+// on the network and server errors (20 seconds by default), a timeout <= 0 disables the retries.
+func WithRetryTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.doer.SetRetryTimeout(timeout)
+	}
+}
+
+func newOptions(httpClient *http.Client, userAgent string, opts []Option) *options {
+	o := &options{doer: sender.NewDoer(httpClient, userAgent)}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
 // New Creates a new Core.
-func New(httpClient *http.Client, userAgent string, caDirURL, kid string, privateKey crypto.PrivateKey) (*Core, error) {
-	doer := sender.NewDoer(httpClient, userAgent)
+// The private key of the account can be a crypto.Signer (e.g. a key stored in a HSM or a KMS): the private material is never accessed.
+func New(httpClient *http.Client, userAgent string, caDirURL, kid string, privateKey crypto.PrivateKey, opts ...Option) (*Core, error) {
+	o := newOptions(httpClient, userAgent, opts)
+
+	dir, resp, err := getDirectory(o.doer, caDirURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := newCore(o, httpClient, dir, caDirURL, kid, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if dir.NewNonceURL == "" {
+		if nonce, errN := nonces.GetFromResponse(resp); errN == nil {
+			c.nonceManager.Push(nonce)
+		}
+	}
+
+	return c, nil
+}
+
+// State the reusable state of a Core: it allows to persist the directory,
+// the account URL and the unused nonces between short-lived processes.
+type State struct {
+	Directory acme.Directory
+	// DirectoryURL the URL of the directory, used to get the nonces if the directory has no newNonce URL.
+	DirectoryURL string
+	KID          string
+	Nonces       []string
+}
+
+// NewWithState Creates a new Core from the state of another Core: the directory is not fetched.
+func NewWithState(httpClient *http.Client, userAgent string, state State, privateKey crypto.PrivateKey, opts ...Option) (*Core, error) {
+	if state.Directory.NewAccountURL == "" {
+		return nil, errors.New("invalid state: the directory is incomplete")
+	}
 
-	dir, err := getDirectory(doer, caDirURL)
+	if state.Directory.NewNonceURL == "" && state.DirectoryURL == "" {
+		return nil, errors.New("invalid state: the directory has no newNonce URL and the directory URL is missing")
+	}
+
+	c, err := newCore(newOptions(httpClient, userAgent, opts), httpClient, state.Directory, state.DirectoryURL, state.KID, privateKey)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, nonce := range state.Nonces {
+		c.nonceManager.Push(nonce)
+	}
+
+	return c, nil
+}
+
+func newCore(o *options, httpClient *http.Client, dir acme.Directory, dirURL, kid string, privateKey crypto.PrivateKey) (*Core, error) {
+	doer := o.doer
+
 	nonceManager := nonces.NewManager(doer, dir.NewNonceURL)
+	if o.nonceStore != nil {
+		nonceManager.SetStore(o.nonceStore)
+	}
+
+	if dir.NewNonceURL == "" {
+		// without newNonce URL, the nonces are taken from the responses of the server (e.g. the directory).
+		nonceManager.SetFallbackURL(dirURL)
+	}
 
-	jws := secure.NewJWS(privateKey, kid, nonceManager)
+	jws, err := secure.NewJWSWithAlgorithm(privateKey, kid, nonceManager, o.jwsAlgorithm)
+	if err != nil {
+		return nil, err
+	}
 
-	c := &Core{doer: doer, nonceManager: nonceManager, jws: jws, directory: dir, HTTPClient: httpClient}
+	c := &Core{doer: doer, nonceManager: nonceManager, jws: jws, directory: dir, directoryURL: dirURL, HTTPClient: httpClient, certificateAccept: o.certificateAccept}
 
 	c.common.core = c
 	c.Accounts = (*AccountService)(&c.common)
@@ -59,6 +195,21 @@ func New(httpClient *http.Client, userAgent string, caDirURL, kid string, privat
 	return c, nil
 }
 
+// JWSAlgorithm returns the algorithm of the signatures of the requests.
+func (a *Core) JWSAlgorithm() string {
+	return a.jws.Algorithm()
+}
+
+// State returns the current state of the Core.
+func (a *Core) State() State {
+	return State{
+		Directory:    a.directory,
+		DirectoryURL: a.directoryURL,
+		KID:          a.jws.GetKid(),
+		Nonces:       a.nonceManager.Nonces(),
+	}
+}
+
 // post performs an HTTP POST request and parses the response body as JSON,
 // into the provided respBody object.
 func (a *Core) post(uri string, reqBody, response interface{}) (*http.Response, error) {
@@ -72,11 +223,17 @@ func (a *Core) post(uri string, reqBody, response interface{}) (*http.Response,
 
 // postAsGet performs an HTTP POST ("POST-as-GET") request.
 // https://tools.ietf.org/html/draft-ietf-acme-acme-16#section-6.3
-func (a *Core) postAsGet(uri string, response interface{}) (*http.Response, error) {
-	return a.retrievablePost(uri, []byte{}, response)
+func (a *Core) postAsGet(uri string, response interface{}, opts ...sender.RequestOption) (*http.Response, error) {
+	return a.retrievablePost(uri, []byte{}, response, opts...)
+}
+
+func (a *Core) retrievablePost(uri string, content []byte, response interface{}, opts ...sender.RequestOption) (*http.Response, error) {
+	return a.retrievablePostWithJWS(a.jws, uri, content, response, opts...)
 }
 
-func (a *Core) retrievablePost(uri string, content []byte, response interface{}) (*http.Response, error) {
+// retrievablePostWithJWS performs a signed HTTP POST request with a specific JWS (e.g. the key of a certificate),
+// and retries on the nonce errors.
+func (a *Core) retrievablePostWithJWS(jws *secure.JWS, uri string, content []byte, response interface{}, opts ...sender.RequestOption) (*http.Response, error) {
 	// during tests, allow to support ~90% of bad nonce with a minimum of attempts.
 	bo := backoff.NewExponentialBackOff()
 	bo.InitialInterval = 200 * time.Millisecond
@@ -86,14 +243,19 @@ func (a *Core) retrievablePost(uri string, content []byte, response interface{})
 	ctx, cancel := context.WithCancel(context.Background())
 
 	var resp *http.Response
+	var attempt int
 	operation := func() error {
+		attempt++
+
 		var err error
-		resp, err = a.signedPost(uri, content, response)
+		resp, err = a.signedPost(jws, uri, content, response, opts...)
 		if err != nil {
-			switch err.(type) {
+			switch e := err.(type) {
 			// Retry if the nonce was invalidated
 			case *acme.NonceError:
-				log.Infof("nonce error retry: %s", err)
+				log.Infow("acme: nonce error, retrying",
+					log.F("uri", uri), log.F("attempt", attempt), log.F("nonce", e.Nonce), log.F("error", e.ProblemDetails))
+				a.doer.Metrics().OnNonceError()
 				return err
 			default:
 				cancel()
@@ -112,15 +274,18 @@ func (a *Core) retrievablePost(uri string, content []byte, response interface{})
 	return resp, nil
 }
 
-func (a *Core) signedPost(uri string, content []byte, response interface{}) (*http.Response, error) {
-	signedContent, err := a.jws.SignContent(uri, content)
+func (a *Core) signedPost(jws *secure.JWS, uri string, content []byte, response interface{}, opts ...sender.RequestOption) (*http.Response, error) {
+	signedContent, err := jws.SignContent(uri, content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to post JWS message -> failed to sign content -> %v", err)
 	}
 
 	signedBody := bytes.NewBuffer([]byte(signedContent.FullSerialize()))
 
-	resp, err := a.doer.Post(uri, signedBody, "application/jose+json", response)
+	resp, err := a.doer.Post(uri, signedBody, "application/jose+json", response, opts...)
+	if nonceError, ok := err.(*acme.NonceError); ok {
+		nonceError.Nonce = getNonce(signedContent)
+	}
 
 	// nonceErr is ignored to keep the root error.
 	nonce, nonceErr := nonces.GetFromResponse(resp)
@@ -131,6 +296,15 @@ func (a *Core) signedPost(uri string, content []byte, response interface{}) (*ht
 	return resp, err
 }
 
+// getNonce returns the nonce of the protected header of a JWS.
+func getNonce(signed *jose.JSONWebSignature) string {
+	if len(signed.Signatures) == 0 {
+		return ""
+	}
+
+	return signed.Signatures[0].Protected.Nonce
+}
+
 func (a *Core) signEABContent(newAccountURL, kid string, hmac []byte) ([]byte, error) {
 	eabJWS, err := a.jws.SignEABContent(newAccountURL, kid, hmac)
 	if err != nil {
@@ -140,6 +314,17 @@ func (a *Core) signEABContent(newAccountURL, kid string, hmac []byte) ([]byte, e
 	return []byte(eabJWS.FullSerialize()), nil
 }
 
+// Metrics returns the receiver of the events of the client.
+func (a *Core) Metrics() acme.Metrics {
+	return a.doer.Metrics()
+}
+
+// RateLimit returns the quota advertised by the rate limit headers of the last response of the CA which contained them
+// (nil if the CA doesn't send rate limit headers).
+func (a *Core) RateLimit() *acme.RateLimit {
+	return a.doer.RateLimit()
+}
+
 // GetKeyAuthorization Gets the key authorization
 func (a *Core) GetKeyAuthorization(token string) (string, error) {
 	return a.jws.GetKeyAuthorization(token)
@@ -149,18 +334,26 @@ func (a *Core) GetDirectory() acme.Directory {
 	return a.directory
 }
 
-func getDirectory(do *sender.Doer, caDirURL string) (acme.Directory, error) {
+// GetMeta returns the metadata of the directory (terms of service, website, CAA identities, EAB requirement).
+func (a *Core) GetMeta() acme.Meta {
+	return a.directory.Meta
+}
+
+func getDirectory(do *sender.Doer, caDirURL string) (acme.Directory, *http.Response, error) {
 	var dir acme.Directory
-	if _, err := do.Get(caDirURL, &dir); err != nil {
-		return dir, fmt.Errorf("get directory at '%s': %v", caDirURL, err)
+	resp, err := do.Retry(func() (*http.Response, error) {
+		return do.Get(caDirURL, &dir)
+	})
+	if err != nil {
+		return dir, nil, fmt.Errorf("get directory at '%s': %v", caDirURL, err)
 	}
 
 	if dir.NewAccountURL == "" {
-		return dir, errors.New("directory missing new registration URL")
+		return dir, nil, errors.New("directory missing new registration URL")
 	}
 	if dir.NewOrderURL == "" {
-		return dir, errors.New("directory missing new order URL")
+		return dir, nil, errors.New("directory missing new order URL")
 	}
 
-	return dir, nil
+	return dir, resp, nil
 }
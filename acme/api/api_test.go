@@ -0,0 +1,433 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/log"
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+type structuredLoggerMock struct {
+	entries []map[string]interface{}
+}
+
+func (l *structuredLoggerMock) Info(msg string, fields ...log.Field) {
+	entry := map[string]interface{}{"msg": msg}
+	for _, field := range fields {
+		entry[field.Key] = field.Value
+	}
+	l.entries = append(l.entries, entry)
+}
+
+func (l *structuredLoggerMock) Warn(msg string, fields ...log.Field) {
+	l.Info(msg, fields...)
+}
+
+type metricsMock struct {
+	acme.NoopMetrics
+	requests    int
+	nonceErrors int
+}
+
+func (m *metricsMock) OnRequest(string, string, int, time.Duration) {
+	m.requests++
+}
+
+func (m *metricsMock) OnNonceError() {
+	m.nonceErrors++
+}
+
+func TestCore_retrievablePost_nonceRetry(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	var calls int
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if calls == 1 {
+			w.Header().Set("Replay-Nonce", "67890")
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, `{"type":%q,"detail":"JWS has an invalid anti-replay nonce","status":400}`, acme.BadNonceErr)
+			return
+		}
+
+		err := tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	logger := &structuredLoggerMock{}
+	log.SetStructuredLogger(logger)
+	defer log.SetStructuredLogger(nil)
+
+	metrics := &metricsMock{}
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey, WithMetrics(metrics))
+	require.NoError(t, err)
+
+	_, err = core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, metrics.nonceErrors)
+	// directory, nonce and 2 newOrder requests.
+	assert.Equal(t, 4, metrics.requests)
+	require.Len(t, logger.entries, 1)
+
+	entry := logger.entries[0]
+	assert.Equal(t, "acme: nonce error, retrying", entry["msg"])
+	assert.Equal(t, apiURL+"/newOrder", entry["uri"])
+	assert.Equal(t, 1, entry["attempt"])
+	assert.Equal(t, "12345", entry["nonce"])
+}
+
+type nonceStoreMock struct {
+	nonces []string
+}
+
+func (s *nonceStoreMock) Get() (string, error) {
+	if len(s.nonces) == 0 {
+		return "", nil
+	}
+
+	nonce := s.nonces[0]
+	s.nonces = s.nonces[1:]
+
+	return nonce, nil
+}
+
+func (s *nonceStoreMock) Put(nonce string) error {
+	s.nonces = append(s.nonces, nonce)
+	return nil
+}
+
+func TestNew_withNonceStore(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	var nonces []string
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+		body, errR := ioutil.ReadAll(r.Body)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		jws, errR := jose.ParseSigned(string(body))
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		nonce := jws.Signatures[0].Protected.Nonce
+		nonces = append(nonces, nonce)
+
+		// the stored nonce is rejected (e.g. already used by another process).
+		if nonce == "stored" {
+			w.Header().Set("Replay-Nonce", "67890")
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, `{"type":%q,"detail":"JWS has an invalid anti-replay nonce","status":400}`, acme.BadNonceErr)
+			return
+		}
+
+		w.Header().Set("Replay-Nonce", "next")
+		errR = tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending})
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	store := &nonceStoreMock{nonces: []string{"stored"}}
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey, WithNonceStore(store))
+	require.NoError(t, err)
+
+	_, err = core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"stored", "67890"}, nonces)
+	assert.Equal(t, []string{"next"}, store.nonces)
+}
+
+func TestNew_withJWSAlgorithm(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	// the PSS signatures with SHA-256 require a key larger than 512 bits.
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	var algs []string
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+		body, errR := ioutil.ReadAll(r.Body)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		jws, errR := jose.ParseSigned(string(body))
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		algs = append(algs, jws.Signatures[0].Protected.Algorithm)
+
+		w.Header().Set("Replay-Nonce", "67890")
+		errR = tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending})
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey, WithJWSAlgorithm("PS256"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "PS256", core.JWSAlgorithm())
+
+	_, err = core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"PS256"}, algs)
+}
+
+func TestNew_withJWSAlgorithm_incompatible(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	_, err = New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey, WithJWSAlgorithm("ES256"))
+	require.EqualError(t, err, `the signing algorithm "ES256" is not compatible with the key (*rsa.PrivateKey)`)
+}
+
+func TestNewWithState(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Replay-Nonce", "67890")
+
+		err := tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	state := State{
+		Directory: acme.Directory{
+			NewNonceURL:   apiURL + "/nonce",
+			NewAccountURL: apiURL + "/account",
+			NewOrderURL:   apiURL + "/newOrder",
+		},
+		KID:    apiURL + "/account/1",
+		Nonces: []string{"persisted"},
+	}
+
+	core, err := NewWithState(http.DefaultClient, "lego-test", state, privateKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, state, core.State())
+
+	_, err = core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	expected := state
+	expected.Nonces = []string{"67890"}
+	assert.Equal(t, expected, core.State())
+}
+
+func TestNewWithState_invalid(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	_, err = NewWithState(http.DefaultClient, "lego-test", State{}, privateKey)
+	require.EqualError(t, err, "invalid state: the directory is incomplete")
+}
+
+func TestNew_withoutNewNonceURL(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var dirCalls int
+	mux.HandleFunc("/dir", func(w http.ResponseWriter, _ *http.Request) {
+		dirCalls++
+		w.Header().Set("Replay-Nonce", fmt.Sprintf("dir-%d", dirCalls))
+
+		err := tester.WriteJSONResponse(w, acme.Directory{
+			NewAccountURL: server.URL + "/account",
+			NewOrderURL:   server.URL + "/newOrder",
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	var nonces []string
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+		reqBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		jws, err := jose.ParseSigned(string(reqBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		nonces = append(nonces, jws.Signatures[0].Protected.Nonce)
+
+		err = tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := New(http.DefaultClient, "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	_, err = core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	// the response of the order has no nonce: a nonce is taken from the directory.
+	_, err = core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"dir-1", "dir-2"}, nonces)
+	assert.Equal(t, 2, dirCalls)
+}
+
+func TestNewWithState_withoutNewNonceURL(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var dirCalls int
+	mux.HandleFunc("/dir", func(w http.ResponseWriter, _ *http.Request) {
+		dirCalls++
+		w.Header().Set("Replay-Nonce", fmt.Sprintf("dir-%d", dirCalls))
+	})
+
+	var nonces []string
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+		reqBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		jws, err := jose.ParseSigned(string(reqBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		nonces = append(nonces, jws.Signatures[0].Protected.Nonce)
+
+		err = tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	state := State{
+		Directory: acme.Directory{
+			NewAccountURL: server.URL + "/account",
+			NewOrderURL:   server.URL + "/newOrder",
+		},
+		Nonces: []string{"persisted"},
+	}
+
+	_, err = NewWithState(http.DefaultClient, "lego-test", state, privateKey)
+	require.EqualError(t, err, "invalid state: the directory has no newNonce URL and the directory URL is missing")
+
+	state.DirectoryURL = server.URL + "/dir"
+
+	core, err := NewWithState(http.DefaultClient, "lego-test", state, privateKey)
+	require.NoError(t, err)
+
+	_, err = core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	// the persisted nonces are used up: a nonce is taken from the directory.
+	_, err = core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"persisted", "dir-1"}, nonces)
+	assert.Equal(t, 1, dirCalls)
+}
+
+func TestCore_GetMeta(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/dir", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintf(w, `{
+  "newNonce": "%[1]s/nonce",
+  "newAccount": "%[1]s/account",
+  "newOrder": "%[1]s/newOrder",
+  "meta": {
+    "termsOfService": "https://example.com/tos.pdf",
+    "website": "https://example.com",
+    "caaIdentities": ["example.com", "example.net"],
+    "externalAccountRequired": true
+  }
+}`, server.URL)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/nonce", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Replay-Nonce", "12345")
+	})
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := New(http.DefaultClient, "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	expected := acme.Meta{
+		TermsOfService:          "https://example.com/tos.pdf",
+		Website:                 "https://example.com",
+		CaaIdentities:           []string{"example.com", "example.net"},
+		ExternalAccountRequired: true,
+	}
+	assert.Equal(t, expected, core.GetMeta())
+}
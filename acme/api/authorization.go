@@ -19,6 +19,9 @@ func (c *AuthorizationService) Get(authzURL string) (acme.Authorization, error)
 	if err != nil {
 		return acme.Authorization{}, err
 	}
+
+	authz.URL = authzURL
+
 	return authz, nil
 }
 
@@ -1,13 +1,19 @@
 package api
 
 import (
+	"crypto"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 
 	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/acme/api/internal/secure"
+	"github.com/vostronet/lego/acme/api/internal/sender"
 	"github.com/vostronet/lego/certcrypto"
 	"github.com/vostronet/lego/log"
 )
@@ -15,12 +21,88 @@ import (
 // maxBodySize is the maximum size of body that we will read.
 const maxBodySize = 1024 * 1024
 
+// The media types of the certificate chains.
+// https://tools.ietf.org/html/rfc8555#section-7.4.2
+const (
+	// MediaTypePEMCertificateChain the PEM certificate chain (default).
+	MediaTypePEMCertificateChain = "application/pem-certificate-chain"
+	// MediaTypePKCS7 a PKCS#7 certificate chain.
+	MediaTypePKCS7 = "application/pkcs7-mime"
+)
+
 type CertificateService service
 
 // Get Returns the certificate and the issuer certificate.
 // 'bundle' is only applied if the issuer is provided by the 'up' link.
 func (c *CertificateService) Get(certURL string, bundle bool) ([]byte, []byte, error) {
-	cert, up, err := c.get(certURL)
+	cert, _, err := c.get(certURL, bundle)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cert.Cert, cert.Issuer, nil
+}
+
+// GetStar Returns the current certificate and the issuer certificate of a STAR order.
+// The certificate is replaced by the CA at each renewal, at the same star-certificate URL.
+// - https://tools.ietf.org/html/rfc8739#section-3.3
+func (c *CertificateService) GetStar(order acme.Order, bundle bool) ([]byte, []byte, error) {
+	if len(order.StarCertificate) == 0 {
+		return nil, nil, errors.New("certificate[star]: the order is not a STAR order (no star-certificate URL)")
+	}
+
+	return c.Get(order.StarCertificate, bundle)
+}
+
+// GetAll Returns the default certificate chain and the alternate certificate chains, indexed by their URL.
+// The alternate chains are provided by the "alternate" links.
+// 'bundle' is only applied if the issuer is provided by the 'up' link.
+func (c *CertificateService) GetAll(certURL string, bundle bool) (map[string]*acme.RawCertificate, error) {
+	cert, headers, err := c.get(certURL, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	certs := map[string]*acme.RawCertificate{certURL: cert}
+
+	// URLs of "alternate" link relation
+	// https://tools.ietf.org/html/rfc8555#section-7.4.2
+	for _, alt := range getLinks(headers, "alternate") {
+		altCert, _, err := c.get(alt, bundle)
+		if err != nil {
+			return nil, err
+		}
+
+		certs[alt] = altCert
+	}
+
+	return certs, nil
+}
+
+// Revoke Revokes a certificate.
+func (c *CertificateService) Revoke(req acme.RevokeCertMessage) error {
+	_, err := c.core.post(c.core.GetDirectory().RevokeCertURL, req, nil)
+	return err
+}
+
+// RevokeWithKey Revokes a certificate with a request signed by the private key of the certificate, instead of the account key.
+// The JWK of the key is embedded in the request (no key identifier).
+// - https://tools.ietf.org/html/rfc8555#section-7.6
+func (c *CertificateService) RevokeWithKey(req acme.RevokeCertMessage, privateKey crypto.PrivateKey) error {
+	content, err := json.Marshal(req)
+	if err != nil {
+		return errors.New("failed to marshal message")
+	}
+
+	jws := secure.NewJWS(privateKey, "", c.core.nonceManager)
+
+	_, err = c.core.retrievablePostWithJWS(jws, c.core.GetDirectory().RevokeCertURL, content, nil)
+	return err
+}
+
+// get Returns the certificate, the issuer certificate and the response headers.
+func (c *CertificateService) get(certURL string, bundle bool) (*acme.RawCertificate, http.Header, error) {
+	cert, headers, err := c.retrieve(certURL)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -29,9 +111,14 @@ func (c *CertificateService) Get(certURL string, bundle bool) ([]byte, []byte, e
 	// See https://community.letsencrypt.org/t/acme-v2-no-up-link-in-response/64962
 	_, issuer := pem.Decode(cert)
 	if issuer != nil {
-		return cert, issuer, nil
+		return &acme.RawCertificate{Cert: cert, Issuer: issuer}, headers, nil
 	}
 
+	// The issuer certificate link may be supplied via an "up" link
+	// in the response headers of a new certificate.
+	// See https://tools.ietf.org/html/draft-ietf-acme-acme-12#section-7.4.2
+	up := getLink(headers, "up")
+
 	issuer, err = c.getIssuerFromLink(up)
 	if err != nil {
 		// If we fail to acquire the issuer cert, return the issued certificate - do not fail.
@@ -44,37 +131,63 @@ func (c *CertificateService) Get(certURL string, bundle bool) ([]byte, []byte, e
 		}
 	}
 
-	return cert, issuer, nil
-}
-
-// Revoke Revokes a certificate.
-func (c *CertificateService) Revoke(req acme.RevokeCertMessage) error {
-	_, err := c.core.post(c.core.GetDirectory().RevokeCertURL, req, nil)
-	return err
+	return &acme.RawCertificate{Cert: cert, Issuer: issuer}, headers, nil
 }
 
-// get Returns the certificate and the "up" link.
-func (c *CertificateService) get(certURL string) ([]byte, string, error) {
+// retrieve Returns the raw certificate and the response headers.
+func (c *CertificateService) retrieve(certURL string) ([]byte, http.Header, error) {
 	if len(certURL) == 0 {
-		return nil, "", errors.New("certificate[get]: empty URL")
+		return nil, nil, errors.New("certificate[get]: empty URL")
 	}
 
-	resp, err := c.core.postAsGet(certURL, nil)
+	var opts []sender.RequestOption
+	if c.core.certificateAccept != "" {
+		opts = append(opts, sender.Accept(c.core.certificateAccept))
+	}
+
+	resp, err := c.core.postAsGet(certURL, nil, opts...)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	cert, err := ioutil.ReadAll(http.MaxBytesReader(nil, resp.Body, maxBodySize))
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	// The issuer certificate link may be supplied via an "up" link
-	// in the response headers of a new certificate.
-	// See https://tools.ietf.org/html/draft-ietf-acme-acme-12#section-7.4.2
-	up := getLink(resp.Header, "up")
+	if isPKCS7(resp.Header.Get("Content-Type")) {
+		cert, err = pkcs7ToPEM(cert)
+		if err != nil {
+			return nil, nil, fmt.Errorf("certificate[get]: %v", err)
+		}
+	}
 
-	return cert, up, err
+	return cert, resp.Header, nil
+}
+
+// isPKCS7 returns true if the content type is a PKCS#7 certificate chain.
+func isPKCS7(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == MediaTypePKCS7 || mediaType == "application/x-pkcs7-certificates"
+}
+
+// pkcs7ToPEM converts a PKCS#7 certificate chain to a PEM certificate chain, from the leaf certificate to the root.
+func pkcs7ToPEM(data []byte) ([]byte, error) {
+	certs, err := certcrypto.ParsePKCS7Certificates(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var chain []byte
+	for _, cert := range certs {
+		chain = append(chain, certcrypto.PEMEncode(certcrypto.DERCertificateBytes(cert.Raw))...)
+	}
+
+	return chain, nil
 }
 
 // getIssuerFromLink requests the issuer certificate
@@ -85,11 +198,16 @@ func (c *CertificateService) getIssuerFromLink(up string) ([]byte, error) {
 
 	log.Infof("acme: Requesting issuer cert from %s", up)
 
-	cert, _, err := c.get(up)
+	cert, _, err := c.retrieve(up)
 	if err != nil {
 		return nil, err
 	}
 
+	// a PKCS#7 issuer certificate is already converted to PEM.
+	if block, _ := pem.Decode(cert); block != nil {
+		return cert, nil
+	}
+
 	_, err = x509.ParseCertificate(cert)
 	if err != nil {
 		return nil, err
@@ -4,12 +4,15 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/pem"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
+	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 const certResponseMock = `-----BEGIN CERTIFICATE-----
@@ -73,6 +76,76 @@ rzFL1KZfz+HZdnFwFW2T2gVW8L3ii1l9AJDuKzlvjUH3p6bgihVq02sjT8mx+GM2
 -----END CERTIFICATE-----
 `
 
+// pkcs7ResponseMock the certificate chain of certResponseMock in PKCS#7.
+const pkcs7ResponseMock = `-----BEGIN PKCS7-----
+MIIGTwYJKoZIhvcNAQcCoIIGQDCCBjwCAQExADALBgkqhkiG9w0BBwGgggYkMIID
+EDCCAfigAwIBAgIHPhckqW5fPDANBgkqhkiG9w0BAQsFADAoMSYwJAYDVQQDEx1Q
+ZWJibGUgSW50ZXJtZWRpYXRlIENBIDM5NWU2MTAeFw0xODExMDcxNzQ2NTZaFw0y
+MzExMDcxNzQ2NTZaMBMxETAPBgNVBAMTCGFjbWUud3RmMIIBIjANBgkqhkiG9w0B
+AQEFAAOCAQ8AMIIBCgKCAQEAwtLNKvZXD20XPUQCWYSK9rUSKxD9Eb0c9fagbxOx
+OkLRTgL8LH6yln+bxc3MrHDou4PpDUdeo2CyOQu3CKsTS5mrH3NXYHu0H7p5y3ri
+OJTHnfkGKLT9LciGz7GkXd62nvNP57bOf5Sk4P2M+Qbxd0hPTSfu52740LSy144c
+nxe2P1aDYehrEp6nYCESuyD/CtUHTo0qwJmzIy163Sp3rSs15BuCPyhySnE3BJ8G
+gv+qC6D5I1932DfSqyQJ79iq/HRm0Fn84am3KwvRlUfWxabmsUGARXoqCgnEzcbJ
+VOZKewv0zlQJpfac+b+Imj6Lvt1TGjIz2mVyefYgLx8gwwIDAQABo1QwUjAOBgNV
+HQ8BAf8EBAMCBaAwHQYDVR0lBBYwFAYIKwYBBQUHAwEGCCsGAQUFBwMCMAwGA1Ud
+EwEB/wQCMAAwEwYDVR0RBAwwCoIIYWNtZS53dGYwDQYJKoZIhvcNAQELBQADggEB
+ABB/0iYhmfPSQot5RaeeovQnsqYjI5ryQK2cwzW6qcTJfv8N6+p6XkqF1+W4jXZj
+rQP8MvgO9KNWlvx12vhINE6wubk88L+2piAi5uS2QejmZbXpyYB9s+oPqlk9IDvf
+dlVYOqvYAhSx7ggGi+j73mjZVtjAavP6dKuu475ZCeq+NIC15RpbbikWKtYEHBJ7
+BW8XQKx67iHGx8ygHTDLbREL80Bck3oUm7wIYGMoNijD6RBl25p4gYl9dzOdTqGl
+5hW/1P5hMbgEzHbr4O3BfWqU2g7tV36TASy3jbC3ONFRNNYrpEZ1AL3+cUriOPPk
+KtAKAbQkKbUIfsHpBZjKZMUwggMMMIIB9KADAgECAgg5XmGRgnHQnDANBgkqhkiG
+9w0BAQsFADAgMR4wHAYDVQQDExVQZWJibGUgUm9vdCBDQSA1MGZmYmQwHhcNMTgx
+MTA3MTc0NjQ3WhcNNDgxMTA3MTc0NjQ3WjAoMSYwJAYDVQQDEx1QZWJibGUgSW50
+ZXJtZWRpYXRlIENBIDM5NWU2MTCCASIwDQYJKoZIhvcNAQEBBQADggEPADCCAQoC
+ggEBAJpzBc3gubJFOBhLxNNJliDPvnyekeI9MEMqB43lZWaSTBbRO43hbbNeESZw
+zza7E4SNZLZ2u8rT6Ikqd6HR9BHxkXfl0hgV7xZdODeQSapyLNKCHgvGU6e2xe9q
+19f/oZT8AOjfEAWgEoKKN6dJEEhjrnKrmbTRxbHdEwhxCrUHRfyclLQ7U/sxgT9W
++J3PlK3/fpFdW7WhYt5LkXEcB7NbSWrERTO8L64tMVYLp/8KKXOYjtKw8CRkGTt0
+7lCzR3ub/NYXnzy+UZL7Sm0PWif6VTzkxrbO4B3SqYHotjro3ykQoq0yT3fAX+jh
+nmLnxqFvA29MoDPPglJFZK6YH0sCAwEAAaNCMEAwDgYDVR0PAQH/BAQDAgKEMB0G
+A1UdJQQWMBQGCCsGAQUFBwMBBggrBgEFBQcDAjAPBgNVHRMBAf8EBTADAQH/MA0G
+CSqGSIb3DQEBCwUAA4IBAQC6lTQOPO8i+gI5gpurVFE3us91hp3f2Z+PGCR5XSI4
+Xkmgh07rLULtcCb2VPr1aOaJQ++cJMwNh+3jQ3ZK+Ze64xo86bjOM3CfgrfePDlc
+GenDELsHL9jShX5KXXR8Gx1CpcLofstxIaS/7h4luBjfc/i4flkDL7ZOQdS5kOSm
+5+QCyHMG6y3ucMCN+/cAi5DCU+jJl8jXV6rKI6pHM6r4Hl0gGvUnMqcTNIvqfQks
+1CI87uI3Teu53/BgPeBuZy+vMUvUpl/P4dl2cXAVbZPaBVbwveKLWX0AkO4rOW+N
+QfenpuCKFWrTayNPybH4YzbtHghscaePQEkDa8xgLiFLMQA=
+-----END PKCS7-----
+`
+
+func TestCertificateService_Get_pkcs7(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/certificate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != MediaTypePKCS7 {
+			http.Error(w, "unexpected Accept header: "+r.Header.Get("Accept"), http.StatusNotAcceptable)
+			return
+		}
+
+		p, _ := pem.Decode([]byte(pkcs7ResponseMock))
+
+		w.Header().Set("Content-Type", MediaTypePKCS7)
+		_, err := w.Write(p.Bytes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key, WithCertificateAccept(MediaTypePKCS7))
+	require.NoError(t, err)
+
+	cert, issuer, err := core.Certificates.Get(apiURL+"/certificate", true)
+	require.NoError(t, err)
+	assert.Equal(t, certResponseMock, string(cert), "Certificate")
+	assert.Equal(t, issuerMock, string(issuer), "IssuerCertificate")
+}
+
 func TestCertificateService_Get_issuerRelUp(t *testing.T) {
 	mux, apiURL, tearDown := tester.SetupFakeAPI()
 	defer tearDown()
@@ -127,3 +200,111 @@ func TestCertificateService_Get_embeddedIssuer(t *testing.T) {
 	assert.Equal(t, certResponseMock, string(cert), "Certificate")
 	assert.Equal(t, issuerMock, string(issuer), "IssuerCertificate")
 }
+
+func TestCertificateService_GetStar(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/star/1", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(certResponseMock))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	_, _, err = core.Certificates.GetStar(acme.Order{Certificate: apiURL + "/certificate"}, true)
+	require.EqualError(t, err, "certificate[star]: the order is not a STAR order (no star-certificate URL)")
+
+	cert, issuer, err := core.Certificates.GetStar(acme.Order{StarCertificate: apiURL + "/star/1"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, certResponseMock, string(cert), "Certificate")
+	assert.Equal(t, issuerMock, string(issuer), "IssuerCertificate")
+}
+
+func TestCertificateService_GetAll(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/certificate", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("Link", "<"+apiURL+`/certificate/1>; rel="alternate"`)
+		w.Header().Add("Link", "<"+apiURL+`/certificate/2>; rel="alternate"`)
+		_, err := w.Write([]byte(certResponseMock))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/certificate/", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(certResponseMock))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certs, err := core.Certificates.GetAll(apiURL+"/certificate", true)
+	require.NoError(t, err)
+
+	require.Len(t, certs, 3)
+	for _, link := range []string{"/certificate", "/certificate/1", "/certificate/2"} {
+		require.Contains(t, certs, apiURL+link)
+		assert.Equal(t, certResponseMock, string(certs[apiURL+link].Cert), "Certificate")
+		assert.Equal(t, issuerMock, string(certs[apiURL+link].Issuer), "IssuerCertificate")
+	}
+}
+
+func TestCertificateService_RevokeWithKey(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	certKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	mux.HandleFunc("/revokeCert", func(w http.ResponseWriter, r *http.Request) {
+		reqBody, errR := ioutil.ReadAll(r.Body)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		jws, errR := jose.ParseSigned(string(reqBody))
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusBadRequest)
+			return
+		}
+
+		header := jws.Signatures[0].Protected
+		if header.KeyID != "" || header.JSONWebKey == nil {
+			http.Error(w, "the JWK must be embedded", http.StatusBadRequest)
+			return
+		}
+
+		_, errR = jws.Verify(&certKey.PublicKey)
+		if errR != nil {
+			http.Error(w, errR.Error(), http.StatusUnauthorized)
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", accountKey)
+	require.NoError(t, err)
+
+	err = core.Certificates.RevokeWithKey(acme.RevokeCertMessage{Certificate: "foo"}, certKey)
+	require.NoError(t, err)
+
+	err = core.Certificates.RevokeWithKey(acme.RevokeCertMessage{Certificate: "foo"}, accountKey)
+	require.Error(t, err)
+}
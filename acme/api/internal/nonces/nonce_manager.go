@@ -6,14 +6,20 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/acme/api/internal/sender"
+	"github.com/vostronet/lego/log"
 )
 
 // Manager Manages nonces.
 type Manager struct {
 	do       *sender.Doer
 	nonceURL string
-	nonces   []string
+	// fallbackURL the URL requested (GET) to get a nonce when there is no nonce URL (e.g. the directory URL).
+	fallbackURL string
+	// store the external store of the nonces (optional).
+	store  acme.NonceStore
+	nonces []string
 	sync.Mutex
 }
 
@@ -25,27 +31,74 @@ func NewManager(do *sender.Doer, nonceURL string) *Manager {
 	}
 }
 
+// SetFallbackURL Sets the URL requested (GET) to get a nonce from the Replay-Nonce header,
+// when the server doesn't provide a newNonce URL.
+func (n *Manager) SetFallbackURL(uri string) {
+	n.fallbackURL = uri
+}
+
+// SetStore Sets an external store of the nonces:
+// the nonces are pushed to the store, and taken from the store when there is no nonce in memory.
+func (n *Manager) SetStore(store acme.NonceStore) {
+	n.store = store
+}
+
 // Pop Pops a nonce.
 func (n *Manager) Pop() (string, bool) {
 	n.Lock()
-	defer n.Unlock()
-
 	if len(n.nonces) == 0 {
-		return "", false
+		n.Unlock()
+		// the store is called without the lock: it can be slow (e.g. a remote store).
+		return n.popFromStore()
 	}
 
 	nonce := n.nonces[len(n.nonces)-1]
 	n.nonces = n.nonces[:len(n.nonces)-1]
+	n.Unlock()
 	return nonce, true
 }
 
+// popFromStore gets a nonce from the external store.
+// A store error is not fatal: a new nonce is requested to the server.
+func (n *Manager) popFromStore() (string, bool) {
+	if n.store == nil {
+		return "", false
+	}
+
+	nonce, err := n.store.Get()
+	if err != nil {
+		log.Warnf("acme: unable to get a nonce from the store: %v", err)
+		return "", false
+	}
+
+	return nonce, nonce != ""
+}
+
 // Push Pushes a nonce.
+// The nonce is kept in memory if there is no external store or if the store fails.
 func (n *Manager) Push(nonce string) {
+	if n.store != nil {
+		err := n.store.Put(nonce)
+		if err == nil {
+			return
+		}
+
+		log.Warnf("acme: unable to put a nonce in the store: %v", err)
+	}
+
 	n.Lock()
 	defer n.Unlock()
 	n.nonces = append(n.nonces, nonce)
 }
 
+// Nonces Returns a copy of the unused nonces.
+func (n *Manager) Nonces() []string {
+	n.Lock()
+	defer n.Unlock()
+
+	return append([]string(nil), n.nonces...)
+}
+
 // Nonce implement jose.NonceSource
 func (n *Manager) Nonce() (string, error) {
 	if nonce, ok := n.Pop(); ok {
@@ -55,7 +108,13 @@ func (n *Manager) Nonce() (string, error) {
 }
 
 func (n *Manager) getNonce() (string, error) {
-	resp, err := n.do.Head(n.nonceURL)
+	if n.nonceURL == "" {
+		return n.getFallbackNonce()
+	}
+
+	resp, err := n.do.Retry(func() (*http.Response, error) {
+		return n.do.Head(n.nonceURL)
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get nonce from HTTP HEAD -> %v", err)
 	}
@@ -63,6 +122,29 @@ func (n *Manager) getNonce() (string, error) {
 	return GetFromResponse(resp)
 }
 
+func (n *Manager) getFallbackNonce() (string, error) {
+	if n.fallbackURL == "" {
+		return "", errors.New("no nonce available: the server doesn't provide a newNonce URL")
+	}
+
+	resp, err := n.do.Retry(func() (*http.Response, error) {
+		return n.do.Get(n.fallbackURL, nil)
+	})
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get nonce from HTTP GET -> %v", err)
+	}
+
+	nonce, err := GetFromResponse(resp)
+	if err != nil {
+		return "", fmt.Errorf("no nonce available: the server doesn't provide a newNonce URL, and %v", err)
+	}
+
+	return nonce, nil
+}
+
 // GetFromResponse Extracts a nonce from a HTTP response.
 func GetFromResponse(resp *http.Response) (string, error) {
 	if resp == nil {
@@ -1,6 +1,7 @@
 package nonces
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,6 +10,8 @@ import (
 	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/acme/api/internal/sender"
 	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
@@ -53,3 +56,154 @@ func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
 		t.Fatal("JWS is probably holding a lock while making HTTP request")
 	}
 }
+
+func TestManager_Nonce_withoutNonceURL(t *testing.T) {
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+
+	manager := NewManager(doer, "")
+
+	_, err := manager.Nonce()
+	require.EqualError(t, err, "no nonce available: the server doesn't provide a newNonce URL")
+
+	manager.Push("pushed")
+
+	nonce, err := manager.Nonce()
+	require.NoError(t, err)
+	assert.Equal(t, "pushed", nonce)
+}
+
+func TestManager_Nonce_fallbackURL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if r.URL.Query().Get("nonce") != "" {
+			w.Header().Set("Replay-Nonce", "fallback")
+		}
+	}))
+	defer ts.Close()
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+
+	manager := NewManager(doer, "")
+	manager.SetFallbackURL(ts.URL + "?nonce=true")
+
+	nonce, err := manager.Nonce()
+	require.NoError(t, err)
+	assert.Equal(t, "fallback", nonce)
+
+	manager.SetFallbackURL(ts.URL)
+
+	_, err = manager.Nonce()
+	require.EqualError(t, err, "no nonce available: the server doesn't provide a newNonce URL, and server did not respond with a proper nonce header")
+}
+
+type storeMock struct {
+	nonces []string
+	err    error
+}
+
+func (s *storeMock) Get() (string, error) {
+	if s.err != nil || len(s.nonces) == 0 {
+		return "", s.err
+	}
+
+	nonce := s.nonces[0]
+	s.nonces = s.nonces[1:]
+
+	return nonce, nil
+}
+
+func (s *storeMock) Put(nonce string) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.nonces = append(s.nonces, nonce)
+	return nil
+}
+
+func TestManager_store(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Replay-Nonce", "server")
+	}))
+	defer ts.Close()
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+
+	store := &storeMock{nonces: []string{"stored"}}
+
+	manager := NewManager(doer, ts.URL)
+	manager.SetStore(store)
+
+	nonce, err := manager.Nonce()
+	require.NoError(t, err)
+	assert.Equal(t, "stored", nonce)
+
+	nonce, err = manager.Nonce()
+	require.NoError(t, err)
+	assert.Equal(t, "server", nonce)
+
+	manager.Push("pushed")
+	assert.Equal(t, []string{"pushed"}, store.nonces)
+	assert.Empty(t, manager.Nonces())
+}
+
+func TestManager_store_error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Replay-Nonce", "server")
+	}))
+	defer ts.Close()
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+
+	manager := NewManager(doer, ts.URL)
+	manager.SetStore(&storeMock{err: errors.New("connection refused")})
+
+	nonce, err := manager.Nonce()
+	require.NoError(t, err)
+	assert.Equal(t, "server", nonce)
+
+	// the nonce is kept in memory.
+	manager.Push("pushed")
+	assert.Equal(t, []string{"pushed"}, manager.Nonces())
+
+	nonce, err = manager.Nonce()
+	require.NoError(t, err)
+	assert.Equal(t, "pushed", nonce)
+}
+
+type reentrantStore struct {
+	manager *Manager
+}
+
+func (s *reentrantStore) Get() (string, error) {
+	// deadlocks if the manager holds its lock while calling the store.
+	s.manager.Push("pushed")
+	return "stored", nil
+}
+
+func (s *reentrantStore) Put(string) error {
+	return errors.New("read-only store")
+}
+
+func TestManager_Pop_storeWithoutLock(t *testing.T) {
+	manager := NewManager(sender.NewDoer(http.DefaultClient, "lego-test"), "")
+	manager.SetStore(&reentrantStore{manager: manager})
+
+	resultCh := make(chan string)
+	go func() {
+		nonce, _ := manager.Pop()
+		resultCh <- nonce
+	}()
+
+	select {
+	case nonce := <-resultCh:
+		assert.Equal(t, "stored", nonce)
+		assert.Equal(t, []string{"pushed"}, manager.Nonces())
+	case <-time.After(time.Second):
+		t.Fatal("the manager is probably holding a lock while calling the store")
+	}
+}
@@ -3,12 +3,14 @@ package secure
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
 
 	"github.com/vostronet/lego/acme/api/internal/nonces"
+	xed25519 "golang.org/x/crypto/ed25519"
 	jose "gopkg.in/square/go-jose.v2"
 )
 
@@ -16,44 +18,109 @@ import (
 type JWS struct {
 	privKey crypto.PrivateKey
 	kid     string // Key identifier
+	alg     jose.SignatureAlgorithm
 	nonces  *nonces.Manager
 }
 
 // NewJWS Create a new JWS.
+// Without key identifier, the JWK of the key is embedded in the signed content (e.g. new account, revocation with the key of a certificate).
+// The signing algorithm is derived from the type of the key (see DefaultAlgorithm).
+// The private key can be a crypto.Signer (e.g. a key stored in a HSM or a KMS): only its public key and its Sign method are used.
 func NewJWS(privateKey crypto.PrivateKey, kid string, nonceManager *nonces.Manager) *JWS {
 	return &JWS{
 		privKey: privateKey,
 		nonces:  nonceManager,
 		kid:     kid,
+		alg:     DefaultAlgorithm(privateKey),
 	}
 }
 
+// NewJWSWithAlgorithm Create a new JWS with a specific signing algorithm (e.g. PS256 instead of RS256 for an RSA key).
+// The algorithm must be compatible with the key, an empty algorithm is derived from the type of the key.
+func NewJWSWithAlgorithm(privateKey crypto.PrivateKey, kid string, nonceManager *nonces.Manager, alg string) (*JWS, error) {
+	j := NewJWS(privateKey, kid, nonceManager)
+
+	if alg == "" {
+		return j, nil
+	}
+
+	err := checkAlgorithm(privateKey, jose.SignatureAlgorithm(alg))
+	if err != nil {
+		return nil, err
+	}
+
+	j.alg = jose.SignatureAlgorithm(alg)
+
+	return j, nil
+}
+
+// DefaultAlgorithm returns the signing algorithm derived from the type of the key:
+// RS256 for an RSA key, ES256/ES384/ES512 for an ECDSA key (depending on the curve), EdDSA for an Ed25519 key.
+func DefaultAlgorithm(privateKey crypto.PrivateKey) jose.SignatureAlgorithm {
+	switch k := publicKey(privateKey).(type) {
+	case *rsa.PublicKey:
+		return jose.RS256
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jose.ES256
+		case elliptic.P384():
+			return jose.ES384
+		case elliptic.P521():
+			return jose.ES512
+		}
+	case ed25519.PublicKey:
+		return jose.EdDSA
+	}
+
+	return ""
+}
+
+// checkAlgorithm checks that the signing algorithm is compatible with the key.
+// The ECDSA algorithms are bound to the curve of the key (RFC 7518 section 3.4).
+func checkAlgorithm(privateKey crypto.PrivateKey, alg jose.SignatureAlgorithm) error {
+	switch publicKey(privateKey).(type) {
+	case *rsa.PublicKey:
+		switch alg {
+		case jose.RS256, jose.RS384, jose.RS512, jose.PS256, jose.PS384, jose.PS512:
+			return nil
+		}
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		if alg == DefaultAlgorithm(privateKey) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the signing algorithm %q is not compatible with the key (%T)", alg, privateKey)
+}
+
+// Algorithm Gets the signing algorithm.
+func (j *JWS) Algorithm() string {
+	return string(j.alg)
+}
+
 // SetKid Sets a key identifier.
 func (j *JWS) SetKid(kid string) {
 	j.kid = kid
 }
 
+// GetKid Gets the key identifier.
+func (j *JWS) GetKid() string {
+	return j.kid
+}
+
 // SignContent Signs a content with the JWS.
+// The nonce is available in the protected header of the signatures (e.g. to log a nonce rejected by the server).
 func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, error) {
-	var alg jose.SignatureAlgorithm
-	switch k := j.privKey.(type) {
-	case *rsa.PrivateKey:
-		alg = jose.RS256
-	case *ecdsa.PrivateKey:
-		if k.Curve == elliptic.P256() {
-			alg = jose.ES256
-		} else if k.Curve == elliptic.P384() {
-			alg = jose.ES384
-		}
-	}
-
 	signKey := jose.SigningKey{
-		Algorithm: alg,
-		Key:       jose.JSONWebKey{Key: j.privKey, KeyID: j.kid},
+		Algorithm: j.alg,
+		Key:       jose.JSONWebKey{Key: joseKey(j.privKey), KeyID: j.kid},
 	}
 
+	nonce := &nonceRecorder{source: j.nonces}
+
 	options := jose.SignerOptions{
-		NonceSource: j.nonces,
+		NonceSource: nonce,
 		ExtraHeaders: map[jose.HeaderKey]interface{}{
 			"url": url,
 		},
@@ -72,13 +139,36 @@ func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, e
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign content -> %v", err)
 	}
+
+	// jose only fills the protected header of the parsed signatures.
+	for i := range signed.Signatures {
+		signed.Signatures[i].Protected.Nonce = nonce.value
+	}
+
 	return signed, nil
 }
 
+// nonceRecorder records the nonce provided by the nonce source.
+type nonceRecorder struct {
+	source jose.NonceSource
+	value  string
+}
+
+func (n *nonceRecorder) Nonce() (string, error) {
+	nonce, err := n.source.Nonce()
+	if err != nil {
+		return "", err
+	}
+
+	n.value = nonce
+
+	return nonce, nil
+}
+
 // SignEABContent Signs an external account binding content with the JWS.
 func (j *JWS) SignEABContent(url, kid string, hmac []byte) (*jose.JSONWebSignature, error) {
-	jwk := jose.JSONWebKey{Key: j.privKey}
-	jwkJSON, err := jwk.Public().MarshalJSON()
+	jwk := jose.JSONWebKey{Key: josePublicKey(publicKey(j.privKey))}
+	jwkJSON, err := jwk.MarshalJSON()
 	if err != nil {
 		return nil, fmt.Errorf("acme: error encoding eab jwk key: %v", err)
 	}
@@ -107,16 +197,8 @@ func (j *JWS) SignEABContent(url, kid string, hmac []byte) (*jose.JSONWebSignatu
 
 // GetKeyAuthorization Gets the key authorization for a token.
 func (j *JWS) GetKeyAuthorization(token string) (string, error) {
-	var publicKey crypto.PublicKey
-	switch k := j.privKey.(type) {
-	case *ecdsa.PrivateKey:
-		publicKey = k.Public()
-	case *rsa.PrivateKey:
-		publicKey = k.Public()
-	}
-
 	// Generate the Key Authorization for the challenge
-	jwk := &jose.JSONWebKey{Key: publicKey}
+	jwk := &jose.JSONWebKey{Key: josePublicKey(publicKey(j.privKey))}
 
 	thumbBytes, err := jwk.Thumbprint(crypto.SHA256)
 	if err != nil {
@@ -128,3 +210,18 @@ func (j *JWS) GetKeyAuthorization(token string) (string, error) {
 
 	return token + "." + keyThumb, nil
 }
+
+// joseKey converts the Ed25519 keys to the type expected by jose (golang.org/x/crypto/ed25519),
+// and the keys that are only a crypto.Signer to a jose.OpaqueSigner.
+func joseKey(privateKey crypto.PrivateKey) interface{} {
+	switch k := privateKey.(type) {
+	case ed25519.PrivateKey:
+		return xed25519.PrivateKey(k)
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		return k
+	case crypto.Signer:
+		return signerAdapter{signer: k}
+	default:
+		return privateKey
+	}
+}
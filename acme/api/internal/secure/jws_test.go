@@ -1,6 +1,13 @@
 package secure
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,6 +17,9 @@ import (
 	"github.com/vostronet/lego/acme/api/internal/nonces"
 	"github.com/vostronet/lego/acme/api/internal/sender"
 	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
@@ -54,3 +64,203 @@ func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
 		t.Fatal("JWS is probably holding a lock while making HTTP request")
 	}
 }
+
+func TestJWS_SignContent_ed25519(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("Replay-Nonce", "12345")
+	}))
+	defer ts.Close()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+	j := NewJWS(privateKey, "", nonces.NewManager(doer, ts.URL))
+
+	signed, err := j.SignContent(ts.URL, []byte(`{"foo":"bar"}`))
+	require.NoError(t, err)
+
+	parsed, err := jose.ParseSigned(signed.FullSerialize())
+	require.NoError(t, err)
+
+	require.Len(t, parsed.Signatures, 1)
+	assert.Equal(t, string(jose.EdDSA), parsed.Signatures[0].Header.Algorithm)
+
+	require.Len(t, signed.Signatures, 1)
+	assert.Equal(t, "12345", signed.Signatures[0].Protected.Nonce)
+
+	payload, err := parsed.Verify(parsed.Signatures[0].Header.JSONWebKey)
+	require.NoError(t, err)
+	assert.Equal(t, `{"foo":"bar"}`, string(payload))
+
+	keyAuth, err := j.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	jwk := jose.JSONWebKey{Key: joseKey(privateKey)}
+	public := jwk.Public()
+	thumbprint, err := public.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	assert.Equal(t, "token."+base64.RawURLEncoding.EncodeToString(thumbprint), keyAuth)
+}
+
+func TestJWS_SignContent_algorithm(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("Replay-Nonce", "12345")
+	}))
+	defer ts.Close()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+	j, err := NewJWSWithAlgorithm(privateKey, "", nonces.NewManager(doer, ts.URL), "PS256")
+	require.NoError(t, err)
+
+	assert.Equal(t, "PS256", j.Algorithm())
+
+	signed, err := j.SignContent(ts.URL, []byte(`{"foo":"bar"}`))
+	require.NoError(t, err)
+
+	parsed, err := jose.ParseSigned(signed.FullSerialize())
+	require.NoError(t, err)
+
+	require.Len(t, parsed.Signatures, 1)
+	assert.Equal(t, string(jose.PS256), parsed.Signatures[0].Header.Algorithm)
+
+	_, err = parsed.Verify(parsed.Signatures[0].Header.JSONWebKey)
+	require.NoError(t, err)
+}
+
+func TestNewJWSWithAlgorithm(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		key         crypto.PrivateKey
+		alg         string
+		expected    string
+		expectedErr string
+	}{
+		{desc: "RSA default", key: rsaKey, expected: "RS256"},
+		{desc: "RSA PS256", key: rsaKey, alg: "PS256", expected: "PS256"},
+		{desc: "RSA RS512", key: rsaKey, alg: "RS512", expected: "RS512"},
+		{desc: "RSA ES256", key: rsaKey, alg: "ES256", expectedErr: `the signing algorithm "ES256" is not compatible with the key (*rsa.PrivateKey)`},
+		{desc: "P-384 default", key: p384Key, expected: "ES384"},
+		{desc: "P-384 ES384", key: p384Key, alg: "ES384", expected: "ES384"},
+		{desc: "P-384 ES256", key: p384Key, alg: "ES256", expectedErr: `the signing algorithm "ES256" is not compatible with the key (*ecdsa.PrivateKey)`},
+		{desc: "Ed25519 default", key: edKey, expected: "EdDSA"},
+		{desc: "Ed25519 RS256", key: edKey, alg: "RS256", expectedErr: `the signing algorithm "RS256" is not compatible with the key (ed25519.PrivateKey)`},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			j, err := NewJWSWithAlgorithm(test.key, "", nil, test.alg)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, j.Algorithm())
+		})
+	}
+}
+
+// signerOnly hides the concrete type of a private key: only the crypto.Signer methods are available (like a HSM or a KMS key).
+type signerOnly struct {
+	crypto.Signer
+}
+
+func TestJWS_SignContent_signer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("Replay-Nonce", "12345")
+	}))
+	defer ts.Close()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		key      crypto.Signer
+		alg      string
+		kid      string
+		expected jose.SignatureAlgorithm
+	}{
+		{desc: "RSA", key: rsaKey, expected: jose.RS256},
+		{desc: "RSA PS384", key: rsaKey, alg: "PS384", expected: jose.PS384},
+		{desc: "RSA with kid", key: rsaKey, kid: "https://example.com/acct/1", expected: jose.RS256},
+		{desc: "P-256", key: p256Key, expected: jose.ES256},
+		{desc: "P-521", key: p521Key, expected: jose.ES512},
+		{desc: "Ed25519", key: edKey, expected: jose.EdDSA},
+	}
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			j, err := NewJWSWithAlgorithm(signerOnly{test.key}, test.kid, nonces.NewManager(doer, ts.URL), test.alg)
+			require.NoError(t, err)
+
+			assert.Equal(t, string(test.expected), j.Algorithm())
+
+			signed, err := j.SignContent(ts.URL, []byte(`{"foo":"bar"}`))
+			require.NoError(t, err)
+
+			parsed, err := jose.ParseSigned(signed.FullSerialize())
+			require.NoError(t, err)
+
+			require.Len(t, parsed.Signatures, 1)
+			assert.Equal(t, string(test.expected), parsed.Signatures[0].Header.Algorithm)
+
+			if test.kid != "" {
+				assert.Equal(t, test.kid, parsed.Signatures[0].Header.KeyID)
+				assert.Nil(t, parsed.Signatures[0].Header.JSONWebKey)
+			} else {
+				require.NotNil(t, parsed.Signatures[0].Header.JSONWebKey)
+				assert.True(t, parsed.Signatures[0].Header.JSONWebKey.IsPublic())
+			}
+
+			payload, err := parsed.Verify(josePublicKey(test.key.Public()))
+			require.NoError(t, err)
+			assert.Equal(t, `{"foo":"bar"}`, string(payload))
+
+			// the key authorization must be the same as with the private key.
+			keyAuth, err := j.GetKeyAuthorization("token")
+			require.NoError(t, err)
+
+			expected, err := NewJWS(test.key, "", nil).GetKeyAuthorization("token")
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, keyAuth)
+		})
+	}
+}
+
+func TestNewJWSWithAlgorithm_signer(t *testing.T) {
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = NewJWSWithAlgorithm(signerOnly{p384Key}, "", nil, "ES256")
+	require.EqualError(t, err, `the signing algorithm "ES256" is not compatible with the key (secure.signerOnly)`)
+}
@@ -0,0 +1,134 @@
+package secure
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	xed25519 "golang.org/x/crypto/ed25519"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// signerAdapter adapts a crypto.Signer (e.g. a key stored in a HSM or a KMS) to a jose.OpaqueSigner:
+// the private material is never accessed, only the public key and the Sign method are used.
+type signerAdapter struct {
+	signer crypto.Signer
+}
+
+// Public returns the JWK of the public key of the signer.
+func (s signerAdapter) Public() *jose.JSONWebKey {
+	return &jose.JSONWebKey{Key: josePublicKey(s.signer.Public())}
+}
+
+// Algs returns the signing algorithms compatible with the public key of the signer.
+func (s signerAdapter) Algs() []jose.SignatureAlgorithm {
+	switch s.signer.Public().(type) {
+	case *rsa.PublicKey:
+		return []jose.SignatureAlgorithm{jose.RS256, jose.RS384, jose.RS512, jose.PS256, jose.PS384, jose.PS512}
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		return []jose.SignatureAlgorithm{DefaultAlgorithm(s.signer)}
+	default:
+		return nil
+	}
+}
+
+// SignPayload signs the payload with the signer.
+// The ECDSA signatures (ASN.1 DER) are converted to the JWS format (R || S, RFC 7518 section 3.4).
+func (s signerAdapter) SignPayload(payload []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	if alg == jose.EdDSA {
+		return s.signer.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+
+	hash, err := algorithmHash(alg)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := hash.New()
+	_, _ = hasher.Write(payload)
+	digest := hasher.Sum(nil)
+
+	var opts crypto.SignerOpts = hash
+	switch alg {
+	case jose.PS256, jose.PS384, jose.PS512:
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: hash}
+	}
+
+	signature, err := s.signer.Sign(rand.Reader, digest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if pub, ok := s.signer.Public().(*ecdsa.PublicKey); ok {
+		return ecdsaSignature(pub, signature)
+	}
+
+	return signature, nil
+}
+
+func algorithmHash(alg jose.SignatureAlgorithm) (crypto.Hash, error) {
+	switch alg {
+	case jose.RS256, jose.PS256, jose.ES256:
+		return crypto.SHA256, nil
+	case jose.RS384, jose.PS384, jose.ES384:
+		return crypto.SHA384, nil
+	case jose.RS512, jose.PS512, jose.ES512:
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported signing algorithm: %s", alg)
+	}
+}
+
+// ecdsaSignature converts an ASN.1 DER ECDSA signature to the JWS format:
+// R and S are big-endian integers padded to the size of the curve.
+func ecdsaSignature(pub *ecdsa.PublicKey, der []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ECDSA signature: %v", err)
+	}
+
+	if len(rest) > 0 || sig.R == nil || sig.S == nil {
+		return nil, errors.New("invalid ECDSA signature")
+	}
+
+	size := (pub.Curve.Params().BitSize + 7) / 8
+
+	rBytes, sBytes := sig.R.Bytes(), sig.S.Bytes()
+	if len(rBytes) > size || len(sBytes) > size {
+		return nil, errors.New("invalid ECDSA signature: R or S larger than the curve")
+	}
+
+	out := make([]byte, 2*size)
+	copy(out[size-len(rBytes):size], rBytes)
+	copy(out[2*size-len(sBytes):], sBytes)
+
+	return out, nil
+}
+
+// publicKey returns the public key of a private key or a crypto.Signer.
+func publicKey(privateKey crypto.PrivateKey) crypto.PublicKey {
+	if signer, ok := privateKey.(crypto.Signer); ok {
+		return signer.Public()
+	}
+
+	return nil
+}
+
+// josePublicKey converts the Ed25519 public keys to the type expected by jose (golang.org/x/crypto/ed25519).
+func josePublicKey(pub crypto.PublicKey) crypto.PublicKey {
+	if k, ok := pub.(ed25519.PublicKey); ok {
+		return xed25519.PublicKey(k)
+	}
+
+	return pub
+}
@@ -0,0 +1,81 @@
+package sender
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/acme"
+)
+
+// minResetTimestamp the values of the reset headers above this threshold are Unix timestamps, not delays in seconds.
+const minResetTimestamp = 1000000000
+
+// parseRetryAfter parses the value of a Retry-After header (delay in seconds or HTTP date).
+// Returns the zero time if the value is empty or invalid.
+func parseRetryAfter(value string, now time.Time) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date
+	}
+
+	return time.Time{}
+}
+
+// parseRateLimit parses the rate limit headers of a response:
+// the RateLimit-* headers are preferred to the X-RateLimit-* headers.
+// Returns nil if the response has no rate limit header.
+func parseRateLimit(header http.Header, now time.Time) *acme.RateLimit {
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		limit := header.Get(prefix + "Limit")
+		remaining := header.Get(prefix + "Remaining")
+		reset := header.Get(prefix + "Reset")
+
+		if limit == "" && remaining == "" && reset == "" {
+			continue
+		}
+
+		return &acme.RateLimit{
+			Limit:     parseInt(limit),
+			Remaining: parseInt(remaining),
+			Reset:     parseReset(reset, now),
+		}
+	}
+
+	return nil
+}
+
+// parseReset parses the value of a reset header: a delay in seconds, or a Unix timestamp for some servers.
+func parseReset(value string, now time.Time) time.Time {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}
+	}
+
+	if seconds >= minResetTimestamp {
+		return time.Unix(seconds, 0)
+	}
+
+	return now.Add(time.Duration(seconds) * time.Second)
+}
+
+// parseInt parses the first item of a header value (the RateLimit-Limit header can contain a quota policy, e.g. "10, 10;w=1").
+func parseInt(value string) int {
+	value = strings.TrimSpace(strings.Split(value, ",")[0])
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+
+	return i
+}
@@ -0,0 +1,108 @@
+package sender
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		value    string
+		expected time.Time
+	}{
+		{
+			desc:     "seconds",
+			value:    "120",
+			expected: now.Add(2 * time.Minute),
+		},
+		{
+			desc:     "HTTP date",
+			value:    "Wed, 01 Jan 2020 00:00:30 GMT",
+			expected: now.Add(30 * time.Second),
+		},
+		{
+			desc:  "negative seconds",
+			value: "-1",
+		},
+		{
+			desc:  "invalid",
+			value: "soon",
+		},
+		{
+			desc: "empty",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.True(t, test.expected.Equal(parseRetryAfter(test.value, now)), "expected %s", test.expected)
+		})
+	}
+}
+
+func Test_parseRateLimit(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		header   http.Header
+		expected *acme.RateLimit
+	}{
+		{
+			desc: "no headers",
+		},
+		{
+			desc: "RateLimit headers",
+			header: http.Header{
+				"Ratelimit-Limit":     {"10, 10;w=1, 300;w=3600"},
+				"Ratelimit-Remaining": {"3"},
+				"Ratelimit-Reset":     {"30"},
+			},
+			expected: &acme.RateLimit{Limit: 10, Remaining: 3, Reset: now.Add(30 * time.Second)},
+		},
+		{
+			desc: "X-RateLimit headers with timestamp",
+			header: http.Header{
+				"X-Ratelimit-Limit":     {"20"},
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {"1577836830"},
+			},
+			expected: &acme.RateLimit{Limit: 20, Remaining: 0, Reset: time.Unix(1577836830, 0)},
+		},
+		{
+			desc: "RateLimit headers preferred",
+			header: http.Header{
+				"Ratelimit-Remaining":   {"5"},
+				"X-Ratelimit-Remaining": {"1"},
+			},
+			expected: &acme.RateLimit{Remaining: 5},
+		},
+		{
+			desc: "invalid values",
+			header: http.Header{
+				"Ratelimit-Limit": {"many"},
+				"Ratelimit-Reset": {"later"},
+			},
+			expected: &acme.RateLimit{},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, parseRateLimit(test.header, now))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package sender
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/vostronet/lego/log"
+)
+
+// defaultRetryTimeout the default maximum duration of the retries of the unsigned requests.
+const defaultRetryTimeout = 20 * time.Second
+
+// Retry performs an unsigned request (e.g. the directory or a nonce),
+// and retries on the network errors and the server errors (5xx) with the backoff of the signed requests,
+// until the retry timeout of the Doer (see SetRetryTimeout).
+// The client errors (4xx) are not retried.
+func (d *Doer) Retry(request func() (*http.Response, error)) (*http.Response, error) {
+	if d.retryTimeout <= 0 {
+		return request()
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 200 * time.Millisecond
+	bo.MaxInterval = 5 * time.Second
+	bo.MaxElapsedTime = d.retryTimeout
+
+	var resp *http.Response
+	var attempt int
+	operation := func() error {
+		attempt++
+
+		var err error
+		resp, err = request()
+		if err == nil {
+			return nil
+		}
+
+		if resp != nil && resp.StatusCode < http.StatusInternalServerError {
+			return backoff.Permanent(err)
+		}
+
+		log.Infow("acme: request error, retrying", log.F("attempt", attempt), log.F("error", err))
+
+		return err
+	}
+
+	err := backoff.Retry(operation, bo)
+
+	return resp, err
+}
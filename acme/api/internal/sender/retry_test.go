@@ -0,0 +1,78 @@
+package sender
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		statusCodes   []int
+		noRetry       bool
+		expectedCalls int
+		expectedErr   bool
+	}{
+		{
+			desc:          "success",
+			statusCodes:   []int{http.StatusOK},
+			expectedCalls: 1,
+		},
+		{
+			desc:          "server errors",
+			statusCodes:   []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedCalls: 3,
+		},
+		{
+			desc:          "server error without retry",
+			statusCodes:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			noRetry:       true,
+			expectedCalls: 1,
+			expectedErr:   true,
+		},
+		{
+			desc:          "client error",
+			statusCodes:   []int{http.StatusNotFound, http.StatusOK},
+			expectedCalls: 1,
+			expectedErr:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				code := test.statusCodes[calls]
+				calls++
+
+				rw.Header().Set("Content-Type", "application/problem+json")
+				rw.WriteHeader(code)
+				_, _ = rw.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			doer := NewDoer(http.DefaultClient, "")
+			if test.noRetry {
+				doer.SetRetryTimeout(0)
+			}
+
+			_, err := doer.Retry(func() (*http.Response, error) {
+				return doer.Get(server.URL, nil)
+			})
+			if test.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectedCalls, calls)
+		})
+	}
+}
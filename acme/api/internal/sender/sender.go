@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/vostronet/lego/acme"
 )
@@ -21,17 +23,77 @@ func contentType(ct string) RequestOption {
 	}
 }
 
+// Accept sets the Accept header of a request (the media types expected in the response).
+func Accept(mediaType string) RequestOption {
+	return func(req *http.Request) error {
+		req.Header.Set("Accept", mediaType)
+		return nil
+	}
+}
+
 type Doer struct {
 	httpClient *http.Client
 	userAgent  string
+	headers    http.Header
+	metrics    acme.Metrics
+	// retryTimeout the maximum duration of the retries of the unsigned requests (see Retry).
+	retryTimeout time.Duration
+
+	rateLimitMu sync.Mutex
+	rateLimit   *acme.RateLimit
 }
 
 // NewDoer Creates a new Doer.
 func NewDoer(client *http.Client, userAgent string) *Doer {
 	return &Doer{
-		httpClient: client,
-		userAgent:  userAgent,
+		httpClient:   client,
+		userAgent:    userAgent,
+		metrics:      acme.NoopMetrics{},
+		retryTimeout: defaultRetryTimeout,
+	}
+}
+
+// SetHeaders sets static headers added to all the requests (e.g. a request ID for tracing).
+// The User-Agent and Content-Type headers cannot be overridden.
+func (d *Doer) SetHeaders(headers http.Header) {
+	d.headers = make(http.Header, len(headers))
+	for key, values := range headers {
+		d.headers[key] = append([]string(nil), values...)
+	}
+}
+
+// SetMetrics sets the receiver of the events of the requests.
+func (d *Doer) SetMetrics(metrics acme.Metrics) {
+	if metrics == nil {
+		metrics = acme.NoopMetrics{}
+	}
+
+	d.metrics = metrics
+}
+
+// SetRetryTimeout sets the maximum duration of the retries of the unsigned requests (e.g. the directory or a nonce).
+// A timeout <= 0 disables the retries.
+func (d *Doer) SetRetryTimeout(timeout time.Duration) {
+	d.retryTimeout = timeout
+}
+
+// Metrics returns the receiver of the events of the requests.
+func (d *Doer) Metrics() acme.Metrics {
+	return d.metrics
+}
+
+// RateLimit returns the quota advertised by the rate limit headers of the last response which contained them
+// (nil if the server doesn't send rate limit headers).
+func (d *Doer) RateLimit() *acme.RateLimit {
+	d.rateLimitMu.Lock()
+	defer d.rateLimitMu.Unlock()
+
+	if d.rateLimit == nil {
+		return nil
 	}
+
+	rateLimit := *d.rateLimit
+	return &rateLimit
 }
 
 // Get performs a GET request with a proper User-Agent string.
@@ -58,8 +120,8 @@ func (d *Doer) Head(url string) (*http.Response, error) {
 
 // Post performs a POST request with a proper User-Agent string.
 // If "response" is not provided, callers should close resp.Body when done reading from it.
-func (d *Doer) Post(url string, body io.Reader, bodyType string, response interface{}) (*http.Response, error) {
-	req, err := d.newRequest(http.MethodPost, url, body, contentType(bodyType))
+func (d *Doer) Post(url string, body io.Reader, bodyType string, response interface{}, opts ...RequestOption) (*http.Response, error) {
+	req, err := d.newRequest(http.MethodPost, url, body, append([]RequestOption{contentType(bodyType)}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +135,12 @@ func (d *Doer) newRequest(method, uri string, body io.Reader, opts ...RequestOpt
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
 
+	for key, values := range d.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
 	req.Header.Set("User-Agent", d.formatUserAgent())
 
 	for _, opt := range opts {
@@ -86,12 +154,30 @@ func (d *Doer) newRequest(method, uri string, body io.Reader, opts ...RequestOpt
 }
 
 func (d *Doer) do(req *http.Request, response interface{}) (*http.Response, error) {
+	start := time.Now()
+
 	resp, err := d.httpClient.Do(req)
+
+	var status int
+	if resp != nil {
+		status = resp.StatusCode
+	}
+
+	d.metrics.OnRequest(req.Method, req.URL.String(), status, time.Since(start))
+
 	if err != nil {
 		return nil, err
 	}
 
-	if err = checkError(req, resp); err != nil {
+	now := time.Now()
+
+	if rateLimit := parseRateLimit(resp.Header, now); rateLimit != nil {
+		d.rateLimitMu.Lock()
+		d.rateLimit = rateLimit
+		d.rateLimitMu.Unlock()
+	}
+
+	if err = checkError(req, resp, now); err != nil {
 		return resp, err
 	}
 
@@ -118,7 +204,7 @@ func (d *Doer) formatUserAgent() string {
 	return strings.TrimSpace(ua)
 }
 
-func checkError(req *http.Request, resp *http.Response) error {
+func checkError(req *http.Request, resp *http.Response, now time.Time) error {
 	if resp.StatusCode >= http.StatusBadRequest {
 
 		body, err := ioutil.ReadAll(resp.Body)
@@ -128,7 +214,13 @@ func checkError(req *http.Request, resp *http.Response) error {
 
 		var errorDetails *acme.ProblemDetails
 		err = json.Unmarshal(body, &errorDetails)
-		if err != nil {
+		if err != nil || errorDetails == nil {
+			if resp.StatusCode == http.StatusTooManyRequests {
+				// the rate limit is reported even if the response is not a problem document.
+				errorDetails = &acme.ProblemDetails{HTTPStatus: resp.StatusCode, Detail: strings.TrimSpace(string(body))}
+				return newRateLimitedError(req, resp, errorDetails, now)
+			}
+
 			return fmt.Errorf("%d ::%s :: %s :: %v :: %s", resp.StatusCode, req.Method, req.URL, err, string(body))
 		}
 
@@ -140,7 +232,23 @@ func checkError(req *http.Request, resp *http.Response) error {
 			return &acme.NonceError{ProblemDetails: errorDetails}
 		}
 
+		if errorDetails.Type == acme.RateLimitedErr || resp.StatusCode == http.StatusTooManyRequests {
+			return newRateLimitedError(req, resp, errorDetails, now)
+		}
+
 		return errorDetails
 	}
 	return nil
 }
+
+// newRateLimitedError creates a rate limit error with the retry time and the quota parsed from the headers of the response.
+func newRateLimitedError(req *http.Request, resp *http.Response, errorDetails *acme.ProblemDetails, now time.Time) error {
+	errorDetails.Method = req.Method
+	errorDetails.URL = req.URL.String()
+
+	return &acme.RateLimitedError{
+		ProblemDetails: errorDetails,
+		RetryAfter:     parseRetryAfter(resp.Header.Get("Retry-After"), now),
+		RateLimit:      parseRateLimit(resp.Header, now),
+	}
+}
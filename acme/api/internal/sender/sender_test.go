@@ -1,11 +1,14 @@
 package sender
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/vostronet/lego/acme"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -66,3 +69,155 @@ func TestDo_CustomUserAgent(t *testing.T) {
 	}
 	assert.Len(t, strings.Split(ua, " "), 5)
 }
+
+func TestDo_headers(t *testing.T) {
+	var headers http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+	}))
+	defer ts.Close()
+
+	doer := NewDoer(http.DefaultClient, "")
+	doer.SetHeaders(http.Header{
+		"X-Request-Context": []string{"abc"},
+		"User-Agent":        []string{"overridden"},
+	})
+
+	_, err := doer.Post(ts.URL, strings.NewReader("falalalala"), "text/plain", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc", headers.Get("X-Request-Context"))
+	assert.Equal(t, doer.formatUserAgent(), headers.Get("User-Agent"))
+	assert.Equal(t, "text/plain", headers.Get("Content-Type"))
+
+	_, err = doer.Head(ts.URL)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc", headers.Get("X-Request-Context"))
+}
+
+type metricsMock struct {
+	acme.NoopMetrics
+	requests []string
+}
+
+func (m *metricsMock) OnRequest(method, url string, status int, _ time.Duration) {
+	m.requests = append(m.requests, fmt.Sprintf("%s %s %d", method, url, status))
+}
+
+func TestDo_metrics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	metrics := &metricsMock{}
+
+	doer := NewDoer(http.DefaultClient, "")
+	doer.SetMetrics(metrics)
+
+	_, err := doer.Post(ts.URL, strings.NewReader("falalalala"), "text/plain", nil)
+	require.NoError(t, err)
+
+	_, err = doer.Head(ts.URL)
+	require.NoError(t, err)
+
+	ts.Close()
+
+	_, err = doer.Get(ts.URL, nil)
+	require.Error(t, err)
+
+	expected := []string{
+		"POST " + ts.URL + " 200",
+		"HEAD " + ts.URL + " 204",
+		"GET " + ts.URL + " 0",
+	}
+	assert.Equal(t, expected, metrics.requests)
+}
+
+func TestDo_rateLimited(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Content-Type", "application/problem+json")
+		rw.Header().Set("Retry-After", "120")
+		rw.Header().Set("RateLimit-Limit", "300")
+		rw.Header().Set("RateLimit-Remaining", "0")
+		rw.Header().Set("RateLimit-Reset", "120")
+		rw.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprint(rw, `{"type":"urn:ietf:params:acme:error:rateLimited","detail":"too many new orders recently","status":429}`)
+	}))
+	defer ts.Close()
+
+	doer := NewDoer(http.DefaultClient, "")
+
+	start := time.Now()
+
+	_, err := doer.Post(ts.URL, strings.NewReader("{}"), "application/jose+json", nil)
+	require.Error(t, err)
+
+	rateLimitErr, ok := err.(*acme.RateLimitedError)
+	require.True(t, ok, "unexpected error type: %T", err)
+
+	assert.Equal(t, acme.RateLimitedErr, rateLimitErr.Type)
+	assert.Equal(t, "too many new orders recently", rateLimitErr.Detail)
+	assert.WithinDuration(t, start.Add(2*time.Minute), rateLimitErr.RetryAfter, 5*time.Second)
+
+	require.NotNil(t, rateLimitErr.RateLimit)
+	assert.Equal(t, 300, rateLimitErr.RateLimit.Limit)
+	assert.Equal(t, 0, rateLimitErr.RateLimit.Remaining)
+	assert.WithinDuration(t, start.Add(2*time.Minute), rateLimitErr.RateLimit.Reset, 5*time.Second)
+
+	assert.Equal(t, rateLimitErr.RateLimit, doer.RateLimit())
+}
+
+func TestDo_rateLimited_statusCode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Retry-After", "Wed, 01 Jan 2020 00:00:30 GMT")
+		rw.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprint(rw, "slow down")
+	}))
+	defer ts.Close()
+
+	doer := NewDoer(http.DefaultClient, "")
+
+	_, err := doer.Post(ts.URL, strings.NewReader("{}"), "application/jose+json", nil)
+	require.Error(t, err)
+
+	rateLimitErr, ok := err.(*acme.RateLimitedError)
+	require.True(t, ok, "unexpected error type: %T", err)
+
+	assert.Equal(t, http.StatusTooManyRequests, rateLimitErr.HTTPStatus)
+	assert.Equal(t, "slow down", rateLimitErr.Detail)
+	assert.Equal(t, time.Date(2020, time.January, 1, 0, 0, 30, 0, time.UTC), rateLimitErr.RetryAfter.UTC())
+	assert.Nil(t, rateLimitErr.RateLimit)
+	assert.Nil(t, doer.RateLimit())
+}
+
+func TestDoer_RateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/limited" {
+			rw.Header().Set("X-RateLimit-Limit", "20")
+			rw.Header().Set("X-RateLimit-Remaining", "19")
+			rw.Header().Set("X-RateLimit-Reset", "1577836830")
+		}
+
+		_, _ = fmt.Fprint(rw, "{}")
+	}))
+	defer ts.Close()
+
+	doer := NewDoer(http.DefaultClient, "")
+
+	assert.Nil(t, doer.RateLimit())
+
+	_, err := doer.Get(ts.URL+"/limited", nil)
+	require.NoError(t, err)
+
+	expected := &acme.RateLimit{Limit: 20, Remaining: 19, Reset: time.Unix(1577836830, 0)}
+	assert.Equal(t, expected, doer.RateLimit())
+
+	// the last known quota is kept when a response has no rate limit headers.
+	_, err = doer.Get(ts.URL+"/other", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, doer.RateLimit())
+}
@@ -2,31 +2,109 @@ package api
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/vostronet/lego/acme"
+	"golang.org/x/net/idna"
 )
 
 type OrderService service
 
+// OrderOptions the optional fields of a new order.
+type OrderOptions struct {
+	// NotBefore the requested beginning of the validity of the certificate (not supported by all the CAs).
+	NotBefore time.Time
+	// NotAfter the requested end of the validity of the certificate (not supported by all the CAs).
+	NotAfter time.Time
+	// Extensions the additional fields of the newOrder request body (see NewWithExtensions).
+	Extensions map[string]interface{}
+	// AutoRenewal creates a STAR order (only if the CA advertises the auto-renewal extension).
+	AutoRenewal *AutoRenewalOptions
+}
+
+// AutoRenewalOptions the parameters of a STAR (Short-Term, Automatically Renewed) order.
+// The CA issues a new short-term certificate, for each period of the lifetime,
+// until the end date or the cancellation of the order.
+// - https://tools.ietf.org/html/rfc8739
+type AutoRenewalOptions struct {
+	// StartDate the earliest date of validity of the first certificate (optional).
+	StartDate time.Time
+	// EndDate the latest date of validity of the last certificate (required).
+	EndDate time.Time
+	// Lifetime the validity period of each certificate (required).
+	Lifetime time.Duration
+	// LifetimeAdjust the "left pad" added to the validity period of each certificate (optional).
+	LifetimeAdjust time.Duration
+	// AllowCertificateGet allows the unauthenticated GET requests to the star-certificate URL.
+	AllowCertificateGet bool
+}
+
 // New Creates a new order.
 func (o *OrderService) New(domains []string) (acme.ExtendedOrder, error) {
+	return o.NewWithOptions(domains, nil)
+}
+
+// NewWithExtensions Creates a new order with additional fields in the newOrder request body.
+//
+// Advanced usage: the extensions are vendor-specific fields (e.g. a "validity" field)
+// only supported by some private or experimental CAs.
+// The public CAs ignore or reject the unknown fields.
+// The extensions cannot override the standard fields of the order (e.g. "identifiers").
+func (o *OrderService) NewWithExtensions(domains []string, extensions map[string]interface{}) (acme.ExtendedOrder, error) {
+	return o.NewWithOptions(domains, &OrderOptions{Extensions: extensions})
+}
+
+// NewWithOptions Creates a new order with the optional fields of the order.
+// The notBefore and notAfter fields are sent (in RFC3339 format) only if they are set.
+func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acme.ExtendedOrder, error) {
+	if opts == nil {
+		opts = &OrderOptions{}
+	}
+
 	var identifiers []acme.Identifier
 	for _, domain := range domains {
-		identifiers = append(identifiers, acme.Identifier{Type: "dns", Value: domain})
+		identifiers = append(identifiers, newIdentifier(domain))
 	}
 
-	orderReq := acme.Order{Identifiers: identifiers}
+	order := acme.Order{Identifiers: identifiers}
 
-	var order acme.Order
-	resp, err := o.core.post(o.core.GetDirectory().NewOrderURL, orderReq, &order)
+	if !opts.NotBefore.IsZero() {
+		order.NotBefore = opts.NotBefore.Format(time.RFC3339)
+	}
+
+	if !opts.NotAfter.IsZero() {
+		order.NotAfter = opts.NotAfter.Format(time.RFC3339)
+	}
+
+	if opts.AutoRenewal != nil {
+		autoRenewal, err := o.newAutoRenewal(order, opts.AutoRenewal)
+		if err != nil {
+			return acme.ExtendedOrder{}, err
+		}
+
+		order.AutoRenewal = autoRenewal
+	}
+
+	orderReq, err := newOrderRequest(order, opts.Extensions)
+	if err != nil {
+		return acme.ExtendedOrder{}, err
+	}
+
+	var newOrder acme.Order
+	resp, err := o.core.post(o.core.GetDirectory().NewOrderURL, orderReq, &newOrder)
 	if err != nil {
 		return acme.ExtendedOrder{}, err
 	}
 
 	return acme.ExtendedOrder{
 		Location: resp.Header.Get("Location"),
-		Order:    order,
+		Order:    newOrder,
 	}, nil
 }
 
@@ -63,3 +141,134 @@ func (o *OrderService) UpdateForCSR(orderURL string, csr []byte) (acme.Order, er
 
 	return order, nil
 }
+
+// Cancel Cancels a STAR order: the CA stops the issuance of the certificates of the order.
+// - https://tools.ietf.org/html/rfc8739#section-3.1.2
+func (o *OrderService) Cancel(orderURL string) (acme.Order, error) {
+	if len(orderURL) == 0 {
+		return acme.Order{}, errors.New("order[cancel]: empty URL")
+	}
+
+	// only the status is sent: the other fields of an order (e.g. the identifiers) can't be updated.
+	req := struct {
+		Status string `json:"status"`
+	}{Status: acme.StatusCanceled}
+
+	var order acme.Order
+	_, err := o.core.post(orderURL, req, &order)
+	if err != nil {
+		return acme.Order{}, err
+	}
+
+	return order, nil
+}
+
+// newAutoRenewal creates the auto-renewal object of a STAR order,
+// after checking the parameters against the capabilities advertised by the CA.
+func (o *OrderService) newAutoRenewal(order acme.Order, opts *AutoRenewalOptions) (*acme.AutoRenewal, error) {
+	meta := o.core.GetDirectory().Meta.AutoRenewal
+	if meta == nil {
+		return nil, errors.New("order[new]: the CA does not support the auto-renewal extension (STAR)")
+	}
+
+	// https://tools.ietf.org/html/rfc8739#section-3.1.1
+	if order.NotBefore != "" || order.NotAfter != "" {
+		return nil, errors.New("order[new]: notBefore and notAfter cannot be used with the auto-renewal")
+	}
+
+	if opts.EndDate.IsZero() {
+		return nil, errors.New("order[new]: the end date of the auto-renewal is required")
+	}
+
+	startDate := opts.StartDate
+	if startDate.IsZero() {
+		startDate = time.Now()
+	}
+
+	if !opts.EndDate.After(startDate) {
+		return nil, errors.New("order[new]: the end date of the auto-renewal must be after the start date")
+	}
+
+	if opts.Lifetime < time.Duration(meta.MinLifetime)*time.Second {
+		return nil, fmt.Errorf("order[new]: the lifetime (%s) is lower than the minimum lifetime of the CA (%ds)", opts.Lifetime, meta.MinLifetime)
+	}
+
+	if meta.MaxDuration > 0 && opts.EndDate.Sub(startDate) > time.Duration(meta.MaxDuration)*time.Second {
+		return nil, fmt.Errorf("order[new]: the duration of the auto-renewal is greater than the maximum duration of the CA (%ds)", meta.MaxDuration)
+	}
+
+	if opts.AllowCertificateGet && !meta.AllowCertificateGet {
+		return nil, errors.New("order[new]: the CA does not allow the unauthenticated GET requests to the STAR certificates")
+	}
+
+	autoRenewal := &acme.AutoRenewal{
+		EndDate:             opts.EndDate.Format(time.RFC3339),
+		Lifetime:            int(opts.Lifetime / time.Second),
+		LifetimeAdjust:      int(opts.LifetimeAdjust / time.Second),
+		AllowCertificateGet: opts.AllowCertificateGet,
+	}
+
+	if !opts.StartDate.IsZero() {
+		autoRenewal.StartDate = opts.StartDate.Format(time.RFC3339)
+	}
+
+	return autoRenewal, nil
+}
+
+// newOrderRequest merges the extensions into the fields of the order.
+func newOrderRequest(order acme.Order, extensions map[string]interface{}) (interface{}, error) {
+	if len(extensions) == 0 {
+		return order, nil
+	}
+
+	raw, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+
+	orderReq := map[string]interface{}{}
+	err = json.Unmarshal(raw, &orderReq)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range extensions {
+		if _, ok := orderReq[key]; ok || isOrderField(key) {
+			return nil, fmt.Errorf("order[new]: the extension %q conflicts with a standard field of the order", key)
+		}
+
+		orderReq[key] = value
+	}
+
+	return orderReq, nil
+}
+
+// isOrderField returns true if the key is the JSON name of a field of an order.
+func isOrderField(key string) bool {
+	orderType := reflect.TypeOf(acme.Order{})
+
+	for i := 0; i < orderType.NumField(); i++ {
+		name := strings.Split(orderType.Field(i).Tag.Get("json"), ",")[0]
+		if name == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newIdentifier creates an identifier: an IP address identifier (RFC 8738) if the value is an IP, a DNS identifier otherwise.
+// An internationalized domain name is converted to its A-label form (punycode), as it appears in a certificate.
+// https://tools.ietf.org/html/rfc8738#section-3
+// https://tools.ietf.org/html/rfc8555#section-7.1.4
+func newIdentifier(value string) acme.Identifier {
+	if ip := net.ParseIP(value); ip != nil {
+		return acme.Identifier{Type: "ip", Value: ip.String()}
+	}
+
+	if ascii, err := idna.ToASCII(value); err == nil {
+		value = ascii
+	}
+
+	return acme.Identifier{Type: "dns", Value: value}
+}
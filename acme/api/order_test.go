@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/platform/tester"
@@ -66,6 +67,49 @@ func TestOrderService_New(t *testing.T) {
 	assert.Equal(t, expected, order)
 }
 
+func Test_newIdentifier(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected acme.Identifier
+	}{
+		{
+			desc:     "domain",
+			value:    "example.com",
+			expected: acme.Identifier{Type: "dns", Value: "example.com"},
+		},
+		{
+			desc:     "internationalized domain",
+			value:    "例え.テスト",
+			expected: acme.Identifier{Type: "dns", Value: "xn--r8jz45g.xn--zckzah"},
+		},
+		{
+			desc:     "internationalized wildcard domain",
+			value:    "*.bücher.example",
+			expected: acme.Identifier{Type: "dns", Value: "*.xn--bcher-kva.example"},
+		},
+		{
+			desc:     "IPv4",
+			value:    "192.0.2.1",
+			expected: acme.Identifier{Type: "ip", Value: "192.0.2.1"},
+		},
+		{
+			desc:     "IPv6",
+			value:    "2001:0db8:0000:0000:0000:0000:0000:0001",
+			expected: acme.Identifier{Type: "ip", Value: "2001:db8::1"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, newIdentifier(test.value))
+		})
+	}
+}
+
 func readSignedBody(r *http.Request, privateKey *rsa.PrivateKey) ([]byte, error) {
 	reqBody, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -87,3 +131,283 @@ func readSignedBody(r *http.Request, privateKey *rsa.PrivateKey) ([]byte, error)
 
 	return body, nil
 }
+
+func TestOrderService_NewWithExtensions(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	// small value keeps test fast
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, errK, "Could not generate test key")
+
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := readSignedBody(r, privateKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		payload := map[string]interface{}{}
+		err = json.Unmarshal(body, &payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if payload["validity"] != "P7D" {
+			http.Error(w, "the extension is missing", http.StatusBadRequest)
+			return
+		}
+
+		order := acme.Order{}
+		err = json.Unmarshal(body, &order)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = tester.WriteJSONResponse(w, acme.Order{
+			Status:      acme.StatusValid,
+			Identifiers: order.Identifiers,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	order, err := core.Orders.NewWithExtensions([]string{"example.com"}, map[string]interface{}{"validity": "P7D"})
+	require.NoError(t, err)
+
+	expected := acme.ExtendedOrder{
+		Order: acme.Order{
+			Status:      "valid",
+			Identifiers: []acme.Identifier{{Type: "dns", Value: "example.com"}},
+		},
+	}
+	assert.Equal(t, expected, order)
+}
+
+func TestOrderService_NewWithOptions(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	// small value keeps test fast
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, errK, "Could not generate test key")
+
+	var payload map[string]interface{}
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+		body, err := readSignedBody(r, privateKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		payload = map[string]interface{}{}
+		err = json.Unmarshal(body, &payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	opts := &OrderOptions{
+		NotBefore: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2021, time.January, 8, 12, 0, 0, 0, time.UTC),
+	}
+
+	_, err = core.Orders.NewWithOptions([]string{"example.com"}, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2021-01-01T00:00:00Z", payload["notBefore"])
+	assert.Equal(t, "2021-01-08T12:00:00Z", payload["notAfter"])
+
+	_, err = core.Orders.NewWithOptions([]string{"example.com"}, nil)
+	require.NoError(t, err)
+
+	assert.NotContains(t, payload, "notBefore")
+	assert.NotContains(t, payload, "notAfter")
+}
+
+func TestOrderService_NewWithOptions_autoRenewal(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	// small value keeps test fast
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, errK, "Could not generate test key")
+
+	var payload acme.Order
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+		body, err := readSignedBody(r, privateKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		payload = acme.Order{}
+		err = json.Unmarshal(body, &payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending, AutoRenewal: payload.AutoRenewal})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	opts := &OrderOptions{
+		AutoRenewal: &AutoRenewalOptions{
+			StartDate:           time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:             time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC),
+			Lifetime:            4 * 24 * time.Hour,
+			LifetimeAdjust:      time.Hour,
+			AllowCertificateGet: true,
+		},
+	}
+
+	_, err = core.Orders.NewWithOptions([]string{"example.com"}, opts)
+	require.EqualError(t, err, "order[new]: the CA does not support the auto-renewal extension (STAR)")
+
+	core.directory.Meta.AutoRenewal = &acme.AutoRenewalMeta{MinLifetime: 86400, MaxDuration: 31536000, AllowCertificateGet: true}
+
+	order, err := core.Orders.NewWithOptions([]string{"example.com"}, opts)
+	require.NoError(t, err)
+
+	expected := &acme.AutoRenewal{
+		StartDate:           "2021-01-01T00:00:00Z",
+		EndDate:             "2021-02-01T00:00:00Z",
+		Lifetime:            345600,
+		LifetimeAdjust:      3600,
+		AllowCertificateGet: true,
+	}
+	assert.Equal(t, expected, payload.AutoRenewal)
+	assert.Equal(t, expected, order.AutoRenewal)
+}
+
+func TestOrderService_newAutoRenewal_errors(t *testing.T) {
+	meta := &acme.AutoRenewalMeta{MinLifetime: 86400, MaxDuration: 31536000}
+
+	startDate := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		order    acme.Order
+		opts     *AutoRenewalOptions
+		expected string
+	}{
+		{
+			desc:     "notAfter",
+			order:    acme.Order{NotAfter: "2021-01-08T12:00:00Z"},
+			opts:     &AutoRenewalOptions{EndDate: startDate.Add(30 * 24 * time.Hour), Lifetime: 24 * time.Hour},
+			expected: "order[new]: notBefore and notAfter cannot be used with the auto-renewal",
+		},
+		{
+			desc:     "missing end date",
+			opts:     &AutoRenewalOptions{Lifetime: 24 * time.Hour},
+			expected: "order[new]: the end date of the auto-renewal is required",
+		},
+		{
+			desc:     "end date before start date",
+			opts:     &AutoRenewalOptions{StartDate: startDate, EndDate: startDate.Add(-time.Hour), Lifetime: 24 * time.Hour},
+			expected: "order[new]: the end date of the auto-renewal must be after the start date",
+		},
+		{
+			desc:     "lifetime too short",
+			opts:     &AutoRenewalOptions{StartDate: startDate, EndDate: startDate.Add(30 * 24 * time.Hour), Lifetime: time.Hour},
+			expected: "order[new]: the lifetime (1h0m0s) is lower than the minimum lifetime of the CA (86400s)",
+		},
+		{
+			desc:     "duration too long",
+			opts:     &AutoRenewalOptions{StartDate: startDate, EndDate: startDate.Add(400 * 24 * time.Hour), Lifetime: 24 * time.Hour},
+			expected: "order[new]: the duration of the auto-renewal is greater than the maximum duration of the CA (31536000s)",
+		},
+		{
+			desc:     "certificate GET not allowed",
+			opts:     &AutoRenewalOptions{StartDate: startDate, EndDate: startDate.Add(30 * 24 * time.Hour), Lifetime: 24 * time.Hour, AllowCertificateGet: true},
+			expected: "order[new]: the CA does not allow the unauthenticated GET requests to the STAR certificates",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			o := &OrderService{core: &Core{directory: acme.Directory{Meta: acme.Meta{AutoRenewal: meta}}}}
+
+			_, err := o.newAutoRenewal(test.order, test.opts)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestOrderService_Cancel(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	// small value keeps test fast
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, errK, "Could not generate test key")
+
+	mux.HandleFunc("/order/1", func(w http.ResponseWriter, r *http.Request) {
+		body, err := readSignedBody(r, privateKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if string(body) != `{"status":"canceled"}` {
+			http.Error(w, "invalid body: "+string(body), http.StatusBadRequest)
+			return
+		}
+
+		err = tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusCanceled, StarCertificate: apiURL + "/star/1"})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	order, err := core.Orders.Cancel(apiURL + "/order/1")
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusCanceled, order.Status)
+}
+
+func Test_newOrderRequest_conflict(t *testing.T) {
+	order := acme.Order{Identifiers: []acme.Identifier{{Type: "dns", Value: "example.com"}}}
+
+	_, err := newOrderRequest(order, map[string]interface{}{"notAfter": "2020-01-01T00:00:00Z"})
+	require.EqualError(t, err, `order[new]: the extension "notAfter" conflicts with a standard field of the order`)
+
+	_, err = newOrderRequest(order, map[string]interface{}{"identifiers": nil})
+	require.EqualError(t, err, `order[new]: the extension "identifiers" conflicts with a standard field of the order`)
+}
@@ -11,19 +11,30 @@ type service struct {
 
 // getLink get a rel into the Link header
 func getLink(header http.Header, rel string) string {
+	links := getLinks(header, rel)
+	if len(links) < 1 {
+		return ""
+	}
+
+	return links[0]
+}
+
+// getLinks get all the URLs of a rel into the Link header
+func getLinks(header http.Header, rel string) []string {
 	var linkExpr = regexp.MustCompile(`<(.+?)>;\s*rel="(.+?)"`)
 
+	var links []string
 	for _, link := range header["Link"] {
 		for _, m := range linkExpr.FindAllStringSubmatch(link, -1) {
 			if len(m) != 3 {
 				continue
 			}
 			if m[2] == rel {
-				return m[1]
+				links = append(links, m[1])
 			}
 		}
 	}
-	return ""
+	return links
 }
 
 // getLocation get the value of the header Location
@@ -12,11 +12,30 @@ import (
 const (
 	StatusPending     = "pending"
 	StatusInvalid     = "invalid"
+	StatusReady       = "ready"
 	StatusValid       = "valid"
 	StatusProcessing  = "processing"
 	StatusDeactivated = "deactivated"
 	StatusExpired     = "expired"
 	StatusRevoked     = "revoked"
+	// StatusCanceled the status of a canceled STAR order.
+	// https://tools.ietf.org/html/rfc8739#section-3.1.2
+	StatusCanceled = "canceled"
+)
+
+// CRL reason codes
+// - https://tools.ietf.org/html/rfc5280#section-5.3.1
+const (
+	CRLReasonUnspecified          uint = 0
+	CRLReasonKeyCompromise        uint = 1
+	CRLReasonCACompromise         uint = 2
+	CRLReasonAffiliationChanged   uint = 3
+	CRLReasonSuperseded           uint = 4
+	CRLReasonCessationOfOperation uint = 5
+	CRLReasonCertificateHold      uint = 6
+	CRLReasonRemoveFromCRL        uint = 8
+	CRLReasonPrivilegeWithdrawn   uint = 9
+	CRLReasonAACompromise         uint = 10
 )
 
 // Directory the ACME directory object.
@@ -55,6 +74,27 @@ type Meta struct {
 	// then the CA requires that all new- account requests include an "externalAccountBinding" field
 	// associating the new account with an external account.
 	ExternalAccountRequired bool `json:"externalAccountRequired"`
+
+	// auto-renewal (optional, object):
+	// Advertises the support of the STAR (Short-Term, Automatically Renewed) certificates.
+	// - https://tools.ietf.org/html/rfc8739#section-3.1.3
+	AutoRenewal *AutoRenewalMeta `json:"auto-renewal,omitempty"`
+}
+
+// AutoRenewalMeta the capabilities of the server related to the STAR certificates (related to Meta).
+// - https://tools.ietf.org/html/rfc8739#section-3.1.3
+type AutoRenewalMeta struct {
+	// min-lifetime (required, integer):
+	// The minimum acceptable value for the lifetime of the certificates, in seconds.
+	MinLifetime int `json:"min-lifetime"`
+
+	// max-duration (required, integer):
+	// The maximum delta between the start-date and the end-date of the auto-renewal, in seconds.
+	MaxDuration int `json:"max-duration"`
+
+	// allow-certificate-get (optional, boolean):
+	// Indicates whether the server supports the unauthenticated GET requests to the star-certificate URL.
+	AllowCertificateGet bool `json:"allow-certificate-get,omitempty"`
 }
 
 // ExtendedAccount a extended Account.
@@ -161,6 +201,41 @@ type Order struct {
 	// certificate (optional, string):
 	// A URL for the certificate that has been issued in response to this order
 	Certificate string `json:"certificate,omitempty"`
+
+	// auto-renewal (optional, object):
+	// The parameters of the automatic renewal of a STAR order.
+	// - https://tools.ietf.org/html/rfc8739#section-3.1.1
+	AutoRenewal *AutoRenewal `json:"auto-renewal,omitempty"`
+
+	// star-certificate (optional, string):
+	// A URL for the rolling certificate of a STAR order (replaces the certificate field).
+	// - https://tools.ietf.org/html/rfc8739#section-3.1.1
+	StarCertificate string `json:"star-certificate,omitempty"`
+}
+
+// AutoRenewal the auto-renewal object of a STAR order.
+// - https://tools.ietf.org/html/rfc8739#section-3.1.1
+type AutoRenewal struct {
+	// start-date (optional, string):
+	// The earliest date of validity of the first certificate issued, in RFC 3339 format.
+	// The default is the time of the issuance of the first certificate.
+	StartDate string `json:"start-date,omitempty"`
+
+	// end-date (required, string):
+	// The latest date of validity of the last certificate issued, in RFC 3339 format.
+	EndDate string `json:"end-date"`
+
+	// lifetime (required, integer):
+	// The maximum validity period of each certificate, in seconds.
+	Lifetime int `json:"lifetime"`
+
+	// lifetime-adjust (optional, integer):
+	// The amount of "left pad" added to each certificate, in seconds.
+	LifetimeAdjust int `json:"lifetime-adjust,omitempty"`
+
+	// allow-certificate-get (optional, boolean):
+	// Allows the unauthenticated GET requests to the star-certificate URL.
+	AllowCertificateGet bool `json:"allow-certificate-get,omitempty"`
 }
 
 // Authorization the ACME authorization object.
@@ -194,6 +269,15 @@ type Authorization struct {
 	// For authorizations created as a result of a newOrder request containing a DNS identifier
 	// with a value that contained a wildcard prefix this field MUST be present, and true.
 	Wildcard bool `json:"wildcard,omitempty"`
+
+	// The authorization URL, set when the authorization is fetched (not part of the authorization object).
+	URL string `json:"-"`
+}
+
+// IsReusable returns true if the authorization is valid and not expired:
+// the identifier doesn't need to be validated again.
+func (a Authorization) IsReusable() bool {
+	return a.Status == StatusValid && (a.Expires.IsZero() || time.Now().Before(a.Expires))
 }
 
 // ExtendedChallenge a extended Challenge.
@@ -255,6 +339,12 @@ type Identifier struct {
 	Value string `json:"value"`
 }
 
+// RawCertificate raw data of a certificate.
+type RawCertificate struct {
+	Cert   []byte
+	Issuer []byte
+}
+
 // CSRMessage Certificate Signing Request
 // - https://tools.ietf.org/html/draft-ietf-acme-acme-16#section-7.4
 type CSRMessage struct {
@@ -2,12 +2,19 @@ package acme
 
 import (
 	"fmt"
+	"time"
 )
 
 // Errors types
 const (
-	errNS       = "urn:ietf:params:acme:error:"
-	BadNonceErr = errNS + "badNonce"
+	errNS                    = "urn:ietf:params:acme:error:"
+	AccountDoesNotExistErr   = errNS + "accountDoesNotExist"
+	BadNonceErr              = errNS + "badNonce"
+	BadSignatureAlgorithmErr = errNS + "badSignatureAlgorithm"
+	InvalidContactErr        = errNS + "invalidContact"
+	RateLimitedErr           = errNS + "rateLimited"
+	UnauthorizedErr          = errNS + "unauthorized"
+	UnsupportedContactErr    = errNS + "unsupportedContact"
 )
 
 // ProblemDetails the problem details object
@@ -55,4 +62,29 @@ func (p ProblemDetails) Error() string {
 // if the nonce sent by the client was not accepted by the server.
 type NonceError struct {
 	*ProblemDetails
+	// Nonce is the nonce rejected by the server (can be empty).
+	Nonce string `json:"-"`
+}
+
+// RateLimitedError represents the error which is returned
+// if the client exceeded a rate limit of the server (rateLimited problem or 429 status code).
+type RateLimitedError struct {
+	*ProblemDetails
+	// RetryAfter is the time from which the request can be retried,
+	// parsed from the Retry-After header of the response (zero if the header is absent or invalid).
+	RetryAfter time.Time `json:"-"`
+	// RateLimit is the quota advertised by the rate limit headers of the response (nil if the server doesn't send them).
+	RateLimit *RateLimit `json:"-"`
+}
+
+// RateLimit the quota advertised by the rate limit headers of a response
+// (RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, or their X-RateLimit-* variants).
+// The fields of the absent headers are left to zero.
+type RateLimit struct {
+	// Limit the number of requests allowed in the current window.
+	Limit int
+	// Remaining the number of requests remaining in the current window.
+	Remaining int
+	// Reset the time of the reset of the quota.
+	Reset time.Time
 }
@@ -0,0 +1,36 @@
+package acme
+
+import "time"
+
+// Metrics receives the events of the client, for observability (e.g. Prometheus metrics).
+// The methods can be called concurrently.
+type Metrics interface {
+	// OnRequest is called after each HTTP request sent to the ACME server.
+	// The status is 0 if no response has been received (e.g. a network error).
+	OnRequest(method, url string, status int, duration time.Duration)
+
+	// OnNonceError is called when a request rejected because of a bad nonce is retried.
+	OnNonceError()
+
+	// OnChallengeSolved is called after the resolution (successful or not) of a challenge.
+	OnChallengeSolved(chlgType string, duration time.Duration, err error)
+
+	// OnObtain is called after an attempt (successful or not) to obtain a certificate.
+	OnObtain(duration time.Duration, err error)
+}
+
+// NoopMetrics a Metrics ignoring all the events.
+// It can be embedded to implement only some of the methods of Metrics.
+type NoopMetrics struct{}
+
+// OnRequest implements Metrics.
+func (NoopMetrics) OnRequest(string, string, int, time.Duration) {}
+
+// OnNonceError implements Metrics.
+func (NoopMetrics) OnNonceError() {}
+
+// OnChallengeSolved implements Metrics.
+func (NoopMetrics) OnChallengeSolved(string, time.Duration, error) {}
+
+// OnObtain implements Metrics.
+func (NoopMetrics) OnObtain(time.Duration, error) {}
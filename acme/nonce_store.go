@@ -0,0 +1,12 @@
+package acme
+
+// NonceStore an external store of the unused nonces,
+// it allows to reuse the nonces between short-lived processes (e.g. a cache shared between serverless invocations).
+// The methods can be called concurrently.
+type NonceStore interface {
+	// Get removes a nonce from the store and returns it, or an empty string if the store is empty.
+	Get() (string, error)
+
+	// Put adds a nonce to the store.
+	Put(nonce string) error
+}
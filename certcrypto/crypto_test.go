@@ -5,6 +5,7 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
 	"testing"
 	"time"
 
@@ -19,6 +20,27 @@ func TestGeneratePrivateKey(t *testing.T) {
 	assert.NotNil(t, key)
 }
 
+func TestGeneratePrivateKey_ed25519(t *testing.T) {
+	key, err := GeneratePrivateKey(Ed25519)
+	require.NoError(t, err, "Error generating private key")
+
+	pemBytes := PEMEncode(key)
+
+	parsed, err := ParsePEMPrivateKey(pemBytes)
+	require.NoError(t, err)
+
+	assert.Equal(t, key, parsed)
+
+	csr, err := GenerateCSR(key, "lego.acme", nil, false)
+	require.NoError(t, err)
+
+	req, err := x509.ParseCertificateRequest(csr)
+	require.NoError(t, err)
+
+	assert.Equal(t, x509.PureEd25519, req.SignatureAlgorithm)
+	assert.NoError(t, req.CheckSignature())
+}
+
 func TestGenerateCSR(t *testing.T) {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
 	require.NoError(t, err, "Error generating private key")
@@ -102,6 +124,25 @@ func TestGenerateCSR(t *testing.T) {
 	}
 }
 
+func TestGenerateCSR_ipAddress(t *testing.T) {
+	privateKey, err := GeneratePrivateKey(EC256)
+	require.NoError(t, err, "Error generating private key")
+
+	csrBytes, err := GenerateCSR(privateKey, "192.0.2.1", []string{"192.0.2.1", "lego.acme", "2001:db8::1"}, false)
+	require.NoError(t, err)
+
+	csr, err := x509.ParseCertificateRequest(csrBytes)
+	require.NoError(t, err)
+
+	assert.Empty(t, csr.Subject.CommonName)
+	assert.Equal(t, []string{"lego.acme"}, csr.DNSNames)
+	require.Len(t, csr.IPAddresses, 2)
+	assert.Equal(t, "192.0.2.1", csr.IPAddresses[0].String())
+	assert.Equal(t, "2001:db8::1", csr.IPAddresses[1].String())
+
+	assert.Equal(t, []string{"lego.acme", "192.0.2.1", "2001:db8::1"}, ExtractDomainsCSR(csr))
+}
+
 func TestPEMEncode(t *testing.T) {
 	buf := bytes.NewBufferString("TestingRSAIsSoMuchFun")
 
@@ -0,0 +1,144 @@
+package certcrypto
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// oidSignedData the content type of a PKCS#7 SignedData.
+var oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+// pkcs7ContentInfo a PKCS#7 ContentInfo (RFC 2315, section 7).
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+// pkcs7SignedData a PKCS#7 SignedData (RFC 2315, section 9.1).
+// Only the certificates are used: the CAs return a "certs-only" SignedData without signers.
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      asn1.RawValue
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      asn1.RawValue
+}
+
+// ParsePKCS7Certificates parses the certificates of a PKCS#7 SignedData (e.g. a certificate chain returned as "application/pkcs7-mime").
+// The data can be DER or PEM encoded ("PKCS7" block).
+// The certificates of a SignedData are a SET OF (in any order): they are returned as a chain,
+// the leaf certificate first, followed by its issuers.
+func ParsePKCS7Certificates(data []byte) ([]*x509.Certificate, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "PKCS7" {
+			return nil, fmt.Errorf("PKCS#7: unexpected PEM block type: %s", block.Type)
+		}
+
+		data = block.Bytes
+	}
+
+	var info pkcs7ContentInfo
+	_, err := asn1.Unmarshal(data, &info)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#7: invalid content info: %v", err)
+	}
+
+	if !info.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("PKCS#7: unsupported content type: %s", info.ContentType)
+	}
+
+	var signedData pkcs7SignedData
+	_, err = asn1.Unmarshal(info.Content.Bytes, &signedData)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#7: invalid signed data: %v", err)
+	}
+
+	if len(signedData.Certificates.Bytes) == 0 {
+		return nil, errors.New("PKCS#7: no certificate")
+	}
+
+	certs, err := x509.ParseCertificates(signedData.Certificates.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortChain(certs), nil
+}
+
+// sortChain orders the certificates from the leaf certificate (the first non-CA certificate) to the root, by following the issuers.
+// Without non-CA certificate (e.g. an issuer chain), the chain starts with the certificate which is not the issuer of the others.
+// The certificates outside of this path are kept at the end, in their original order.
+func sortChain(certs []*x509.Certificate) []*x509.Certificate {
+	if len(certs) < 2 {
+		return certs
+	}
+
+	chain := []*x509.Certificate{chainStart(certs)}
+	for current := chain[0]; ; {
+		next := findIssuer(certs, current)
+		if next == nil || containsCertificate(chain, next) {
+			break
+		}
+
+		chain = append(chain, next)
+		current = next
+	}
+
+	for _, cert := range certs {
+		if !containsCertificate(chain, cert) {
+			chain = append(chain, cert)
+		}
+	}
+
+	return chain
+}
+
+// chainStart returns the first certificate of the chain: the leaf certificate, or the certificate which is not the issuer of the others.
+func chainStart(certs []*x509.Certificate) *x509.Certificate {
+	for _, cert := range certs {
+		if !cert.IsCA {
+			return cert
+		}
+	}
+
+	for _, cert := range certs {
+		issuer := false
+		for _, other := range certs {
+			if !other.Equal(cert) && bytes.Equal(other.RawIssuer, cert.RawSubject) {
+				issuer = true
+				break
+			}
+		}
+
+		if !issuer {
+			return cert
+		}
+	}
+
+	return certs[0]
+}
+
+func findIssuer(certs []*x509.Certificate, cert *x509.Certificate) *x509.Certificate {
+	for _, candidate := range certs {
+		if !candidate.Equal(cert) && bytes.Equal(cert.RawIssuer, candidate.RawSubject) {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+
+	return false
+}
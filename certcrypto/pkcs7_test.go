@@ -0,0 +1,203 @@
+package certcrypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pkcs7Chain a "certs-only" PKCS#7 SignedData (generated by "openssl crl2pkcs7 -nocrl"),
+// containing the certificates of example.com and issuer.example.com.
+const pkcs7Chain = `-----BEGIN PKCS7-----
+MIIEUQYJKoZIhvcNAQcCoIIEQjCCBD4CAQExADALBgkqhkiG9w0BBwGgggQmMIIC
+CDCCAXGgAwIBAgIUMpaHVOkATVhUdiJ5wARQWK+UCDgwDQYJKoZIhvcNAQELBQAw
+FjEUMBIGA1UEAwwLZXhhbXBsZS5jb20wHhcNMjYxMDE2MTgxMTE3WhcNMzYxMDEz
+MTgxMTE3WjAWMRQwEgYDVQQDDAtleGFtcGxlLmNvbTCBnzANBgkqhkiG9w0BAQEF
+AAOBjQAwgYkCgYEAw6AOC5txtAHKhj1vLX1GiCQwjurrFwJRRC0adbi9Q5MVSUr/
+BA1cezZdLLzChEGKdYRWFTE0d2SLiExh3+0ispJK1yW1h5ggnud86wBvuNIQiYST
+702Z7aFAYUNUR1VNYVysTE0mnB/xTNkk6Safdca86uTxVyaFBv2ZTrs4QtkCAwEA
+AaNTMFEwHQYDVR0OBBYEFKrYQg22rLP5zY4XrvFAOaFNZccyMB8GA1UdIwQYMBaA
+FKrYQg22rLP5zY4XrvFAOaFNZccyMA8GA1UdEwEB/wQFMAMBAf8wDQYJKoZIhvcN
+AQELBQADgYEAFHk1tm6JMoa4y0SKOt79DsuOxU9D22zhMFVnXu3bxbCKgaUwbL0l
+vdoupFzh4sp4LzzGM8KvZV92381ClVcfWdK6V5kuP77nS9i1b0LJtwgB5MBRawf7
+IwG6hptRpj9DNuRW4gRVpXYQN5OfyrggeEE3UTpeDDSEwlEOQ/Kq2M4wggIWMIIB
+f6ADAgECAhRL/7KAj/V943ucZNbmLfuKrkqkpjANBgkqhkiG9w0BAQsFADAdMRsw
+GQYDVQQDDBJpc3N1ZXIuZXhhbXBsZS5jb20wHhcNMjYxMDE2MTgxMTE3WhcNMzYx
+MDEzMTgxMTE3WjAdMRswGQYDVQQDDBJpc3N1ZXIuZXhhbXBsZS5jb20wgZ8wDQYJ
+KoZIhvcNAQEBBQADgY0AMIGJAoGBAMPKsZKLxVdOyD2BcURU9DAdc0lh9k72Jk6Y
+7t6gzvCeT179+YdB+7wkSkhGIQwWqffThI8JGSkS/HtCH/4FCwVdOwbau6Pz02Un
+ogKujn9cmaWBFf9NeTM86UL4Vm5Z3sCiiXb7UoQCjT5K1L9dS3vGVm6U9yFFGCFZ
+ka+wCP65AgMBAAGjUzBRMB0GA1UdDgQWBBSCGD2209YoFDniU80tneXCb8hx2DAf
+BgNVHSMEGDAWgBSCGD2209YoFDniU80tneXCb8hx2DAPBgNVHRMBAf8EBTADAQH/
+MA0GCSqGSIb3DQEBCwUAA4GBAIBw7rIwEEw4aPngLJz23lLMVdaHYGjFtNE2OkAm
+j3ZOn1IBO8sYDiZ8Y0U4X0ad/wZhkXr/k39Td1H2E1e1N66Vf8W53NhyWt+ABsJ2
+o1+RmhpDKfnazymTEdmssQcaMgTq2PGCAJ0fDF/p/uk1/re7JW+z2NjmCCFqNb5p
+dxX1MQA=
+-----END PKCS7-----
+`
+
+func TestParsePKCS7Certificates(t *testing.T) {
+	block, _ := pem.Decode([]byte(pkcs7Chain))
+	require.NotNil(t, block)
+
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{
+			desc: "PEM",
+			data: []byte(pkcs7Chain),
+		},
+		{
+			desc: "DER",
+			data: block.Bytes,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			certs, err := ParsePKCS7Certificates(test.data)
+			require.NoError(t, err)
+
+			require.Len(t, certs, 2)
+			assert.Equal(t, "example.com", certs[0].Subject.CommonName)
+			assert.Equal(t, "issuer.example.com", certs[1].Subject.CommonName)
+		})
+	}
+}
+
+func TestParsePKCS7Certificates_unordered(t *testing.T) {
+	root, rootKey := newTestCertificate(t, "root", nil, nil, true)
+	intermediate, intermediateKey := newTestCertificate(t, "intermediate", root, rootKey, true)
+	leaf, _ := newTestCertificate(t, "example.com", intermediate, intermediateKey, false)
+
+	testCases := []struct {
+		desc  string
+		certs []*x509.Certificate
+	}{
+		{
+			desc:  "reversed",
+			certs: []*x509.Certificate{root, intermediate, leaf},
+		},
+		{
+			desc:  "shuffled",
+			certs: []*x509.Certificate{intermediate, leaf, root},
+		},
+		{
+			desc:  "ordered",
+			certs: []*x509.Certificate{leaf, intermediate, root},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			certs, err := ParsePKCS7Certificates(newTestPKCS7(t, test.certs...))
+			require.NoError(t, err)
+
+			var names []string
+			for _, cert := range certs {
+				names = append(names, cert.Subject.CommonName)
+			}
+
+			assert.Equal(t, []string{"example.com", "intermediate", "root"}, names)
+		})
+	}
+}
+
+func TestParsePKCS7Certificates_issuersOnly(t *testing.T) {
+	root, rootKey := newTestCertificate(t, "root", nil, nil, true)
+	intermediate, _ := newTestCertificate(t, "intermediate", root, rootKey, true)
+
+	certs, err := ParsePKCS7Certificates(newTestPKCS7(t, root, intermediate))
+	require.NoError(t, err)
+
+	require.Len(t, certs, 2)
+	assert.Equal(t, "intermediate", certs[0].Subject.CommonName)
+	assert.Equal(t, "root", certs[1].Subject.CommonName)
+}
+
+func TestParsePKCS7Certificates_error(t *testing.T) {
+	_, err := ParsePKCS7Certificates([]byte("-----BEGIN CERTIFICATE-----\nMIIBCgKCAQEA\n-----END CERTIFICATE-----\n"))
+	require.EqualError(t, err, "PKCS#7: unexpected PEM block type: CERTIFICATE")
+
+	_, err = ParsePKCS7Certificates([]byte("invalid"))
+	require.Error(t, err)
+}
+
+// newTestCertificate creates a certificate signed by the parent (self-signed if the parent is nil).
+func newTestCertificate(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+// newTestPKCS7 creates a "certs-only" PKCS#7 SignedData with the certificates in the given order.
+func newTestPKCS7(t *testing.T, certs ...*x509.Certificate) []byte {
+	t.Helper()
+
+	var raw []byte
+	for _, cert := range certs {
+		raw = append(raw, cert.Raw...)
+	}
+
+	emptySet := asn1.RawValue{FullBytes: []byte{0x31, 0x00}}
+
+	contentInfo, err := asn1.Marshal(struct{ ContentType asn1.ObjectIdentifier }{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}})
+	require.NoError(t, err)
+
+	signedData, err := asn1.Marshal(pkcs7SignedData{
+		Version:          1,
+		DigestAlgorithms: emptySet,
+		ContentInfo:      asn1.RawValue{FullBytes: contentInfo},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: raw},
+		CRLs:             asn1.RawValue{FullBytes: []byte{}},
+		SignerInfos:      emptySet,
+	})
+	require.NoError(t, err)
+
+	data, err := asn1.Marshal(pkcs7ContentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedData},
+	})
+	require.NoError(t, err)
+
+	return data
+}
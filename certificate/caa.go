@@ -0,0 +1,114 @@
+package certificate
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/vostronet/lego/log"
+	"github.com/miekg/dns"
+)
+
+// CAACheck the mode of the CAA records pre-check, done before ordering a certificate.
+type CAACheck int
+
+const (
+	// CAACheckDisabled the CAA records are not checked (the default).
+	CAACheckDisabled CAACheck = iota
+	// CAACheckWarn a warning is logged if the CAA records don't allow the CA to issue the certificate.
+	CAACheckWarn
+	// CAACheckEnforce the certificate is not ordered if the CAA records don't allow the CA to issue it.
+	CAACheckEnforce
+)
+
+// caaCriticalFlag the "Issuer Critical" flag of a CAA record.
+const caaCriticalFlag = 128
+
+// checkCAA verifies that the CAA records of the domains allow the CA to issue the certificate.
+// The CA is identified by the CAA identities of the directory metadata:
+// the check is skipped if the CA doesn't provide them.
+func (c *Certifier) checkCAA(domains []string) error {
+	if c.options.CAACheck == CAACheckDisabled {
+		return nil
+	}
+
+	identities := c.core.GetDirectory().Meta.CaaIdentities
+	if len(identities) == 0 {
+		log.Warnf("[%s] acme: The CA doesn't provide its CAA identities, the CAA check is skipped", strings.Join(domains, ", "))
+		return nil
+	}
+
+	failures := make(obtainError)
+
+	for _, domain := range domains {
+		if net.ParseIP(domain) != nil {
+			continue
+		}
+
+		records, err := c.lookupCAA(strings.TrimPrefix(domain, "*."))
+		if err != nil {
+			failures[domain] = err
+			continue
+		}
+
+		err = checkCAARecords(domain, records, identities)
+		if err != nil {
+			failures[domain] = err
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	if c.options.CAACheck == CAACheckWarn {
+		for domain, err := range failures {
+			log.Warnf("[%s] acme: CAA check: %v", domain, err)
+		}
+		return nil
+	}
+
+	return failures
+}
+
+// checkCAARecords verifies that the relevant CAA record set of a domain allows one of the CA identities
+// to issue a certificate for the domain (RFC 8659, section 4).
+func checkCAARecords(domain string, records []*dns.CAA, identities []string) error {
+	var issue, issueWild []*dns.CAA
+
+	for _, record := range records {
+		switch strings.ToLower(record.Tag) {
+		case "issue":
+			issue = append(issue, record)
+		case "issuewild":
+			issueWild = append(issueWild, record)
+		case "iodef":
+		default:
+			if record.Flag&caaCriticalFlag != 0 {
+				return fmt.Errorf("the CAA record with the unknown critical property %q forbids the issuance", record.Tag)
+			}
+		}
+	}
+
+	relevant := issue
+	if strings.HasPrefix(domain, "*.") && len(issueWild) > 0 {
+		relevant = issueWild
+	}
+
+	// no issue property: all the CAs are allowed.
+	if len(relevant) == 0 {
+		return nil
+	}
+
+	for _, record := range relevant {
+		issuer := strings.TrimSpace(strings.SplitN(record.Value, ";", 2)[0])
+
+		for _, identity := range identities {
+			if strings.EqualFold(issuer, identity) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("the CAA records don't allow the CA (%s) to issue a certificate", strings.Join(identities, ", "))
+}
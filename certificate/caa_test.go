@@ -0,0 +1,208 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/acme/api"
+)
+
+func Test_checkCAARecords(t *testing.T) {
+	identities := []string{"letsencrypt.org"}
+
+	testCases := []struct {
+		desc     string
+		domain   string
+		records  []*dns.CAA
+		expected string
+	}{
+		{
+			desc:   "no records",
+			domain: "example.com",
+		},
+		{
+			desc:   "iodef only",
+			domain: "example.com",
+			records: []*dns.CAA{
+				{Tag: "iodef", Value: "mailto:security@example.com"},
+			},
+		},
+		{
+			desc:   "allowed",
+			domain: "example.com",
+			records: []*dns.CAA{
+				{Tag: "issue", Value: "pki.goog"},
+				{Tag: "issue", Value: "LetsEncrypt.org; validationmethods=dns-01"},
+			},
+		},
+		{
+			desc:   "forbidden",
+			domain: "example.com",
+			records: []*dns.CAA{
+				{Tag: "issue", Value: "pki.goog"},
+			},
+			expected: "the CAA records don't allow the CA (letsencrypt.org) to issue a certificate",
+		},
+		{
+			desc:   "no CA allowed",
+			domain: "example.com",
+			records: []*dns.CAA{
+				{Tag: "issue", Value: ";"},
+			},
+			expected: "the CAA records don't allow the CA (letsencrypt.org) to issue a certificate",
+		},
+		{
+			desc:   "wildcard allowed by issuewild",
+			domain: "*.example.com",
+			records: []*dns.CAA{
+				{Tag: "issue", Value: "pki.goog"},
+				{Tag: "issuewild", Value: "letsencrypt.org"},
+			},
+		},
+		{
+			desc:   "wildcard forbidden by issuewild",
+			domain: "*.example.com",
+			records: []*dns.CAA{
+				{Tag: "issue", Value: "letsencrypt.org"},
+				{Tag: "issuewild", Value: ";"},
+			},
+			expected: "the CAA records don't allow the CA (letsencrypt.org) to issue a certificate",
+		},
+		{
+			desc:   "wildcard without issuewild",
+			domain: "*.example.com",
+			records: []*dns.CAA{
+				{Tag: "issue", Value: "letsencrypt.org"},
+			},
+		},
+		{
+			desc:   "issuewild ignored for non-wildcard",
+			domain: "example.com",
+			records: []*dns.CAA{
+				{Tag: "issuewild", Value: "pki.goog"},
+			},
+		},
+		{
+			desc:   "unknown critical property",
+			domain: "example.com",
+			records: []*dns.CAA{
+				{Flag: 128, Tag: "tbs", Value: "unknown"},
+				{Tag: "issue", Value: "letsencrypt.org"},
+			},
+			expected: `the CAA record with the unknown critical property "tbs" forbids the issuance`,
+		},
+		{
+			desc:   "unknown non-critical property",
+			domain: "example.com",
+			records: []*dns.CAA{
+				{Tag: "tbs", Value: "unknown"},
+				{Tag: "issue", Value: "letsencrypt.org"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			err := checkCAARecords(test.domain, test.records, identities)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestCertifier_checkCAA(t *testing.T) {
+	records := map[string][]*dns.CAA{
+		"allowed.com":   {{Tag: "issue", Value: "letsencrypt.org"}},
+		"forbidden.com": {{Tag: "issue", Value: "pki.goog"}},
+	}
+
+	lookup := func(domain string) ([]*dns.CAA, error) {
+		if domain == "error.com" {
+			return nil, errors.New("SERVFAIL")
+		}
+		return records[domain], nil
+	}
+
+	testCases := []struct {
+		desc       string
+		mode       CAACheck
+		identities []string
+		domains    []string
+		expected   string
+	}{
+		{
+			desc:       "disabled",
+			mode:       CAACheckDisabled,
+			identities: []string{"letsencrypt.org"},
+			domains:    []string{"forbidden.com"},
+		},
+		{
+			desc:       "warn",
+			mode:       CAACheckWarn,
+			identities: []string{"letsencrypt.org"},
+			domains:    []string{"forbidden.com"},
+		},
+		{
+			desc:       "enforce allowed",
+			mode:       CAACheckEnforce,
+			identities: []string{"letsencrypt.org"},
+			domains:    []string{"allowed.com", "*.allowed.com", "127.0.0.1"},
+		},
+		{
+			desc:       "enforce forbidden",
+			mode:       CAACheckEnforce,
+			identities: []string{"letsencrypt.org"},
+			domains:    []string{"allowed.com", "forbidden.com"},
+			expected:   "[forbidden.com] the CAA records don't allow the CA (letsencrypt.org) to issue a certificate",
+		},
+		{
+			desc:       "enforce lookup error",
+			mode:       CAACheckEnforce,
+			identities: []string{"letsencrypt.org"},
+			domains:    []string{"error.com"},
+			expected:   "[error.com] SERVFAIL",
+		},
+		{
+			desc:    "no CAA identities",
+			mode:    CAACheckEnforce,
+			domains: []string{"forbidden.com"},
+		},
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			state := api.State{Directory: acme.Directory{
+				NewNonceURL:   "https://example.com/nonce",
+				NewAccountURL: "https://example.com/account",
+				Meta:          acme.Meta{CaaIdentities: test.identities},
+			}}
+
+			core, err := api.NewWithState(http.DefaultClient, "lego-test", state, privateKey)
+			require.NoError(t, err)
+
+			certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{CAACheck: test.mode})
+			certifier.lookupCAA = lookup
+
+			err = certifier.checkCAA(test.domains)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expected)
+			}
+		})
+	}
+}
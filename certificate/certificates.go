@@ -8,7 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,8 +18,10 @@ import (
 	"github.com/vostronet/lego/acme/api"
 	"github.com/vostronet/lego/certcrypto"
 	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/challenge/dns01"
 	"github.com/vostronet/lego/log"
 	"github.com/vostronet/lego/platform/wait"
+	"github.com/miekg/dns"
 	"golang.org/x/crypto/ocsp"
 	"golang.org/x/net/idna"
 )
@@ -38,6 +42,92 @@ type Resource struct {
 	Certificate       []byte `json:"-"`
 	IssuerCertificate []byte `json:"-"`
 	CSR               []byte `json:"-"`
+	// Validations the type of the challenge validated by the CA for each domain.
+	Validations map[string]challenge.Type `json:"validations,omitempty"`
+	// OrderURL the URL of the order of the certificate (see Certifier.Resume).
+	OrderURL string `json:"orderUrl,omitempty"`
+}
+
+// IssuerChain parses the issuer certificates, ordered from the issuer of the leaf certificate to the root.
+// The certificates of the Certificate bundle are used if IssuerCertificate is empty.
+// The leaf certificate and the duplicates are removed from the chain.
+func (r *Resource) IssuerChain() ([]*x509.Certificate, error) {
+	var leaf *x509.Certificate
+	var bundle []*x509.Certificate
+
+	if len(r.Certificate) > 0 {
+		certs, err := certcrypto.ParsePEMBundle(r.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the certificate: %v", err)
+		}
+
+		leaf, bundle = certs[0], certs[1:]
+	}
+
+	candidates := bundle
+	if len(r.IssuerCertificate) > 0 {
+		certs, err := certcrypto.ParsePEMBundle(r.IssuerCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the issuer certificate: %v", err)
+		}
+
+		candidates = certs
+	}
+
+	// removes the leaf certificate and the duplicates.
+	var issuers []*x509.Certificate
+	for _, cert := range candidates {
+		if (leaf != nil && cert.Equal(leaf)) || containsCertificate(issuers, cert) {
+			continue
+		}
+
+		issuers = append(issuers, cert)
+	}
+
+	if leaf == nil {
+		return issuers, nil
+	}
+
+	// follows the issuers from the leaf certificate,
+	// the certificates outside of this path are kept at the end, in their original order.
+	var chain []*x509.Certificate
+	for current := leaf; ; {
+		next := findIssuer(issuers, current)
+		if next == nil || containsCertificate(chain, next) {
+			break
+		}
+
+		chain = append(chain, next)
+		current = next
+	}
+
+	for _, cert := range issuers {
+		if !containsCertificate(chain, cert) {
+			chain = append(chain, cert)
+		}
+	}
+
+	return chain, nil
+}
+
+func findIssuer(certs []*x509.Certificate, cert *x509.Certificate) *x509.Certificate {
+	for _, candidate := range certs {
+		if !candidate.Equal(cert) && bytes.Equal(cert.RawIssuer, candidate.RawSubject) {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ObtainRequest The request to obtain certificate.
@@ -50,35 +140,78 @@ type Resource struct {
 // If this parameter is non-nil it will be used instead of generating a new one.
 //
 // If bundle is true, the []byte contains both the issuer certificate and your issued certificate as a bundle.
+//
+// If PreferredChain is set, the certificate chain whose top issuer has this Common Name is selected
+// among the default and the alternate chains provided by the CA.
+// The default chain is used if no chain matches.
 type ObtainRequest struct {
-	Domains    []string
-	Bundle     bool
-	PrivateKey crypto.PrivateKey
-	MustStaple bool
+	Domains        []string
+	Bundle         bool
+	PrivateKey     crypto.PrivateKey
+	MustStaple     bool
+	PreferredChain string
+	// NotBefore and NotAfter the requested validity of the certificate (optional, not supported by all the CAs).
+	NotBefore time.Time
+	NotAfter  time.Time
 }
 
 type resolver interface {
 	Solve(authorizations []acme.Authorization) error
 }
 
+// validationResolver a resolver reporting the type of the challenge validated for each domain.
+type validationResolver interface {
+	SolveWithValidations(authorizations []acme.Authorization) (map[string]challenge.Type, error)
+}
+
+// concurrentResolver a resolver telling if the challenges of several orders can be solved at the same time.
+type concurrentResolver interface {
+	ConcurrentOrders() bool
+}
+
+// ObtainedHook is called with the resource of every certificate successfully obtained (or renewed),
+// before the resource is returned to the caller.
+// It allows to push the certificate, the private key and the issuer certificate to an external storage.
+type ObtainedHook func(certRes *Resource) error
+
+// OrderCreatedHook is called when an order is created, before its authorizations are solved,
+// with the URL of the order and the private key of the certificate (generated at this point if the request has none).
+// It allows to save what is needed to resume the order (see Resume) if the process is interrupted before the issuance.
+type OrderCreatedHook func(domain, orderURL string, privateKey crypto.PrivateKey) error
+
 type CertifierOptions struct {
-	KeyType certcrypto.KeyType
-	Timeout time.Duration
+	KeyType      certcrypto.KeyType
+	Timeout      time.Duration
+	ObtainedHook ObtainedHook
+	// OrderCreatedHook is called with the URL of every new order (optional).
+	OrderCreatedHook OrderCreatedHook
+	// OrderExtensions additional fields of the newOrder requests (advanced usage, see api.OrderService.NewWithExtensions).
+	OrderExtensions map[string]interface{}
+	// CAACheck the mode of the CAA records pre-check (disabled by default).
+	CAACheck CAACheck
+	// Metrics receives the durations of the attempts to obtain a certificate (optional).
+	Metrics acme.Metrics
 }
 
 // Certifier A service to obtain/renew/revoke certificates.
 type Certifier struct {
-	core     *api.Core
-	resolver resolver
-	options  CertifierOptions
+	core      *api.Core
+	resolver  resolver
+	options   CertifierOptions
+	lookupCAA func(domain string) ([]*dns.CAA, error)
 }
 
 // NewCertifier creates a Certifier.
 func NewCertifier(core *api.Core, resolver resolver, options CertifierOptions) *Certifier {
+	if options.Metrics == nil {
+		options.Metrics = acme.NoopMetrics{}
+	}
+
 	return &Certifier{
-		core:     core,
-		resolver: resolver,
-		options:  options,
+		core:      core,
+		resolver:  resolver,
+		options:   options,
+		lookupCAA: dns01.LookupCAA,
 	}
 }
 
@@ -87,6 +220,16 @@ func NewCertifier(core *api.Core, resolver resolver, options CertifierOptions) *
 // This function will never return a partial certificate.
 // If one domain in the list fails, the whole certificate will fail.
 func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
+	start := time.Now()
+
+	certRes, err := c.obtain(request)
+
+	c.options.Metrics.OnObtain(time.Since(start), err)
+
+	return certRes, err
+}
+
+func (c *Certifier) obtain(request ObtainRequest) (*Resource, error) {
 	if len(request.Domains) == 0 {
 		return nil, errors.New("no domains to obtain a certificate for")
 	}
@@ -99,7 +242,25 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 		log.Infof("[%s] acme: Obtaining SAN certificate", strings.Join(domains, ", "))
 	}
 
-	order, err := c.core.Orders.New(domains)
+	err := c.checkCAA(domains)
+	if err != nil {
+		return nil, err
+	}
+
+	orderOpts := &api.OrderOptions{
+		NotBefore:  request.NotBefore,
+		NotAfter:   request.NotAfter,
+		Extensions: c.options.OrderExtensions,
+	}
+
+	order, err := c.core.Orders.NewWithOptions(domains, orderOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("[%s] acme: Order created: %s", strings.Join(domains, ", "), order.Location)
+
+	privateKey, err := c.runOrderCreatedHook(request.Domains[0], order.Location, request.PrivateKey)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +272,7 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 		return nil, err
 	}
 
-	err = c.resolver.Solve(authz)
+	validations, err := c.solve(authz)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
 		c.deactivateAuthorizations(order)
@@ -121,19 +282,28 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 	log.Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
 
 	failures := make(obtainError)
-	cert, err := c.getForOrder(domains, order, request.Bundle, request.PrivateKey, request.MustStaple)
+	cert, err := c.getForOrder(domains, order, request.Bundle, privateKey, request.MustStaple, request.PreferredChain)
 	if err != nil {
 		for _, auth := range authz {
 			failures[challenge.GetTargetedDomain(auth)] = err
 		}
 	}
 
+	if cert != nil {
+		cert.Validations = validations
+
+		// the user-facing domain is the domain as requested (e.g. an internationalized domain name in its Unicode form).
+		if sanitized := sanitizeDomain(request.Domains[:1]); len(sanitized) == 1 && sanitized[0] == cert.Domain {
+			cert.Domain = request.Domains[0]
+		}
+	}
+
 	// Do not return an empty failures map, because
 	// it would still be a non-nil error value
 	if len(failures) > 0 {
 		return cert, failures
 	}
-	return cert, nil
+	return cert, c.runObtainedHook(cert)
 }
 
 // ObtainForCSR tries to obtain a certificate matching the CSR passed into it.
@@ -146,6 +316,21 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 // This function will never return a partial certificate.
 // If one domain in the list fails, the whole certificate will fail.
 func (c *Certifier) ObtainForCSR(csr x509.CertificateRequest, bundle bool) (*Resource, error) {
+	start := time.Now()
+
+	certRes, err := c.obtainForCSR(csr, bundle)
+
+	c.options.Metrics.OnObtain(time.Since(start), err)
+
+	return certRes, err
+}
+
+func (c *Certifier) obtainForCSR(csr x509.CertificateRequest, bundle bool) (*Resource, error) {
+	err := checkCSR(&csr)
+	if err != nil {
+		return nil, err
+	}
+
 	// figure out what domains it concerns
 	// start with the common name
 	domains := certcrypto.ExtractDomainsCSR(&csr)
@@ -156,11 +341,18 @@ func (c *Certifier) ObtainForCSR(csr x509.CertificateRequest, bundle bool) (*Res
 		log.Infof("[%s] acme: Obtaining SAN certificate given a CSR", strings.Join(domains, ", "))
 	}
 
-	order, err := c.core.Orders.New(domains)
+	err = c.checkCAA(domains)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := c.core.Orders.NewWithExtensions(domains, c.options.OrderExtensions)
 	if err != nil {
 		return nil, err
 	}
 
+	log.Infof("[%s] acme: Order created: %s", strings.Join(domains, ", "), order.Location)
+
 	authz, err := c.getAuthorizations(order)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
@@ -168,7 +360,7 @@ func (c *Certifier) ObtainForCSR(csr x509.CertificateRequest, bundle bool) (*Res
 		return nil, err
 	}
 
-	err = c.resolver.Solve(authz)
+	validations, err := c.solve(authz)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
 		c.deactivateAuthorizations(order)
@@ -178,7 +370,7 @@ func (c *Certifier) ObtainForCSR(csr x509.CertificateRequest, bundle bool) (*Res
 	log.Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
 
 	failures := make(obtainError)
-	cert, err := c.getForCSR(domains, order, bundle, csr.Raw, nil)
+	cert, err := c.getForCSR(domains, order, bundle, csr.Raw, nil, "")
 	if err != nil {
 		for _, auth := range authz {
 			failures[challenge.GetTargetedDomain(auth)] = err
@@ -188,6 +380,7 @@ func (c *Certifier) ObtainForCSR(csr x509.CertificateRequest, bundle bool) (*Res
 	if cert != nil {
 		// Add the CSR to the certificate so that it can be used for renewals.
 		cert.CSR = certcrypto.PEMEncode(&csr)
+		cert.Validations = validations
 	}
 
 	// Do not return an empty failures map,
@@ -195,10 +388,76 @@ func (c *Certifier) ObtainForCSR(csr x509.CertificateRequest, bundle bool) (*Res
 	if len(failures) > 0 {
 		return cert, failures
 	}
-	return cert, nil
+	return cert, c.runObtainedHook(cert)
 }
 
-func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, bundle bool, privateKey crypto.PrivateKey, mustStaple bool) (*Resource, error) {
+// solve solves the challenges of the authorizations,
+// and returns the type of the challenge validated for each domain if the resolver reports it.
+func (c *Certifier) solve(authz []acme.Authorization) (map[string]challenge.Type, error) {
+	if r, ok := c.resolver.(validationResolver); ok {
+		return r.SolveWithValidations(authz)
+	}
+
+	return nil, c.resolver.Solve(authz)
+}
+
+// checkCSR verifies that a CSR can be submitted as-is to the CA.
+// The CSR is never modified: its SANs and extensions (e.g. OCSP Must-Staple) are preserved.
+func checkCSR(csr *x509.CertificateRequest) error {
+	switch csr.PublicKeyAlgorithm {
+	case x509.RSA, x509.ECDSA, x509.Ed25519:
+	default:
+		return fmt.Errorf("acme: unsupported public key algorithm in the CSR: %s (only RSA, ECDSA and Ed25519 keys are supported)", csr.PublicKeyAlgorithm)
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		return fmt.Errorf("acme: invalid CSR signature: %v", err)
+	}
+
+	if len(certcrypto.ExtractDomainsCSR(csr)) == 0 {
+		return errors.New("acme: the CSR doesn't contain any domain or IP address")
+	}
+
+	return nil
+}
+
+// runOrderCreatedHook calls the order created hook, if any, with a new order,
+// and returns the private key of the certificate (generated if there is a hook and no key).
+// A failure of the hook doesn't stop the issuance: the order can't be resumed, but it can still be completed.
+func (c *Certifier) runOrderCreatedHook(domain, orderURL string, privateKey crypto.PrivateKey) (crypto.PrivateKey, error) {
+	if c.options.OrderCreatedHook == nil {
+		return privateKey, nil
+	}
+
+	if privateKey == nil {
+		var err error
+		privateKey, err = certcrypto.GeneratePrivateKey(c.options.KeyType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.options.OrderCreatedHook(domain, orderURL, privateKey); err != nil {
+		log.Warnf("[%s] acme: error while running the order created hook: %v", domain, err)
+	}
+
+	return privateKey, nil
+}
+
+// runObtainedHook calls the obtained hook, if any, with a freshly obtained certificate.
+func (c *Certifier) runObtainedHook(certRes *Resource) error {
+	if c.options.ObtainedHook == nil || certRes == nil {
+		return nil
+	}
+
+	if err := c.options.ObtainedHook(certRes); err != nil {
+		return fmt.Errorf("[%s] acme: error while running the obtained hook: %v", certRes.Domain, err)
+	}
+
+	return nil
+}
+
+func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, bundle bool, privateKey crypto.PrivateKey, mustStaple bool, preferredChain string) (*Resource, error) {
 	if privateKey == nil {
 		var err error
 		privateKey, err = certcrypto.GeneratePrivateKey(c.options.KeyType)
@@ -229,10 +488,10 @@ func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, bund
 		return nil, err
 	}
 
-	return c.getForCSR(domains, order, bundle, csr, certcrypto.PEMEncode(privateKey))
+	return c.getForCSR(domains, order, bundle, csr, certcrypto.PEMEncode(privateKey), preferredChain)
 }
 
-func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle bool, csr []byte, privateKeyPem []byte) (*Resource, error) {
+func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle bool, csr []byte, privateKeyPem []byte, preferredChain string) (*Resource, error) {
 	respOrder, err := c.core.Orders.UpdateForCSR(order.Finalize, csr)
 	if err != nil {
 		return nil, err
@@ -243,11 +502,12 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 		Domain:     commonName,
 		CertURL:    respOrder.Certificate,
 		PrivateKey: privateKeyPem,
+		OrderURL:   order.Location,
 	}
 
 	if respOrder.Status == acme.StatusValid {
 		// if the certificate is available right away, short cut!
-		ok, errR := c.checkResponse(respOrder, certRes, bundle)
+		ok, errR := c.checkResponse(respOrder, certRes, bundle, preferredChain)
 		if errR != nil {
 			return nil, errR
 		}
@@ -257,26 +517,31 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 		}
 	}
 
+	err = c.waitForCertificate(order.Location, certRes, bundle, preferredChain)
+
+	return certRes, err
+}
+
+// waitForCertificate polls the order until the certificate is issued, and loads it into certRes.
+func (c *Certifier) waitForCertificate(orderURL string, certRes *Resource, bundle bool, preferredChain string) error {
 	timeout := c.options.Timeout
 	if c.options.Timeout <= 0 {
 		timeout = 30 * time.Second
 	}
 
-	err = wait.For("certificate", timeout, timeout/60, func() (bool, error) {
-		ord, errW := c.core.Orders.Get(order.Location)
+	return wait.For("certificate", timeout, timeout/60, func() (bool, error) {
+		ord, errW := c.core.Orders.Get(orderURL)
 		if errW != nil {
 			return false, errW
 		}
 
-		done, errW := c.checkResponse(ord, certRes, bundle)
+		done, errW := c.checkResponse(ord, certRes, bundle, preferredChain)
 		if errW != nil {
 			return false, errW
 		}
 
 		return done, nil
 	})
-
-	return certRes, err
 }
 
 // checkResponse checks to see if the certificate is ready and a link is contained in the response.
@@ -287,44 +552,154 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 // The certRes input should already have the Domain (common name) field populated.
 //
 // If bundle is true, the certificate will be bundled with the issuer's cert.
-func (c *Certifier) checkResponse(order acme.Order, certRes *Resource, bundle bool) (bool, error) {
+//
+// If preferredChain is not empty, the chain whose top issuer matches it is selected
+// among the default and the alternate chains.
+func (c *Certifier) checkResponse(order acme.Order, certRes *Resource, bundle bool, preferredChain string) (bool, error) {
 	valid, err := checkOrderStatus(order)
 	if err != nil || !valid {
 		return valid, err
 	}
 
-	cert, issuer, err := c.core.Certificates.Get(order.Certificate, bundle)
+	if preferredChain == "" {
+		cert, issuer, errG := c.core.Certificates.Get(order.Certificate, bundle)
+		if errG != nil {
+			return false, errG
+		}
+
+		log.Infof("[%s] Server responded with a certificate.", certRes.Domain)
+
+		setCertificate(certRes, order.Certificate, &acme.RawCertificate{Cert: cert, Issuer: issuer})
+
+		return true, nil
+	}
+
+	certs, err := c.core.Certificates.GetAll(order.Certificate, bundle)
 	if err != nil {
 		return false, err
 	}
 
-	log.Infof("[%s] Server responded with a certificate.", certRes.Domain)
+	// The default chain is checked first.
+	links := []string{order.Certificate}
+	for link := range certs {
+		if link != order.Certificate {
+			links = append(links, link)
+		}
+	}
+	sort.Strings(links[1:])
+
+	for _, link := range links {
+		ok, errC := hasPreferredChain(certs[link].Issuer, preferredChain)
+		if errC != nil {
+			return false, errC
+		}
+
+		if ok {
+			log.Infof("[%s] Server responded with a certificate for the preferred chain %q.", certRes.Domain, preferredChain)
+
+			setCertificate(certRes, link, certs[link])
+
+			return true, nil
+		}
+	}
+
+	log.Infof("[%s] Server responded with a certificate, but no chain matches the preferred chain %q: the default chain is used.", certRes.Domain, preferredChain)
 
-	certRes.IssuerCertificate = issuer
-	certRes.Certificate = cert
-	certRes.CertURL = order.Certificate
-	certRes.CertStableURL = order.Certificate
+	setCertificate(certRes, order.Certificate, certs[order.Certificate])
 
 	return true, nil
 }
 
+func setCertificate(certRes *Resource, certURL string, cert *acme.RawCertificate) {
+	certRes.IssuerCertificate = cert.Issuer
+	certRes.Certificate = cert.Cert
+	certRes.CertURL = certURL
+	certRes.CertStableURL = certURL
+}
+
+// hasPreferredChain checks if the Common Name of the issuer of the top certificate of the chain matches the preferred chain.
+func hasPreferredChain(issuer []byte, preferredChain string) (bool, error) {
+	if len(issuer) == 0 {
+		return false, nil
+	}
+
+	certs, err := certcrypto.ParsePEMBundle(issuer)
+	if err != nil {
+		return false, err
+	}
+
+	topCert := certs[len(certs)-1]
+
+	return topCert.Issuer.CommonName == preferredChain, nil
+}
+
 // Revoke takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
 func (c *Certifier) Revoke(cert []byte) error {
-	certificates, err := certcrypto.ParsePEMBundle(cert)
+	return c.RevokeWithReason(cert, nil)
+}
+
+// RevokeWithReason takes a PEM encoded certificate or bundle and tries to revoke it at the CA,
+// with a reason code (e.g. acme.CRLReasonKeyCompromise).
+// If the reason is nil, the reason is not sent (unspecified).
+func (c *Certifier) RevokeWithReason(cert []byte, reason *uint) error {
+	revokeMsg, _, err := newRevokeCertMessage(cert, reason)
+	if err != nil {
+		return err
+	}
+
+	return c.core.Certificates.Revoke(revokeMsg)
+}
+
+// RevokeWithKey takes a PEM encoded certificate or bundle and tries to revoke it at the CA,
+// with a request signed by the private key of the certificate instead of the account key
+// (e.g. the access to the account which requested the certificate is lost).
+// If the reason is nil, the reason is not sent (unspecified).
+func (c *Certifier) RevokeWithKey(cert []byte, privateKey crypto.PrivateKey, reason *uint) error {
+	revokeMsg, x509Cert, err := newRevokeCertMessage(cert, reason)
 	if err != nil {
 		return err
 	}
 
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok || !publicKeyEqual(signer.Public(), x509Cert.PublicKey) {
+		return errors.New("the private key doesn't match the certificate")
+	}
+
+	return c.core.Certificates.RevokeWithKey(revokeMsg, privateKey)
+}
+
+func newRevokeCertMessage(cert []byte, reason *uint) (acme.RevokeCertMessage, *x509.Certificate, error) {
+	certificates, err := certcrypto.ParsePEMBundle(cert)
+	if err != nil {
+		return acme.RevokeCertMessage{}, nil, err
+	}
+
 	x509Cert := certificates[0]
 	if x509Cert.IsCA {
-		return fmt.Errorf("certificate bundle starts with a CA certificate")
+		return acme.RevokeCertMessage{}, nil, fmt.Errorf("certificate bundle starts with a CA certificate")
 	}
 
 	revokeMsg := acme.RevokeCertMessage{
 		Certificate: base64.RawURLEncoding.EncodeToString(x509Cert.Raw),
+		Reason:      reason,
 	}
 
-	return c.core.Certificates.Revoke(revokeMsg)
+	return revokeMsg, x509Cert, nil
+}
+
+// publicKeyEqual compares two public keys (RSA, ECDSA or Ed25519).
+func publicKeyEqual(a, b crypto.PublicKey) bool {
+	rawA, err := x509.MarshalPKIXPublicKey(a)
+	if err != nil {
+		return false
+	}
+
+	rawB, err := x509.MarshalPKIXPublicKey(b)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(rawA, rawB)
 }
 
 // Renew takes a Resource and tries to renew the certificate.
@@ -508,15 +883,32 @@ func checkOrderStatus(order acme.Order) (bool, error) {
 //   MUST be encoded according to the rules in Section 7 of [RFC5280].
 //
 // https://tools.ietf.org/html/rfc5280#section-7
+//
+// The duplicates are removed: a domain can be provided in its Unicode and its A-label forms
+// (e.g. the domains of a certificate merged with the domains requested by the user).
 func sanitizeDomain(domains []string) []string {
 	var sanitizedDomains []string
+	seen := make(map[string]bool)
 	for _, domain := range domains {
-		sanitizedDomain, err := idna.ToASCII(domain)
-		if err != nil {
-			log.Infof("skip domain %q: unable to sanitize (punnycode): %v", domain, err)
+		sanitizedDomain := domain
+		if ip := net.ParseIP(domain); ip != nil {
+			// RFC 8738: IP address identifiers are not domain names.
+			sanitizedDomain = ip.String()
 		} else {
-			sanitizedDomains = append(sanitizedDomains, sanitizedDomain)
+			var err error
+			sanitizedDomain, err = idna.ToASCII(domain)
+			if err != nil {
+				log.Infof("skip domain %q: unable to sanitize (punnycode): %v", domain, err)
+				continue
+			}
 		}
+
+		if seen[sanitizedDomain] {
+			continue
+		}
+		seen[sanitizedDomain] = true
+
+		sanitizedDomains = append(sanitizedDomains, sanitizedDomain)
 	}
 	return sanitizedDomains
 }
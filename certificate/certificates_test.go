@@ -1,18 +1,32 @@
 package certificate
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/acme/api"
 	"github.com/vostronet/lego/certcrypto"
+	"github.com/vostronet/lego/challenge"
 	"github.com/vostronet/lego/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 const certResponseMock = `-----BEGIN CERTIFICATE-----
@@ -102,7 +116,7 @@ func Test_checkResponse(t *testing.T) {
 	certRes := &Resource{}
 	bundle := false
 
-	valid, err := certifier.checkResponse(order, certRes, bundle)
+	valid, err := certifier.checkResponse(order, certRes, bundle, "")
 	require.NoError(t, err)
 	assert.True(t, valid)
 	assert.NotNil(t, certRes)
@@ -150,7 +164,7 @@ func Test_checkResponse_issuerRelUp(t *testing.T) {
 	certRes := &Resource{}
 	bundle := false
 
-	valid, err := certifier.checkResponse(order, certRes, bundle)
+	valid, err := certifier.checkResponse(order, certRes, bundle, "")
 	require.NoError(t, err)
 	assert.True(t, valid)
 	assert.NotNil(t, certRes)
@@ -189,7 +203,7 @@ func Test_checkResponse_embeddedIssuer(t *testing.T) {
 	certRes := &Resource{}
 	bundle := false
 
-	valid, err := certifier.checkResponse(order, certRes, bundle)
+	valid, err := certifier.checkResponse(order, certRes, bundle, "")
 	require.NoError(t, err)
 	assert.True(t, valid)
 	assert.NotNil(t, certRes)
@@ -202,6 +216,82 @@ func Test_checkResponse_embeddedIssuer(t *testing.T) {
 	assert.Equal(t, issuerMock, string(certRes.IssuerCertificate), "IssuerCertificate")
 }
 
+func Test_checkResponse_preferredChain(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	// The alternate chain is "issued" by the leaf certificate itself,
+	// so the top issuer of this chain is the intermediate CA of the default chain.
+	leaf, _ := pem.Decode([]byte(certResponseMock))
+	altChain := string(pem.EncodeToMemory(leaf)) + string(pem.EncodeToMemory(leaf))
+
+	mux.HandleFunc("/certificate", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Link", "<"+apiURL+`/certificate/alt>; rel="alternate"`)
+		_, err := w.Write([]byte(certResponseMock))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/certificate/alt", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(altChain))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	order := acme.Order{
+		Status:      acme.StatusValid,
+		Certificate: apiURL + "/certificate",
+	}
+
+	testCases := []struct {
+		desc           string
+		preferredChain string
+		expectedURL    string
+		expectedCert   string
+	}{
+		{
+			desc:           "default chain",
+			preferredChain: "Pebble Root CA 50ffbd",
+			expectedURL:    apiURL + "/certificate",
+			expectedCert:   certResponseMock,
+		},
+		{
+			desc:           "alternate chain",
+			preferredChain: "Pebble Intermediate CA 395e61",
+			expectedURL:    apiURL + "/certificate/alt",
+			expectedCert:   altChain,
+		},
+		{
+			desc:           "no matching chain",
+			preferredChain: "ISRG Root X1",
+			expectedURL:    apiURL + "/certificate",
+			expectedCert:   certResponseMock,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			certRes := &Resource{}
+
+			valid, err := certifier.checkResponse(order, certRes, false, test.preferredChain)
+			require.NoError(t, err)
+			assert.True(t, valid)
+			assert.Equal(t, test.expectedURL, certRes.CertURL)
+			assert.Equal(t, test.expectedCert, string(certRes.Certificate), "Certificate")
+		})
+	}
+}
+
 func Test_Get(t *testing.T) {
 	mux, apiURL, tearDown := tester.SetupFakeAPI()
 	defer tearDown()
@@ -234,6 +324,258 @@ func Test_Get(t *testing.T) {
 	assert.Equal(t, issuerMock, string(certRes.IssuerCertificate), "IssuerCertificate")
 }
 
+func TestCertifier_RevokeWithReason(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		reason   *uint
+		expected string
+	}{
+		{
+			desc:     "without reason",
+			expected: `{"certificate":"%s"}`,
+		},
+		{
+			desc:     "key compromise",
+			reason:   func(r uint) *uint { return &r }(acme.CRLReasonKeyCompromise),
+			expected: `{"certificate":"%s","reason":1}`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			mux, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			var revokeMsg acme.RevokeCertMessage
+			var payload string
+
+			mux.HandleFunc("/revokeCert", func(w http.ResponseWriter, r *http.Request) {
+				reqBody, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				jws, err := jose.ParseSigned(string(reqBody))
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				payload = string(jws.UnsafePayloadWithoutVerification())
+
+				err = json.Unmarshal([]byte(payload), &revokeMsg)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+				}
+			})
+
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			require.NoError(t, err, "Could not generate test key")
+
+			core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+			require.NoError(t, err)
+
+			certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+			err = certifier.RevokeWithReason([]byte(certResponseMock), test.reason)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, fmt.Sprintf(test.expected, revokeMsg.Certificate), payload)
+			assert.NotEmpty(t, revokeMsg.Certificate)
+		})
+	}
+}
+
+func TestCertifier_RevokeWithKey_keyMismatch(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	err = certifier.RevokeWithKey([]byte(certResponseMock), key, nil)
+	require.EqualError(t, err, "the private key doesn't match the certificate")
+}
+
+func Test_runObtainedHook(t *testing.T) {
+	var called *Resource
+	certifier := NewCertifier(nil, &resolverMock{}, CertifierOptions{
+		ObtainedHook: func(certRes *Resource) error {
+			called = certRes
+			return nil
+		},
+	})
+
+	certRes := &Resource{Domain: "acme.wtf", Certificate: []byte(certResponseMock)}
+
+	err := certifier.runObtainedHook(certRes)
+	require.NoError(t, err)
+	assert.Equal(t, certRes, called)
+}
+
+func Test_runObtainedHook_error(t *testing.T) {
+	certifier := NewCertifier(nil, &resolverMock{}, CertifierOptions{
+		ObtainedHook: func(_ *Resource) error {
+			return errors.New("vault is sealed")
+		},
+	})
+
+	err := certifier.runObtainedHook(&Resource{Domain: "acme.wtf"})
+	require.EqualError(t, err, "[acme.wtf] acme: error while running the obtained hook: vault is sealed")
+}
+
+func Test_runOrderCreatedHook(t *testing.T) {
+	var calledURL string
+	var calledKey crypto.PrivateKey
+	certifier := NewCertifier(nil, &resolverMock{}, CertifierOptions{
+		KeyType: certcrypto.EC256,
+		OrderCreatedHook: func(domain, orderURL string, privateKey crypto.PrivateKey) error {
+			calledURL, calledKey = orderURL, privateKey
+			return nil
+		},
+	})
+
+	// the private key is generated before the authorizations, to be saved with the order URL.
+	privateKey, err := certifier.runOrderCreatedHook("acme.wtf", "https://example.com/order/1", nil)
+	require.NoError(t, err)
+	require.NotNil(t, privateKey)
+	assert.Equal(t, "https://example.com/order/1", calledURL)
+	assert.Equal(t, privateKey, calledKey)
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	privateKey, err = certifier.runOrderCreatedHook("acme.wtf", "https://example.com/order/2", key)
+	require.NoError(t, err)
+	assert.Equal(t, key, privateKey)
+	assert.Equal(t, key, calledKey)
+}
+
+func Test_runOrderCreatedHook_error(t *testing.T) {
+	certifier := NewCertifier(nil, &resolverMock{}, CertifierOptions{
+		KeyType: certcrypto.EC256,
+		OrderCreatedHook: func(_, _ string, _ crypto.PrivateKey) error {
+			return errors.New("disk full")
+		},
+	})
+
+	// the issuance continues without the possibility to resume the order.
+	privateKey, err := certifier.runOrderCreatedHook("acme.wtf", "https://example.com/order/1", nil)
+	require.NoError(t, err)
+	assert.NotNil(t, privateKey)
+}
+
+func Test_runOrderCreatedHook_noHook(t *testing.T) {
+	certifier := NewCertifier(nil, &resolverMock{}, CertifierOptions{})
+
+	// the private key is generated later, during the finalization.
+	privateKey, err := certifier.runOrderCreatedHook("acme.wtf", "https://example.com/order/1", nil)
+	require.NoError(t, err)
+	assert.Nil(t, privateKey)
+}
+
+type obtainMetricsMock struct {
+	acme.NoopMetrics
+	errs []error
+}
+
+func (m *obtainMetricsMock) OnObtain(_ time.Duration, err error) {
+	m.errs = append(m.errs, err)
+}
+
+func TestCertifier_Obtain_metrics(t *testing.T) {
+	metrics := &obtainMetricsMock{}
+
+	certifier := NewCertifier(nil, &resolverMock{}, CertifierOptions{Metrics: metrics})
+
+	_, err := certifier.Obtain(ObtainRequest{})
+	require.Error(t, err)
+
+	require.Len(t, metrics.errs, 1)
+	assert.Equal(t, err, metrics.errs[0])
+}
+
+func Test_checkCSR(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		key      interface{}
+		template *x509.CertificateRequest
+		expected string
+	}{
+		{
+			desc:     "RSA",
+			key:      rsaKey,
+			template: &x509.CertificateRequest{Subject: pkix.Name{CommonName: "example.com"}, DNSNames: []string{"example.org"}},
+		},
+		{
+			desc:     "ECDSA",
+			key:      ecKey,
+			template: &x509.CertificateRequest{DNSNames: []string{"example.com", "example.org"}},
+		},
+		{
+			desc:     "Ed25519",
+			key:      edKey,
+			template: &x509.CertificateRequest{Subject: pkix.Name{CommonName: "example.com"}},
+		},
+		{
+			desc:     "no domains",
+			key:      ecKey,
+			template: &x509.CertificateRequest{},
+			expected: "acme: the CSR doesn't contain any domain or IP address",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			raw, err := x509.CreateCertificateRequest(rand.Reader, test.template, test.key)
+			require.NoError(t, err)
+
+			csr, err := x509.ParseCertificateRequest(raw)
+			require.NoError(t, err)
+
+			err = checkCSR(csr)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_checkCSR_invalidSignature(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	raw, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{"example.com"}}, privateKey)
+	require.NoError(t, err)
+
+	csr, err := x509.ParseCertificateRequest(raw)
+	require.NoError(t, err)
+
+	csr.Signature[0] ^= 0xff
+
+	err = checkCSR(csr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "acme: invalid CSR signature")
+}
+
 type resolverMock struct {
 	error error
 }
@@ -241,3 +583,136 @@ type resolverMock struct {
 func (r *resolverMock) Solve(authorizations []acme.Authorization) error {
 	return r.error
 }
+
+type validationResolverMock struct {
+	resolverMock
+	validations map[string]challenge.Type
+}
+
+func (r *validationResolverMock) SolveWithValidations(authorizations []acme.Authorization) (map[string]challenge.Type, error) {
+	return r.validations, r.error
+}
+
+func Test_sanitizeDomain(t *testing.T) {
+	domains := []string{"例え.テスト", "xn--r8jz45g.xn--zckzah", "*.bücher.example", "example.com", "2001:0db8::0001"}
+
+	expected := []string{"xn--r8jz45g.xn--zckzah", "*.xn--bcher-kva.example", "example.com", "2001:db8::1"}
+	assert.Equal(t, expected, sanitizeDomain(domains))
+}
+
+func TestCertifier_solve(t *testing.T) {
+	certifier := NewCertifier(nil, &resolverMock{}, CertifierOptions{})
+
+	validations, err := certifier.solve(nil)
+	require.NoError(t, err)
+	assert.Nil(t, validations)
+
+	expected := map[string]challenge.Type{"example.com": challenge.DNS01, "*.example.com": challenge.DNS01}
+	certifier = NewCertifier(nil, &validationResolverMock{validations: expected}, CertifierOptions{})
+
+	validations, err = certifier.solve(nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, validations)
+}
+
+func TestResource_IssuerChain(t *testing.T) {
+	leafBlock, rest := pem.Decode([]byte(certResponseMock))
+	issuerBlock, _ := pem.Decode(rest)
+
+	leafPEM := string(pem.EncodeToMemory(leafBlock))
+	issuerPEM := string(pem.EncodeToMemory(issuerBlock))
+
+	testCases := []struct {
+		desc     string
+		resource Resource
+	}{
+		{
+			desc:     "issuer certificate",
+			resource: Resource{Certificate: []byte(leafPEM), IssuerCertificate: []byte(issuerMock)},
+		},
+		{
+			desc:     "bundle",
+			resource: Resource{Certificate: []byte(certResponseMock)},
+		},
+		{
+			desc:     "leaf and duplicates in the issuer certificate",
+			resource: Resource{Certificate: []byte(certResponseMock), IssuerCertificate: []byte(leafPEM + issuerPEM + issuerPEM)},
+		},
+		{
+			desc:     "issuer certificate only",
+			resource: Resource{IssuerCertificate: []byte(issuerMock)},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			chain, err := test.resource.IssuerChain()
+			require.NoError(t, err)
+
+			require.Len(t, chain, 1)
+			assert.Equal(t, "Pebble Intermediate CA 395e61", chain[0].Subject.CommonName)
+		})
+	}
+}
+
+func TestResource_IssuerChain_order(t *testing.T) {
+	root := generateTestCertificate(t, "root", nil, nil)
+	intermediate := generateTestCertificate(t, "intermediate", root.cert, root.key)
+	leaf := generateTestCertificate(t, "leaf", intermediate.cert, intermediate.key)
+
+	resource := Resource{
+		Certificate:       certcrypto.PEMEncode(certcrypto.DERCertificateBytes(leaf.cert.Raw)),
+		IssuerCertificate: append(certcrypto.PEMEncode(certcrypto.DERCertificateBytes(root.cert.Raw)), certcrypto.PEMEncode(certcrypto.DERCertificateBytes(intermediate.cert.Raw))...),
+	}
+
+	chain, err := resource.IssuerChain()
+	require.NoError(t, err)
+
+	var names []string
+	for _, cert := range chain {
+		names = append(names, cert.Subject.CommonName)
+	}
+
+	assert.Equal(t, []string{"intermediate", "root"}, names)
+}
+
+func TestResource_IssuerChain_invalid(t *testing.T) {
+	resource := Resource{Certificate: []byte(certResponseMock), IssuerCertificate: []byte("invalid")}
+
+	_, err := resource.IssuerChain()
+	require.EqualError(t, err, "failed to parse the issuer certificate: no certificates were found while parsing the bundle")
+}
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func generateTestCertificate(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  parent == nil || name != "leaf",
+		BasicConstraintsValid: true,
+	}
+
+	signer, signerKey := parent, parentKey
+	if parent == nil {
+		signer, signerKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return testCertificate{cert: cert, key: key}
+}
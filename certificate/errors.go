@@ -24,6 +24,11 @@ func (e obtainError) Error() string {
 	return buffer.String()
 }
 
+// DomainErrors returns the errors by domain.
+func (e obtainError) DomainErrors() map[string]error {
+	return e
+}
+
 type domainError struct {
 	Domain string
 	Error  error
@@ -0,0 +1,76 @@
+package certificate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vostronet/lego/certcrypto"
+	pkcs12 "software.sslmate.com/src/go-pkcs12"
+)
+
+// PKCS12Encryption the encryption of the private key and the certificates of a PKCS#12 file.
+type PKCS12Encryption string
+
+const (
+	// PKCS12RC2 the legacy encryption: RC2-40 for the certificates, 3DES for the private key, SHA-1 MAC.
+	// It is supported by most of the importers (e.g. Windows Server before 2019, Java before 12, OpenSSL 1.x).
+	PKCS12RC2 PKCS12Encryption = "RC2"
+	// PKCS12DES the legacy encryption: 3DES for the certificates and the private key, SHA-1 MAC.
+	PKCS12DES PKCS12Encryption = "DES"
+	// PKCS12AES the modern encryption: AES-256-CBC with PBKDF2-SHA256 for the certificates and the private key, SHA-256 MAC.
+	// It requires a recent importer (e.g. Windows 10 1709, Java 12, OpenSSL 3).
+	PKCS12AES PKCS12Encryption = "AES"
+)
+
+// ParsePKCS12Encryption parses the name of a PKCS#12 encryption (case-insensitive).
+func ParsePKCS12Encryption(name string) (PKCS12Encryption, error) {
+	switch encryption := PKCS12Encryption(strings.ToUpper(name)); encryption {
+	case PKCS12RC2, PKCS12DES, PKCS12AES:
+		return encryption, nil
+	default:
+		return "", fmt.Errorf("unsupported PKCS#12 encryption: %s", name)
+	}
+}
+
+// EncodePKCS12 creates a PKCS#12 file (.pfx, .p12) containing the private key, the certificate and the issuer chain of the resource,
+// encrypted with the password (e.g. to import the certificate in a Windows or a Java keystore).
+func EncodePKCS12(res *Resource, password string, encryption PKCS12Encryption) ([]byte, error) {
+	if len(res.PrivateKey) == 0 {
+		return nil, errors.New("the private key is required to create a PKCS#12 file")
+	}
+
+	privateKey, err := certcrypto.ParsePEMPrivateKey(res.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key: %v", err)
+	}
+
+	cert, err := certcrypto.ParsePEMCertificate(res.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the certificate: %v", err)
+	}
+
+	issuers, err := res.IssuerChain()
+	if err != nil {
+		return nil, err
+	}
+
+	var encoder *pkcs12.Encoder
+	switch encryption {
+	case PKCS12RC2:
+		encoder = pkcs12.LegacyRC2
+	case PKCS12DES:
+		encoder = pkcs12.LegacyDES
+	case PKCS12AES:
+		encoder = pkcs12.Modern2023
+	default:
+		return nil, fmt.Errorf("unsupported PKCS#12 encryption: %s", encryption)
+	}
+
+	pfx, err := encoder.Encode(privateKey, cert, issuers, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the PKCS#12 file: %v", err)
+	}
+
+	return pfx, nil
+}
@@ -0,0 +1,92 @@
+package certificate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vostronet/lego/certcrypto"
+	pkcs12 "software.sslmate.com/src/go-pkcs12"
+)
+
+func TestEncodePKCS12(t *testing.T) {
+	root := generateTestCertificate(t, "root", nil, nil)
+	intermediate := generateTestCertificate(t, "intermediate", root.cert, root.key)
+	leaf := generateTestCertificate(t, "leaf", intermediate.cert, intermediate.key)
+
+	resource := &Resource{
+		PrivateKey:        certcrypto.PEMEncode(leaf.key),
+		Certificate:       certcrypto.PEMEncode(certcrypto.DERCertificateBytes(leaf.cert.Raw)),
+		IssuerCertificate: append(certcrypto.PEMEncode(certcrypto.DERCertificateBytes(intermediate.cert.Raw)), certcrypto.PEMEncode(certcrypto.DERCertificateBytes(root.cert.Raw))...),
+	}
+
+	testCases := []PKCS12Encryption{PKCS12RC2, PKCS12DES, PKCS12AES}
+
+	for _, encryption := range testCases {
+		encryption := encryption
+		t.Run(string(encryption), func(t *testing.T) {
+			t.Parallel()
+
+			pfx, err := EncodePKCS12(resource, "secret", encryption)
+			require.NoError(t, err)
+
+			privateKey, cert, caCerts, err := pkcs12.DecodeChain(pfx, "secret")
+			require.NoError(t, err)
+
+			assert.Equal(t, leaf.key, privateKey)
+			assert.True(t, leaf.cert.Equal(cert))
+			require.Len(t, caCerts, 2)
+			assert.True(t, intermediate.cert.Equal(caCerts[0]))
+			assert.True(t, root.cert.Equal(caCerts[1]))
+
+			_, _, _, err = pkcs12.DecodeChain(pfx, "invalid")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestEncodePKCS12_errors(t *testing.T) {
+	leaf := generateTestCertificate(t, "leaf", nil, nil)
+
+	testCases := []struct {
+		desc       string
+		resource   *Resource
+		encryption PKCS12Encryption
+		expected   string
+	}{
+		{
+			desc:       "without private key",
+			resource:   &Resource{Certificate: certcrypto.PEMEncode(certcrypto.DERCertificateBytes(leaf.cert.Raw))},
+			encryption: PKCS12AES,
+			expected:   "the private key is required to create a PKCS#12 file",
+		},
+		{
+			desc: "unsupported encryption",
+			resource: &Resource{
+				PrivateKey:  certcrypto.PEMEncode(leaf.key),
+				Certificate: certcrypto.PEMEncode(certcrypto.DERCertificateBytes(leaf.cert.Raw)),
+			},
+			encryption: "RC4",
+			expected:   "unsupported PKCS#12 encryption: RC4",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := EncodePKCS12(test.resource, "secret", test.encryption)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestParsePKCS12Encryption(t *testing.T) {
+	encryption, err := ParsePKCS12Encryption("aes")
+	require.NoError(t, err)
+	assert.Equal(t, PKCS12AES, encryption)
+
+	_, err = ParsePKCS12Encryption("RC4")
+	require.EqualError(t, err, "unsupported PKCS#12 encryption: RC4")
+}
@@ -0,0 +1,172 @@
+package certificate
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/log"
+)
+
+const (
+	defaultBatchConcurrency = 4
+	defaultRateLimitWait    = 1 * time.Minute
+)
+
+// RenewBatchOptions the options of a batch renewal.
+type RenewBatchOptions struct {
+	Bundle     bool
+	MustStaple bool
+	// MaxConcurrency the maximum number of concurrent renewals (default: 4).
+	// The renewals are not concurrent when the resolver can't solve the challenges of several orders at the same time
+	// (e.g. with the HTTP-01 or TLS-ALPN-01 servers, which listen on a single port).
+	MaxConcurrency int
+	// MaxRetries the maximum number of retries of a renewal rejected by a rate limit of the CA (default: no retry).
+	MaxRetries int
+	// MaxRetryWait the maximum duration to wait before a retry:
+	// a renewal is not retried if the CA asks to wait longer (default: no maximum).
+	MaxRetryWait time.Duration
+}
+
+// RenewResult the result of the renewal of a certificate of a batch.
+type RenewResult struct {
+	// Domain the domain of the renewed certificate resource.
+	Domain string
+	// Resource the new certificate resource (nil if the renewal failed).
+	Resource *Resource
+	Err      error
+}
+
+// RenewBatch renews several certificates with a bounded number of concurrent renewals.
+//
+// The renewals share the account and the nonces of the Certifier.
+// When the CA rejects a renewal because of a rate limit,
+// all the renewals are paused for the duration asked by the CA (Retry-After),
+// and the renewal is retried up to MaxRetries times.
+//
+// The results are in the same order as the certificates.
+func (c *Certifier) RenewBatch(certificates []Resource, options RenewBatchOptions) []RenewResult {
+	concurrency := options.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	if r, ok := c.resolver.(concurrentResolver); ok && !r.ConcurrentOrders() {
+		concurrency = 1
+	}
+
+	results := make([]RenewResult, len(certificates))
+
+	limiter := &rateLimitPause{now: time.Now, sleep: time.Sleep}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(certificates); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				results[index] = c.renewWithRetry(certificates[index], options, limiter)
+			}
+		}()
+	}
+
+	for index := range certificates {
+		indexes <- index
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+func (c *Certifier) renewWithRetry(certRes Resource, options RenewBatchOptions, limiter *rateLimitPause) RenewResult {
+	result := RenewResult{Domain: certRes.Domain}
+
+	for attempt := 0; ; attempt++ {
+		limiter.wait()
+
+		result.Resource, result.Err = c.Renew(certRes, options.Bundle, options.MustStaple)
+		if result.Err == nil {
+			return result
+		}
+
+		rateLimitErr := findRateLimitedError(result.Err)
+		if rateLimitErr == nil || attempt >= options.MaxRetries {
+			return result
+		}
+
+		retryAfter := retryDelay(rateLimitErr.RetryAfter, limiter.now())
+		if options.MaxRetryWait > 0 && retryAfter > options.MaxRetryWait {
+			result.Err = fmt.Errorf("%v: the CA asks to retry after %s, longer than the maximum wait (%s)", result.Err, retryAfter, options.MaxRetryWait)
+			return result
+		}
+
+		log.Warnf("[%s] acme: Rate limited by the CA, retrying in %s", certRes.Domain, retryAfter)
+
+		limiter.pause(retryAfter)
+	}
+}
+
+// rateLimitPause pauses all the renewals of a batch after a rate limit error.
+type rateLimitPause struct {
+	mu    sync.Mutex
+	until time.Time
+
+	now   func() time.Time
+	sleep func(d time.Duration)
+}
+
+func (p *rateLimitPause) pause(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	until := p.now().Add(d)
+	if until.After(p.until) {
+		p.until = until
+	}
+}
+
+func (p *rateLimitPause) wait() {
+	p.mu.Lock()
+	until := p.until
+	p.mu.Unlock()
+
+	if d := until.Sub(p.now()); d > 0 {
+		p.sleep(d)
+	}
+}
+
+// findRateLimitedError returns the rate limit error of the CA, if any.
+func findRateLimitedError(err error) *acme.RateLimitedError {
+	switch e := err.(type) {
+	case *acme.RateLimitedError:
+		return e
+	case obtainError:
+		for _, domainErr := range e {
+			if rateLimitErr, ok := domainErr.(*acme.RateLimitedError); ok {
+				return rateLimitErr
+			}
+		}
+	}
+
+	return nil
+}
+
+// retryDelay returns the delay before the retry time requested by the CA (Retry-After header),
+// or a default delay if the CA didn't request one.
+func retryDelay(retryAfter, now time.Time) time.Duration {
+	if retryAfter.IsZero() {
+		return defaultRateLimitWait
+	}
+
+	if d := retryAfter.Sub(now); d > 0 {
+		return d
+	}
+
+	return 0
+}
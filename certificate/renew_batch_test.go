@@ -0,0 +1,179 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/acme/api"
+	"github.com/vostronet/lego/challenge/http01"
+	challengeresolver "github.com/vostronet/lego/challenge/resolver"
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertifier_RenewBatch(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	var calls int32
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		err := tester.WriteJSONResponse(w, acme.ProblemDetails{
+			Type:       acme.RateLimitedErr,
+			Detail:     "too many certificates already issued",
+			HTTPStatus: http.StatusTooManyRequests,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{})
+
+	certificates := []Resource{
+		{Domain: "acme.wtf", Certificate: []byte(certResponseMock)},
+		{Domain: "invalid.com", Certificate: []byte("invalid")},
+		{Domain: "acme.wtf", Certificate: []byte(certResponseMock)},
+	}
+
+	results := certifier.RenewBatch(certificates, RenewBatchOptions{MaxConcurrency: 2, MaxRetries: 2})
+	require.Len(t, results, 3)
+
+	for i, result := range results {
+		assert.Equal(t, certificates[i].Domain, result.Domain)
+		assert.Nil(t, result.Resource)
+		require.Error(t, result.Err)
+	}
+
+	assert.NotNil(t, findRateLimitedError(results[0].Err))
+	assert.Nil(t, findRateLimitedError(results[1].Err))
+	assert.NotNil(t, findRateLimitedError(results[2].Err))
+
+	// 2 certificates, 1 attempt + 2 retries.
+	assert.EqualValues(t, 6, atomic.LoadInt32(&calls))
+}
+
+func TestCertifier_RenewBatch_http01(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	var inFlight, maxInFlight int32
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, _ *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		if n > atomic.LoadInt32(&maxInFlight) {
+			atomic.StoreInt32(&maxInFlight, n)
+		}
+
+		// gives the time to another renewal to start.
+		time.Sleep(100 * time.Millisecond)
+
+		w.WriteHeader(http.StatusForbidden)
+		err := tester.WriteJSONResponse(w, acme.ProblemDetails{
+			Type:       "urn:ietf:params:acme:error:unauthorized",
+			HTTPStatus: http.StatusForbidden,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	solversManager := challengeresolver.NewSolversManager(core)
+	err = solversManager.SetHTTP01Provider(http01.NewProviderServer("", "0"))
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, challengeresolver.NewProber(solversManager), CertifierOptions{})
+
+	certificates := []Resource{
+		{Domain: "acme.wtf", Certificate: []byte(certResponseMock)},
+		{Domain: "acme.wtf", Certificate: []byte(certResponseMock)},
+	}
+
+	results := certifier.RenewBatch(certificates, RenewBatchOptions{MaxConcurrency: 2})
+	require.Len(t, results, 2)
+
+	// the HTTP-01 server listens on a single port: the renewals are not concurrent.
+	assert.EqualValues(t, 1, atomic.LoadInt32(&maxInFlight))
+}
+
+func Test_findRateLimitedError(t *testing.T) {
+	rateLimitErr := &acme.RateLimitedError{ProblemDetails: &acme.ProblemDetails{Type: acme.RateLimitedErr}, RetryAfter: time.Now().Add(10 * time.Second)}
+
+	assert.Equal(t, rateLimitErr, findRateLimitedError(rateLimitErr))
+	assert.Equal(t, rateLimitErr, findRateLimitedError(obtainError{"example.com": rateLimitErr}))
+	assert.Nil(t, findRateLimitedError(errors.New("error")))
+	assert.Nil(t, findRateLimitedError(obtainError{"example.com": errors.New("error")}))
+}
+
+func Test_retryDelay(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc       string
+		retryAfter time.Time
+		expected   time.Duration
+	}{
+		{
+			desc:       "future",
+			retryAfter: now.Add(2 * time.Minute),
+			expected:   2 * time.Minute,
+		},
+		{
+			desc:       "past",
+			retryAfter: now.Add(-time.Hour),
+			expected:   0,
+		},
+		{
+			desc:     "unknown",
+			expected: defaultRateLimitWait,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, retryDelay(test.retryAfter, now))
+		})
+	}
+}
+
+func Test_rateLimitPause(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var slept []time.Duration
+	limiter := &rateLimitPause{
+		now:   func() time.Time { return now },
+		sleep: func(d time.Duration) { slept = append(slept, d) },
+	}
+
+	limiter.wait()
+	assert.Empty(t, slept)
+
+	limiter.pause(30 * time.Second)
+	limiter.pause(10 * time.Second)
+
+	limiter.wait()
+	assert.Equal(t, []time.Duration{30 * time.Second}, slept)
+}
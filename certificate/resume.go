@@ -0,0 +1,166 @@
+package certificate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/certcrypto"
+	"github.com/vostronet/lego/log"
+)
+
+// Resume resumes an in-progress order (e.g. created by a process which crashed before its finalization),
+// instead of creating a new order:
+// the state of the order is fetched, only the pending authorizations are solved, then the order is finalized.
+//
+// The domains of the request are optional: if set, they must match the identifiers of the order.
+// The order URL and the private key are passed to the OrderCreatedHook at the creation of the order (see CertifierOptions),
+// the order URL of a certificate is also available in Resource.OrderURL, and in the logs.
+//
+// If the order is already finalized (processing or valid), the certificate is fetched:
+// the private key of the request is required, because the CSR was created with this key.
+func (c *Certifier) Resume(orderURL string, request ObtainRequest) (*Resource, error) {
+	if orderURL == "" {
+		return nil, errors.New("resume: empty order URL")
+	}
+
+	o, err := c.core.Orders.Get(orderURL)
+	if err != nil {
+		return nil, err
+	}
+
+	order := acme.ExtendedOrder{Order: o, Location: orderURL}
+
+	domains, err := resumeDomains(order.Order, request.Domains)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("[%s] acme: Resuming the order %s (status: %s)", strings.Join(domains, ", "), orderURL, order.Status)
+
+	var cert *Resource
+
+	switch order.Status {
+	case acme.StatusPending, acme.StatusReady:
+		cert, err = c.resumeAuthorizations(domains, order, request)
+
+	case acme.StatusProcessing, acme.StatusValid:
+		cert, err = c.resumeCertificate(domains, order, request)
+
+	case acme.StatusInvalid:
+		if order.Error != nil {
+			return nil, fmt.Errorf("[%s] acme: the order is invalid: %v", domains[0], order.Error)
+		}
+
+		return nil, fmt.Errorf("[%s] acme: the order is invalid", domains[0])
+
+	default:
+		return nil, fmt.Errorf("[%s] acme: unexpected status of the order: %q", domains[0], order.Status)
+	}
+
+	if err != nil {
+		return cert, err
+	}
+
+	// the user-facing domain is the domain as requested (e.g. an internationalized domain name in its Unicode form).
+	if len(request.Domains) > 0 {
+		if sanitized := sanitizeDomain(request.Domains[:1]); len(sanitized) == 1 && sanitized[0] == cert.Domain {
+			cert.Domain = request.Domains[0]
+		}
+	}
+
+	return cert, c.runObtainedHook(cert)
+}
+
+// resumeAuthorizations solves the pending authorizations of the order (the valid authorizations are skipped), then finalizes the order.
+func (c *Certifier) resumeAuthorizations(domains []string, order acme.ExtendedOrder, request ObtainRequest) (*Resource, error) {
+	authz, err := c.getAuthorizations(order)
+	if err != nil {
+		return nil, err
+	}
+
+	validations, err := c.solve(authz)
+	if err != nil {
+		// If any challenge fails, return. Do not generate partial SAN certificates.
+		c.deactivateAuthorizations(order)
+		return nil, err
+	}
+
+	log.Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
+
+	cert, err := c.getForOrder(domains, order, request.Bundle, request.PrivateKey, request.MustStaple, request.PreferredChain)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.Validations = validations
+
+	return cert, nil
+}
+
+// resumeCertificate fetches the certificate of a finalized order.
+func (c *Certifier) resumeCertificate(domains []string, order acme.ExtendedOrder, request ObtainRequest) (*Resource, error) {
+	if request.PrivateKey == nil {
+		return nil, fmt.Errorf("[%s] acme: the order is already finalized: the private key of the certificate is required", domains[0])
+	}
+
+	cert := &Resource{
+		Domain:     domains[0],
+		PrivateKey: certcrypto.PEMEncode(request.PrivateKey),
+		OrderURL:   order.Location,
+	}
+
+	err := c.waitForCertificate(order.Location, cert, request.Bundle, request.PreferredChain)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+// resumeDomains returns the domains of an order:
+// the requested domains if they match the identifiers of the order, the identifiers of the order otherwise.
+func resumeDomains(order acme.Order, requested []string) ([]string, error) {
+	var identifiers []string
+	for _, identifier := range order.Identifiers {
+		identifiers = append(identifiers, identifier.Value)
+	}
+
+	if len(identifiers) == 0 {
+		return nil, errors.New("resume: the order has no identifiers")
+	}
+
+	if len(requested) == 0 {
+		return identifiers, nil
+	}
+
+	domains := sanitizeDomain(requested)
+
+	if !sameDomains(domains, identifiers) {
+		return nil, fmt.Errorf("resume: the domains of the request (%s) do not match the identifiers of the order (%s)",
+			strings.Join(domains, ", "), strings.Join(identifiers, ", "))
+	}
+
+	return domains, nil
+}
+
+func sameDomains(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	x := append([]string(nil), a...)
+	y := append([]string(nil), b...)
+	sort.Strings(x)
+	sort.Strings(y)
+
+	for i := range x {
+		if !strings.EqualFold(x[i], y[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,169 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/acme/api"
+	"github.com/vostronet/lego/certcrypto"
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertifier_Resume(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		status      string
+		withKey     bool
+		expectedErr string
+	}{
+		{
+			desc:    "ready",
+			status:  acme.StatusReady,
+			withKey: true,
+		},
+		{
+			desc:    "ready without private key",
+			status:  acme.StatusReady,
+			withKey: false,
+		},
+		{
+			desc:    "valid",
+			status:  acme.StatusValid,
+			withKey: true,
+		},
+		{
+			desc:        "valid without private key",
+			status:      acme.StatusValid,
+			expectedErr: "[acme.wtf] acme: the order is already finalized: the private key of the certificate is required",
+		},
+		{
+			desc:        "invalid",
+			status:      acme.StatusInvalid,
+			expectedErr: "[acme.wtf] acme: the order is invalid",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			mux.HandleFunc("/order/1", func(w http.ResponseWriter, _ *http.Request) {
+				order := acme.Order{
+					Status:      test.status,
+					Identifiers: []acme.Identifier{{Type: "dns", Value: "acme.wtf"}},
+					Finalize:    apiURL + "/order/1/finalize",
+				}
+
+				if test.status == acme.StatusValid {
+					order.Certificate = apiURL + "/certificate"
+				}
+
+				err := tester.WriteJSONResponse(w, order)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+			})
+
+			mux.HandleFunc("/order/1/finalize", func(w http.ResponseWriter, _ *http.Request) {
+				err := tester.WriteJSONResponse(w, acme.Order{
+					Status:      acme.StatusValid,
+					Identifiers: []acme.Identifier{{Type: "dns", Value: "acme.wtf"}},
+					Certificate: apiURL + "/certificate",
+				})
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+			})
+
+			mux.HandleFunc("/certificate", func(w http.ResponseWriter, _ *http.Request) {
+				_, err := w.Write([]byte(certResponseMock))
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+			})
+
+			key, err := rsa.GenerateKey(rand.Reader, 1024)
+			require.NoError(t, err, "Could not generate test key")
+
+			core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+			require.NoError(t, err)
+
+			certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.EC256})
+
+			request := ObtainRequest{Domains: []string{"acme.wtf"}, Bundle: true}
+			if test.withKey {
+				request.PrivateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				require.NoError(t, err)
+			}
+
+			cert, err := certifier.Resume(apiURL+"/order/1", request)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, "acme.wtf", cert.Domain)
+			assert.Equal(t, apiURL+"/order/1", cert.OrderURL)
+			assert.Equal(t, apiURL+"/certificate", cert.CertURL)
+			assert.Equal(t, certResponseMock, string(cert.Certificate))
+			assert.NotEmpty(t, cert.PrivateKey)
+		})
+	}
+}
+
+func Test_resumeDomains(t *testing.T) {
+	order := acme.Order{
+		Identifiers: []acme.Identifier{
+			{Type: "dns", Value: "example.com"},
+			{Type: "dns", Value: "xn--r8jz45g.xn--zckzah"},
+		},
+	}
+
+	testCases := []struct {
+		desc        string
+		requested   []string
+		expected    []string
+		expectedErr string
+	}{
+		{
+			desc:     "no requested domains",
+			expected: []string{"example.com", "xn--r8jz45g.xn--zckzah"},
+		},
+		{
+			desc:      "same domains in another order",
+			requested: []string{"例え.テスト", "example.com"},
+			expected:  []string{"xn--r8jz45g.xn--zckzah", "example.com"},
+		},
+		{
+			desc:        "other domains",
+			requested:   []string{"example.com", "example.org"},
+			expectedErr: "resume: the domains of the request (example.com, example.org) do not match the identifiers of the order (example.com, xn--r8jz45g.xn--zckzah)",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			domains, err := resumeDomains(order, test.requested)
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, domains)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package dns01
+
+import (
+	"fmt"
+
+	"github.com/miekg/dns"
+)
+
+// LookupCAA returns the relevant CAA record set of the domain (RFC 8659, section 3),
+// resolved through the recursive nameservers:
+// the CAA records of the domain, or of its closest parent domain having CAA records.
+// Returns no records if no CAA record is found up to the TLD.
+func LookupCAA(domain string) ([]*dns.CAA, error) {
+	fqdn := ToFqdn(domain)
+
+	for _, index := range dns.Split(fqdn) {
+		name := fqdn[index:]
+
+		r, err := dnsQuery(name, dns.TypeCAA, recursiveNameservers, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the CAA records of %s: %v", name, err)
+		}
+
+		switch r.Rcode {
+		case dns.RcodeSuccess, dns.RcodeNameError:
+		default:
+			return nil, fmt.Errorf("unexpected response code '%s' for the CAA records of %s", dns.RcodeToString[r.Rcode], name)
+		}
+
+		var records []*dns.CAA
+		for _, rr := range r.Answer {
+			if caa, ok := rr.(*dns.CAA); ok {
+				records = append(records, caa)
+			}
+		}
+
+		if len(records) > 0 {
+			return records, nil
+		}
+	}
+
+	return nil, nil
+}
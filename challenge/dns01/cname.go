@@ -1,6 +1,15 @@
 package dns01
 
-import "github.com/miekg/dns"
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+	"github.com/vostronet/lego/log"
+)
+
+const challengePrefix = "_acme-challenge."
 
 // Update FQDN with CNAME if any
 func updateDomainWithCName(r *dns.Msg, fqdn string) string {
@@ -14,3 +23,87 @@ func updateDomainWithCName(r *dns.Msg, fqdn string) string {
 
 	return fqdn
 }
+
+// LookupCNAME returns the target of the CNAME record of the FQDN, resolved through the recursive nameservers.
+func LookupCNAME(fqdn string) (string, error) {
+	r, err := dnsQuery(fqdn, dns.TypeCNAME, recursiveNameservers, true)
+	if err != nil {
+		return "", err
+	}
+
+	if r.Rcode != dns.RcodeSuccess {
+		return "", fmt.Errorf("unexpected response for '%s' [%s]", fqdn, dns.RcodeToString[r.Rcode])
+	}
+
+	target := updateDomainWithCName(r, fqdn)
+	if target == fqdn {
+		return "", fmt.Errorf("no CNAME record found for '%s'", fqdn)
+	}
+
+	return target, nil
+}
+
+// FollowCNAME follows the CNAME record of the challenge name (`_acme-challenge.<domain>`) before the presentation of the TXT record:
+// the TXT record is created on the CNAME target as-is (e.g. in a zone dedicated to the ACME challenges, like with acme-dns)
+// instead of the challenge name. The challenge name is used if it has no CNAME record.
+//
+// The domain is still passed to the DNS provider, GetRecord returns the CNAME target as the name of the TXT record.
+//
+// Unlike LEGO_EXPERIMENTAL_CNAME_SUPPORT, the option only applies to the challenges created with it,
+// and the CNAME is resolved once per challenge (not each time GetRecord is called).
+// Unlike the cname DNS provider, it works with any DNS provider without wrapping it, and doesn't require a CNAME record.
+func FollowCNAME() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.lookupCNAME = LookupCNAME
+		return nil
+	}
+}
+
+// cnameTargets the CNAME targets followed by the challenges being solved (see FollowCNAME), by key authorization.
+// A key authorization is bound to the token of a challenge and to the account key:
+// a target only applies to the TXT record of its own challenge.
+var cnameTargets = struct {
+	sync.Mutex
+	targets map[string]string
+}{targets: make(map[string]string)}
+
+// followCNAME follows the CNAME record of the challenge name of the domain for the key authorization (see FollowCNAME):
+// GetRecord returns the CNAME target until the target is released (see releaseCNAMETarget).
+func (c *Challenge) followCNAME(domain, keyAuth string) {
+	if c.lookupCNAME == nil {
+		return
+	}
+
+	fqdn := challengeName(domain)
+
+	target, err := c.lookupCNAME(fqdn)
+	if err != nil {
+		log.Infof("[%s] acme: No CNAME record to follow for %s: %v", domain, fqdn, err)
+		return
+	}
+
+	target = strings.ToLower(ToFqdn(target))
+
+	log.Infof("[%s] acme: Following the CNAME record of %s, the TXT record is created on %s", domain, fqdn, target)
+
+	cnameTargets.Lock()
+	cnameTargets.targets[keyAuth] = target
+	cnameTargets.Unlock()
+}
+
+// cnameTarget returns the CNAME target followed for the key authorization, if any.
+func cnameTarget(keyAuth string) (string, bool) {
+	cnameTargets.Lock()
+	defer cnameTargets.Unlock()
+
+	target, ok := cnameTargets.targets[keyAuth]
+	return target, ok
+}
+
+// releaseCNAMETarget forgets the CNAME target followed for the key authorization, once the TXT record is removed.
+func releaseCNAMETarget(keyAuth string) {
+	cnameTargets.Lock()
+	defer cnameTargets.Unlock()
+
+	delete(cnameTargets.targets, keyAuth)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/vostronet/lego/log"
 	"github.com/vostronet/lego/platform/wait"
 	"github.com/miekg/dns"
+	"golang.org/x/net/idna"
 )
 
 const (
@@ -49,6 +50,16 @@ type Challenge struct {
 	provider   challenge.Provider
 	preCheck   preCheck
 	dnsTimeout time.Duration
+	// skips the local propagation check and lets the CA determine the propagation of the TXT record.
+	delegatePropagation bool
+	// skips the local propagation check of the matching TXT records (nil: all the records are checked).
+	skipPropagation func(fqdn string) bool
+	// looks up the SOA record of the zone to scale the propagation timings (nil: the timings of the provider are used).
+	lookupSOA func(fqdn string) (*dns.SOA, error)
+	// called with the FQDN and the value of the TXT record before its presentation.
+	presentHook challenge.PresentHook
+	// looks up the CNAME record of the challenge name, the TXT record is created on its target (nil: the CNAME is not followed).
+	lookupCNAME func(fqdn string) (string, error)
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
@@ -60,6 +71,10 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 		dnsTimeout: 10 * time.Second,
 	}
 
+	if transformer, ok := unwrapProvider(provider).(RecordValueTransformer); ok {
+		chlg.preCheck.transformValue = transformer.TransformRecordValue
+	}
+
 	for _, opt := range opts {
 		err := opt(chlg)
 		if err != nil {
@@ -70,6 +85,11 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 	return chlg
 }
 
+// SetPresentHook sets the hook called with the FQDN and the value of the TXT record before its presentation.
+func (c *Challenge) SetPresentHook(hook challenge.PresentHook) {
+	c.presentHook = hook
+}
+
 // PreSolve just submits the txt record to the dns provider.
 // It does not validate record propagation, or do anything at all with the acme server.
 func (c *Challenge) PreSolve(authz acme.Authorization) error {
@@ -91,6 +111,21 @@ func (c *Challenge) PreSolve(authz acme.Authorization) error {
 		return err
 	}
 
+	c.followCNAME(authz.Identifier.Value, keyAuth)
+
+	if c.presentHook != nil {
+		fqdn, value := GetRecordForProvider(unwrapProvider(c.provider), authz.Identifier.Value, keyAuth)
+
+		c.presentHook(challenge.PresentInfo{
+			Type:    challenge.DNS01,
+			Domain:  authz.Identifier.Value,
+			Token:   chlng.Token,
+			KeyAuth: keyAuth,
+			FQDN:    fqdn,
+			Value:   value,
+		})
+	}
+
 	err = c.provider.Present(authz.Identifier.Value, chlng.Token, keyAuth)
 	if err != nil {
 		return fmt.Errorf("[%s] acme: error presenting token: %s", domain, err)
@@ -116,6 +151,31 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 
 	fqdn, value := GetRecord(authz.Identifier.Value, keyAuth)
 
+	if c.delegatePropagation {
+		log.Infof("[%s] acme: Skipping the local DNS record propagation check, the propagation is delegated to the CA", domain)
+
+		chlng.KeyAuthorization = keyAuth
+		return c.validate(c.core, domain, chlng)
+	}
+
+	if c.skipPropagation != nil && c.skipPropagation(fqdn) {
+		log.Infof("[%s] acme: Skipping the local DNS record propagation check of %s", domain, fqdn)
+
+		chlng.KeyAuthorization = keyAuth
+		return c.validate(c.core, domain, chlng)
+	}
+
+	err = c.waitPropagation(domain, fqdn, value)
+	if err != nil {
+		return err
+	}
+
+	chlng.KeyAuthorization = keyAuth
+	return c.validate(c.core, domain, chlng)
+}
+
+// waitPropagation waits for the propagation of the TXT record, with the timings of the provider (or of the SOA record).
+func (c *Challenge) waitPropagation(domain, fqdn, value string) error {
 	var timeout, interval time.Duration
 	switch provider := c.provider.(type) {
 	case challenge.ProviderTimeout:
@@ -124,21 +184,25 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		timeout, interval = DefaultPropagationTimeout, DefaultPollingInterval
 	}
 
+	if c.lookupSOA != nil {
+		soa, errS := c.lookupSOA(fqdn)
+		if errS != nil {
+			log.Warnf("[%s] acme: Could not get the SOA record, using the default propagation timings: %v", domain, errS)
+		} else {
+			timeout, interval = soaTimings(soa, timeout, interval)
+			log.Infof("[%s] acme: Using the propagation timings of the SOA record of %s: timeout %s, interval %s", domain, soa.Hdr.Name, timeout, interval)
+		}
+	}
+
 	log.Infof("[%s] acme: Checking DNS record propagation using %+v", domain, recursiveNameservers)
 
-	err = wait.For("propagation", timeout, interval, func() (bool, error) {
+	return wait.For("propagation", timeout, interval, func() (bool, error) {
 		stop, errP := c.preCheck.call(domain, fqdn, value)
 		if !stop || errP != nil {
 			log.Infof("[%s] acme: Waiting for DNS record propagation.", domain)
 		}
 		return stop, errP
 	})
-	if err != nil {
-		return err
-	}
-
-	chlng.KeyAuthorization = keyAuth
-	return c.validate(c.core, domain, chlng)
 }
 
 // CleanUp cleans the challenge.
@@ -155,26 +219,66 @@ func (c *Challenge) CleanUp(authz acme.Authorization) error {
 		return err
 	}
 
+	defer releaseCNAMETarget(keyAuth)
+
 	return c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth)
 }
 
 func (c *Challenge) Sequential() (bool, time.Duration) {
-	if p, ok := c.provider.(sequential); ok {
-		return ok, p.Sequential()
-	}
-	return false, 0
+	return IsSequential(c.provider)
 }
 
 type sequential interface {
 	Sequential() time.Duration
 }
 
+// sequentialWrapper is implemented by the providers wrapping other providers (e.g. multi, router):
+// they are sequential only if one of the wrapped providers is sequential.
+type sequentialWrapper interface {
+	IsSequential() bool
+}
+
+// IsSequential returns true, and the interval between two challenges,
+// if the provider solves the challenges one after the other (i.e. it implements Sequential() time.Duration).
+// A provider wrapping other providers can implement IsSequential() bool to tell if one of them is sequential.
+func IsSequential(provider challenge.Provider) (bool, time.Duration) {
+	p, ok := unwrapProvider(provider).(sequential)
+	if !ok {
+		return false, 0
+	}
+
+	if w, ok := p.(sequentialWrapper); ok && !w.IsSequential() {
+		return false, 0
+	}
+
+	return true, p.Sequential()
+}
+
+// SingleValueTXT returns true if the provider can only publish one value per TXT record name:
+// the challenges sharing a TXT record name (e.g. "example.com" and "*.example.com") must be solved one after the other.
+func (c *Challenge) SingleValueTXT() bool {
+	p, ok := unwrapProvider(c.provider).(singleValueTXT)
+	return ok && p.SingleValueTXT()
+}
+
+// singleValueTXT is implemented by the providers which can only publish one value per TXT record name
+// (e.g. the API replaces all the values of the record).
+type singleValueTXT interface {
+	SingleValueTXT() bool
+}
+
 // GetRecord returns a DNS record which will fulfill the `dns-01` challenge
+// (on the CNAME target of the challenge name if the CNAME has been followed, see FollowCNAME).
 func GetRecord(domain, keyAuth string) (fqdn string, value string) {
 	keyAuthShaBytes := sha256.Sum256([]byte(keyAuth))
 	// base64URL encoding without padding
 	value = base64.RawURLEncoding.EncodeToString(keyAuthShaBytes[:sha256.Size])
-	fqdn = fmt.Sprintf("_acme-challenge.%s.", domain)
+
+	fqdn = challengeName(domain)
+
+	if target, ok := cnameTarget(keyAuth); ok {
+		return target, value
+	}
 
 	if ok, _ := strconv.ParseBool(os.Getenv("LEGO_EXPERIMENTAL_CNAME_SUPPORT")); ok {
 		r, err := dnsQuery(fqdn, dns.TypeCNAME, recursiveNameservers, true)
@@ -186,3 +290,13 @@ func GetRecord(domain, keyAuth string) (fqdn string, value string) {
 
 	return
 }
+
+// challengeName returns the name of the TXT record of the domain: `_acme-challenge.<domain>.`.
+func challengeName(domain string) string {
+	// the record of an internationalized domain name is published under its A-label form (punycode).
+	if ascii, err := idna.ToASCII(domain); err == nil {
+		domain = ascii
+	}
+
+	return fmt.Sprintf("_acme-challenge.%s.", domain)
+}
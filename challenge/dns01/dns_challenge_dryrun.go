@@ -0,0 +1,103 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/log"
+)
+
+// DryRunResult the result of a dry run of the DNS-01 challenge.
+type DryRunResult struct {
+	// FQDN the name of the TXT record.
+	FQDN string
+	// Value the value of the TXT record.
+	Value string
+	// Present the duration of the creation of the TXT record.
+	Present time.Duration
+	// Propagation the duration of the propagation check (zero if the check is skipped or delegated).
+	Propagation time.Duration
+	// CleanUp the duration of the removal of the TXT record.
+	CleanUp time.Duration
+}
+
+// DryRun exercises the setup of the DNS-01 challenge of a domain, without any ACME order:
+// it creates a TXT record (with a dummy key authorization), waits for its propagation, then removes it.
+// The record is removed even if the propagation check fails.
+// The challenge can be created without ACME core (i.e. `NewChallenge(nil, nil, provider, opts...)`).
+// The challenge of a wildcard domain (`*.example.com`) is the challenge of its base domain, like in the authorizations.
+func (c *Challenge) DryRun(domain string) (*DryRunResult, error) {
+	if c.provider == nil {
+		return nil, fmt.Errorf("[%s] acme: no DNS Provider configured", domain)
+	}
+
+	token, keyAuth, err := newDryRunKeyAuth()
+	if err != nil {
+		return nil, fmt.Errorf("[%s] acme: could not generate the dummy key authorization: %v", domain, err)
+	}
+
+	identifier := strings.TrimPrefix(domain, "*.")
+
+	c.followCNAME(identifier, keyAuth)
+	defer releaseCNAMETarget(keyAuth)
+
+	fqdn, value := GetRecord(identifier, keyAuth)
+
+	result := &DryRunResult{FQDN: fqdn, Value: value}
+
+	log.Infof("[%s] acme: Dry run of the DNS-01 challenge: presenting %s", domain, fqdn)
+
+	start := time.Now()
+	err = c.provider.Present(identifier, token, keyAuth)
+	result.Present = time.Since(start)
+	if err != nil {
+		return result, fmt.Errorf("[%s] acme: error presenting token: %v", domain, err)
+	}
+
+	errP := c.dryRunPropagation(domain, fqdn, value, result)
+
+	log.Infof("[%s] acme: Dry run of the DNS-01 challenge: cleaning %s", domain, fqdn)
+
+	start = time.Now()
+	errC := c.provider.CleanUp(identifier, token, keyAuth)
+	result.CleanUp = time.Since(start)
+
+	if errP != nil {
+		return result, fmt.Errorf("[%s] acme: %v", domain, errP)
+	}
+
+	if errC != nil {
+		return result, fmt.Errorf("[%s] acme: error cleaning up: %v", domain, errC)
+	}
+
+	return result, nil
+}
+
+func (c *Challenge) dryRunPropagation(domain, fqdn, value string, result *DryRunResult) error {
+	if c.delegatePropagation || (c.skipPropagation != nil && c.skipPropagation(fqdn)) {
+		log.Infof("[%s] acme: Skipping the local DNS record propagation check of %s", domain, fqdn)
+		return nil
+	}
+
+	start := time.Now()
+	err := c.waitPropagation(domain, fqdn, value)
+	result.Propagation = time.Since(start)
+
+	return err
+}
+
+// newDryRunKeyAuth generates a random token and a dummy key authorization (not bound to any account key).
+func newDryRunKeyAuth() (string, string, error) {
+	raw := make([]byte, 32)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	return token, token + ".lego-dry-run", nil
+}
@@ -0,0 +1,121 @@
+package dns01
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerRecorderMock struct {
+	providerTimeoutMock
+	presented, cleaned []string
+	domains            []string
+}
+
+func (p *providerRecorderMock) Present(domain, token, keyAuth string) error {
+	p.presented = append(p.presented, keyAuth)
+	p.domains = append(p.domains, domain)
+	return p.present
+}
+
+func (p *providerRecorderMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleaned = append(p.cleaned, keyAuth)
+	return p.cleanUp
+}
+
+func TestChallenge_DryRun(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		domain      string
+		preCheck    WrapPreCheckFunc
+		present     error
+		cleanUp     error
+		delegate    bool
+		expectedErr string
+		cleaned     bool
+	}{
+		{
+			desc:     "success",
+			preCheck: func(_, _, _ string, _ PreCheckFunc) (bool, error) { return true, nil },
+			cleaned:  true,
+		},
+		{
+			desc:     "wildcard",
+			domain:   "*.example.com",
+			preCheck: func(_, _, _ string, _ PreCheckFunc) (bool, error) { return true, nil },
+			cleaned:  true,
+		},
+		{
+			desc:        "present fail",
+			preCheck:    func(_, _, _ string, _ PreCheckFunc) (bool, error) { return true, nil },
+			present:     errors.New("OOPS"),
+			expectedErr: "[example.com] acme: error presenting token: OOPS",
+		},
+		{
+			desc:        "preCheck fail",
+			preCheck:    func(_, _, _ string, _ PreCheckFunc) (bool, error) { return false, errors.New("OOPS") },
+			expectedErr: "[example.com] acme: time limit exceeded: last error: OOPS",
+			cleaned:     true,
+		},
+		{
+			desc:     "delegate propagation",
+			preCheck: func(_, _, _ string, _ PreCheckFunc) (bool, error) { return false, errors.New("OOPS") },
+			delegate: true,
+			cleaned:  true,
+		},
+		{
+			desc:        "cleanUp fail",
+			preCheck:    func(_, _, _ string, _ PreCheckFunc) (bool, error) { return true, nil },
+			cleanUp:     errors.New("OOPS"),
+			expectedErr: "[example.com] acme: error cleaning up: OOPS",
+			cleaned:     true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			provider := &providerRecorderMock{
+				providerTimeoutMock: providerTimeoutMock{
+					present:  test.present,
+					cleanUp:  test.cleanUp,
+					timeout:  time.Second,
+					interval: 100 * time.Millisecond,
+				},
+			}
+
+			chlg := NewChallenge(nil, nil, provider,
+				WrapPreCheck(test.preCheck),
+				CondOption(test.delegate, DelegatePropagationCheck()))
+
+			domain := "example.com"
+			if test.domain != "" {
+				domain = test.domain
+			}
+
+			result, err := chlg.DryRun(domain)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expectedErr)
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, "_acme-challenge.example.com.", result.FQDN)
+			assert.NotEmpty(t, result.Value)
+
+			require.Len(t, provider.presented, 1)
+			assert.Equal(t, []string{"example.com"}, provider.domains)
+			if test.cleaned {
+				assert.Equal(t, provider.presented, provider.cleaned)
+			} else {
+				assert.Empty(t, provider.cleaned)
+			}
+		})
+	}
+}
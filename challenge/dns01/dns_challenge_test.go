@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/vostronet/lego/acme/api"
 	"github.com/vostronet/lego/challenge"
 	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,6 +33,27 @@ func (p *providerTimeoutMock) Present(domain, token, keyAuth string) error { ret
 func (p *providerTimeoutMock) CleanUp(domain, token, keyAuth string) error { return p.cleanUp }
 func (p *providerTimeoutMock) Timeout() (time.Duration, time.Duration)     { return p.timeout, p.interval }
 
+type providerSingleValueMock struct {
+	providerMock
+}
+
+func (p *providerSingleValueMock) SingleValueTXT() bool { return true }
+
+// providerRecordMock records the FQDN of the TXT record, as computed by a provider.
+type providerRecordMock struct {
+	presented, cleaned string
+}
+
+func (p *providerRecordMock) Present(domain, token, keyAuth string) error {
+	p.presented, _ = GetRecord(domain, keyAuth)
+	return nil
+}
+
+func (p *providerRecordMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleaned, _ = GetRecord(domain, keyAuth)
+	return nil
+}
+
 func TestChallenge_PreSolve(t *testing.T) {
 	_, apiURL, tearDown := tester.SetupFakeAPI()
 	defer tearDown()
@@ -115,6 +138,164 @@ func TestChallenge_PreSolve(t *testing.T) {
 	}
 }
 
+func TestChallenge_PreSolve_presentHook(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	chlg := NewChallenge(core, nil, &providerMock{})
+
+	var infos []challenge.PresentInfo
+	chlg.SetPresentHook(func(info challenge.PresentInfo) {
+		infos = append(infos, info)
+	})
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Value: "example.com"},
+		Challenges: []acme.Challenge{{Type: challenge.DNS01.String(), Token: "token"}},
+	}
+
+	err = chlg.PreSolve(authz)
+	require.NoError(t, err)
+
+	require.Len(t, infos, 1)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	fqdn, value := GetRecord("example.com", keyAuth)
+
+	expected := challenge.PresentInfo{
+		Type:    challenge.DNS01,
+		Domain:  "example.com",
+		Token:   "token",
+		KeyAuth: keyAuth,
+		FQDN:    fqdn,
+		Value:   value,
+	}
+	assert.Equal(t, expected, infos[0])
+}
+
+func TestChallenge_PreSolve_presentHook_wrappedProvider(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	chlg := NewChallenge(core, nil, WithRetry(&transformerProviderMock{}, 3, time.Millisecond))
+
+	var infos []challenge.PresentInfo
+	chlg.SetPresentHook(func(info challenge.PresentInfo) {
+		infos = append(infos, info)
+	})
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Value: "example.com"},
+		Challenges: []acme.Challenge{{Type: challenge.DNS01.String(), Token: "token"}},
+	}
+
+	err = chlg.PreSolve(authz)
+	require.NoError(t, err)
+
+	require.Len(t, infos, 1)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	_, value := GetRecord("example.com", keyAuth)
+
+	assert.Equal(t, QuoteRecordValue(value), infos[0].Value)
+}
+
+func TestChallenge_PreSolve_followCNAME(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc         string
+		token        string
+		lookupCNAME  func(fqdn string) (string, error)
+		expectedFqdn string
+	}{
+		{
+			desc:  "CNAME followed",
+			token: "token-cname",
+			lookupCNAME: func(fqdn string) (string, error) {
+				if fqdn != "_acme-challenge.example.com." {
+					return "", fmt.Errorf("unexpected FQDN: %s", fqdn)
+				}
+				return "_acme-challenge.Example.com.Acme.Example.org", nil
+			},
+			expectedFqdn: "_acme-challenge.example.com.acme.example.org.",
+		},
+		{
+			desc:  "CNAME target without challenge prefix",
+			token: "token-acme-dns",
+			lookupCNAME: func(fqdn string) (string, error) {
+				return "d420c923-bbd7-4056-ab64-c3ca54c9b3cf.auth.example.org.", nil
+			},
+			expectedFqdn: "d420c923-bbd7-4056-ab64-c3ca54c9b3cf.auth.example.org.",
+		},
+		{
+			desc:  "no CNAME",
+			token: "token-no-cname",
+			lookupCNAME: func(fqdn string) (string, error) {
+				return "", fmt.Errorf("no CNAME record found for '%s'", fqdn)
+			},
+			expectedFqdn: "_acme-challenge.example.com.",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			provider := &providerRecordMock{}
+
+			chlg := NewChallenge(core, nil, provider, FollowCNAME())
+			chlg.lookupCNAME = test.lookupCNAME
+
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{Value: "example.com"},
+				Challenges: []acme.Challenge{{Type: challenge.DNS01.String(), Token: test.token}},
+			}
+
+			err := chlg.PreSolve(authz)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedFqdn, provider.presented)
+
+			err = chlg.CleanUp(authz)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedFqdn, provider.cleaned)
+
+			// the CNAME target is forgotten after the clean-up.
+			keyAuth, err := core.GetKeyAuthorization(test.token)
+			require.NoError(t, err)
+
+			fqdn, _ := GetRecord("example.com", keyAuth)
+			assert.Equal(t, "_acme-challenge.example.com.", fqdn)
+		})
+	}
+}
+
 func TestChallenge_Solve(t *testing.T) {
 	_, apiURL, tearDown := tester.SetupFakeAPI()
 	defer tearDown()
@@ -130,6 +311,8 @@ func TestChallenge_Solve(t *testing.T) {
 		validate    ValidateFunc
 		preCheck    WrapPreCheckFunc
 		provider    challenge.Provider
+		delegate    bool
+		skip        func(fqdn string) bool
 		expectError bool
 	}{
 		{
@@ -158,6 +341,39 @@ func TestChallenge_Solve(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			desc:     "delegate propagation",
+			validate: func(_ *api.Core, _ string, _ acme.Challenge) error { return nil },
+			preCheck: func(_, _, _ string, _ PreCheckFunc) (bool, error) { return false, errors.New("OOPS") },
+			provider: &providerMock{},
+			delegate: true,
+		},
+		{
+			desc:        "delegate propagation validate fail",
+			validate:    func(_ *api.Core, _ string, _ acme.Challenge) error { return errors.New("OOPS") },
+			preCheck:    func(_, _, _ string, _ PreCheckFunc) (bool, error) { return true, nil },
+			provider:    &providerMock{},
+			delegate:    true,
+			expectError: true,
+		},
+		{
+			desc:     "propagation disabled for the fqdn",
+			validate: func(_ *api.Core, _ string, _ acme.Challenge) error { return nil },
+			preCheck: func(_, _, _ string, _ PreCheckFunc) (bool, error) { return false, errors.New("OOPS") },
+			provider: &providerMock{},
+			skip:     func(fqdn string) bool { return fqdn == "_acme-challenge.example.com." },
+		},
+		{
+			desc:     "propagation disabled for another fqdn",
+			validate: func(_ *api.Core, _ string, _ acme.Challenge) error { return nil },
+			preCheck: func(_, _, _ string, _ PreCheckFunc) (bool, error) { return false, errors.New("OOPS") },
+			provider: &providerTimeoutMock{
+				timeout:  2 * time.Second,
+				interval: 500 * time.Millisecond,
+			},
+			skip:        func(fqdn string) bool { return fqdn == "_acme-challenge.example.org." },
+			expectError: true,
+		},
 		{
 			desc:     "present fail",
 			validate: func(_ *api.Core, _ string, _ acme.Challenge) error { return nil },
@@ -183,6 +399,8 @@ func TestChallenge_Solve(t *testing.T) {
 			if test.preCheck != nil {
 				options = append(options, WrapPreCheck(test.preCheck))
 			}
+			options = append(options, CondOption(test.delegate, DelegatePropagationCheck()))
+			options = append(options, CondOption(test.skip != nil, DisablePropagationFor(test.skip)))
 			chlg := NewChallenge(core, test.validate, test.provider, options...)
 
 			authz := acme.Authorization{
@@ -287,3 +505,39 @@ func TestChallenge_CleanUp(t *testing.T) {
 		})
 	}
 }
+
+func TestChallenge_SingleValueTXT(t *testing.T) {
+	chlg := NewChallenge(nil, nil, &providerMock{})
+	assert.False(t, chlg.SingleValueTXT())
+
+	chlg = NewChallenge(nil, nil, &providerSingleValueMock{})
+	assert.True(t, chlg.SingleValueTXT())
+}
+
+func TestFollowCNAME(t *testing.T) {
+	chlg := NewChallenge(nil, nil, nil)
+	assert.Nil(t, chlg.lookupCNAME)
+
+	chlg = NewChallenge(nil, nil, nil, FollowCNAME())
+	assert.NotNil(t, chlg.lookupCNAME)
+}
+
+func TestDisablePropagationFor(t *testing.T) {
+	chlg := NewChallenge(nil, nil, &providerMock{},
+		DisablePropagationFor(func(fqdn string) bool { return fqdn == "_acme-challenge.example.com." }),
+		DisablePropagationFor(func(fqdn string) bool { return fqdn == "_acme-challenge.example.org." }),
+	)
+
+	require.NotNil(t, chlg.skipPropagation)
+	assert.True(t, chlg.skipPropagation("_acme-challenge.example.com."))
+	assert.True(t, chlg.skipPropagation("_acme-challenge.example.org."))
+	assert.False(t, chlg.skipPropagation("_acme-challenge.example.net."))
+}
+
+func TestGetRecord_internationalizedDomain(t *testing.T) {
+	fqdn, value := GetRecord("例え.テスト", "keyAuth")
+	assert.Equal(t, "_acme-challenge.xn--r8jz45g.xn--zckzah.", fqdn)
+
+	_, expected := GetRecord("xn--r8jz45g.xn--zckzah", "keyAuth")
+	assert.Equal(t, expected, value)
+}
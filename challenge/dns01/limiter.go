@@ -0,0 +1,91 @@
+package dns01
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter caps the number of concurrent API calls of a DNS provider,
+// and enforces a minimum delay between the starts of two API calls.
+// A nil RateLimiter doesn't limit anything.
+type RateLimiter struct {
+	sem   chan struct{}
+	delay time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewRateLimiter creates a RateLimiter.
+// A maxConcurrency lower than 1 doesn't limit the number of concurrent calls,
+// and a delay lower than or equal to 0 doesn't add any delay between the calls.
+func NewRateLimiter(maxConcurrency int, delay time.Duration) *RateLimiter {
+	limiter := &RateLimiter{delay: delay}
+
+	if maxConcurrency > 0 {
+		limiter.sem = make(chan struct{}, maxConcurrency)
+	}
+
+	return limiter
+}
+
+// Do calls fn once a call slot is available and the minimum delay since the previous call is elapsed.
+func (r *RateLimiter) Do(fn func() error) error {
+	if r == nil {
+		return fn()
+	}
+
+	if r.sem != nil {
+		r.sem <- struct{}{}
+		defer func() { <-r.sem }()
+	}
+
+	r.wait()
+
+	return fn()
+}
+
+// wait reserves the next start time of a call, and waits until this time.
+func (r *RateLimiter) wait() {
+	if r.delay <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	start := r.next
+	if start.Before(now) {
+		start = now
+	}
+	r.next = start.Add(r.delay)
+	r.mu.Unlock()
+
+	time.Sleep(start.Sub(now))
+}
+
+// Transport returns an http.RoundTripper sending the requests through the RateLimiter.
+// It allows to limit the API calls of the providers based on a third-party client.
+// If next is nil, http.DefaultTransport is used.
+func (r *RateLimiter) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var resp *http.Response
+		err := r.Do(func() error {
+			var errD error
+			resp, errD = next.RoundTrip(req)
+			return errD
+		})
+
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,92 @@
+package dns01
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Do_nil(t *testing.T) {
+	var limiter *RateLimiter
+
+	err := limiter.Do(func() error { return errors.New("oops") })
+	require.EqualError(t, err, "oops")
+}
+
+func TestRateLimiter_Do_maxConcurrency(t *testing.T) {
+	limiter := NewRateLimiter(2, 0)
+
+	var current, maxCurrent int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := limiter.Do(func() error {
+				n := atomic.AddInt32(&current, 1)
+				defer atomic.AddInt32(&current, -1)
+
+				for {
+					m := atomic.LoadInt32(&maxCurrent)
+					if n <= m || atomic.CompareAndSwapInt32(&maxCurrent, m, n) {
+						break
+					}
+				}
+
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 2, atomic.LoadInt32(&maxCurrent))
+}
+
+func TestRateLimiter_Do_delay(t *testing.T) {
+	limiter := NewRateLimiter(0, 20*time.Millisecond)
+
+	var starts []time.Time
+	for i := 0; i < 3; i++ {
+		err := limiter.Do(func() error {
+			starts = append(starts, time.Now())
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	for i := 1; i < len(starts); i++ {
+		assert.True(t, starts[i].Sub(starts[i-1]) >= 15*time.Millisecond, "the calls must be delayed")
+	}
+}
+
+func TestRateLimiter_Transport(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(1, 10*time.Millisecond)
+	client := &http.Client{Transport: limiter.Transport(nil)}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	assert.True(t, time.Since(start) >= 15*time.Millisecond, "the requests must be delayed")
+}
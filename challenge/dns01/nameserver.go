@@ -15,8 +15,23 @@ const defaultResolvConf = "/etc/resolv.conf"
 // dnsTimeout is used to override the default DNS timeout of 10 seconds.
 var dnsTimeout = 10 * time.Second
 
+// dnsTCPOnly forces the DNS queries over TCP (by default, the queries are sent over UDP and retried over TCP on truncation).
+var dnsTCPOnly bool
+
+// fqdnCacheTTL is the duration during which the zone apex found for a FQDN is reused.
+var fqdnCacheTTL = 5 * time.Minute
+
+// ZoneFinder determines the zone apex for the given fqdn by using the given nameservers.
+type ZoneFinder func(fqdn string, nameservers []string) (string, error)
+
+type zoneCacheEntry struct {
+	zone      string
+	expiresAt time.Time
+}
+
 var (
-	fqdnToZone   = map[string]string{}
+	fqdnToZone   = map[string]zoneCacheEntry{}
+	zoneFinder   = ZoneFinder(findZoneBySOA)
 	muFqdnToZone sync.Mutex
 )
 
@@ -31,7 +46,21 @@ var recursiveNameservers = getNameservers(defaultResolvConf, defaultNameservers)
 // ClearFqdnCache clears the cache of fqdn to zone mappings. Primarily used in testing.
 func ClearFqdnCache() {
 	muFqdnToZone.Lock()
-	fqdnToZone = map[string]string{}
+	fqdnToZone = map[string]zoneCacheEntry{}
+	muFqdnToZone.Unlock()
+}
+
+// SetZoneFinder replaces the lookup of the zone apexes (e.g. to avoid the SOA lookups in the tests),
+// and clears the cache of fqdn to zone mappings.
+// A nil finder restores the default lookup, based on the SOA records.
+func SetZoneFinder(finder ZoneFinder) {
+	if finder == nil {
+		finder = findZoneBySOA
+	}
+
+	muFqdnToZone.Lock()
+	zoneFinder = finder
+	fqdnToZone = map[string]zoneCacheEntry{}
 	muFqdnToZone.Unlock()
 }
 
@@ -42,6 +71,15 @@ func AddDNSTimeout(timeout time.Duration) ChallengeOption {
 	}
 }
 
+// UseTCPOnly sends the DNS queries (propagation checks, zone lookups) over TCP only, instead of UDP.
+// Useful with the resolvers which truncate or drop the large UDP responses.
+func UseTCPOnly() ChallengeOption {
+	return func(_ *Challenge) error {
+		dnsTCPOnly = true
+		return nil
+	}
+}
+
 func AddRecursiveNameservers(nameservers []string) ChallengeOption {
 	return func(_ *Challenge) error {
 		recursiveNameservers = ParseNameservers(nameservers)
@@ -106,15 +144,34 @@ func FindZoneByFqdn(fqdn string) (string, error) {
 
 // FindZoneByFqdnCustom determines the zone apex for the given fqdn
 // by recursing up the domain labels until the nameserver returns a SOA record in the answer section.
+// The zone apexes are cached during fqdnCacheTTL.
 func FindZoneByFqdnCustom(fqdn string, nameservers []string) (string, error) {
 	muFqdnToZone.Lock()
-	defer muFqdnToZone.Unlock()
+	entry, ok := fqdnToZone[fqdn]
+	finder := zoneFinder
+	muFqdnToZone.Unlock()
 
 	// Do we have it cached?
-	if zone, ok := fqdnToZone[fqdn]; ok {
-		return zone, nil
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.zone, nil
 	}
 
+	// the lock is not held during the lookup: the challenges can be solved in parallel.
+	zone, err := finder(fqdn, nameservers)
+	if err != nil {
+		return "", err
+	}
+
+	muFqdnToZone.Lock()
+	fqdnToZone[fqdn] = zoneCacheEntry{zone: zone, expiresAt: time.Now().Add(fqdnCacheTTL)}
+	muFqdnToZone.Unlock()
+
+	return zone, nil
+}
+
+// findZoneBySOA determines the zone apex for the given fqdn
+// by recursing up the domain labels until the nameserver returns a SOA record in the answer section.
+func findZoneBySOA(fqdn string, nameservers []string) (string, error) {
 	var err error
 	var in *dns.Msg
 
@@ -147,9 +204,7 @@ func FindZoneByFqdnCustom(fqdn string, nameservers []string) (string, error) {
 
 			for _, ans := range in.Answer {
 				if soa, ok := ans.(*dns.SOA); ok {
-					zone := soa.Hdr.Name
-					fqdnToZone[fqdn] = zone
-					return zone, nil
+					return soa.Hdr.Name, nil
 				}
 			}
 		case dns.RcodeNameError:
@@ -201,6 +256,12 @@ func createDNSMsg(fqdn string, rtype uint16, recursive bool) *dns.Msg {
 }
 
 func sendDNSQuery(m *dns.Msg, ns string) (*dns.Msg, error) {
+	if dnsTCPOnly {
+		tcp := &dns.Client{Net: "tcp", Timeout: dnsTimeout}
+		in, _, err := tcp.Exchange(m, ns)
+		return in, err
+	}
+
 	udp := &dns.Client{Net: "udp", Timeout: dnsTimeout}
 	in, _, err := udp.Exchange(m, ns)
 
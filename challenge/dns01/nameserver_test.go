@@ -1,9 +1,15 @@
 package dns01
 
 import (
+	"fmt"
+	"net"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -145,6 +151,66 @@ func TestFindZoneByFqdnCustom(t *testing.T) {
 	}
 }
 
+func TestFindZoneByFqdn_zoneFinder(t *testing.T) {
+	var calls int32
+	SetZoneFinder(func(fqdn string, nameservers []string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		if fqdn != "_acme-challenge.example.com." {
+			return "", fmt.Errorf("could not find the start of authority for %s", fqdn)
+		}
+		return "example.com.", nil
+	})
+	defer SetZoneFinder(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			zone, err := FindZoneByFqdn("_acme-challenge.example.com.")
+			assert.NoError(t, err)
+			assert.Equal(t, "example.com.", zone)
+		}()
+	}
+	wg.Wait()
+
+	zone, err := FindZoneByFqdn("_acme-challenge.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com.", zone)
+
+	// the concurrent lookups can be done before the first result is cached.
+	first := atomic.LoadInt32(&calls)
+	assert.True(t, first >= 1 && first <= 10)
+
+	_, err = FindZoneByFqdn("_acme-challenge.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, first, atomic.LoadInt32(&calls), "the zone must be cached")
+
+	_, err = FindZoneByFqdn("_acme-challenge.example.org.")
+	require.EqualError(t, err, "could not find the start of authority for _acme-challenge.example.org.")
+}
+
+func TestFindZoneByFqdn_cacheTTL(t *testing.T) {
+	var calls int
+	SetZoneFinder(func(fqdn string, nameservers []string) (string, error) {
+		calls++
+		return "example.com.", nil
+	})
+	defer SetZoneFinder(nil)
+
+	defer func(ttl time.Duration) { fqdnCacheTTL = ttl }(fqdnCacheTTL)
+	fqdnCacheTTL = -time.Second
+
+	for i := 0; i < 3; i++ {
+		zone, err := FindZoneByFqdn("_acme-challenge.example.com.")
+		require.NoError(t, err)
+		assert.Equal(t, "example.com.", zone)
+	}
+
+	assert.Equal(t, 3, calls, "the expired zones must be looked up again")
+}
+
 func TestResolveConfServers(t *testing.T) {
 	var testCases = []struct {
 		fixture  string
@@ -175,3 +241,35 @@ func TestResolveConfServers(t *testing.T) {
 		})
 	}
 }
+
+func TestSendDNSQuery_tcpOnly(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &dns.Server{
+		Listener: listener,
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			m := new(dns.Msg)
+			m.SetReply(req)
+			m.Answer = append(m.Answer, &dns.TXT{
+				Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 120},
+				Txt: []string{"value"},
+			})
+			_ = w.WriteMsg(m)
+		}),
+	}
+
+	go func() { _ = server.ActivateAndServe() }()
+	defer func() { _ = server.Shutdown() }()
+
+	defer func(tcpOnly bool) { dnsTCPOnly = tcpOnly }(dnsTCPOnly)
+
+	err = UseTCPOnly()(nil)
+	require.NoError(t, err)
+
+	in, err := sendDNSQuery(createDNSMsg("_acme-challenge.example.com.", dns.TypeTXT, true), listener.Addr().String())
+	require.NoError(t, err)
+
+	require.Len(t, in.Answer, 1)
+	assert.Equal(t, []string{"value"}, in.Answer[0].(*dns.TXT).Txt)
+}
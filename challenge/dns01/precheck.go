@@ -47,11 +47,50 @@ func DisableCompletePropagationRequirement() ChallengeOption {
 	}
 }
 
+// DisablePropagationFor skips the local DNS propagation check of the TXT records matching the predicate
+// (e.g. the zones of a slow external provider): the challenge is submitted as soon as the TXT record is presented.
+// The other TXT records are still checked. Several predicates can be combined: a record matching any of them is skipped.
+func DisablePropagationFor(skip func(fqdn string) bool) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if skip == nil {
+			return errors.New("invalid propagation predicate: the predicate is nil")
+		}
+
+		previous := chlg.skipPropagation
+		if previous == nil {
+			chlg.skipPropagation = skip
+			return nil
+		}
+
+		chlg.skipPropagation = func(fqdn string) bool {
+			return previous(fqdn) || skip(fqdn)
+		}
+		return nil
+	}
+}
+
+// DelegatePropagationCheck skips the local DNS propagation check:
+// the challenge is submitted as soon as the TXT record is presented,
+// and the propagation is determined by the CA's own resolvers.
+// The status of the authorization is polled (with back-off) until it becomes valid or the validation times out.
+//
+// The CA validates the challenge only once: if the TXT record is not yet visible to the resolvers of the CA,
+// the authorization becomes invalid and the challenge is not retried (a new order is required).
+// The option suits the providers whose records are served as soon as they are created.
+func DelegatePropagationCheck() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.delegatePropagation = true
+		return nil
+	}
+}
+
 type preCheck struct {
 	// checks DNS propagation before notifying ACME that the DNS challenge is ready.
 	checkFunc WrapPreCheckFunc
 	// require the TXT record to be propagated to all authoritative name servers
 	requireCompletePropagation bool
+	// transforms the expected TXT record value in the form used by the provider (can be nil).
+	transformValue func(value string) string
 }
 
 func newPreCheck() preCheck {
@@ -89,7 +128,17 @@ func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
 		return false, err
 	}
 
-	return checkAuthoritativeNss(fqdn, value, authoritativeNss)
+	found, err := checkAuthoritativeNss(fqdn, value, authoritativeNss)
+	if found || p.transformValue == nil {
+		return found, err
+	}
+
+	// Some providers publish the transformed value as is (i.e. with the quotes).
+	if transformed := p.transformValue(value); transformed != value {
+		return checkAuthoritativeNss(fqdn, transformed, authoritativeNss)
+	}
+
+	return found, err
 }
 
 // checkAuthoritativeNss queries each of the given nameservers for the expected TXT record.
@@ -0,0 +1,50 @@
+package dns01
+
+import (
+	"strings"
+
+	"github.com/vostronet/lego/challenge"
+)
+
+// RecordValueTransformer is implemented by the providers which require the value of the TXT record
+// in a specific form (quoted, unquoted, etc.).
+// The transformation is applied to the value published by the provider (see GetRecordForProvider),
+// and the propagation check also accepts the transformed value when the provider publishes it as is.
+type RecordValueTransformer interface {
+	TransformRecordValue(value string) string
+}
+
+// QuoteRecordValue surrounds the value of a TXT record with double quotes.
+// The value is not modified if it's already quoted.
+func QuoteRecordValue(value string) string {
+	if isQuoted(value) {
+		return value
+	}
+	return `"` + value + `"`
+}
+
+// UnquoteRecordValue removes the double quotes surrounding the value of a TXT record.
+func UnquoteRecordValue(value string) string {
+	if isQuoted(value) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// GetRecordForProvider returns a DNS record which will fulfill the `dns-01` challenge,
+// the value is transformed if the provider implements RecordValueTransformer.
+func GetRecordForProvider(provider challenge.Provider, domain, keyAuth string) (fqdn string, value string) {
+	fqdn, value = GetRecord(domain, keyAuth)
+	return fqdn, transformRecordValue(provider, value)
+}
+
+func transformRecordValue(provider challenge.Provider, value string) string {
+	if transformer, ok := provider.(RecordValueTransformer); ok {
+		return transformer.TransformRecordValue(value)
+	}
+	return value
+}
+
+func isQuoted(value string) bool {
+	return len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`)
+}
@@ -0,0 +1,89 @@
+package dns01
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteRecordValue(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected string
+	}{
+		{
+			desc:     "unquoted value",
+			value:    "abc",
+			expected: `"abc"`,
+		},
+		{
+			desc:     "quoted value",
+			value:    `"abc"`,
+			expected: `"abc"`,
+		},
+		{
+			desc:     "single quote",
+			value:    `"`,
+			expected: `"""`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, QuoteRecordValue(test.value))
+		})
+	}
+}
+
+func TestUnquoteRecordValue(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected string
+	}{
+		{
+			desc:     "unquoted value",
+			value:    "abc",
+			expected: "abc",
+		},
+		{
+			desc:     "quoted value",
+			value:    `"abc"`,
+			expected: "abc",
+		},
+		{
+			desc:     "partially quoted value",
+			value:    `"abc`,
+			expected: `"abc`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, UnquoteRecordValue(test.value))
+		})
+	}
+}
+
+type transformerProviderMock struct {
+	providerMock
+}
+
+func (p *transformerProviderMock) TransformRecordValue(value string) string {
+	return QuoteRecordValue(value)
+}
+
+func TestGetRecordForProvider(t *testing.T) {
+	_, value := GetRecordForProvider(&providerMock{}, "example.com", "keyAuth")
+	assert.Equal(t, "pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM", value)
+
+	_, value = GetRecordForProvider(&transformerProviderMock{}, "example.com", "keyAuth")
+	assert.Equal(t, `"pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"`, value)
+}
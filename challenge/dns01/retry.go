@@ -0,0 +1,115 @@
+package dns01
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/log"
+)
+
+// maxRetryInterval the maximum interval between two attempts of a retried provider.
+const maxRetryInterval = 30 * time.Second
+
+// RetryableError can be implemented by the errors of the DNS providers
+// to tell if the operation can be retried (e.g. a 5xx response) or not (e.g. a 4xx response).
+// The errors not implementing it are retried.
+type RetryableError interface {
+	error
+	Retryable() bool
+}
+
+// retryProvider retries the failed Present and CleanUp of a DNS provider.
+type retryProvider struct {
+	provider challenge.Provider
+	attempts int
+	interval time.Duration
+}
+
+// WithRetry wraps a DNS provider to retry Present and CleanUp on error (e.g. transient errors of the API),
+// up to attempts times, with an exponential backoff starting at interval.
+// The other capabilities of the provider (Timeout, Check, Sequential, SingleValueTXT, etc.) are preserved.
+func WithRetry(provider challenge.Provider, attempts int, interval time.Duration) challenge.Provider {
+	if attempts <= 1 {
+		return provider
+	}
+
+	return &retryProvider{provider: provider, attempts: attempts, interval: interval}
+}
+
+// Present creates the TXT record, with retries.
+func (r *retryProvider) Present(domain, token, keyAuth string) error {
+	return r.retry(domain, "present", func() error {
+		return r.provider.Present(domain, token, keyAuth)
+	})
+}
+
+// CleanUp removes the TXT record, with retries.
+func (r *retryProvider) CleanUp(domain, token, keyAuth string) error {
+	return r.retry(domain, "clean up", func() error {
+		return r.provider.CleanUp(domain, token, keyAuth)
+	})
+}
+
+// Timeout returns the timeout and interval of the wrapped provider.
+func (r *retryProvider) Timeout() (timeout, interval time.Duration) {
+	if p, ok := r.provider.(challenge.ProviderTimeout); ok {
+		return p.Timeout()
+	}
+
+	return DefaultPropagationTimeout, DefaultPollingInterval
+}
+
+// Check verifies the credentials of the wrapped provider (see challenge.CredentialChecker).
+// It returns challenge.ErrCheckNotSupported if the wrapped provider doesn't support it.
+func (r *retryProvider) Check() error {
+	if p, ok := r.provider.(challenge.CredentialChecker); ok {
+		return p.Check()
+	}
+
+	return challenge.ErrCheckNotSupported
+}
+
+// Unwrap returns the wrapped provider.
+func (r *retryProvider) Unwrap() challenge.Provider {
+	return r.provider
+}
+
+func (r *retryProvider) retry(domain, action string, fn func() error) error {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = r.interval
+	bo.MaxInterval = maxRetryInterval
+	bo.MaxElapsedTime = 0
+
+	operation := func() error {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		if e, ok := err.(RetryableError); ok && !e.Retryable() {
+			return backoff.Permanent(err)
+		}
+
+		return err
+	}
+
+	notify := func(err error, next time.Duration) {
+		log.Warnf("[%s] acme: Failed to %s the TXT record, retrying in %s: %v", domain, action, next, err)
+	}
+
+	return backoff.RetryNotify(operation, backoff.WithMaxRetries(bo, uint64(r.attempts-1)), notify)
+}
+
+// unwrapProvider returns the provider wrapped by the decorators of the package (e.g. WithRetry),
+// to check its capabilities.
+func unwrapProvider(provider challenge.Provider) challenge.Provider {
+	for {
+		p, ok := provider.(interface{ Unwrap() challenge.Provider })
+		if !ok {
+			return provider
+		}
+
+		provider = p.Unwrap()
+	}
+}
@@ -0,0 +1,167 @@
+package dns01
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/challenge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingProvider struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (p *failingProvider) Present(domain, token, keyAuth string) error {
+	p.calls++
+	if p.calls <= p.failures {
+		return p.err
+	}
+	return nil
+}
+
+func (p *failingProvider) CleanUp(domain, token, keyAuth string) error {
+	return p.Present(domain, token, keyAuth)
+}
+
+type sequentialFailingProvider struct {
+	failingProvider
+}
+
+func (p *sequentialFailingProvider) Sequential() time.Duration {
+	return 42 * time.Second
+}
+
+type checkerFailingProvider struct {
+	failingProvider
+	checkErr error
+}
+
+func (p *checkerFailingProvider) Check() error {
+	return p.checkErr
+}
+
+type apiError struct {
+	retryable bool
+}
+
+func (e apiError) Error() string {
+	return "api error"
+}
+
+func (e apiError) Retryable() bool {
+	return e.retryable
+}
+
+func TestWithRetry(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		failures      int
+		err           error
+		expectedCalls int
+		expectedErr   bool
+	}{
+		{
+			desc:          "success",
+			expectedCalls: 1,
+		},
+		{
+			desc:          "transient errors",
+			failures:      2,
+			err:           errors.New("network error"),
+			expectedCalls: 3,
+		},
+		{
+			desc:          "too many errors",
+			failures:      5,
+			err:           errors.New("network error"),
+			expectedCalls: 3,
+			expectedErr:   true,
+		},
+		{
+			desc:          "retryable error",
+			failures:      1,
+			err:           apiError{retryable: true},
+			expectedCalls: 2,
+		},
+		{
+			desc:          "non-retryable error",
+			failures:      1,
+			err:           apiError{retryable: false},
+			expectedCalls: 1,
+			expectedErr:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &failingProvider{failures: test.failures, err: test.err}
+
+			provider := WithRetry(mock, 3, time.Millisecond)
+
+			err := provider.Present("example.com", "token", "keyAuth")
+			if test.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectedCalls, mock.calls)
+		})
+	}
+}
+
+func TestWithRetry_CleanUp(t *testing.T) {
+	mock := &failingProvider{failures: 1, err: errors.New("network error")}
+
+	provider := WithRetry(mock, 2, time.Millisecond)
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, mock.calls)
+}
+
+func TestWithRetry_noRetry(t *testing.T) {
+	mock := &failingProvider{}
+
+	provider := WithRetry(mock, 1, time.Millisecond)
+
+	assert.Equal(t, mock, provider)
+}
+
+func TestWithRetry_capabilities(t *testing.T) {
+	mock := &sequentialFailingProvider{}
+
+	chlg := NewChallenge(nil, nil, WithRetry(mock, 3, time.Millisecond))
+
+	sequential, interval := chlg.Sequential()
+	assert.True(t, sequential)
+	assert.Equal(t, 42*time.Second, interval)
+
+	timeout, pollingInterval := chlg.provider.(*retryProvider).Timeout()
+	assert.Equal(t, DefaultPropagationTimeout, timeout)
+	assert.Equal(t, DefaultPollingInterval, pollingInterval)
+}
+
+func TestWithRetry_Check(t *testing.T) {
+	provider := WithRetry(&checkerFailingProvider{checkErr: errors.New("invalid credentials")}, 3, time.Millisecond)
+
+	checker, ok := provider.(challenge.CredentialChecker)
+	require.True(t, ok)
+
+	err := checker.Check()
+	require.EqualError(t, err, "invalid credentials")
+
+	checker = WithRetry(&failingProvider{}, 3, time.Millisecond).(challenge.CredentialChecker)
+
+	err = checker.Check()
+	assert.Equal(t, challenge.ErrCheckNotSupported, err)
+}
@@ -0,0 +1,81 @@
+package dns01
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// maxSOAPropagationTimeout the maximum propagation timeout computed from the SOA timings.
+	maxSOAPropagationTimeout = 1 * time.Hour
+
+	// maxSOAPollingInterval the maximum polling interval computed from the SOA timings.
+	maxSOAPollingInterval = 1 * time.Minute
+)
+
+// UseSOATimings derives the propagation timeout and the polling interval from the SOA record of the zone:
+// the secondary nameservers pull the zone at most every "refresh" seconds,
+// and the resolvers cache the missing TXT records during at most "minimum" seconds (the negative-cache TTL).
+// The SOA timings replace the timings of the provider, even if they are shorter.
+func UseSOATimings() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.lookupSOA = LookupSOA
+		return nil
+	}
+}
+
+// LookupSOA returns the SOA record of the zone of the fqdn.
+func LookupSOA(fqdn string) (*dns.SOA, error) {
+	zone, err := FindZoneByFqdn(fqdn)
+	if err != nil {
+		return nil, err
+	}
+
+	in, err := dnsQuery(zone, dns.TypeSOA, recursiveNameservers, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ans := range in.Answer {
+		if soa, ok := ans.(*dns.SOA); ok {
+			return soa, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no SOA record for the zone %s", zone)
+}
+
+// soaTimings returns the propagation timeout and the polling interval derived from the SOA record:
+// the timeout is the longest of the refresh and the minimum, and the interval is the minimum, bounded by the timeout.
+// The timings of the provider are kept for the zero values of the SOA record.
+func soaTimings(soa *dns.SOA, timeout, interval time.Duration) (time.Duration, time.Duration) {
+	refresh := time.Duration(soa.Refresh) * time.Second
+	minimum := time.Duration(soa.Minttl) * time.Second
+
+	expected := refresh
+	if minimum > expected {
+		expected = minimum
+	}
+
+	if expected > 0 {
+		timeout = expected
+		if timeout > maxSOAPropagationTimeout {
+			timeout = maxSOAPropagationTimeout
+		}
+	}
+
+	if minimum > 0 {
+		interval = minimum
+		if interval > maxSOAPollingInterval {
+			interval = maxSOAPollingInterval
+		}
+	}
+
+	if interval > timeout {
+		interval = timeout
+	}
+
+	return timeout, interval
+}
@@ -0,0 +1,104 @@
+package dns01
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_soaTimings(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		refresh          uint32
+		minimum          uint32
+		timeout          time.Duration
+		interval         time.Duration
+		expectedTimeout  time.Duration
+		expectedInterval time.Duration
+	}{
+		{
+			desc:             "fast zone: shorter than the timings of the provider",
+			refresh:          30,
+			minimum:          5,
+			timeout:          60 * time.Second,
+			interval:         2 * time.Second,
+			expectedTimeout:  30 * time.Second,
+			expectedInterval: 5 * time.Second,
+		},
+		{
+			desc:             "slow zone: timeout from the refresh",
+			refresh:          600,
+			minimum:          30,
+			timeout:          60 * time.Second,
+			interval:         2 * time.Second,
+			expectedTimeout:  10 * time.Minute,
+			expectedInterval: 30 * time.Second,
+		},
+		{
+			desc:             "slow zone: timeout from the minimum",
+			refresh:          60,
+			minimum:          300,
+			timeout:          60 * time.Second,
+			interval:         2 * time.Second,
+			expectedTimeout:  5 * time.Minute,
+			expectedInterval: maxSOAPollingInterval,
+		},
+		{
+			desc:             "very slow zone: bounded",
+			refresh:          86400,
+			minimum:          86400,
+			timeout:          60 * time.Second,
+			interval:         2 * time.Second,
+			expectedTimeout:  maxSOAPropagationTimeout,
+			expectedInterval: maxSOAPollingInterval,
+		},
+		{
+			desc:             "interval bounded by the timeout",
+			refresh:          0,
+			minimum:          10,
+			timeout:          60 * time.Second,
+			interval:         2 * time.Second,
+			expectedTimeout:  10 * time.Second,
+			expectedInterval: 10 * time.Second,
+		},
+		{
+			desc:             "zero values: the timings of the provider are kept",
+			timeout:          60 * time.Second,
+			interval:         2 * time.Second,
+			expectedTimeout:  60 * time.Second,
+			expectedInterval: 2 * time.Second,
+		},
+		{
+			desc:             "zero minimum: the interval of the provider is kept",
+			refresh:          300,
+			timeout:          60 * time.Second,
+			interval:         30 * time.Second,
+			expectedTimeout:  5 * time.Minute,
+			expectedInterval: 30 * time.Second,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			soa := &dns.SOA{Refresh: test.refresh, Minttl: test.minimum}
+
+			timeout, interval := soaTimings(soa, test.timeout, test.interval)
+
+			assert.Equal(t, test.expectedTimeout, timeout)
+			assert.Equal(t, test.expectedInterval, interval)
+		})
+	}
+}
+
+func TestUseSOATimings(t *testing.T) {
+	chlg := NewChallenge(nil, nil, nil)
+	assert.Nil(t, chlg.lookupSOA)
+
+	chlg = NewChallenge(nil, nil, nil, UseSOATimings())
+	assert.NotNil(t, chlg.lookupSOA)
+}
@@ -17,9 +17,10 @@ func ChallengePath(token string) string {
 }
 
 type Challenge struct {
-	core     *api.Core
-	validate ValidateFunc
-	provider challenge.Provider
+	core        *api.Core
+	validate    ValidateFunc
+	provider    challenge.Provider
+	presentHook challenge.PresentHook
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider) *Challenge {
@@ -34,6 +35,11 @@ func (c *Challenge) SetProvider(provider challenge.Provider) {
 	c.provider = provider
 }
 
+// SetPresentHook sets the hook called with the path and the body of the resource before its presentation.
+func (c *Challenge) SetPresentHook(hook challenge.PresentHook) {
+	c.presentHook = hook
+}
+
 func (c *Challenge) Solve(authz acme.Authorization) error {
 	domain := challenge.GetTargetedDomain(authz)
 	log.Infof("[%s] acme: Trying to solve HTTP-01", domain)
@@ -49,6 +55,17 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		return err
 	}
 
+	if c.presentHook != nil {
+		c.presentHook(challenge.PresentInfo{
+			Type:    challenge.HTTP01,
+			Domain:  authz.Identifier.Value,
+			Token:   chlng.Token,
+			KeyAuth: keyAuth,
+			Path:    ChallengePath(chlng.Token),
+			Body:    keyAuth,
+		})
+	}
+
 	err = c.provider.Present(authz.Identifier.Value, chlng.Token, keyAuth)
 	if err != nil {
 		return fmt.Errorf("[%s] acme: error presenting token: %v", domain, err)
@@ -1,22 +1,31 @@
 package http01
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/vostronet/lego/log"
 )
 
+// shutdownTimeout the maximum duration to wait for the in-flight requests when the server is stopped.
+const shutdownTimeout = 5 * time.Second
+
 // ProviderServer implements ChallengeProvider for `http-01` challenge
 // It may be instantiated without using the NewProviderServer function if
 // you want only to use the default values.
 type ProviderServer struct {
-	iface    string
-	port     string
-	done     chan bool
-	listener net.Listener
+	iface         string
+	port          string
+	proxyHeader   string
+	acceptAnyHost bool
+	ctx           context.Context
+	server        *http.Server
+	done          chan struct{}
+	listener      net.Listener
 }
 
 // NewProviderServer creates a new ProviderServer on the selected interface and port.
@@ -26,20 +35,61 @@ func NewProviderServer(iface, port string) *ProviderServer {
 	return &ProviderServer{iface: iface, port: port}
 }
 
+// SetProxyHeader changes the header used to match the host of the incoming requests with the domain.
+// By default, the Host header is used.
+// Behind a reverse proxy or a port-forwarding proxy, the original host is usually in another header,
+// e.g. "X-Forwarded-Host" or "Forwarded" (only the first proxy of the header is trusted).
+// An empty name or "Host" restores the default.
+func (s *ProviderServer) SetProxyHeader(name string) {
+	if strings.EqualFold(name, "Host") {
+		name = ""
+	}
+
+	s.proxyHeader = name
+}
+
+// SetAcceptAnyHost allows to serve the key authorization regardless of the host of the incoming requests.
+// It's useful when the host is rewritten by a proxy without forwarding the original host.
+func (s *ProviderServer) SetAcceptAnyHost(accept bool) {
+	s.acceptAnyHost = accept
+}
+
+// SetContext binds the server to a context:
+// when the context is cancelled (e.g. the obtain is aborted), the running server is shut down gracefully and the port is released,
+// even if CleanUp is never called.
+func (s *ProviderServer) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
 // Present starts a web server and makes the token available at `ChallengePath(token)` for web requests.
 func (s *ProviderServer) Present(domain, token, keyAuth string) error {
 	if s.port == "" {
 		s.port = "80"
 	}
 
+	if s.ctx != nil && s.ctx.Err() != nil {
+		return fmt.Errorf("could not start HTTP server for challenge -> %v", s.ctx.Err())
+	}
+
 	var err error
 	s.listener, err = net.Listen("tcp", s.GetAddress())
 	if err != nil {
 		return fmt.Errorf("could not start HTTP server for challenge -> %v", err)
 	}
 
-	s.done = make(chan bool)
-	go s.serve(domain, token, keyAuth)
+	s.server = &http.Server{Handler: s.handler(domain, keyAuth, ChallengePath(token))}
+
+	// Once httpServer is shut down
+	// we don't want any lingering connections, so disable KeepAlives.
+	s.server.SetKeepAlivesEnabled(false)
+
+	s.done = make(chan struct{})
+	go s.serve(s.server, s.listener, s.done)
+
+	if s.ctx != nil {
+		go shutdownOnCancel(s.ctx, s.server, s.done)
+	}
+
 	return nil
 }
 
@@ -47,24 +97,39 @@ func (s *ProviderServer) GetAddress() string {
 	return net.JoinHostPort(s.iface, s.port)
 }
 
-// CleanUp closes the HTTP server and removes the token from `ChallengePath(token)`
+// CleanUp shuts the HTTP server down gracefully and removes the token from `ChallengePath(token)`
 func (s *ProviderServer) CleanUp(domain, token, keyAuth string) error {
-	if s.listener == nil {
+	if s.server == nil {
 		return nil
 	}
-	s.listener.Close()
+
+	shutdown(s.server)
 	<-s.done
 	return nil
 }
 
-func (s *ProviderServer) serve(domain, token, keyAuth string) {
-	path := ChallengePath(token)
+func (s *ProviderServer) serve(server *http.Server, listener net.Listener, done chan struct{}) {
+	defer close(done)
 
-	// The handler validates the HOST header and request type.
-	// For validation it then writes the token the server returned with the challenge
+	err := server.Serve(listener)
+	if err != nil && err != http.ErrServerClosed && !strings.Contains(err.Error(), "use of closed network connection") {
+		log.Println(err)
+	}
+}
+
+// handler the handler of the challenge path:
+// it validates the HOST header and request type,
+// for validation it then writes the token the server returned with the challenge.
+func (s *ProviderServer) handler(domain, keyAuth, path string) http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
-		if strings.HasPrefix(r.Host, domain) && r.Method == http.MethodGet {
+		host := s.requestHost(r)
+
+		log.Infow("Received a validation request",
+			log.F("domain", domain), log.F("method", r.Method), log.F("host", host),
+			log.F("remoteAddr", r.RemoteAddr), log.F("forwardedFor", r.Header.Get("X-Forwarded-For")))
+
+		if (s.acceptAnyHost || matchDomain(host, domain)) && r.Method == http.MethodGet {
 			w.Header().Add("Content-Type", "text/plain")
 			_, err := w.Write([]byte(keyAuth))
 			if err != nil {
@@ -73,7 +138,7 @@ func (s *ProviderServer) serve(domain, token, keyAuth string) {
 			}
 			log.Infof("[%s] Served key authentication", domain)
 		} else {
-			log.Warnf("Received request for domain %s with method %s but the domain did not match any challenge. Please ensure your are passing the HOST header properly.", r.Host, r.Method)
+			log.Warnf("Received request for domain %s with method %s but the domain did not match any challenge. Please ensure your are passing the HOST header properly.", host, r.Method)
 			_, err := w.Write([]byte("TEST"))
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -82,15 +147,65 @@ func (s *ProviderServer) serve(domain, token, keyAuth string) {
 		}
 	})
 
-	httpServer := &http.Server{Handler: mux}
+	return mux
+}
 
-	// Once httpServer is shut down
-	// we don't want any lingering connections, so disable KeepAlives.
-	httpServer.SetKeepAlivesEnabled(false)
+// shutdownOnCancel shuts the server down when the context is cancelled before the end of the server.
+func shutdownOnCancel(ctx context.Context, server *http.Server, done chan struct{}) {
+	select {
+	case <-ctx.Done():
+		log.Infof("Shutting down the HTTP server for challenge: %v", ctx.Err())
+		shutdown(server)
+	case <-done:
+	}
+}
 
-	err := httpServer.Serve(s.listener)
-	if err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
-		log.Println(err)
+// shutdown stops the server gracefully: the in-flight requests are closed after shutdownTimeout.
+func shutdown(server *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		_ = server.Close()
+	}
+}
+
+// requestHost returns the host of the request, read from the proxy header if any.
+func (s *ProviderServer) requestHost(r *http.Request) string {
+	if s.proxyHeader == "" {
+		return r.Host
 	}
-	s.done <- true
+
+	value := r.Header.Get(s.proxyHeader)
+
+	// only the first proxy is trusted.
+	value = strings.TrimSpace(strings.Split(value, ",")[0])
+
+	if !strings.EqualFold(s.proxyHeader, "Forwarded") {
+		return value
+	}
+
+	// RFC 7239: Forwarded: for=192.0.2.60;proto=http;host=example.com
+	for _, pair := range strings.Split(value, ";") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) == 2 && strings.EqualFold(kv[0], "host") {
+			return strings.Trim(kv[1], `"`)
+		}
+	}
+
+	return ""
+}
+
+// matchDomain returns true if the host (with an optional port) matches the domain.
+func matchDomain(host, domain string) bool {
+	if strings.EqualFold(host, domain) {
+		return true
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.EqualFold(strings.TrimSuffix(host, "."), domain)
 }
@@ -1,11 +1,14 @@
 package http01
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/acme/api"
@@ -96,3 +99,139 @@ func TestChallengeInvalidPort(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid port")
 	assert.Contains(t, err.Error(), "123456")
 }
+
+func TestProviderServer_proxyHeader(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		proxyHeader   string
+		acceptAnyHost bool
+		host          string
+		headers       map[string]string
+		expected      string
+	}{
+		{
+			desc:     "host",
+			host:     "example.com",
+			expected: "keyAuth",
+		},
+		{
+			desc:     "host with port",
+			host:     "example.com:8080",
+			expected: "keyAuth",
+		},
+		{
+			desc:     "host mismatch",
+			host:     "internal:8080",
+			expected: "TEST",
+		},
+		{
+			desc:     "host with the domain as prefix",
+			host:     "example.com.internal",
+			expected: "TEST",
+		},
+		{
+			desc:          "accept any host",
+			acceptAnyHost: true,
+			host:          "internal:8080",
+			expected:      "keyAuth",
+		},
+		{
+			desc:        "X-Forwarded-Host",
+			proxyHeader: "X-Forwarded-Host",
+			host:        "internal:8080",
+			headers:     map[string]string{"X-Forwarded-Host": "example.com, proxy.internal"},
+			expected:    "keyAuth",
+		},
+		{
+			desc:        "X-Forwarded-Host mismatch",
+			proxyHeader: "X-Forwarded-Host",
+			host:        "example.com",
+			headers:     map[string]string{"X-Forwarded-Host": "other.com"},
+			expected:    "TEST",
+		},
+		{
+			desc:        "Forwarded",
+			proxyHeader: "Forwarded",
+			host:        "internal:8080",
+			headers:     map[string]string{"Forwarded": `for=192.0.2.60;proto=http;host="example.com:80", for=10.0.0.1`},
+			expected:    "keyAuth",
+		},
+		{
+			desc:        "Host as proxy header",
+			proxyHeader: "Host",
+			host:        "example.com",
+			expected:    "keyAuth",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			providerServer := NewProviderServer("127.0.0.1", "0")
+			providerServer.SetProxyHeader(test.proxyHeader)
+			providerServer.SetAcceptAnyHost(test.acceptAnyHost)
+
+			err := providerServer.Present("example.com", "token", "keyAuth")
+			require.NoError(t, err)
+
+			defer func() { _ = providerServer.CleanUp("example.com", "token", "keyAuth") }()
+
+			req, err := http.NewRequest(http.MethodGet, "http://"+providerServer.listener.Addr().String()+ChallengePath("token"), nil)
+			require.NoError(t, err)
+
+			req.Host = test.host
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, string(body))
+		})
+	}
+}
+
+func TestProviderServer_SetContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	providerServer := NewProviderServer("127.0.0.1", "0")
+	providerServer.SetContext(ctx)
+
+	err := providerServer.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	addr := providerServer.listener.Addr().String()
+
+	cancel()
+
+	select {
+	case <-providerServer.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the server has not been shut down after the cancellation of the context")
+	}
+
+	// the port is released.
+	listener, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	_ = listener.Close()
+
+	// the clean-up of a server shut down by the context doesn't fail.
+	err = providerServer.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+}
+
+func TestProviderServer_SetContext_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	providerServer := NewProviderServer("127.0.0.1", "0")
+	providerServer.SetContext(ctx)
+
+	err := providerServer.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "could not start HTTP server for challenge -> context canceled")
+}
@@ -1,6 +1,9 @@
 package challenge
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 // Provider enables implementing a custom challenge
 // provider. Present presents the solution to a challenge available to
@@ -26,3 +29,33 @@ type ProviderTimeout interface {
 	Provider
 	Timeout() (timeout, interval time.Duration)
 }
+
+// CredentialChecker allows a Provider to verify its credentials and its access to the zones
+// without presenting a challenge (e.g. before using the provider in an automated pipeline).
+type CredentialChecker interface {
+	Check() error
+}
+
+// ErrCheckNotSupported is returned by the Check of a provider wrapping other providers (e.g. multi, router),
+// if one of the wrapped providers doesn't implement CredentialChecker: its credentials can't be verified.
+var ErrCheckNotSupported = errors.New("the check of the credentials is not supported by the provider")
+
+// PresentInfo the values of a challenge, computed just before their presentation by the provider.
+type PresentInfo struct {
+	Type    Type
+	Domain  string
+	Token   string
+	KeyAuth string
+	// FQDN the name of the TXT record (DNS-01 only).
+	FQDN string
+	// Value the value of the TXT record (DNS-01 only).
+	Value string
+	// Path the URL path of the resource (HTTP-01 only).
+	Path string
+	// Body the content of the resource (HTTP-01 only).
+	Body string
+}
+
+// PresentHook is called with the values of a challenge right before their presentation by the provider
+// (e.g. to log them or to verify them externally when the CA can't see the record).
+type PresentHook func(info PresentInfo)
@@ -23,3 +23,8 @@ func (e obtainError) Error() string {
 	}
 	return buffer.String()
 }
+
+// DomainErrors returns the errors by domain.
+func (e obtainError) DomainErrors() map[string]error {
+	return e
+}
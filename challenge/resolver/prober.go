@@ -2,6 +2,7 @@ package resolver
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/vostronet/lego/acme"
@@ -29,48 +30,148 @@ type sequential interface {
 	Sequential() (bool, time.Duration)
 }
 
+// the solvers which can only solve one challenge at a time for a given identifier
+// (e.g. a DNS provider publishing only one value per TXT record name).
+type singleValueTXT interface {
+	SingleValueTXT() bool
+}
+
 // an authz with the solver we have chosen and the index of the challenge associated with it
 type selectedAuthSolver struct {
-	authz  acme.Authorization
-	solver solver
+	authz    acme.Authorization
+	solver   solver
+	chlgType challenge.Type
 }
 
 type Prober struct {
 	solverManager *SolverManager
+	// fetches the status of the authorizations before presenting the challenges.
+	recheckAuthz bool
+	// presents the challenges even if the authorizations are already valid.
+	forceChallenge bool
+	// receives the durations of the challenges resolutions.
+	metrics acme.Metrics
+	// the maximum number of challenges presented and validated at the same time (one at a time if <= 1).
+	concurrency int
 }
 
 func NewProber(solverManager *SolverManager) *Prober {
 	return &Prober{
 		solverManager: solverManager,
+		metrics:       acme.NoopMetrics{},
+	}
+}
+
+// SetAuthorizationRecheck enables the check of the authorization status just before presenting a challenge.
+// The challenge is skipped if the authorization has been validated in the meantime
+// (e.g. by another order for the same identifier, the CAs reuse the valid authorizations).
+func (p *Prober) SetAuthorizationRecheck(enable bool) {
+	p.recheckAuthz = enable
+}
+
+// SetForceChallenge enables the resolution of the challenges even if the authorizations are already valid.
+// It allows to always exercise the solvers (e.g. to debug or to test a provider):
+// the CA doesn't validate again an authorization that is already valid.
+// A valid authorization without a challenge of a configured solver is skipped.
+func (p *Prober) SetForceChallenge(enable bool) {
+	p.forceChallenge = enable
+}
+
+// SetMetrics sets the receiver of the durations of the challenges resolutions.
+// A nil receiver disables the metrics.
+func (p *Prober) SetMetrics(metrics acme.Metrics) {
+	if metrics == nil {
+		metrics = acme.NoopMetrics{}
+	}
+
+	p.metrics = metrics
+}
+
+// SetConcurrency sets the maximum number of challenges presented, validated and cleaned up at the same time
+// (e.g. to speed up a certificate with many DNS identifiers).
+// Only the challenges presented before their validation (i.e. DNS-01) are solved concurrently,
+// the solvers (and the DNS providers) must be safe for concurrent use.
+// The challenges are solved one at a time by default (limit <= 1).
+func (p *Prober) SetConcurrency(limit int) {
+	p.concurrency = limit
+}
+
+// ConcurrentOrders returns true if the challenges of several orders can be solved at the same time (e.g. a batch of renewals):
+// only the DNS-01 solver supports it, the HTTP-01 and TLS-ALPN-01 solvers (e.g. the built-in servers) listen on a single port.
+func (p *Prober) ConcurrentOrders() bool {
+	for chlgType := range p.solverManager.solvers {
+		if chlgType != challenge.DNS01 {
+			return false
+		}
 	}
+
+	return true
 }
 
 // Solve Looks through the challenge combinations to find a solvable match.
 // Then solves the challenges in series and returns.
 func (p *Prober) Solve(authorizations []acme.Authorization) error {
+	_, err := p.SolveWithValidations(authorizations)
+	return err
+}
+
+// SolveWithValidations solves the challenges like Solve,
+// and returns the type of the challenge validated for each domain (including the authorizations already valid).
+func (p *Prober) SolveWithValidations(authorizations []acme.Authorization) (map[string]challenge.Type, error) {
 	failures := make(obtainError)
+	validations := make(map[string]challenge.Type)
 
 	var authSolvers []*selectedAuthSolver
 	var authSolversSequential []*selectedAuthSolver
 
+	// the identifiers of the parallel challenges which require a single-value TXT record.
+	singleValueIdentifiers := make(map[string]bool)
+
 	// Loop through the resources, basically through the domains.
 	// First pass just selects a solver for each authz.
 	for _, authz := range authorizations {
 		domain := challenge.GetTargetedDomain(authz)
 		if authz.Status == acme.StatusValid {
-			// Boulder might recycle recent validated authz (see issue #267)
-			log.Infof("[%s] acme: authorization already valid; skipping challenge", domain)
-			continue
+			if !p.forceChallenge {
+				// Boulder might recycle recent validated authz (see issue #267)
+				log.Infof("[%s] acme: authorization already valid; skipping challenge", domain)
+				addValidation(validations, authz)
+				continue
+			}
+
+			// a valid authorization may only list the validated challenge, which may not have a solver.
+			if !p.solverManager.hasSolver(authz) {
+				log.Infof("[%s] acme: authorization already valid, no challenge to force; skipping challenge", domain)
+				addValidation(validations, authz)
+				continue
+			}
+
+			log.Infof("[%s] acme: authorization already valid; forcing the challenge", domain)
+		}
+
+		if p.recheckAuthz && !p.forceChallenge {
+			if current := p.fetchReusableAuthorization(authz); current != nil {
+				log.Infof("[%s] acme: authorization validated in the meantime; skipping challenge", domain)
+				addValidation(validations, *current)
+				continue
+			}
 		}
 
-		if solvr := p.solverManager.chooseSolver(authz); solvr != nil {
-			authSolver := &selectedAuthSolver{authz: authz, solver: solvr}
+		if solvr, chlgType := p.solverManager.chooseSolver(authz); solvr != nil {
+			authSolver := &selectedAuthSolver{authz: authz, solver: solvr, chlgType: chlgType}
 
-			switch s := solvr.(type) {
-			case sequential:
-				if ok, _ := s.Sequential(); ok {
+			switch {
+			case isSequential(solvr):
+				// the sequential challenges are already solved one after the other (single-value TXT or not).
+				authSolversSequential = append(authSolversSequential, authSolver)
+			case isSingleValueTXT(solvr):
+				// the base domain and the wildcard domain share the same TXT record name:
+				// the second challenge is solved after the cleanup of the first one.
+				if singleValueIdentifiers[authz.Identifier.Value] {
+					log.Infof("[%s] acme: the provider only supports a single TXT value; the challenge is delayed", domain)
 					authSolversSequential = append(authSolversSequential, authSolver)
 				} else {
+					singleValueIdentifiers[authz.Identifier.Value] = true
 					authSolvers = append(authSolvers, authSolver)
 				}
 			default:
@@ -81,19 +182,56 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 		}
 	}
 
-	parallelSolve(authSolvers, failures)
+	parallelSolve(authSolvers, failures, p.metrics, p.concurrency)
+
+	sequentialSolve(authSolversSequential, failures, p.metrics)
 
-	sequentialSolve(authSolversSequential, failures)
+	for _, authSolver := range append(authSolvers, authSolversSequential...) {
+		domain := challenge.GetTargetedDomain(authSolver.authz)
+		if failures[domain] == nil {
+			validations[domain] = authSolver.chlgType
+		}
+	}
 
 	// Be careful not to return an empty failures map,
 	// for even an empty obtainError is a non-nil error value
 	if len(failures) > 0 {
-		return failures
+		return validations, failures
+	}
+	return validations, nil
+}
+
+// fetchReusableAuthorization fetches the current state of an authorization,
+// and returns it only if the authorization is reusable.
+func (p *Prober) fetchReusableAuthorization(authz acme.Authorization) *acme.Authorization {
+	if authz.URL == "" || p.solverManager.core == nil {
+		return nil
+	}
+
+	current, err := p.solverManager.core.Authorizations.Get(authz.URL)
+	if err != nil {
+		log.Warnf("[%s] acme: could not check the authorization status: %v", challenge.GetTargetedDomain(authz), err)
+		return nil
+	}
+
+	if !current.IsReusable() {
+		return nil
+	}
+
+	return &current
+}
+
+// addValidation records the type of the valid challenge of an authorization, if any.
+func addValidation(validations map[string]challenge.Type, authz acme.Authorization) {
+	for _, chlg := range authz.Challenges {
+		if chlg.Status == acme.StatusValid {
+			validations[challenge.GetTargetedDomain(authz)] = challenge.Type(chlg.Type)
+			return
+		}
 	}
-	return nil
 }
 
-func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
+func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError, metrics acme.Metrics) {
 	for i, authSolver := range authSolvers {
 		// Submit the challenge
 		domain := challenge.GetTargetedDomain(authSolver.authz)
@@ -108,7 +246,7 @@ func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 		}
 
 		// Solve challenge
-		err := authSolver.solver.Solve(authSolver.authz)
+		err := solve(authSolver, metrics)
 		if err != nil {
 			failures[domain] = err
 			cleanUp(authSolver.solver, authSolver.authz)
@@ -119,49 +257,132 @@ func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 		cleanUp(authSolver.solver, authSolver.authz)
 
 		if len(authSolvers)-1 > i {
-			solvr := authSolver.solver.(sequential)
-			_, interval := solvr.Sequential()
-			log.Infof("sequence: wait for %s", interval)
-			time.Sleep(interval)
+			if solvr, ok := authSolver.solver.(sequential); ok {
+				if _, interval := solvr.Sequential(); interval > 0 {
+					log.Infof("sequence: wait for %s", interval)
+					time.Sleep(interval)
+				}
+			}
 		}
 	}
 }
 
-func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
-	// For all valid preSolvers, first submit the challenges so they have max time to propagate
+func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError, metrics acme.Metrics, concurrency int) {
+	var mu sync.Mutex
+	addFailure := func(authz acme.Authorization, err error) {
+		mu.Lock()
+		failures[challenge.GetTargetedDomain(authz)] = err
+		mu.Unlock()
+	}
+
+	// only the challenges presented before their validation can be solved concurrently.
+	var preSolvers, others []*selectedAuthSolver
 	for _, authSolver := range authSolvers {
-		authz := authSolver.authz
-		if solvr, ok := authSolver.solver.(preSolver); ok {
-			err := solvr.PreSolve(authz)
-			if err != nil {
-				failures[challenge.GetTargetedDomain(authz)] = err
-			}
+		if _, ok := authSolver.solver.(preSolver); ok {
+			preSolvers = append(preSolvers, authSolver)
+		} else {
+			others = append(others, authSolver)
 		}
 	}
 
+	// For all valid preSolvers, first submit the challenges so they have max time to propagate
+	forEach(preSolvers, concurrency, func(authSolver *selectedAuthSolver) {
+		err := authSolver.solver.(preSolver).PreSolve(authSolver.authz)
+		if err != nil {
+			addFailure(authSolver.authz, err)
+		}
+	})
+
 	defer func() {
 		// Clean all created TXT records
-		for _, authSolver := range authSolvers {
+		forEach(preSolvers, concurrency, func(authSolver *selectedAuthSolver) {
+			cleanUp(authSolver.solver, authSolver.authz)
+		})
+
+		for _, authSolver := range others {
 			cleanUp(authSolver.solver, authSolver.authz)
 		}
 	}()
 
+	var presented []*selectedAuthSolver
+	for _, authSolver := range preSolvers {
+		if failures[challenge.GetTargetedDomain(authSolver.authz)] == nil {
+			presented = append(presented, authSolver)
+		}
+	}
+
 	// Finally solve all challenges for real
-	for _, authSolver := range authSolvers {
-		authz := authSolver.authz
-		domain := challenge.GetTargetedDomain(authz)
-		if failures[domain] != nil {
-			// already failed in previous loop
-			continue
+	forEach(presented, concurrency, func(authSolver *selectedAuthSolver) {
+		err := solve(authSolver, metrics)
+		if err != nil {
+			addFailure(authSolver.authz, err)
 		}
+	})
 
-		err := authSolver.solver.Solve(authz)
+	for _, authSolver := range others {
+		err := solve(authSolver, metrics)
 		if err != nil {
-			failures[domain] = err
+			addFailure(authSolver.authz, err)
 		}
 	}
 }
 
+// forEach calls the function for each authorization solver,
+// with at most limit concurrent calls (one at a time, in order, if limit <= 1).
+func forEach(authSolvers []*selectedAuthSolver, limit int, fn func(authSolver *selectedAuthSolver)) {
+	if limit <= 1 {
+		for _, authSolver := range authSolvers {
+			fn(authSolver)
+		}
+		return
+	}
+
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for _, authSolver := range authSolvers {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(authSolver *selectedAuthSolver) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			fn(authSolver)
+		}(authSolver)
+	}
+
+	wg.Wait()
+}
+
+// solve solves the challenge of an authorization and reports the duration of the resolution.
+func solve(authSolver *selectedAuthSolver, metrics acme.Metrics) error {
+	start := time.Now()
+
+	err := authSolver.solver.Solve(authSolver.authz)
+
+	metrics.OnChallengeSolved(string(authSolver.chlgType), time.Since(start), err)
+
+	return err
+}
+
+func isSequential(solvr solver) bool {
+	s, ok := solvr.(sequential)
+	if !ok {
+		return false
+	}
+
+	sequential, _ := s.Sequential()
+	return sequential
+}
+
+func isSingleValueTXT(solvr solver) bool {
+	s, ok := solvr.(singleValueTXT)
+	return ok && s.SingleValueTXT()
+}
+
 func cleanUp(solvr solver, authz acme.Authorization) {
 	if solvr, ok := solvr.(cleanup); ok {
 		domain := challenge.GetTargetedDomain(authz)
@@ -1,6 +1,8 @@
 package resolver
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/vostronet/lego/acme"
@@ -23,6 +25,106 @@ func (s *preSolverMock) CleanUp(authorization acme.Authorization) error {
 	return s.cleanUp[authorization.Identifier.Value]
 }
 
+// singleValueSolverMock a solver which can only publish one value per identifier.
+type singleValueSolverMock struct {
+	presented map[string]string
+	events    []string
+}
+
+func (s *singleValueSolverMock) SingleValueTXT() bool {
+	return true
+}
+
+func (s *singleValueSolverMock) PreSolve(authorization acme.Authorization) error {
+	domain := challenge.GetTargetedDomain(authorization)
+	if current, ok := s.presented[authorization.Identifier.Value]; ok {
+		return fmt.Errorf("%s: the value of %s is already presented", domain, current)
+	}
+
+	s.presented[authorization.Identifier.Value] = domain
+	s.events = append(s.events, "present "+domain)
+	return nil
+}
+
+func (s *singleValueSolverMock) Solve(authorization acme.Authorization) error {
+	s.events = append(s.events, "solve "+challenge.GetTargetedDomain(authorization))
+	return nil
+}
+
+func (s *singleValueSolverMock) CleanUp(authorization acme.Authorization) error {
+	delete(s.presented, authorization.Identifier.Value)
+	s.events = append(s.events, "cleanup "+challenge.GetTargetedDomain(authorization))
+	return nil
+}
+
+// concurrentSolverMock a solver which records the number of concurrent validations.
+type concurrentSolverMock struct {
+	mu         sync.Mutex
+	solving    int
+	maxSolving int
+	presented  map[string]bool
+	solve      map[string]error
+}
+
+func (s *concurrentSolverMock) PreSolve(authorization acme.Authorization) error {
+	s.mu.Lock()
+	s.presented[authorization.Identifier.Value] = true
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *concurrentSolverMock) Solve(authorization acme.Authorization) error {
+	s.mu.Lock()
+	s.solving++
+	if s.solving > s.maxSolving {
+		s.maxSolving = s.solving
+	}
+	s.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	s.mu.Lock()
+	s.solving--
+	s.mu.Unlock()
+
+	return s.solve[authorization.Identifier.Value]
+}
+
+func (s *concurrentSolverMock) CleanUp(authorization acme.Authorization) error {
+	s.mu.Lock()
+	delete(s.presented, authorization.Identifier.Value)
+	s.mu.Unlock()
+	return nil
+}
+
+// presentHookSolverMock a solver which receives the present hook.
+type presentHookSolverMock struct {
+	hook challenge.PresentHook
+}
+
+func (s *presentHookSolverMock) SetPresentHook(hook challenge.PresentHook) {
+	s.hook = hook
+}
+
+func (s *presentHookSolverMock) Solve(acme.Authorization) error {
+	return nil
+}
+
+// metricsMock records the resolutions of the challenges.
+type metricsMock struct {
+	acme.NoopMetrics
+	challenges []string
+}
+
+func (m *metricsMock) OnChallengeSolved(chlgType string, _ time.Duration, err error) {
+	if err != nil {
+		m.challenges = append(m.challenges, chlgType+" "+err.Error())
+		return
+	}
+
+	m.challenges = append(m.challenges, chlgType+" ok")
+}
+
 func createStubAuthorizationHTTP01(domain, status string) acme.Authorization {
 	return acme.Authorization{
 		Status:  status,
@@ -1,11 +1,19 @@
 package resolver
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/acme/api"
 	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,6 +22,7 @@ func TestProber_Solve(t *testing.T) {
 		desc          string
 		solvers       map[challenge.Type]solver
 		authz         []acme.Authorization
+		force         bool
 		expectedError string
 	}{
 		{
@@ -46,6 +55,40 @@ func TestProber_Solve(t *testing.T) {
 				createStubAuthorizationHTTP01("mydomain.wtf", acme.StatusValid),
 			},
 		},
+		{
+			desc: "already valid, forced challenge",
+			solvers: map[challenge.Type]solver{
+				challenge.HTTP01: &preSolverMock{
+					preSolve: map[string]error{
+						"acme.wtf": errors.New("preSolve error acme.wtf"),
+					},
+					solve:   map[string]error{},
+					cleanUp: map[string]error{},
+				},
+			},
+			authz: []acme.Authorization{
+				createStubAuthorizationHTTP01("acme.wtf", acme.StatusValid),
+				createStubAuthorizationHTTP01("lego.wtf", acme.StatusValid),
+			},
+			force: true,
+			expectedError: `acme: Error -> One or more domains had a problem:
+[acme.wtf] preSolve error acme.wtf
+`,
+		},
+		{
+			desc: "already valid, forced challenge without solver",
+			solvers: map[challenge.Type]solver{
+				challenge.DNS01: &preSolverMock{
+					preSolve: map[string]error{},
+					solve:    map[string]error{},
+					cleanUp:  map[string]error{},
+				},
+			},
+			authz: []acme.Authorization{
+				createStubAuthorizationHTTP01("acme.wtf", acme.StatusValid),
+			},
+			force: true,
+		},
 		{
 			desc: "when preSolve fail, auth is flagged as error and skipped",
 			solvers: map[challenge.Type]solver{
@@ -104,7 +147,9 @@ func TestProber_Solve(t *testing.T) {
 			t.Parallel()
 
 			prober := &Prober{
-				solverManager: &SolverManager{solvers: test.solvers},
+				solverManager:  &SolverManager{solvers: test.solvers},
+				forceChallenge: test.force,
+				metrics:        acme.NoopMetrics{},
 			}
 
 			err := prober.Solve(test.authz)
@@ -116,3 +161,168 @@ func TestProber_Solve(t *testing.T) {
 		})
 	}
 }
+
+func TestProber_Solve_recheckAuthorizations(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 512)
+
+	mux.HandleFunc("/my-authz", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		err := tester.WriteJSONResponse(w, acme.Authorization{Status: acme.StatusValid, Expires: time.Now().Add(time.Hour)})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	authz := createStubAuthorizationHTTP01("acme.wtf", acme.StatusPending)
+	authz.URL = apiURL + "/my-authz"
+
+	prober := &Prober{
+		solverManager: &SolverManager{
+			core: core,
+			solvers: map[challenge.Type]solver{
+				challenge.HTTP01: &preSolverMock{
+					preSolve: map[string]error{"acme.wtf": errors.New("preSolve error acme.wtf")},
+					solve:    map[string]error{},
+					cleanUp:  map[string]error{},
+				},
+			},
+		},
+		metrics: acme.NoopMetrics{},
+	}
+
+	err = prober.Solve([]acme.Authorization{authz})
+	require.EqualError(t, err, "acme: Error -> One or more domains had a problem:\n[acme.wtf] preSolve error acme.wtf\n")
+
+	prober.SetAuthorizationRecheck(true)
+
+	err = prober.Solve([]acme.Authorization{authz})
+	require.NoError(t, err)
+}
+
+func TestProber_SolveWithValidations(t *testing.T) {
+	alreadyValid := createStubAuthorizationHTTP01("lego.wtf", acme.StatusValid)
+	alreadyValid.Challenges = []acme.Challenge{
+		{Type: challenge.HTTP01.String(), Status: acme.StatusPending},
+		{Type: challenge.DNS01.String(), Status: acme.StatusValid},
+	}
+
+	metrics := &metricsMock{}
+
+	prober := &Prober{
+		solverManager: &SolverManager{
+			solvers: map[challenge.Type]solver{
+				challenge.HTTP01: &preSolverMock{
+					preSolve: map[string]error{},
+					solve: map[string]error{
+						"mydomain.wtf": errors.New("solve error mydomain.wtf"),
+					},
+					cleanUp: map[string]error{},
+				},
+			},
+		},
+		metrics: metrics,
+	}
+
+	validations, err := prober.SolveWithValidations([]acme.Authorization{
+		createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing),
+		alreadyValid,
+		createStubAuthorizationHTTP01("mydomain.wtf", acme.StatusProcessing),
+	})
+	require.EqualError(t, err, "acme: Error -> One or more domains had a problem:\n[mydomain.wtf] solve error mydomain.wtf\n")
+
+	expected := map[string]challenge.Type{
+		"acme.wtf": challenge.HTTP01,
+		"lego.wtf": challenge.DNS01,
+	}
+	assert.Equal(t, expected, validations)
+
+	assert.Equal(t, []string{"http-01 ok", "http-01 solve error mydomain.wtf"}, metrics.challenges)
+}
+
+func TestProber_SetMetrics_nil(t *testing.T) {
+	prober := NewProber(&SolverManager{solvers: map[challenge.Type]solver{
+		challenge.HTTP01: &preSolverMock{preSolve: map[string]error{}, solve: map[string]error{}, cleanUp: map[string]error{}},
+	}})
+	prober.SetMetrics(nil)
+
+	err := prober.Solve([]acme.Authorization{createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing)})
+	require.NoError(t, err)
+}
+
+func TestProber_Solve_singleValueTXT(t *testing.T) {
+	solvr := &singleValueSolverMock{presented: map[string]string{}}
+
+	prober := &Prober{
+		solverManager: &SolverManager{solvers: map[challenge.Type]solver{challenge.HTTP01: solvr}},
+		metrics:       acme.NoopMetrics{},
+	}
+
+	wildcard := createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing)
+	wildcard.Wildcard = true
+
+	err := prober.Solve([]acme.Authorization{
+		createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing),
+		wildcard,
+		createStubAuthorizationHTTP01("lego.wtf", acme.StatusProcessing),
+	})
+	require.NoError(t, err)
+
+	expected := []string{
+		"present acme.wtf",
+		"present lego.wtf",
+		"solve acme.wtf",
+		"solve lego.wtf",
+		"cleanup acme.wtf",
+		"cleanup lego.wtf",
+		"present *.acme.wtf",
+		"solve *.acme.wtf",
+		"cleanup *.acme.wtf",
+	}
+	assert.Equal(t, expected, solvr.events)
+}
+
+func TestProber_Solve_concurrency(t *testing.T) {
+	solvr := &concurrentSolverMock{
+		presented: map[string]bool{},
+		solve:     map[string]error{"d3.wtf": errors.New("d3 error"), "d7.wtf": errors.New("d7 error")},
+	}
+
+	prober := &Prober{
+		solverManager: &SolverManager{solvers: map[challenge.Type]solver{challenge.HTTP01: solvr}},
+		metrics:       acme.NoopMetrics{},
+	}
+	prober.SetConcurrency(3)
+
+	var authz []acme.Authorization
+	for i := 0; i < 10; i++ {
+		authz = append(authz, createStubAuthorizationHTTP01(fmt.Sprintf("d%d.wtf", i), acme.StatusProcessing))
+	}
+
+	err := prober.Solve(authz)
+	require.EqualError(t, err, "acme: Error -> One or more domains had a problem:\n[d3.wtf] d3 error\n[d7.wtf] d7 error\n")
+
+	assert.Equal(t, 3, solvr.maxSolving)
+	assert.Empty(t, solvr.presented)
+}
+
+func TestProber_ConcurrentOrders(t *testing.T) {
+	solverManager := &SolverManager{solvers: map[challenge.Type]solver{challenge.DNS01: &preSolverMock{}}}
+	prober := NewProber(solverManager)
+
+	assert.True(t, prober.ConcurrentOrders())
+
+	solverManager.solvers[challenge.HTTP01] = &preSolverMock{}
+
+	assert.False(t, prober.ConcurrentOrders())
+}
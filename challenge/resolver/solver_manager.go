@@ -24,9 +24,30 @@ func (a byType) Len() int           { return len(a) }
 func (a byType) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a byType) Less(i, j int) bool { return a[i].Type > a[j].Type }
 
+// ValidationOptions the options used to wait for the validation of an authorization by the CA.
+type ValidationOptions struct {
+	// Timeout the maximum duration to wait for the validation.
+	// If zero, the duration is inferred from the Retry-After header returned by the CA.
+	Timeout time.Duration
+	// PollingInterval the constant interval between two checks of the authorization status.
+	// If zero, the interval starts from the Retry-After header returned by the CA and grows exponentially.
+	PollingInterval time.Duration
+	// MaxAttempts the maximum number of checks of the authorization status.
+	// If zero, the number of checks is only limited by the timeout.
+	MaxAttempts int
+}
+
 type SolverManager struct {
-	core    *api.Core
-	solvers map[challenge.Type]solver
+	core              *api.Core
+	solvers           map[challenge.Type]solver
+	validationOptions ValidationOptions
+	presentHook       challenge.PresentHook
+	preference        []challenge.Type
+}
+
+// the solvers which can report the values of a challenge before its presentation.
+type presentHookSetter interface {
+	SetPresentHook(hook challenge.PresentHook)
 }
 
 func NewSolversManager(core *api.Core) *SolverManager {
@@ -38,45 +59,113 @@ func NewSolversManager(core *api.Core) *SolverManager {
 
 // SetHTTP01Provider specifies a custom provider p that can solve the given HTTP-01 challenge.
 func (c *SolverManager) SetHTTP01Provider(p challenge.Provider) error {
-	c.solvers[challenge.HTTP01] = http01.NewChallenge(c.core, validate, p)
+	chlg := http01.NewChallenge(c.core, c.validate, p)
+	chlg.SetPresentHook(c.presentHook)
+
+	c.solvers[challenge.HTTP01] = chlg
 	return nil
 }
 
 // SetTLSALPN01Provider specifies a custom provider p that can solve the given TLS-ALPN-01 challenge.
 func (c *SolverManager) SetTLSALPN01Provider(p challenge.Provider) error {
-	c.solvers[challenge.TLSALPN01] = tlsalpn01.NewChallenge(c.core, validate, p)
+	chlg := tlsalpn01.NewChallenge(c.core, c.validate, p)
+	chlg.SetPresentHook(c.presentHook)
+
+	c.solvers[challenge.TLSALPN01] = chlg
 	return nil
 }
 
 // SetDNS01Provider specifies a custom provider p that can solve the given DNS-01 challenge.
 func (c *SolverManager) SetDNS01Provider(p challenge.Provider, opts ...dns01.ChallengeOption) error {
-	c.solvers[challenge.DNS01] = dns01.NewChallenge(c.core, validate, p, opts...)
+	chlg := dns01.NewChallenge(c.core, c.validate, p, opts...)
+	chlg.SetPresentHook(c.presentHook)
+
+	c.solvers[challenge.DNS01] = chlg
 	return nil
 }
 
+// SetValidationOptions defines how to wait for the validation of the authorizations by the CA.
+func (c *SolverManager) SetValidationOptions(opts ValidationOptions) {
+	c.validationOptions = opts
+}
+
+// SetPresentHook sets a hook called with the values of each challenge (e.g. the FQDN and the value of the TXT record)
+// right before their presentation by the provider, to log them or to verify them externally.
+func (c *SolverManager) SetPresentHook(hook challenge.PresentHook) {
+	c.presentHook = hook
+
+	for _, solvr := range c.solvers {
+		if setter, ok := solvr.(presentHookSetter); ok {
+			setter.SetPresentHook(hook)
+		}
+	}
+}
+
+// SetChallengePreference defines the order in which the challenge types offered by the CA for an authorization are tried:
+// the first type of the list with a configured solver is used.
+// The types not listed come after, in the default order (TLS-ALPN-01, HTTP-01, DNS-01).
+// The preference only applies to the types offered for each authorization
+// (e.g. with DNS-01 after HTTP-01, DNS-01 is still used for the wildcard domains, for which only DNS-01 is offered).
+func (c *SolverManager) SetChallengePreference(types ...challenge.Type) {
+	c.preference = types
+}
+
 // Remove Remove a challenge type from the available solvers.
 func (c *SolverManager) Remove(chlgType challenge.Type) {
 	delete(c.solvers, chlgType)
 }
 
 // Checks all challenges from the server in order and returns the first matching solver.
-func (c *SolverManager) chooseSolver(authz acme.Authorization) solver {
+func (c *SolverManager) chooseSolver(authz acme.Authorization) (solver, challenge.Type) {
 	// Allow to have a deterministic challenge order
 	sort.Sort(byType(authz.Challenges))
 
+	if len(c.preference) > 0 {
+		sort.SliceStable(authz.Challenges, func(i, j int) bool {
+			return c.preferenceRank(authz.Challenges[i].Type) < c.preferenceRank(authz.Challenges[j].Type)
+		})
+	}
+
 	domain := challenge.GetTargetedDomain(authz)
 	for _, chlg := range authz.Challenges {
 		if solvr, ok := c.solvers[challenge.Type(chlg.Type)]; ok {
 			log.Infof("[%s] acme: use %s solver", domain, chlg.Type)
-			return solvr
+			return solvr, challenge.Type(chlg.Type)
 		}
 		log.Infof("[%s] acme: Could not find solver for: %s", domain, chlg.Type)
 	}
 
-	return nil
+	return nil, ""
 }
 
-func validate(core *api.Core, domain string, chlg acme.Challenge) error {
+// hasSolver returns true if a solver is configured for one of the challenges of the authorization.
+func (c *SolverManager) hasSolver(authz acme.Authorization) bool {
+	for _, chlg := range authz.Challenges {
+		if _, ok := c.solvers[challenge.Type(chlg.Type)]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// preferenceRank returns the position of a challenge type in the preference list,
+// the types not listed are ranked after all the listed ones.
+func (c *SolverManager) preferenceRank(chlgType string) int {
+	for i, pref := range c.preference {
+		if string(pref) == chlgType {
+			return i
+		}
+	}
+
+	return len(c.preference)
+}
+
+func (c *SolverManager) validate(core *api.Core, domain string, chlg acme.Challenge) error {
+	return validate(core, domain, chlg, c.validationOptions)
+}
+
+func validate(core *api.Core, domain string, chlg acme.Challenge, opts ValidationOptions) error {
 	chlng, err := core.Challenges.New(chlg.URL)
 	if err != nil {
 		return fmt.Errorf("failed to initiate challenge: %v", err)
@@ -92,20 +181,7 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 		return nil
 	}
 
-	ra, err := strconv.Atoi(chlng.RetryAfter)
-	if err != nil {
-		// The ACME server MUST return a Retry-After.
-		// If it doesn't, we'll just poll hard.
-		// Boulder does not implement the ability to retry challenges or the Retry-After header.
-		// https://github.com/letsencrypt/boulder/blob/master/docs/acme-divergences.md#section-82
-		ra = 5
-	}
-	initialInterval := time.Duration(ra) * time.Second
-
-	bo := backoff.NewExponentialBackOff()
-	bo.InitialInterval = initialInterval
-	bo.MaxInterval = 10 * initialInterval
-	bo.MaxElapsedTime = 100 * initialInterval
+	bo := newValidationBackOff(chlng.RetryAfter, opts)
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -135,6 +211,46 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 	return backoff.Retry(operation, backoff.WithContext(bo, ctx))
 }
 
+// newValidationBackOff creates the back-off policy used to poll the status of an authorization.
+func newValidationBackOff(retryAfter string, opts ValidationOptions) backoff.BackOff {
+	ra, err := strconv.Atoi(retryAfter)
+	if err != nil {
+		// The ACME server MUST return a Retry-After.
+		// If it doesn't, we'll just poll hard.
+		// Boulder does not implement the ability to retry challenges or the Retry-After header.
+		// https://github.com/letsencrypt/boulder/blob/master/docs/acme-divergences.md#section-82
+		ra = 5
+	}
+	initialInterval := time.Duration(ra) * time.Second
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = initialInterval
+	bo.MaxInterval = 10 * initialInterval
+	bo.MaxElapsedTime = 100 * initialInterval
+
+	if opts.PollingInterval > 0 {
+		bo.InitialInterval = opts.PollingInterval
+		bo.MaxInterval = opts.PollingInterval
+		bo.Multiplier = 1
+		bo.RandomizationFactor = 0
+		bo.MaxElapsedTime = 100 * opts.PollingInterval
+	}
+
+	if opts.Timeout > 0 {
+		bo.MaxElapsedTime = opts.Timeout
+	}
+
+	switch {
+	case opts.MaxAttempts == 1:
+		return &backoff.StopBackOff{}
+	case opts.MaxAttempts > 1:
+		// the first check is not a retry.
+		return backoff.WithMaxRetries(bo, uint64(opts.MaxAttempts-1))
+	}
+
+	return bo
+}
+
 func checkChallengeStatus(chlng acme.ExtendedChallenge) (bool, error) {
 	switch chlng.Status {
 	case acme.StatusValid:
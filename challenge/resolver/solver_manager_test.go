@@ -8,9 +8,12 @@ import (
 	"net/http"
 	"sort"
 	"testing"
+	"time"
 
+	"github.com/cenkalti/backoff"
 	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/acme/api"
+	"github.com/vostronet/lego/challenge"
 	"github.com/vostronet/lego/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +34,136 @@ func TestByType(t *testing.T) {
 	assert.Equal(t, expected, challenges)
 }
 
+func TestSolverManager_SetPresentHook(t *testing.T) {
+	solvr := &presentHookSolverMock{}
+
+	manager := NewSolversManager(nil)
+	manager.solvers[challenge.DNS01] = solvr
+
+	var infos []challenge.PresentInfo
+	manager.SetPresentHook(func(info challenge.PresentInfo) { infos = append(infos, info) })
+
+	require.NotNil(t, solvr.hook)
+
+	solvr.hook(challenge.PresentInfo{Type: challenge.DNS01, Domain: "example.com"})
+
+	assert.Equal(t, []challenge.PresentInfo{{Type: challenge.DNS01, Domain: "example.com"}}, infos)
+}
+
+func TestSolverManager_chooseSolver(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		preference []challenge.Type
+		offered    []acme.Challenge
+		expected   challenge.Type
+	}{
+		{
+			desc:     "default order",
+			offered:  []acme.Challenge{{Type: "dns-01"}, {Type: "http-01"}},
+			expected: challenge.HTTP01,
+		},
+		{
+			desc:       "preferred type",
+			preference: []challenge.Type{challenge.DNS01, challenge.HTTP01},
+			offered:    []acme.Challenge{{Type: "http-01"}, {Type: "dns-01"}},
+			expected:   challenge.DNS01,
+		},
+		{
+			desc:       "preferred type not offered",
+			preference: []challenge.Type{challenge.HTTP01, challenge.DNS01},
+			offered:    []acme.Challenge{{Type: "dns-01"}},
+			expected:   challenge.DNS01,
+		},
+		{
+			desc:       "preferred type without solver",
+			preference: []challenge.Type{challenge.TLSALPN01, challenge.DNS01},
+			offered:    []acme.Challenge{{Type: "tls-alpn-01"}, {Type: "http-01"}, {Type: "dns-01"}},
+			expected:   challenge.DNS01,
+		},
+		{
+			desc:       "unlisted types after the listed ones",
+			preference: []challenge.Type{challenge.DNS01},
+			offered:    []acme.Challenge{{Type: "http-01"}, {Type: "dns-01"}},
+			expected:   challenge.DNS01,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			manager := NewSolversManager(nil)
+			manager.solvers[challenge.HTTP01] = &preSolverMock{}
+			manager.solvers[challenge.DNS01] = &preSolverMock{}
+			manager.SetChallengePreference(test.preference...)
+
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{Type: "dns", Value: "example.com"},
+				Challenges: test.offered,
+			}
+
+			solvr, chlgType := manager.chooseSolver(authz)
+			require.NotNil(t, solvr)
+
+			assert.Equal(t, test.expected, chlgType)
+		})
+	}
+}
+
+func Test_newValidationBackOff(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		retryAfter string
+		opts       ValidationOptions
+		expected   []time.Duration
+	}{
+		{
+			desc:     "max attempts",
+			opts:     ValidationOptions{PollingInterval: 100 * time.Millisecond, MaxAttempts: 3},
+			expected: []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, backoff.Stop},
+		},
+		{
+			desc:     "single attempt",
+			opts:     ValidationOptions{PollingInterval: 100 * time.Millisecond, MaxAttempts: 1},
+			expected: []time.Duration{backoff.Stop},
+		},
+		{
+			desc:     "constant polling interval",
+			opts:     ValidationOptions{PollingInterval: 100 * time.Millisecond},
+			expected: []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			bo := newValidationBackOff(test.retryAfter, test.opts)
+			bo.Reset()
+
+			var intervals []time.Duration
+			for range test.expected {
+				intervals = append(intervals, bo.NextBackOff())
+			}
+
+			assert.Equal(t, test.expected, intervals)
+		})
+	}
+}
+
+func Test_newValidationBackOff_retryAfter(t *testing.T) {
+	bo := newValidationBackOff("3", ValidationOptions{Timeout: time.Minute})
+
+	exponential, ok := bo.(*backoff.ExponentialBackOff)
+	require.True(t, ok)
+
+	assert.Equal(t, 3*time.Second, exponential.InitialInterval)
+	assert.Equal(t, 30*time.Second, exponential.MaxInterval)
+	assert.Equal(t, time.Minute, exponential.MaxElapsedTime)
+}
+
 func TestValidate(t *testing.T) {
 	mux, apiURL, tearDown := tester.SetupFakeAPI()
 	defer tearDown()
@@ -138,7 +271,7 @@ func TestValidate(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			statuses = test.statuses
 
-			err := validate(core, "example.com", acme.Challenge{Type: "http-01", Token: "token", URL: apiURL + "/chlg"})
+			err := validate(core, "example.com", acme.Challenge{Type: "http-01", Token: "token", URL: apiURL + "/chlg"}, ValidationOptions{})
 			if test.want == "" {
 				require.NoError(t, err)
 			} else {
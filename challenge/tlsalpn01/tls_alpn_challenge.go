@@ -22,9 +22,10 @@ var idPeAcmeIdentifierV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
 type ValidateFunc func(core *api.Core, domain string, chlng acme.Challenge) error
 
 type Challenge struct {
-	core     *api.Core
-	validate ValidateFunc
-	provider challenge.Provider
+	core        *api.Core
+	validate    ValidateFunc
+	provider    challenge.Provider
+	presentHook challenge.PresentHook
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider) *Challenge {
@@ -39,6 +40,11 @@ func (c *Challenge) SetProvider(provider challenge.Provider) {
 	c.provider = provider
 }
 
+// SetPresentHook sets the hook called with the values of the challenge before its presentation.
+func (c *Challenge) SetPresentHook(hook challenge.PresentHook) {
+	c.presentHook = hook
+}
+
 // Solve manages the provider to validate and solve the challenge.
 func (c *Challenge) Solve(authz acme.Authorization) error {
 	domain := authz.Identifier.Value
@@ -55,6 +61,15 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		return err
 	}
 
+	if c.presentHook != nil {
+		c.presentHook(challenge.PresentInfo{
+			Type:    challenge.TLSALPN01,
+			Domain:  domain,
+			Token:   chlng.Token,
+			KeyAuth: keyAuth,
+		})
+	}
+
 	err = c.provider.Present(domain, chlng.Token, keyAuth)
 	if err != nil {
 		return fmt.Errorf("[%s] acme: error presenting token: %v", challenge.GetTargetedDomain(authz), err)
@@ -114,6 +129,8 @@ func ChallengeBlocks(domain, keyAuth string) ([]byte, []byte, error) {
 
 // ChallengeCert returns a certificate with the acmeValidation-v1 extension
 // and domain name for the `tls-alpn-01` challenge.
+// It allows to serve the challenge from an existing TLS server (see ProviderCallback),
+// the certificate must be returned for the "acme-tls/1" protocol (ACMETLS1Protocol).
 func ChallengeCert(domain, keyAuth string) (*tls.Certificate, error) {
 	tempCertPEM, rsaPrivatePEM, err := ChallengeBlocks(domain, keyAuth)
 	if err != nil {
@@ -0,0 +1,71 @@
+package tlsalpn01
+
+import (
+	"crypto/tls"
+	"errors"
+)
+
+// Material the challenge certificate of a domain, to be served by an external TLS stack.
+type Material struct {
+	Domain string
+	// Certificate the certificate (DER) and its private key, ready to be used in a tls.Config.
+	Certificate *tls.Certificate
+	// CertificatePEM the PEM encoded certificate.
+	CertificatePEM []byte
+	// PrivateKeyPEM the PEM encoded private key of the certificate.
+	PrivateKeyPEM []byte
+}
+
+// PresentFunc receives the challenge certificate of a domain:
+// the certificate must be served with the "acme-tls/1" protocol (ACMETLS1Protocol) until the clean-up.
+type PresentFunc func(material Material) error
+
+// CleanUpFunc is called when the challenge certificate of a domain is no longer needed.
+type CleanUpFunc func(domain string) error
+
+// ProviderCallback implements ChallengeProvider for `TLS-ALPN-01` challenge
+// by handing the challenge certificates over to callbacks,
+// to serve them with an existing TLS server instead of the built-in listener.
+type ProviderCallback struct {
+	present PresentFunc
+	cleanUp CleanUpFunc
+}
+
+// NewProviderCallback creates a new ProviderCallback.
+// The clean-up callback is optional.
+func NewProviderCallback(present PresentFunc, cleanUp CleanUpFunc) (*ProviderCallback, error) {
+	if present == nil {
+		return nil, errors.New("tlsalpn01: the present callback is missing")
+	}
+
+	return &ProviderCallback{present: present, cleanUp: cleanUp}, nil
+}
+
+// Present generates the challenge certificate of the domain and passes it to the present callback.
+func (p *ProviderCallback) Present(domain, token, keyAuth string) error {
+	certPEM, keyPEM, err := ChallengeBlocks(domain, keyAuth)
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+
+	return p.present(Material{
+		Domain:         domain,
+		Certificate:    &cert,
+		CertificatePEM: certPEM,
+		PrivateKeyPEM:  keyPEM,
+	})
+}
+
+// CleanUp calls the clean-up callback, if any.
+func (p *ProviderCallback) CleanUp(domain, token, keyAuth string) error {
+	if p.cleanUp == nil {
+		return nil
+	}
+
+	return p.cleanUp(domain)
+}
@@ -0,0 +1,86 @@
+package tlsalpn01
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProviderCallback(t *testing.T) {
+	_, err := NewProviderCallback(nil, nil)
+	require.EqualError(t, err, "tlsalpn01: the present callback is missing")
+}
+
+func TestProviderCallback(t *testing.T) {
+	var presented *Material
+	var cleaned []string
+
+	provider, err := NewProviderCallback(
+		func(material Material) error {
+			presented = &material
+			return nil
+		},
+		func(domain string) error {
+			cleaned = append(cleaned, domain)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+
+	err = provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	require.NotNil(t, presented)
+	assert.Equal(t, "example.com", presented.Domain)
+	require.NotNil(t, presented.Certificate)
+	require.Len(t, presented.Certificate.Certificate, 1)
+	assert.NotNil(t, presented.Certificate.PrivateKey)
+
+	block, _ := pem.Decode(presented.CertificatePEM)
+	require.NotNil(t, block)
+	assert.Equal(t, presented.Certificate.Certificate[0], block.Bytes)
+
+	keyBlock, _ := pem.Decode(presented.PrivateKeyPEM)
+	require.NotNil(t, keyBlock)
+
+	cert, err := x509.ParseCertificate(presented.Certificate.Certificate[0])
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com"}, cert.DNSNames)
+
+	zBytes := sha256.Sum256([]byte("keyAuth"))
+	value, err := asn1.Marshal(zBytes[:sha256.Size])
+	require.NoError(t, err)
+
+	var found bool
+	for _, ext := range cert.Extensions {
+		if idPeAcmeIdentifierV1.Equal(ext.Id) {
+			found = true
+			assert.True(t, ext.Critical)
+			assert.Equal(t, value, ext.Value)
+		}
+	}
+	assert.True(t, found, "the acmeValidation-v1 extension is missing")
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com"}, cleaned)
+}
+
+func TestProviderCallback_errors(t *testing.T) {
+	provider, err := NewProviderCallback(func(Material) error { return errors.New("present error") }, nil)
+	require.NoError(t, err)
+
+	err = provider.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "present error")
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+}
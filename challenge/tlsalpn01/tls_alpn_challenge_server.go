@@ -1,11 +1,13 @@
 package tlsalpn01
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/vostronet/lego/log"
 )
@@ -17,6 +19,9 @@ const (
 	// defaultTLSPort is the port that the ProviderServer will default to
 	// when no other port is provided.
 	defaultTLSPort = "443"
+
+	// shutdownTimeout the maximum duration to wait for the in-flight connections when the server is stopped.
+	shutdownTimeout = 5 * time.Second
 )
 
 // ProviderServer implements ChallengeProvider for `TLS-ALPN-01` challenge.
@@ -25,6 +30,9 @@ const (
 type ProviderServer struct {
 	iface    string
 	port     string
+	ctx      context.Context
+	server   *http.Server
+	done     chan struct{}
 	listener net.Listener
 }
 
@@ -35,6 +43,13 @@ func NewProviderServer(iface, port string) *ProviderServer {
 	return &ProviderServer{iface: iface, port: port}
 }
 
+// SetContext binds the server to a context:
+// when the context is cancelled (e.g. the obtain is aborted), the running server is shut down gracefully and the port is released,
+// even if CleanUp is never called.
+func (s *ProviderServer) SetContext(ctx context.Context) {
+	s.ctx = ctx
+}
+
 func (s *ProviderServer) GetAddress() string {
 	return net.JoinHostPort(s.iface, s.port)
 }
@@ -47,6 +62,10 @@ func (s *ProviderServer) Present(domain, token, keyAuth string) error {
 		s.port = defaultTLSPort
 	}
 
+	if s.ctx != nil && s.ctx.Err() != nil {
+		return fmt.Errorf("could not start HTTPS server for challenge -> %v", s.ctx.Err())
+	}
+
 	// Generate the challenge certificate using the provided keyAuth and domain.
 	cert, err := ChallengeCert(domain, keyAuth)
 	if err != nil {
@@ -69,27 +88,52 @@ func (s *ProviderServer) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("could not start HTTPS server for challenge -> %v", err)
 	}
 
-	// Shut the server down when we're finished.
-	go func() {
-		err := http.Serve(s.listener, nil)
-		if err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
+	s.server = &http.Server{}
+	s.done = make(chan struct{})
+
+	go func(server *http.Server, listener net.Listener, done chan struct{}) {
+		defer close(done)
+
+		err := server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed && !strings.Contains(err.Error(), "use of closed network connection") {
 			log.Println(err)
 		}
-	}()
+	}(s.server, s.listener, s.done)
+
+	// Shut the server down if the context is cancelled before the clean-up.
+	if s.ctx != nil {
+		go func(ctx context.Context, server *http.Server, done chan struct{}) {
+			select {
+			case <-ctx.Done():
+				log.Infof("Shutting down the HTTPS server for challenge: %v", ctx.Err())
+				shutdown(server)
+			case <-done:
+			}
+		}(s.ctx, s.server, s.done)
+	}
 
 	return nil
 }
 
-// CleanUp closes the HTTPS server.
+// CleanUp shuts the HTTPS server down gracefully.
 func (s *ProviderServer) CleanUp(domain, token, keyAuth string) error {
-	if s.listener == nil {
+	if s.server == nil {
 		return nil
 	}
 
-	// Server was created, close it.
-	if err := s.listener.Close(); err != nil && err != http.ErrServerClosed {
-		return err
-	}
+	shutdown(s.server)
+	<-s.done
 
 	return nil
 }
+
+// shutdown stops the server gracefully: the in-flight connections are closed after shutdownTimeout.
+func shutdown(server *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		_ = server.Close()
+	}
+}
@@ -1,14 +1,17 @@
 package tlsalpn01
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/subtle"
 	"crypto/tls"
 	"encoding/asn1"
+	"net"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/acme/api"
@@ -118,3 +121,44 @@ func TestChallengeInvalidPort(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid port")
 	assert.Contains(t, err.Error(), "123456")
 }
+
+func TestProviderServer_SetContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	providerServer := NewProviderServer("127.0.0.1", "0")
+	providerServer.SetContext(ctx)
+
+	err := providerServer.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	addr := providerServer.listener.Addr().String()
+
+	cancel()
+
+	select {
+	case <-providerServer.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the server has not been shut down after the cancellation of the context")
+	}
+
+	// the port is released.
+	listener, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	_ = listener.Close()
+
+	// the clean-up of a server shut down by the context doesn't fail.
+	err = providerServer.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+}
+
+func TestProviderServer_SetContext_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	providerServer := NewProviderServer("127.0.0.1", "0")
+	providerServer.SetContext(ctx)
+
+	err := providerServer.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "could not start HTTPS server for challenge -> context canceled")
+}
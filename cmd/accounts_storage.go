@@ -11,8 +11,11 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/vostronet/lego/acme/api"
 	"github.com/vostronet/lego/certcrypto"
 	"github.com/vostronet/lego/lego"
 	"github.com/vostronet/lego/log"
@@ -125,7 +128,40 @@ func (s *AccountsStorage) Save(account *Account) error {
 	return ioutil.WriteFile(s.accountFilePath, jsonBytes, filePerm)
 }
 
-func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey) *Account {
+// MoveToArchive moves the account directory (account file, keys and state) to the archives directory.
+// Returns the path of the archived directory.
+//
+//     ./.lego/archives/accounts/localhost_14000/1594647825.hubert@hubert.com/
+//          │       │        │             │             └── date and userID
+//          │       │        │             └── CA server ("server" option)
+//          │       │        └── archived accounts directory
+//          │       └── archives directory
+//          └── "path" option
+//
+func (s *AccountsStorage) MoveToArchive() (string, error) {
+	rel, err := filepath.Rel(s.rootPath, s.rootUserPath)
+	if err != nil {
+		return "", err
+	}
+
+	archiveDir := filepath.Join(filepath.Dir(s.rootPath), baseArchivesFolderName, baseAccountsRootFolderName, filepath.Dir(rel))
+
+	err = createNonExistingFolder(archiveDir)
+	if err != nil {
+		return "", err
+	}
+
+	archivePath := filepath.Join(archiveDir, strconv.FormatInt(time.Now().Unix(), 10)+"."+filepath.Base(rel))
+
+	err = os.Rename(s.rootUserPath, archivePath)
+	if err != nil {
+		return "", err
+	}
+
+	return archivePath, nil
+}
+
+func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey, state *api.State) *Account {
 	fileBytes, err := ioutil.ReadFile(s.accountFilePath)
 	if err != nil {
 		log.Fatalf("Could not load file for account %s -> %v", s.userID, err)
@@ -140,7 +176,7 @@ func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey) *Account {
 	account.key = privateKey
 
 	if account.Registration == nil || account.Registration.Body.Status == "" {
-		reg, err := tryRecoverRegistration(s.ctx, privateKey)
+		reg, err := tryRecoverRegistration(s.ctx, privateKey, state)
 		if err != nil {
 			log.Fatalf("Could not load account for %s. Registration is nil -> %#v", s.userID, err)
 		}
@@ -219,18 +255,36 @@ func loadPrivateKey(file string) (crypto.PrivateKey, error) {
 		return x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
 	case "EC PRIVATE KEY":
 		return x509.ParseECPrivateKey(keyBlock.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
 	}
 
 	return nil, errors.New("unknown private key type")
 }
 
-func tryRecoverRegistration(ctx *cli.Context, privateKey crypto.PrivateKey) (*registration.Resource, error) {
+func tryRecoverRegistration(ctx *cli.Context, privateKey crypto.PrivateKey, state *api.State) (*registration.Resource, error) {
 	// couldn't load account but got a key. Try to look the account up.
-	config := lego.NewConfig(&Account{key: privateKey})
-	config.CADirURL = ctx.GlobalString("server")
-	config.UserAgent = fmt.Sprintf("lego-cli/%s", ctx.App.Version)
+	account := &Account{key: privateKey}
+
+	// the account URL is known from the persisted state: no need to look the account up by key.
+	if state != nil && state.KID != "" {
+		account.Registration = &registration.Resource{URI: state.KID}
+
+		client, err := newRecoveryClient(ctx, account, state)
+		if err != nil {
+			return nil, err
+		}
+
+		reg, errQ := client.Registration.QueryRegistration()
+		if errQ == nil {
+			return reg, nil
+		}
 
-	client, err := lego.NewClient(config)
+		log.Warnf("Could not query the account %s from the persisted state: %v", state.KID, errQ)
+		account.Registration = nil
+	}
+
+	client, err := newRecoveryClient(ctx, account, state)
 	if err != nil {
 		return nil, err
 	}
@@ -241,3 +295,13 @@ func tryRecoverRegistration(ctx *cli.Context, privateKey crypto.PrivateKey) (*re
 	}
 	return reg, nil
 }
+
+// newRecoveryClient creates the client used to recover the registration of the account.
+func newRecoveryClient(ctx *cli.Context, account *Account, state *api.State) (*lego.Client, error) {
+	config := lego.NewConfig(account)
+	config.CADirURL = ctx.GlobalString("server")
+	config.UserAgent = fmt.Sprintf("lego-cli/%s", ctx.App.Version)
+	config.State = state
+
+	return lego.NewClient(config)
+}
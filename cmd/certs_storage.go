@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/x509"
 	"encoding/json"
 	"io/ioutil"
@@ -15,6 +16,7 @@ import (
 	"github.com/vostronet/lego/certificate"
 	"github.com/vostronet/lego/log"
 	"github.com/urfave/cli"
+	"golang.org/x/crypto/ocsp"
 	"golang.org/x/net/idna"
 )
 
@@ -41,15 +43,33 @@ type CertificatesStorage struct {
 	rootPath    string
 	archivePath string
 	pem         bool
+	pfx         bool
+	pfxPassword string
+	pfxFormat   certificate.PKCS12Encryption
+	ocsp        bool
 	filename    string // Deprecated
 }
 
+// ocspFetcher fetches the OCSP response of a certificate (see certificate.Certifier.GetOCSP).
+type ocspFetcher interface {
+	GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error)
+}
+
 // NewCertificatesStorage create a new certificates storage.
 func NewCertificatesStorage(ctx *cli.Context) *CertificatesStorage {
+	pfxFormat, err := certificate.ParsePKCS12Encryption(ctx.GlobalString("pfx.format"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	return &CertificatesStorage{
 		rootPath:    filepath.Join(ctx.GlobalString("path"), baseCertificatesFolderName),
 		archivePath: filepath.Join(ctx.GlobalString("path"), baseArchivesFolderName),
 		pem:         ctx.GlobalBool("pem"),
+		pfx:         ctx.GlobalBool("pfx"),
+		pfxPassword: ctx.GlobalString("pfx.pass"),
+		pfxFormat:   pfxFormat,
+		ocsp:        ctx.GlobalBool("ocsp"),
 		filename:    ctx.GlobalString("filename"),
 	}
 }
@@ -97,14 +117,21 @@ func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) {
 		}
 
 		if s.pem {
-			err = s.WriteFile(domain, ".pem", bytes.Join([][]byte{certRes.Certificate, certRes.PrivateKey}, nil))
+			err = s.WriteFile(domain, ".pem", bytes.Join([][]byte{fullChain(certRes), certRes.PrivateKey}, nil))
 			if err != nil {
 				log.Fatalf("Unable to save Certificate and PrivateKey in .pem for domain %s\n\t%v", domain, err)
 			}
 		}
-	} else if s.pem {
-		// we don't have the private key; can't write the .pem file
-		log.Fatalf("Unable to save pem without private key for domain %s\n\t%v; are you using a CSR?", domain, err)
+
+		if s.pfx {
+			err = s.WritePFXFile(domain, certRes)
+			if err != nil {
+				log.Fatalf("Unable to save PFX certificate for domain %s\n\t%v", domain, err)
+			}
+		}
+	} else if s.pem || s.pfx {
+		// we don't have the private key; can't write the .pem and the .pfx files
+		log.Fatalf("Unable to save pem or pfx without private key for domain %s\n\t%v; are you using a CSR?", domain, err)
 	}
 
 	jsonBytes, err := json.MarshalIndent(certRes, "", "\t")
@@ -116,6 +143,128 @@ func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) {
 	if err != nil {
 		log.Fatalf("Unable to save CertResource for domain %s\n\t%v", domain, err)
 	}
+
+	s.removeOrder(domain)
+}
+
+// SaveOrder saves the URL of an order and the private key of its certificate,
+// to resume the order (--resume-order) if the issuance is interrupted.
+func (s *CertificatesStorage) SaveOrder(domain, orderURL string, privateKey crypto.PrivateKey) error {
+	err := createNonExistingFolder(s.rootPath)
+	if err != nil {
+		return err
+	}
+
+	err = s.WriteFile(domain, ".order.key", certcrypto.PEMEncode(privateKey))
+	if err != nil {
+		return err
+	}
+
+	return s.WriteFile(domain, ".order", []byte(orderURL+"\n"))
+}
+
+// ReadOrderKey reads the private key of the certificate of the order saved by SaveOrder.
+func (s *CertificatesStorage) ReadOrderKey(domain string) (crypto.PrivateKey, error) {
+	raw, err := ioutil.ReadFile(s.GetFileName(domain, ".order.key"))
+	if err != nil {
+		return nil, err
+	}
+
+	return certcrypto.ParsePEMPrivateKey(raw)
+}
+
+// removeOrder removes the files of the order once its certificate is saved: there is nothing left to resume.
+func (s *CertificatesStorage) removeOrder(domain string) {
+	for _, extension := range []string{".order", ".order.key"} {
+		err := os.Remove(s.GetFileName(domain, extension))
+		if err != nil && !os.IsNotExist(err) {
+			log.Warnf("[%s] Unable to remove the %s file of the order: %v", domain, extension, err)
+		}
+	}
+}
+
+// WritePFXFile writes the private key, the certificate and the issuer chain in a .pfx (PKCS#12) file, encrypted with the pfx password.
+func (s *CertificatesStorage) WritePFXFile(domain string, certRes *certificate.Resource) error {
+	pfx, err := certificate.EncodePKCS12(certRes, s.pfxPassword, s.pfxFormat)
+	if err != nil {
+		return err
+	}
+
+	return s.WriteFile(domain, ".pfx", pfx)
+}
+
+// SaveOCSP fetches the OCSP response of the certificate and stores it (DER encoded) in the .ocsp file.
+// The certificate is already stored: a failure is only logged, and a stale response (nextUpdate in the past) is not stored.
+// The OCSP response of the previous certificate is removed if the response of the certificate is not stored.
+// Returns true if the OCSP response of the certificate is stored.
+func (s *CertificatesStorage) SaveOCSP(fetcher ocspFetcher, certRes *certificate.Resource) bool {
+	if !s.ocsp {
+		return false
+	}
+
+	domain := certRes.Domain
+
+	raw, resp, err := fetcher.GetOCSP(fullChain(certRes))
+	if err != nil {
+		log.Warnf("[%s] Unable to fetch the OCSP response: %v", domain, err)
+		s.removeOCSP(domain)
+		return false
+	}
+
+	if resp == nil {
+		log.Warnf("[%s] No OCSP response", domain)
+		s.removeOCSP(domain)
+		return false
+	}
+
+	if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(time.Now()) {
+		log.Warnf("[%s] The OCSP response is stale (next update: %s): not saved", domain, resp.NextUpdate)
+		s.removeOCSP(domain)
+		return false
+	}
+
+	if resp.Status != ocsp.Good {
+		log.Warnf("[%s] The OCSP status of the certificate is not good: %d", domain, resp.Status)
+	}
+
+	err = s.WriteFile(domain, ".ocsp", raw)
+	if err != nil {
+		log.Fatalf("Unable to save the OCSP response for domain %s\n\t%v", domain, err)
+	}
+
+	if !resp.NextUpdate.IsZero() {
+		log.Infof("[%s] OCSP response saved, next update: %s", domain, resp.NextUpdate)
+	}
+
+	return true
+}
+
+// removeOCSP removes the OCSP response of the previous certificate: it must not be stapled with the new certificate.
+func (s *CertificatesStorage) removeOCSP(domain string) {
+	err := os.Remove(s.GetFileName(domain, ".ocsp"))
+	if err != nil && !os.IsNotExist(err) {
+		log.Warnf("[%s] Unable to remove the OCSP response of the previous certificate: %v", domain, err)
+	}
+}
+
+// fullChain returns the certificate followed by the issuer certificates (the order expected by HAProxy),
+// the issuer certificate is added only if the certificate is not already bundled.
+func fullChain(certRes *certificate.Resource) []byte {
+	if len(certRes.IssuerCertificate) == 0 {
+		return certRes.Certificate
+	}
+
+	certs, err := certcrypto.ParsePEMBundle(certRes.Certificate)
+	if err != nil || len(certs) > 1 {
+		return certRes.Certificate
+	}
+
+	chain := certRes.Certificate
+	if !bytes.HasSuffix(chain, []byte("\n")) {
+		chain = append(append([]byte{}, chain...), '\n')
+	}
+
+	return bytes.Join([][]byte{chain, certRes.IssuerCertificate}, nil)
 }
 
 func (s *CertificatesStorage) ReadResource(domain string) certificate.Resource {
@@ -162,6 +311,11 @@ func (s *CertificatesStorage) ReadCertificate(domain, extension string) ([]*x509
 }
 
 func (s *CertificatesStorage) WriteFile(domain, extension string, data []byte) error {
+	return ioutil.WriteFile(s.GetFileName(domain, extension), data, filePerm)
+}
+
+// GetFileName returns the path of the file used to store the data related to a domain.
+func (s *CertificatesStorage) GetFileName(domain, extension string) string {
 	var baseFileName string
 	if s.filename != "" {
 		baseFileName = s.filename
@@ -169,9 +323,7 @@ func (s *CertificatesStorage) WriteFile(domain, extension string, data []byte) e
 		baseFileName = sanitizedDomain(domain)
 	}
 
-	filePath := filepath.Join(s.rootPath, baseFileName+extension)
-
-	return ioutil.WriteFile(filePath, data, filePerm)
+	return filepath.Join(s.rootPath, baseFileName+extension)
 }
 
 func (s *CertificatesStorage) MoveToArchive(domain string) error {
@@ -196,7 +348,8 @@ func (s *CertificatesStorage) MoveToArchive(domain string) error {
 
 // sanitizedDomain Make sure no funny chars are in the cert names (like wildcards ;))
 func sanitizedDomain(domain string) string {
-	safe, err := idna.ToASCII(strings.Replace(domain, "*", "_", -1))
+	// the colons of the IPv6 addresses are not allowed in file names on some systems.
+	safe, err := idna.ToASCII(strings.NewReplacer("*", "_", ":", "-").Replace(domain))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/certcrypto"
+	"github.com/vostronet/lego/certificate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+type fakeOCSPFetcher struct {
+	raw  []byte
+	resp *ocsp.Response
+	err  error
+}
+
+func (f fakeOCSPFetcher) GetOCSP(_ []byte) ([]byte, *ocsp.Response, error) {
+	return f.raw, f.resp, f.err
+}
+
+func Test_fullChain(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	leaf, err := certcrypto.GeneratePemCert(privateKey, "example.com", nil)
+	require.NoError(t, err)
+
+	issuer, err := certcrypto.GeneratePemCert(privateKey, "issuer.example.com", nil)
+	require.NoError(t, err)
+
+	bundle := bytes.Join([][]byte{leaf, issuer}, nil)
+
+	testCases := []struct {
+		desc     string
+		certRes  *certificate.Resource
+		expected []byte
+	}{
+		{
+			desc:     "without issuer",
+			certRes:  &certificate.Resource{Certificate: leaf},
+			expected: leaf,
+		},
+		{
+			desc:     "not bundled",
+			certRes:  &certificate.Resource{Certificate: leaf, IssuerCertificate: issuer},
+			expected: bundle,
+		},
+		{
+			desc:     "already bundled",
+			certRes:  &certificate.Resource{Certificate: bundle, IssuerCertificate: issuer},
+			expected: bundle,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			chain := fullChain(test.certRes)
+			assert.Equal(t, test.expected, chain)
+
+			certs, err := certcrypto.ParsePEMBundle(chain)
+			require.NoError(t, err)
+			assert.Equal(t, "example.com", certs[0].DNSNames[0])
+		})
+	}
+}
+
+func TestCertificatesStorage_SaveOCSP(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		disabled      bool
+		fetcher       fakeOCSPFetcher
+		expectedSaved bool
+		expected      []byte
+	}{
+		{
+			desc:          "good response",
+			fetcher:       fakeOCSPFetcher{raw: []byte("ocsp"), resp: &ocsp.Response{Status: ocsp.Good, NextUpdate: time.Now().Add(time.Hour)}},
+			expectedSaved: true,
+			expected:      []byte("ocsp"),
+		},
+		{
+			desc:          "without next update",
+			fetcher:       fakeOCSPFetcher{raw: []byte("ocsp"), resp: &ocsp.Response{Status: ocsp.Good}},
+			expectedSaved: true,
+			expected:      []byte("ocsp"),
+		},
+		{
+			desc:     "disabled",
+			disabled: true,
+			fetcher:  fakeOCSPFetcher{raw: []byte("ocsp"), resp: &ocsp.Response{Status: ocsp.Good}},
+			expected: []byte("previous"),
+		},
+		{
+			desc:    "stale response",
+			fetcher: fakeOCSPFetcher{raw: []byte("ocsp"), resp: &ocsp.Response{Status: ocsp.Good, NextUpdate: time.Now().Add(-time.Hour)}},
+		},
+		{
+			desc:    "no response",
+			fetcher: fakeOCSPFetcher{raw: []byte("ocsp")},
+		},
+		{
+			desc:    "fetch error",
+			fetcher: fakeOCSPFetcher{err: errors.New("no OCSP server specified in cert")},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "lego-ocsp")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			// OCSP response of the previous certificate.
+			err = ioutil.WriteFile(filepath.Join(dir, "example.com.ocsp"), []byte("previous"), filePerm)
+			require.NoError(t, err)
+
+			storage := &CertificatesStorage{rootPath: dir, ocsp: !test.disabled}
+
+			saved := storage.SaveOCSP(test.fetcher, &certificate.Resource{Domain: "example.com"})
+			assert.Equal(t, test.expectedSaved, saved)
+
+			raw, err := ioutil.ReadFile(filepath.Join(dir, "example.com.ocsp"))
+			if test.expected == nil {
+				assert.True(t, os.IsNotExist(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, raw)
+		})
+	}
+}
+
+func TestCertificatesStorage_SaveOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-order")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	storage := &CertificatesStorage{rootPath: filepath.Join(dir, "certificates")}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	err = storage.SaveOrder("example.com", "https://ca.example.com/order/1", privateKey)
+	require.NoError(t, err)
+
+	orderURL, err := ioutil.ReadFile(filepath.Join(dir, "certificates", "example.com.order"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://ca.example.com/order/1\n", string(orderURL))
+
+	key, err := storage.ReadOrderKey("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, key)
+
+	// the files of the order are removed once the certificate is saved.
+	storage.SaveResource(&certificate.Resource{Domain: "example.com", Certificate: []byte("cert")})
+
+	_, err = os.Stat(filepath.Join(dir, "certificates", "example.com.order"))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = os.Stat(filepath.Join(dir, "certificates", "example.com.order.key"))
+	assert.True(t, os.IsNotExist(err))
+}
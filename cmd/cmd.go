@@ -7,8 +7,12 @@ func CreateCommands() []cli.Command {
 	return []cli.Command{
 		createRun(),
 		createRevoke(),
+		createDeactivate(),
+		createUpdateAccount(),
 		createRenew(),
+		createEnsure(),
 		createDNSHelp(),
+		createDNS(),
 		createList(),
 	}
 }
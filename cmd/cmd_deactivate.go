@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"github.com/vostronet/lego/log"
+	"github.com/urfave/cli"
+)
+
+func createDeactivate() cli.Command {
+	return cli.Command{
+		Name:   "deactivate",
+		Usage:  "Deactivate the account",
+		Action: deactivate,
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "keep, k",
+				Usage: "Keep the account files after the deactivation instead of archiving them.",
+			},
+		},
+	}
+}
+
+func deactivate(ctx *cli.Context) error {
+	accountsStorage := NewAccountsStorage(ctx)
+
+	acc, client := setup(ctx, accountsStorage)
+
+	if acc.Registration == nil {
+		log.Fatalf("Account %s is not registered.\n", acc.Email)
+	}
+
+	err := client.Registration.DeleteRegistration()
+	if err != nil {
+		log.Fatalf("Error while deactivating the account %s\n\t%v", acc.Email, err)
+	}
+
+	log.Printf("Account %s was deactivated.", acc.Email)
+
+	if ctx.Bool("keep") {
+		return nil
+	}
+
+	// the state of a deactivated account is not reusable: the account files are archived with the state file.
+	archive, err := accountsStorage.MoveToArchive()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Account %s was archived: %s", acc.Email, archive)
+
+	return nil
+}
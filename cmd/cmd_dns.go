@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/log"
+	"github.com/vostronet/lego/providers/dns"
+	"github.com/urfave/cli"
+)
+
+func createDNS() cli.Command {
+	return cli.Command{
+		Name:  "dns",
+		Usage: "Manage the DNS providers",
+		Subcommands: []cli.Command{
+			{
+				Name:   "check",
+				Usage:  "Check the credentials and the zone access of a DNS provider, without issuing a certificate",
+				Action: dnsCheck,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "dns",
+						Usage: fmt.Sprintf("DNS code (default: the '--dns' global option): %s", allDNSCodes()),
+					},
+				},
+			},
+			{
+				Name: "test",
+				Usage: "Create the TXT records of the domains with the DNS provider, check their propagation, then remove them, without issuing a certificate" +
+					" (uses the '--dns', '--dns.provider-map', '--domains' and the DNS-01 challenge global options)",
+				Action: dnsTest,
+			},
+		},
+	}
+}
+
+func dnsCheck(ctx *cli.Context) error {
+	name := ctx.String("dns")
+	if name == "" {
+		name = ctx.GlobalString("dns")
+	}
+
+	if name == "" {
+		log.Fatal("A DNS provider must be set with the '--dns' option.")
+	}
+
+	provider, err := dns.NewDNSChallengeProviderByName(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	checker, ok := provider.(challenge.CredentialChecker)
+	if !ok {
+		log.Printf("The check of the credentials is not supported by the DNS provider %s.", name)
+		return nil
+	}
+
+	err = checker.Check()
+	if err == challenge.ErrCheckNotSupported {
+		// a provider wrapping other providers (e.g. multi, router) can't check the providers without credential check.
+		log.Printf("The check of the credentials is not supported by the DNS provider %s.", name)
+		return nil
+	}
+
+	if err != nil {
+		log.Fatalf("The check of the DNS provider %s failed: %v", name, err)
+	}
+
+	log.Printf("The credentials of the DNS provider %s are valid.", name)
+
+	return nil
+}
+
+// dnsTest exercises the setup of the DNS-01 challenge of the domains, without ACME order:
+// a TXT record is created with a dummy key authorization, its propagation is checked, then it is removed.
+func dnsTest(ctx *cli.Context) error {
+	if !ctx.GlobalIsSet("dns") && !ctx.GlobalIsSet("dns.provider-map") {
+		log.Fatal("A DNS provider must be set with the '--dns' or the '--dns.provider-map' option.")
+	}
+
+	domains := ctx.GlobalStringSlice("domains")
+	if len(domains) == 0 {
+		log.Fatal("Please specify --domains/-d")
+	}
+
+	provider, err := setupDNSProvider(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if ctx.GlobalInt("dns.retries") > 0 {
+		provider = dns01.WithRetry(provider, ctx.GlobalInt("dns.retries")+1, dnsRetryInterval)
+	}
+
+	chlg := dns01.NewChallenge(nil, nil, provider, setupDNSOptions(ctx)...)
+
+	tested := make(map[string]bool)
+
+	for _, domain := range domains {
+		// a wildcard domain and its base domain share the TXT record of the challenge.
+		identifier := strings.TrimPrefix(domain, "*.")
+		if tested[identifier] {
+			continue
+		}
+		tested[identifier] = true
+
+		result, err := chlg.DryRun(domain)
+		if err != nil {
+			log.Fatalf("The test of the DNS-01 challenge failed: %v", err)
+		}
+
+		log.Printf("[%s] The test of the DNS-01 challenge succeeded (%s): present %s, propagation %s, clean up %s.",
+			domain, result.FQDN, result.Present, result.Propagation, result.CleanUp)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"github.com/vostronet/lego/log"
+	"github.com/urfave/cli"
+)
+
+func createEnsure() cli.Command {
+	return cli.Command{
+		Name:  "ensure",
+		Usage: "Obtain a certificate if none exists, renew it if it expires soon, do nothing otherwise",
+		Before: func(ctx *cli.Context) error {
+			// we require either domains or csr, but not both
+			hasDomains := len(ctx.GlobalStringSlice("domains")) > 0
+			hasCsr := len(ctx.GlobalString("csr")) > 0
+			if hasDomains && hasCsr {
+				log.Fatal("Please specify either --domains/-d or --csr/-c, but not both")
+			}
+			if !hasDomains && !hasCsr {
+				log.Fatal("Please specify --domains/-d (or --csr/-c if you already have a CSR)")
+			}
+			return nil
+		},
+		Action: ensure,
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  "days",
+				Value: 30,
+				Usage: "The number of days left on a certificate to renew it.",
+			},
+			cli.Float64Flag{
+				Name:  "renew-ratio",
+				Usage: "The ratio (between 0 and 1) of the total validity left on a certificate to renew it (e.g. 0.33 renews a 90-day certificate 30 days before its expiration, and a 7-day certificate 2.3 days before). Takes precedence over --days.",
+			},
+			cli.BoolFlag{
+				Name:  "reuse-key",
+				Usage: "Used to indicate you want to reuse your current private key for the renewed certificate.",
+			},
+			cli.BoolFlag{
+				Name:  "no-bundle",
+				Usage: "Do not create a certificate bundle by adding the issuers certificate to the new certificate.",
+			},
+			cli.BoolFlag{
+				Name:  "must-staple",
+				Usage: "Include the OCSP must staple TLS extension in the CSR and generated certificate. Only works if the CSR is generated by lego.",
+			},
+			cli.StringFlag{
+				Name:  "preferred-chain",
+				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name. If no match, the default offered chain will be used. Only used when obtaining a certificate for domains.",
+			},
+			cli.DurationFlag{
+				Name:  "not-after",
+				Usage: "Request a certificate valid until this duration from now (e.g. 72h), for the CAs supporting the notAfter field of the orders. Only used when obtaining a certificate for domains.",
+			},
+			cli.StringFlag{
+				Name:  "ensure-hook",
+				Usage: "Define a hook. The hook is executed only when the certificates are effectively created or renewed. The certificate metadata are exposed through the LEGO_CERT_* environment variables.",
+			},
+		},
+	}
+}
+
+func ensure(ctx *cli.Context) error {
+	accountsStorage := NewAccountsStorage(ctx)
+
+	account, client := setup(ctx, accountsStorage)
+	setupChallenges(ctx, client)
+
+	defer saveState(ctx, accountsStorage, client)
+
+	if account.Registration == nil {
+		registerAccount(ctx, client, accountsStorage, account)
+	}
+
+	certsStorage := NewCertificatesStorage(ctx)
+	certsStorage.CreateRootFolder()
+
+	hook := ctx.String("ensure-hook")
+
+	var domain string
+	if ctx.GlobalIsSet("csr") {
+		csr, err := readCSRFile(ctx.GlobalString("csr"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		domain = getCSRDomain(csr)
+	} else {
+		domain = ctx.GlobalStringSlice("domains")[0]
+	}
+
+	if certsStorage.ExistsFile(domain, ".crt") {
+		bundle := !ctx.Bool("no-bundle")
+
+		if ctx.GlobalIsSet("csr") {
+			return renewForCSR(ctx, client, certsStorage, bundle, hook)
+		}
+
+		return renewForDomains(ctx, client, certsStorage, bundle, hook)
+	}
+
+	log.Infof("[%s] No existing certificate: obtaining a new certificate.", domain)
+
+	cert, err := obtainCertificate(ctx, client)
+	if err != nil {
+		log.Fatalf("Could not obtain certificates:\n\t%v", err)
+	}
+
+	certsStorage.SaveResource(cert)
+	ocspSaved := certsStorage.SaveOCSP(client.Certificate, cert)
+
+	return launchHook(hook, hookMeta(certsStorage, cert, ocspSaved))
+}
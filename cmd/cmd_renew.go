@@ -1,13 +1,8 @@
 package cmd
 
 import (
-	"context"
 	"crypto"
 	"crypto/x509"
-	"errors"
-	"fmt"
-	"os/exec"
-	"strings"
 	"time"
 
 	"github.com/vostronet/lego/certcrypto"
@@ -40,6 +35,10 @@ func createRenew() cli.Command {
 				Value: 30,
 				Usage: "The number of days left on a certificate to renew it.",
 			},
+			cli.Float64Flag{
+				Name:  "renew-ratio",
+				Usage: "The ratio (between 0 and 1) of the total validity left on a certificate to renew it (e.g. 0.33 renews a 90-day certificate 30 days before its expiration, and a 7-day certificate 2.3 days before). Takes precedence over --days.",
+			},
 			cli.BoolFlag{
 				Name:  "reuse-key",
 				Usage: "Used to indicate you want to reuse your current private key for the new certificate.",
@@ -52,18 +51,30 @@ func createRenew() cli.Command {
 				Name:  "must-staple",
 				Usage: "Include the OCSP must staple TLS extension in the CSR and generated certificate. Only works if the CSR is generated by lego.",
 			},
+			cli.StringFlag{
+				Name:  "preferred-chain",
+				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name. If no match, the default offered chain will be used. Only used when obtaining a certificate for domains.",
+			},
+			cli.DurationFlag{
+				Name:  "not-after",
+				Usage: "Request a certificate valid until this duration from now (e.g. 72h), for the CAs supporting the notAfter field of the orders. Only used when obtaining a certificate for domains.",
+			},
 			cli.StringFlag{
 				Name:  "renew-hook",
-				Usage: "Define a hook. The hook is executed only when the certificates are effectively renewed.",
+				Usage: "Define a hook. The hook is executed only when the certificates are effectively renewed. The certificate metadata are exposed through the LEGO_CERT_* environment variables.",
 			},
 		},
 	}
 }
 
 func renew(ctx *cli.Context) error {
-	account, client := setup(ctx, NewAccountsStorage(ctx))
+	accountsStorage := NewAccountsStorage(ctx)
+
+	account, client := setup(ctx, accountsStorage)
 	setupChallenges(ctx, client)
 
+	defer saveState(ctx, accountsStorage, client)
+
 	if account.Registration == nil {
 		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
 	}
@@ -72,16 +83,18 @@ func renew(ctx *cli.Context) error {
 
 	bundle := !ctx.Bool("no-bundle")
 
+	hook := ctx.String("renew-hook")
+
 	// CSR
 	if ctx.GlobalIsSet("csr") {
-		return renewForCSR(ctx, client, certsStorage, bundle)
+		return renewForCSR(ctx, client, certsStorage, bundle, hook)
 	}
 
 	// Domains
-	return renewForDomains(ctx, client, certsStorage, bundle)
+	return renewForDomains(ctx, client, certsStorage, bundle, hook)
 }
 
-func renewForDomains(ctx *cli.Context, client *lego.Client, certsStorage *CertificatesStorage, bundle bool) error {
+func renewForDomains(ctx *cli.Context, client *lego.Client, certsStorage *CertificatesStorage, bundle bool, hook string) error {
 	domains := ctx.GlobalStringSlice("domains")
 	domain := domains[0]
 
@@ -95,7 +108,7 @@ func renewForDomains(ctx *cli.Context, client *lego.Client, certsStorage *Certif
 
 	cert := certificates[0]
 
-	if !needRenewal(cert, domain, ctx.Int("days")) {
+	if !needRenewal(cert, domain, ctx.Int("days"), ctx.Float64("renew-ratio")) {
 		return nil
 	}
 
@@ -119,10 +132,12 @@ func renewForDomains(ctx *cli.Context, client *lego.Client, certsStorage *Certif
 	}
 
 	request := certificate.ObtainRequest{
-		Domains:    merge(certDomains, domains),
-		Bundle:     bundle,
-		PrivateKey: privateKey,
-		MustStaple: ctx.Bool("must-staple"),
+		Domains:        merge(certDomains, domains),
+		Bundle:         bundle,
+		PrivateKey:     privateKey,
+		MustStaple:     ctx.Bool("must-staple"),
+		PreferredChain: ctx.String("preferred-chain"),
+		NotAfter:       notAfter(ctx),
 	}
 	certRes, err := client.Certificate.Obtain(request)
 	if err != nil {
@@ -130,17 +145,18 @@ func renewForDomains(ctx *cli.Context, client *lego.Client, certsStorage *Certif
 	}
 
 	certsStorage.SaveResource(certRes)
+	ocspSaved := certsStorage.SaveOCSP(client.Certificate, certRes)
 
-	return renewHook(ctx)
+	return launchHook(hook, hookMeta(certsStorage, certRes, ocspSaved))
 }
 
-func renewForCSR(ctx *cli.Context, client *lego.Client, certsStorage *CertificatesStorage, bundle bool) error {
+func renewForCSR(ctx *cli.Context, client *lego.Client, certsStorage *CertificatesStorage, bundle bool, hook string) error {
 	csr, err := readCSRFile(ctx.GlobalString("csr"))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	domain := csr.Subject.CommonName
+	domain := getCSRDomain(csr)
 
 	// load the cert resource from files.
 	// We store the certificate, private key and metadata in different files
@@ -152,7 +168,7 @@ func renewForCSR(ctx *cli.Context, client *lego.Client, certsStorage *Certificat
 
 	cert := certificates[0]
 
-	if !needRenewal(cert, domain, ctx.Int("days")) {
+	if !needRenewal(cert, domain, ctx.Int("days"), ctx.Float64("renew-ratio")) {
 		return nil
 	}
 
@@ -166,15 +182,34 @@ func renewForCSR(ctx *cli.Context, client *lego.Client, certsStorage *Certificat
 	}
 
 	certsStorage.SaveResource(certRes)
+	ocspSaved := certsStorage.SaveOCSP(client.Certificate, certRes)
 
-	return renewHook(ctx)
+	return launchHook(hook, hookMeta(certsStorage, certRes, ocspSaved))
 }
 
-func needRenewal(x509Cert *x509.Certificate, domain string, days int) bool {
+func needRenewal(x509Cert *x509.Certificate, domain string, days int, ratio float64) bool {
 	if x509Cert.IsCA {
 		log.Fatalf("[%s] Certificate bundle starts with a CA certificate", domain)
 	}
 
+	if ratio < 0 || ratio > 1 {
+		log.Fatalf("[%s] The renewal ratio must be between 0 and 1: %v", domain, ratio)
+	}
+
+	// the ratio is suited for the short-lived certificates, the number of days is not relevant.
+	if ratio > 0 {
+		validity := x509Cert.NotAfter.Sub(x509Cert.NotBefore)
+		remaining := time.Until(x509Cert.NotAfter)
+
+		if validity > 0 && remaining > time.Duration(ratio*float64(validity)) {
+			log.Printf("[%s] The certificate expires in %s (%.0f%% of its validity), the ratio defined to perform the renewal is %.0f%%: no renewal.",
+				domain, remaining.Round(time.Minute), 100*float64(remaining)/float64(validity), 100*ratio)
+			return false
+		}
+
+		return true
+	}
+
 	if days >= 0 {
 		notAfter := int(time.Until(x509Cert.NotAfter).Hours() / 24.0)
 		if notAfter > days {
@@ -202,25 +237,3 @@ func merge(prevDomains []string, nextDomains []string) []string {
 	}
 	return prevDomains
 }
-
-func renewHook(ctx *cli.Context) error {
-	hook := ctx.String("renew-hook")
-	if hook == "" {
-		return nil
-	}
-
-	ctxCmd, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	parts := strings.Fields(hook)
-	output, err := exec.CommandContext(ctxCmd, parts[0], parts[1:]...).CombinedOutput()
-	if len(output) > 0 {
-		fmt.Println(string(output))
-	}
-
-	if ctxCmd.Err() == context.DeadlineExceeded {
-		return errors.New("hook timed out")
-	}
-
-	return err
-}
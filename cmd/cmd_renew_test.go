@@ -63,6 +63,7 @@ func Test_needRenewal(t *testing.T) {
 		desc     string
 		x509Cert *x509.Certificate
 		days     int
+		ratio    float64
 		expected bool
 	}{
 		{
@@ -105,13 +106,43 @@ func Test_needRenewal(t *testing.T) {
 			days:     -1,
 			expected: true,
 		},
+		{
+			desc: "ratio 0.33, 7-day certificate, NotAfter 5 days",
+			x509Cert: &x509.Certificate{
+				NotBefore: time.Now().Add(-2 * 24 * time.Hour),
+				NotAfter:  time.Now().Add(5 * 24 * time.Hour),
+			},
+			days:     30,
+			ratio:    0.33,
+			expected: false,
+		},
+		{
+			desc: "ratio 0.33, 7-day certificate, NotAfter 2 days",
+			x509Cert: &x509.Certificate{
+				NotBefore: time.Now().Add(-5 * 24 * time.Hour),
+				NotAfter:  time.Now().Add(2 * 24 * time.Hour),
+			},
+			days:     0,
+			ratio:    0.33,
+			expected: true,
+		},
+		{
+			desc: "ratio 0.33, 90-day certificate, NotAfter 31 days",
+			x509Cert: &x509.Certificate{
+				NotBefore: time.Now().Add(-59 * 24 * time.Hour),
+				NotAfter:  time.Now().Add(31 * 24 * time.Hour),
+			},
+			days:     -1,
+			ratio:    0.33,
+			expected: false,
+		},
 	}
 
 	for _, test := range testCases {
 		test := test
 		t.Run(test.desc, func(t *testing.T) {
 
-			actual := needRenewal(test.x509Cert, "foo.com", test.days)
+			actual := needRenewal(test.x509Cert, "foo.com", test.days, test.ratio)
 
 			assert.Equal(t, test.expected, actual)
 		})
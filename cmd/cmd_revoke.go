@@ -1,10 +1,31 @@
 package cmd
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/certcrypto"
+	"github.com/vostronet/lego/lego"
 	"github.com/vostronet/lego/log"
 	"github.com/urfave/cli"
 )
 
+// revocationReasons the names of the revocation reason codes (RFC 5280).
+var revocationReasons = map[string]uint{
+	"unspecified":          acme.CRLReasonUnspecified,
+	"keyCompromise":        acme.CRLReasonKeyCompromise,
+	"cACompromise":         acme.CRLReasonCACompromise,
+	"affiliationChanged":   acme.CRLReasonAffiliationChanged,
+	"superseded":           acme.CRLReasonSuperseded,
+	"cessationOfOperation": acme.CRLReasonCessationOfOperation,
+	"certificateHold":      acme.CRLReasonCertificateHold,
+	"removeFromCRL":        acme.CRLReasonRemoveFromCRL,
+	"privilegeWithdrawn":   acme.CRLReasonPrivilegeWithdrawn,
+	"aACompromise":         acme.CRLReasonAACompromise,
+}
+
 func createRevoke() cli.Command {
 	return cli.Command{
 		Name:   "revoke",
@@ -15,17 +36,35 @@ func createRevoke() cli.Command {
 				Name:  "keep, k",
 				Usage: "Keep the certificates after the revocation instead of archiving them.",
 			},
+			cli.StringFlag{
+				Name:  "reason",
+				Usage: "The reason of the revocation (RFC 5280): " + strings.Join(revocationReasonNames(), ", ") + ".",
+				Value: "unspecified",
+			},
+			cli.BoolFlag{
+				Name:  "cert-key",
+				Usage: "Sign the revocation request with the private key of the certificate instead of the account key (the account doesn't need to be registered).",
+			},
 		},
 	}
 }
 
 func revoke(ctx *cli.Context) error {
-	acc, client := setup(ctx, NewAccountsStorage(ctx))
+	accountsStorage := NewAccountsStorage(ctx)
+
+	acc, client := setup(ctx, accountsStorage)
+
+	defer saveState(ctx, accountsStorage, client)
 
-	if acc.Registration == nil {
+	if acc.Registration == nil && !ctx.Bool("cert-key") {
 		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", acc.Email)
 	}
 
+	reason, err := getRevocationReason(ctx.String("reason"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	certsStorage := NewCertificatesStorage(ctx)
 	certsStorage.CreateRootFolder()
 
@@ -37,7 +76,11 @@ func revoke(ctx *cli.Context) error {
 			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
 		}
 
-		err = client.Certificate.Revoke(certBytes)
+		if ctx.Bool("cert-key") {
+			err = revokeWithCertificateKey(client, certsStorage, domain, certBytes, reason)
+		} else {
+			err = client.Certificate.RevokeWithReason(certBytes, reason)
+		}
 		if err != nil {
 			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
 		}
@@ -60,3 +103,48 @@ func revoke(ctx *cli.Context) error {
 
 	return nil
 }
+
+// revokeWithCertificateKey revokes a certificate with a request signed by its private key.
+func revokeWithCertificateKey(client *lego.Client, certsStorage *CertificatesStorage, domain string, certBytes []byte, reason *uint) error {
+	keyBytes, err := certsStorage.ReadFile(domain, ".key")
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := certcrypto.ParsePEMPrivateKey(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return client.Certificate.RevokeWithKey(certBytes, privateKey, reason)
+}
+
+// getRevocationReason returns the code of a revocation reason (nil for "unspecified": the reason is not sent to the CA).
+func getRevocationReason(name string) (*uint, error) {
+	for reasonName, code := range revocationReasons {
+		if !strings.EqualFold(reasonName, name) {
+			continue
+		}
+
+		if code == acme.CRLReasonUnspecified {
+			return nil, nil
+		}
+
+		return &code, nil
+	}
+
+	return nil, fmt.Errorf("invalid revocation reason %q, expected one of: %s", name, strings.Join(revocationReasonNames(), ", "))
+}
+
+func revocationReasonNames() []string {
+	var names []string
+	for name := range revocationReasons {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return revocationReasons[names[i]] < revocationReasons[names[j]]
+	})
+
+	return names
+}
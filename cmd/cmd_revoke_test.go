@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getRevocationReason(t *testing.T) {
+	reason, err := getRevocationReason("unspecified")
+	require.NoError(t, err)
+	assert.Nil(t, reason)
+
+	reason, err = getRevocationReason("keyCompromise")
+	require.NoError(t, err)
+	require.NotNil(t, reason)
+	assert.Equal(t, acme.CRLReasonKeyCompromise, *reason)
+
+	reason, err = getRevocationReason("CESSATIONOFOPERATION")
+	require.NoError(t, err)
+	require.NotNil(t, reason)
+	assert.Equal(t, acme.CRLReasonCessationOfOperation, *reason)
+
+	_, err = getRevocationReason("foo")
+	require.Error(t, err)
+}
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/base64"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/vostronet/lego/certificate"
 	"github.com/vostronet/lego/lego"
@@ -27,6 +29,9 @@ func createRun() cli.Command {
 			if !hasDomains && !hasCsr {
 				log.Fatal("Please specify --domains/-d (or --csr/-c if you already have a CSR)")
 			}
+			if hasCsr && ctx.IsSet("resume-order") {
+				log.Fatal("The --resume-order option is only supported with --domains/-d")
+			}
 			return nil
 		},
 		Action: run,
@@ -39,6 +44,22 @@ func createRun() cli.Command {
 				Name:  "must-staple",
 				Usage: "Include the OCSP must staple TLS extension in the CSR and generated certificate. Only works if the CSR is generated by lego.",
 			},
+			cli.StringFlag{
+				Name:  "preferred-chain",
+				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name. If no match, the default offered chain will be used. Only used when obtaining a certificate for domains.",
+			},
+			cli.DurationFlag{
+				Name:  "not-after",
+				Usage: "Request a certificate valid until this duration from now (e.g. 72h), for the CAs supporting the notAfter field of the orders. Only used when obtaining a certificate for domains.",
+			},
+			cli.StringFlag{
+				Name:  "resume-order",
+				Usage: "Resume an in-progress order (e.g. interrupted before its finalization) from its URL, instead of creating a new order. The URL and the private key of the certificate are saved in the .order and .order.key files at the creation of the order. Only used when obtaining a certificate for domains.",
+			},
+			cli.StringFlag{
+				Name:  "run-hook",
+				Usage: "Define a hook. The hook is executed when the certificates are effectively created. The certificate metadata are exposed through the LEGO_CERT_* environment variables.",
+			},
 		},
 	}
 }
@@ -49,26 +70,10 @@ func run(ctx *cli.Context) error {
 	account, client := setup(ctx, accountsStorage)
 	setupChallenges(ctx, client)
 
-	if account.Registration == nil {
-		reg, err := register(ctx, client)
-		if err != nil {
-			log.Fatalf("Could not complete registration\n\t%v", err)
-		}
+	defer saveState(ctx, accountsStorage, client)
 
-		account.Registration = reg
-
-		if err = accountsStorage.Save(account); err != nil {
-			log.Fatal(err)
-		}
-
-		fmt.Println("!!!! HEADS UP !!!!")
-		fmt.Printf(`
-		Your account credentials have been saved in your Let's Encrypt
-		configuration directory at "%s".
-		You should make a secure backup	of this folder now. This
-		configuration directory will also contain certificates and
-		private keys obtained from Let's Encrypt so making regular
-		backups of this folder is ideal.`, accountsStorage.GetRootPath())
+	if account.Registration == nil {
+		registerAccount(ctx, client, accountsStorage, account)
 	}
 
 	certsStorage := NewCertificatesStorage(ctx)
@@ -82,8 +87,51 @@ func run(ctx *cli.Context) error {
 	}
 
 	certsStorage.SaveResource(cert)
+	ocspSaved := certsStorage.SaveOCSP(client.Certificate, cert)
+
+	return launchHook(ctx.String("run-hook"), hookMeta(certsStorage, cert, ocspSaved))
+}
+
+// registerAccount registers the account and saves it.
+// The account key is looked up first: an account already registered with the key is reused.
+// If no account is found, or if the lookup fails, a new account is registered.
+func registerAccount(ctx *cli.Context, client *lego.Client, accountsStorage *AccountsStorage, account *Account) {
+	reg, err := client.Registration.ResolveAccountByKey()
+	if err == nil {
+		log.Printf("The account key is already registered (%s): the account is reused.", reg.URI)
+
+		account.Registration = reg
+
+		if err = accountsStorage.Save(account); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	if !registration.IsAccountNotFound(err) {
+		log.Warnf("Could not look up the account of the account key, registering a new account: %v", err)
+	}
+
+	reg, err = register(ctx, client)
+	if err != nil {
+		log.Fatalf("Could not complete registration\n\t%v", err)
+	}
+
+	account.Registration = reg
 
-	return nil
+	if err = accountsStorage.Save(account); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("!!!! HEADS UP !!!!")
+	fmt.Printf(`
+		Your account credentials have been saved in your Let's Encrypt
+		configuration directory at "%s".
+		You should make a secure backup	of this folder now. This
+		configuration directory will also contain certificates and
+		private keys obtained from Let's Encrypt so making regular
+		backups of this folder is ideal.`, accountsStorage.GetRootPath())
 }
 
 func handleTOS(ctx *cli.Context, client *lego.Client) bool {
@@ -128,6 +176,11 @@ func register(ctx *cli.Context, client *lego.Client) (*registration.Resource, er
 			log.Fatalf("Requires arguments --kid and --hmac.")
 		}
 
+		hmacEncoded, err := checkEABHmac(hmacEncoded)
+		if err != nil {
+			log.Fatal(err)
+		}
+
 		return client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
 			TermsOfServiceAgreed: accepted,
 			Kid:                  kid,
@@ -138,6 +191,19 @@ func register(ctx *cli.Context, client *lego.Client) (*registration.Resource, er
 	return client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
 }
 
+// checkEABHmac ensures the MAC key is a valid Base64 URL encoded value.
+// The padding is removed because the ACME client expects the MAC key without padding.
+func checkEABHmac(hmacEncoded string) (string, error) {
+	hmacEncoded = strings.TrimRight(strings.TrimSpace(hmacEncoded), "=")
+
+	_, err := base64.RawURLEncoding.DecodeString(hmacEncoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid --hmac: the MAC key must be in Base64 URL Encoding (RFC 4648, section 5): %v", err)
+	}
+
+	return hmacEncoded, nil
+}
+
 func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Resource, error) {
 	bundle := !ctx.Bool("no-bundle")
 
@@ -145,10 +211,24 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 	if len(domains) > 0 {
 		// obtain a certificate, generating a new private key
 		request := certificate.ObtainRequest{
-			Domains:    domains,
-			Bundle:     bundle,
-			MustStaple: ctx.Bool("must-staple"),
+			Domains:        domains,
+			Bundle:         bundle,
+			MustStaple:     ctx.Bool("must-staple"),
+			PreferredChain: ctx.String("preferred-chain"),
+			NotAfter:       notAfter(ctx),
+		}
+
+		if orderURL := ctx.String("resume-order"); orderURL != "" {
+			privateKey, err := NewCertificatesStorage(ctx).ReadOrderKey(domains[0])
+			if err != nil {
+				return nil, fmt.Errorf("unable to read the private key of the order: %v", err)
+			}
+
+			request.PrivateKey = privateKey
+
+			return client.Certificate.Resume(orderURL, request)
 		}
+
 		return client.Certificate.Obtain(request)
 	}
 
@@ -161,3 +241,14 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 	// obtain a certificate for this CSR
 	return client.Certificate.ObtainForCSR(*csr, bundle)
 }
+
+// notAfter returns the requested end of the validity of the certificate (the "not-after" duration from now),
+// or a zero time if the option is not set.
+func notAfter(ctx *cli.Context) time.Time {
+	d := ctx.Duration("not-after")
+	if d <= 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(d)
+}
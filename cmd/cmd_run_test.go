@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkEABHmac(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		hmac     string
+		expected string
+	}{
+		{
+			desc:     "without padding",
+			hmac:     "YWJjZA",
+			expected: "YWJjZA",
+		},
+		{
+			desc:     "with padding",
+			hmac:     "YWJjZA==",
+			expected: "YWJjZA",
+		},
+		{
+			desc:     "URL alphabet",
+			hmac:     "-_-_",
+			expected: "-_-_",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			hmac, err := checkEABHmac(test.hmac)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, hmac)
+		})
+	}
+}
+
+func Test_checkEABHmac_invalid(t *testing.T) {
+	_, err := checkEABHmac("a+b/c")
+	require.Error(t, err)
+}
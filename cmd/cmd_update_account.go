@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/vostronet/lego/log"
+	"github.com/urfave/cli"
+)
+
+func createUpdateAccount() cli.Command {
+	return cli.Command{
+		Name:   "update-account",
+		Usage:  "Update the contacts of the account (e.g. to add an email address for the expiration notices)",
+		Action: updateAccount,
+		Flags: []cli.Flag{
+			cli.StringSliceFlag{
+				Name:  "contact, c",
+				Usage: "A contact of the account: an email address or a URL (e.g. mailto:foo@example.com). Can be specified multiple times, replaces all the contacts of the account.",
+			},
+			cli.BoolFlag{
+				Name:  "no-contact",
+				Usage: "Remove all the contacts of the account.",
+			},
+		},
+	}
+}
+
+func updateAccount(ctx *cli.Context) error {
+	contacts := getContacts(ctx)
+
+	accountsStorage := NewAccountsStorage(ctx)
+
+	acc, client := setup(ctx, accountsStorage)
+
+	if acc.Registration == nil {
+		log.Fatalf("Account %s is not registered.\n", acc.Email)
+	}
+
+	reg, err := client.Registration.UpdateRegistration(contacts)
+	if err != nil {
+		log.Fatalf("Error while updating the contacts of the account %s\n\t%v", acc.Email, err)
+	}
+
+	acc.Registration = reg
+
+	err = accountsStorage.Save(acc)
+	if err != nil {
+		log.Fatalf("Could not save the account file: %v", err)
+	}
+
+	log.Printf("The contacts of the account %s were updated: [%s]", acc.Email, strings.Join(reg.Body.Contact, ", "))
+
+	return nil
+}
+
+// getContacts returns the contacts of the update-account command: the email addresses are converted to mailto URLs.
+func getContacts(ctx *cli.Context) []string {
+	values := ctx.StringSlice("contact")
+
+	if ctx.Bool("no-contact") {
+		if len(values) > 0 {
+			log.Fatal("The '--contact' and '--no-contact' options are mutually exclusive.")
+		}
+
+		return []string{}
+	}
+
+	if len(values) == 0 {
+		log.Fatal("At least one contact must be set with the '--contact' option (or '--no-contact' to remove all the contacts).")
+	}
+
+	var contacts []string
+	for _, value := range values {
+		if !strings.Contains(value, ":") {
+			value = "mailto:" + value
+		}
+
+		contacts = append(contacts, value)
+	}
+
+	return contacts
+}
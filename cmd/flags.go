@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/vostronet/lego/certificate"
 	"github.com/vostronet/lego/lego"
 	"github.com/urfave/cli"
 )
@@ -29,21 +30,24 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Usage: "Certificate signing request filename, if an external CSR is to be used.",
 		},
 		cli.BoolFlag{
-			Name:  "eab",
-			Usage: "Use External Account Binding for account registration. Requires --kid and --hmac.",
+			Name:   "eab",
+			EnvVar: "LEGO_EAB",
+			Usage:  "Use External Account Binding for account registration. Requires --kid and --hmac.",
 		},
 		cli.StringFlag{
-			Name:  "kid",
-			Usage: "Key identifier from External CA. Used for External Account Binding.",
+			Name:   "kid",
+			EnvVar: "LEGO_EAB_KID",
+			Usage:  "Key identifier from External CA. Used for External Account Binding.",
 		},
 		cli.StringFlag{
-			Name:  "hmac",
-			Usage: "MAC key from External CA. Should be in Base64 URL Encoding without padding format. Used for External Account Binding.",
+			Name:   "hmac",
+			EnvVar: "LEGO_EAB_HMAC",
+			Usage:  "MAC key from External CA. Should be in Base64 URL Encoding without padding format. Used for External Account Binding.",
 		},
 		cli.StringFlag{
 			Name:  "key-type, k",
 			Value: "ec384",
-			Usage: "Key type to use for private keys. Supported: rsa2048, rsa4096, rsa8192, ec256, ec384.",
+			Usage: "Key type to use for private keys. Supported: rsa2048, rsa4096, rsa8192, ec256, ec384, ed25519 (not supported by all the CAs).",
 		},
 		cli.StringFlag{
 			Name:  "filename",
@@ -63,6 +67,14 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Usage: "Set the port and interface to use for HTTP based challenges to listen on.Supported: interface:port or :port.",
 			Value: ":80",
 		},
+		cli.StringFlag{
+			Name:  "http.proxy-header",
+			Usage: "Validate the host of the incoming requests with this header instead of the Host header (e.g. 'X-Forwarded-Host' or 'Forwarded' behind a proxy).",
+		},
+		cli.BoolFlag{
+			Name:  "http.accept-any-host",
+			Usage: "Serve the HTTP challenges regardless of the host of the incoming requests (e.g. when a proxy rewrites the host).",
+		},
 		cli.StringFlag{
 			Name:  "http.webroot",
 			Usage: "Set the webroot folder to use for HTTP based challenges to write directly in a file in .well-known/acme-challenge.",
@@ -80,22 +92,58 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Usage: "Set the port and interface to use for TLS based challenges to listen on. Supported: interface:port or :port.",
 			Value: ":443",
 		},
+		cli.StringFlag{
+			Name:  "tls.webhook",
+			Usage: "Set the URL of an external server serving the TLS based challenges: the challenge certificates are sent to <url>/present and <url>/cleanup, instead of listening locally.",
+		},
 		cli.StringFlag{
 			Name:  "dns",
 			Usage: "Solve a DNS challenge using the specified provider. Can be mixed with other types of challenges. Run 'lego dnshelp' for help on usage.",
 		},
+		cli.StringFlag{
+			Name:  "dns.provider-map",
+			Usage: "Set the path of a file routing the domains to several DNS providers: each line maps a domain suffix to a provider name (e.g. 'example.com route53'). The '--dns' provider, if any, is used for the unmatched domains.",
+		},
 		cli.BoolFlag{
 			Name:  "dns.disable-cp",
 			Usage: "By setting this flag to true, disables the need to wait the propagation of the TXT record to all authoritative name servers.",
 		},
+		cli.BoolFlag{
+			Name:  "dns.delegate-propagation",
+			Usage: "By setting this flag to true, skips the local propagation check of the TXT record and lets the CA determine the propagation (the authorization is polled until it becomes valid or the validation times out).",
+		},
+		cli.BoolFlag{
+			Name:  "dns.soa-timings",
+			Usage: "By setting this flag to true, derives the propagation timeout and the polling interval from the SOA record (refresh and minimum) of the zone.",
+		},
+		cli.IntFlag{
+			Name:  "dns.retries",
+			Usage: "Set the number of retries, with an exponential backoff, of the creation and the removal of the TXT records when the DNS provider fails.",
+		},
 		cli.StringSliceFlag{
 			Name:  "dns.resolvers",
 			Usage: "Set the resolvers to use for performing recursive DNS queries. Supported: host:port. The default is to use the system resolvers, or Google's DNS resolvers if the system's cannot be determined.",
 		},
+		cli.BoolFlag{
+			Name:  "dns.tcp-only",
+			Usage: "By setting this flag to true, sends the DNS queries of the propagation checks over TCP only (by default, UDP with a fallback to TCP on truncated responses).",
+		},
+		cli.BoolFlag{
+			Name:  "dns.follow-cname",
+			Usage: "By setting this flag to true, follows the CNAME record of '_acme-challenge.<domain>' and creates the TXT record on the CNAME target (e.g. a zone dedicated to the ACME challenges).",
+		},
 		cli.IntFlag{
 			Name:  "http-timeout",
 			Usage: "Set the HTTP timeout value to a specific value in seconds.",
 		},
+		cli.StringFlag{
+			Name:  "root-ca",
+			Usage: "Path of a PEM file of root CA certificates trusted for the ACME server (e.g. a private PKI), in addition to the system-wide trusted roots.",
+		},
+		cli.BoolFlag{
+			Name:  "insecure-skip-verify",
+			Usage: "Disable the TLS verification of the ACME server. INSECURE: only for an internal CA, never for a public CA.",
+		},
 		cli.IntFlag{
 			Name:  "dns-timeout",
 			Usage: "Set the DNS timeout value to a specific value in seconds. Used only when performing authoritative name servers queries.",
@@ -103,12 +151,64 @@ func CreateFlags(defaultPath string) []cli.Flag {
 		},
 		cli.BoolFlag{
 			Name:  "pem",
-			Usage: "Generate a .pem file by concatenating the .key and .crt files together.",
+			Usage: "Generate a .pem file containing the full certificate chain followed by the private key (the format expected by HAProxy).",
+		},
+		cli.BoolFlag{
+			Name:  "pfx",
+			Usage: "Generate a .pfx (PKCS#12) file containing the private key, the certificate and the issuer chain (e.g. for Windows or Java keystores).",
+		},
+		cli.StringFlag{
+			Name:   "pfx.pass",
+			Usage:  "The password used to encrypt the .pfx (PKCS#12) file.",
+			Value:  "changeit",
+			EnvVar: "LEGO_PFX_PASSWORD",
+		},
+		cli.StringFlag{
+			Name:   "pfx.format",
+			Usage:  "The encryption of the .pfx (PKCS#12) file: RC2 (legacy, the most compatible), DES (legacy) or AES (modern, requires a recent importer).",
+			Value:  string(certificate.PKCS12RC2),
+			EnvVar: "LEGO_PFX_FORMAT",
+		},
+		cli.BoolFlag{
+			Name:  "ocsp",
+			Usage: "Fetch the OCSP response of the certificate after obtaining or renewing it and store it in a .ocsp file (DER encoded), to be used for OCSP stapling.",
 		},
 		cli.IntFlag{
 			Name:  "cert.timeout",
-			Usage: "Set the certificate timeout value to a specific value in seconds. Only used when obtaining certificates.",
-			Value: 30,
+			Usage: "Set the certificate timeout value to a specific value in seconds. Only used when obtaining certificates. By default, the time depends on the CA.",
+		},
+		cli.StringFlag{
+			Name:  "cert.caa-check",
+			Usage: "Check the CAA records of the domains against the CAA identities of the CA (directory metadata) before ordering a certificate. Supported: 'warn' (log a warning), 'enforce' (do not order the certificate). Disabled by default.",
+		},
+		cli.IntFlag{
+			Name:  "validation.timeout",
+			Usage: "Set the maximum time to wait for the CA to validate the challenges, in seconds. By default, the time depends on the CA.",
+		},
+		cli.IntFlag{
+			Name:  "validation.polling-interval",
+			Usage: "Set the interval between two checks of the validation status, in milliseconds. By default, the interval depends on the Retry-After header returned by the CA.",
+		},
+		cli.IntFlag{
+			Name:  "validation.max-attempts",
+			Usage: "Set the maximum number of checks of the validation status. By default, the number of checks is only limited by the validation timeout.",
+		},
+		cli.StringSliceFlag{
+			Name:  "challenge.preference",
+			Usage: "Set the order in which the challenge types offered by the CA are tried, when several challenges are configured (e.g. '--challenge.preference dns-01 --challenge.preference http-01'). Supported: 'http-01', 'tls-alpn-01', 'dns-01'. The types not listed come after, in the default order (tls-alpn-01, http-01, dns-01).",
+		},
+		cli.BoolFlag{
+			Name:  "force-challenge",
+			Usage: "Present the challenges even if the authorizations are already valid, to always exercise the challenge solvers (e.g. to test a DNS provider). The CA doesn't issue new challenges for a valid authorization.",
+		},
+		cli.BoolFlag{
+			Name:  "state-cache",
+			Usage: "Persist the ACME directory, the account URL and the unused nonces between runs, to skip the directory fetch and the account lookup of consecutive runs.",
+		},
+		cli.IntFlag{
+			Name:  "state-cache.ttl",
+			Usage: "Set the maximum age of the persisted state, in seconds. The persisted nonces are only reused during one minute.",
+			Value: 600,
 		},
 	}
 }
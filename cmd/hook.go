@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/certificate"
+)
+
+const (
+	hookEnvCertDomain     = "LEGO_CERT_DOMAIN"
+	hookEnvCertPath       = "LEGO_CERT_PATH"
+	hookEnvCertKeyPath    = "LEGO_CERT_KEY_PATH"
+	hookEnvCertIssuerPath = "LEGO_CERT_ISSUER_PATH"
+	hookEnvCertOCSPPath   = "LEGO_CERT_OCSP_PATH"
+)
+
+// hookTimeout is the maximum duration of a hook execution.
+const hookTimeout = 30 * time.Second
+
+// launchHook executes the hook command with the certificate metadata exposed as environment variables.
+func launchHook(hook string, meta map[string]string) error {
+	if hook == "" {
+		return nil
+	}
+
+	ctxCmd, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	parts := strings.Fields(hook)
+
+	cmd := exec.CommandContext(ctxCmd, parts[0], parts[1:]...)
+	cmd.Env = append(os.Environ(), metaToEnv(meta)...)
+
+	output, err := cmd.CombinedOutput()
+	if len(output) > 0 {
+		fmt.Println(string(output))
+	}
+
+	if ctxCmd.Err() == context.DeadlineExceeded {
+		return errors.New("hook timed out")
+	}
+
+	return err
+}
+
+// hookMeta returns the metadata of a stored certificate resource.
+// The path of the OCSP response is only set if the OCSP response of this certificate has been stored (see CertificatesStorage.SaveOCSP).
+func hookMeta(certsStorage *CertificatesStorage, certRes *certificate.Resource, ocspSaved bool) map[string]string {
+	meta := map[string]string{
+		hookEnvCertDomain: certRes.Domain,
+		hookEnvCertPath:   certsStorage.GetFileName(certRes.Domain, ".crt"),
+	}
+
+	if certRes.PrivateKey != nil {
+		meta[hookEnvCertKeyPath] = certsStorage.GetFileName(certRes.Domain, ".key")
+	}
+
+	if certRes.IssuerCertificate != nil {
+		meta[hookEnvCertIssuerPath] = certsStorage.GetFileName(certRes.Domain, ".issuer.crt")
+	}
+
+	if ocspSaved {
+		meta[hookEnvCertOCSPPath] = certsStorage.GetFileName(certRes.Domain, ".ocsp")
+	}
+
+	return meta
+}
+
+func metaToEnv(meta map[string]string) []string {
+	var envs []string
+
+	for k, v := range meta {
+		envs = append(envs, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	return envs
+}
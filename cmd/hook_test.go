@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/vostronet/lego/certificate"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_hookMeta(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		ocspSaved bool
+		expected  map[string]string
+	}{
+		{
+			desc:      "OCSP response saved",
+			ocspSaved: true,
+			expected: map[string]string{
+				hookEnvCertDomain:   "example.com",
+				hookEnvCertPath:     "/certs/example.com.crt",
+				hookEnvCertOCSPPath: "/certs/example.com.ocsp",
+			},
+		},
+		{
+			desc: "OCSP response not saved",
+			expected: map[string]string{
+				hookEnvCertDomain: "example.com",
+				hookEnvCertPath:   "/certs/example.com.crt",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			storage := &CertificatesStorage{rootPath: "/certs", ocsp: true}
+
+			meta := hookMeta(storage, &certificate.Resource{Domain: "example.com"}, test.ocspSaved)
+
+			assert.Equal(t, test.expected, meta)
+		})
+	}
+}
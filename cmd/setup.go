@@ -9,7 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/vostronet/lego/acme/api"
 	"github.com/vostronet/lego/certcrypto"
+	"github.com/vostronet/lego/certificate"
+	"github.com/vostronet/lego/challenge"
 	"github.com/vostronet/lego/lego"
 	"github.com/vostronet/lego/log"
 	"github.com/vostronet/lego/registration"
@@ -22,44 +25,104 @@ func setup(ctx *cli.Context, accountsStorage *AccountsStorage) (*Account, *lego.
 	keyType := getKeyType(ctx)
 	privateKey := accountsStorage.GetPrivateKey(keyType)
 
+	state := NewStateStorage(ctx, accountsStorage).Load()
+
 	var account *Account
 	if accountsStorage.ExistsAccountFilePath() {
-		account = accountsStorage.LoadAccount(privateKey)
+		account = accountsStorage.LoadAccount(privateKey, state)
 	} else {
 		account = &Account{Email: accountsStorage.GetUserID(), key: privateKey}
 	}
 
-	client := newClient(ctx, account, keyType)
+	client := newClient(ctx, account, keyType, state)
 
 	return account, client
 }
 
-func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyType) *lego.Client {
+// saveState persists the state of the client, if the state cache is enabled.
+func saveState(ctx *cli.Context, accountsStorage *AccountsStorage, client *lego.Client) {
+	NewStateStorage(ctx, accountsStorage).Save(client.State())
+}
+
+func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyType, state *api.State) *lego.Client {
 	config := lego.NewConfig(acc)
 	config.CADirURL = ctx.GlobalString("server")
+	config.State = state
+
+	certsStorage := NewCertificatesStorage(ctx)
 
 	config.Certificate = lego.CertificateConfig{
-		KeyType: keyType,
-		Timeout: time.Duration(ctx.GlobalInt("cert.timeout")) * time.Second,
+		KeyType:          keyType,
+		CAACheck:         getCAACheck(ctx),
+		OrderCreatedHook: certsStorage.SaveOrder,
 	}
 	config.UserAgent = fmt.Sprintf("lego-cli/%s", ctx.App.Version)
 
+	if ctx.GlobalIsSet("cert.timeout") {
+		config.Certificate.Timeout = time.Duration(ctx.GlobalInt("cert.timeout")) * time.Second
+	}
+
+	if ctx.GlobalIsSet("validation.timeout") {
+		config.Challenge.ValidationTimeout = time.Duration(ctx.GlobalInt("validation.timeout")) * time.Second
+	}
+
+	if ctx.GlobalIsSet("validation.polling-interval") {
+		config.Challenge.ValidationPollingInterval = time.Duration(ctx.GlobalInt("validation.polling-interval")) * time.Millisecond
+	}
+
+	config.Challenge.ValidationMaxAttempts = ctx.GlobalInt("validation.max-attempts")
+	config.Challenge.ForceChallenge = ctx.GlobalBool("force-challenge")
+	config.Challenge.Preference = getChallengePreference(ctx)
+
 	if ctx.GlobalIsSet("http-timeout") {
 		config.HTTPClient.Timeout = time.Duration(ctx.GlobalInt("http-timeout")) * time.Second
 	}
 
+	config.RootCAFile = ctx.GlobalString("root-ca")
+	config.InsecureSkipVerify = ctx.GlobalBool("insecure-skip-verify")
+
 	client, err := lego.NewClient(config)
 	if err != nil {
 		log.Fatalf("Could not create client: %v", err)
 	}
 
-	if client.GetExternalAccountRequired() && !ctx.GlobalIsSet("eab") {
+	if client.GetExternalAccountRequired() && !ctx.GlobalBool("eab") {
 		log.Fatal("Server requires External Account Binding. Use --eab with --kid and --hmac.")
 	}
 
 	return client
 }
 
+// getCAACheck the mode of the CAA records pre-check.
+func getCAACheck(ctx *cli.Context) certificate.CAACheck {
+	switch strings.ToLower(ctx.GlobalString("cert.caa-check")) {
+	case "":
+		return certificate.CAACheckDisabled
+	case "warn":
+		return certificate.CAACheckWarn
+	case "enforce":
+		return certificate.CAACheckEnforce
+	default:
+		log.Fatalf("Unsupported CAA check mode: %s", ctx.GlobalString("cert.caa-check"))
+		return certificate.CAACheckDisabled
+	}
+}
+
+// getChallengePreference the order in which the challenge types are tried.
+func getChallengePreference(ctx *cli.Context) []challenge.Type {
+	var preference []challenge.Type
+	for _, value := range ctx.GlobalStringSlice("challenge.preference") {
+		switch chlgType := challenge.Type(strings.ToLower(value)); chlgType {
+		case challenge.HTTP01, challenge.TLSALPN01, challenge.DNS01:
+			preference = append(preference, chlgType)
+		default:
+			log.Fatalf("Unsupported challenge type: %s", value)
+		}
+	}
+
+	return preference
+}
+
 // getKeyType the type from which private keys should be generated
 func getKeyType(ctx *cli.Context) certcrypto.KeyType {
 	keyType := ctx.GlobalString("key-type")
@@ -74,6 +137,8 @@ func getKeyType(ctx *cli.Context) certcrypto.KeyType {
 		return certcrypto.EC256
 	case "EC384":
 		return certcrypto.EC384
+	case "ED25519":
+		return certcrypto.Ed25519
 	}
 
 	log.Fatalf("Unsupported KeyType: %s", keyType)
@@ -127,3 +192,13 @@ func readCSRFile(filename string) (*x509.CertificateRequest, error) {
 	// (if this assumption is wrong, parsing these bytes will fail)
 	return x509.ParseCertificateRequest(raw)
 }
+
+// getCSRDomain returns the main domain of a CSR (the common name, or the first SAN if the common name is empty).
+func getCSRDomain(csr *x509.CertificateRequest) string {
+	domains := certcrypto.ExtractDomainsCSR(csr)
+	if len(domains) == 0 {
+		log.Fatal("The CSR doesn't contain any domain or IP address.")
+	}
+
+	return domains[0]
+}
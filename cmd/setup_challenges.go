@@ -12,14 +12,19 @@ import (
 	"github.com/vostronet/lego/lego"
 	"github.com/vostronet/lego/log"
 	"github.com/vostronet/lego/providers/dns"
+	"github.com/vostronet/lego/providers/dns/router"
 	"github.com/vostronet/lego/providers/http/memcached"
 	"github.com/vostronet/lego/providers/http/webroot"
+	"github.com/vostronet/lego/providers/tls/webhook"
 	"github.com/urfave/cli"
 )
 
+// dnsRetryInterval the initial interval between the retries of a failed DNS provider.
+const dnsRetryInterval = 2 * time.Second
+
 func setupChallenges(ctx *cli.Context, client *lego.Client) {
-	if !ctx.GlobalBool("http") && !ctx.GlobalBool("tls") && !ctx.GlobalIsSet("dns") {
-		log.Fatal("No challenge selected. You must specify at least one challenge: `--http`, `--tls`, `--dns`.")
+	if !ctx.GlobalBool("http") && !ctx.GlobalBool("tls") && !ctx.GlobalIsSet("dns") && !ctx.GlobalIsSet("dns.provider-map") {
+		log.Fatal("No challenge selected. You must specify at least one challenge: `--http`, `--tls`, `--dns`, `--dns.provider-map`.")
 	}
 
 	if ctx.GlobalBool("http") {
@@ -36,7 +41,7 @@ func setupChallenges(ctx *cli.Context, client *lego.Client) {
 		}
 	}
 
-	if ctx.GlobalIsSet("dns") {
+	if ctx.GlobalIsSet("dns") || ctx.GlobalIsSet("dns.provider-map") {
 		setupDNS(ctx, client)
 	}
 }
@@ -66,17 +71,31 @@ func setupHTTPProvider(ctx *cli.Context) challenge.Provider {
 			log.Fatal(err)
 		}
 
-		return http01.NewProviderServer(host, port)
+		return setupHTTPProviderServer(ctx, http01.NewProviderServer(host, port))
 	case ctx.GlobalBool("http"):
-		return http01.NewProviderServer("", "")
+		return setupHTTPProviderServer(ctx, http01.NewProviderServer("", ""))
 	default:
 		log.Fatal("Invalid HTTP challenge options.")
 		return nil
 	}
 }
 
+func setupHTTPProviderServer(ctx *cli.Context, srv *http01.ProviderServer) *http01.ProviderServer {
+	srv.SetProxyHeader(ctx.GlobalString("http.proxy-header"))
+	srv.SetAcceptAnyHost(ctx.GlobalBool("http.accept-any-host"))
+
+	return srv
+}
+
 func setupTLSProvider(ctx *cli.Context) challenge.Provider {
 	switch {
+	case ctx.GlobalIsSet("tls.webhook"):
+		provider, err := webhook.NewTLSProvider(ctx.GlobalString("tls.webhook"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return provider
 	case ctx.GlobalIsSet("tls.port"):
 		iface := ctx.GlobalString("tls.port")
 		if !strings.Contains(iface, ":") {
@@ -98,21 +117,64 @@ func setupTLSProvider(ctx *cli.Context) challenge.Provider {
 }
 
 func setupDNS(ctx *cli.Context, client *lego.Client) {
-	provider, err := dns.NewDNSChallengeProviderByName(ctx.GlobalString("dns"))
+	provider, err := setupDNSProvider(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if ctx.GlobalInt("dns.retries") > 0 {
+		provider = dns01.WithRetry(provider, ctx.GlobalInt("dns.retries")+1, dnsRetryInterval)
+	}
+
+	err = client.Challenge.SetDNS01Provider(provider, setupDNSOptions(ctx)...)
 	if err != nil {
 		log.Fatal(err)
 	}
+}
 
+// setupDNSOptions creates the options of the DNS-01 challenge from the global flags.
+func setupDNSOptions(ctx *cli.Context) []dns01.ChallengeOption {
 	servers := ctx.GlobalStringSlice("dns.resolvers")
-	err = client.Challenge.SetDNS01Provider(provider,
+
+	return []dns01.ChallengeOption{
 		dns01.CondOption(len(servers) > 0,
 			dns01.AddRecursiveNameservers(dns01.ParseNameservers(ctx.GlobalStringSlice("dns.resolvers")))),
 		dns01.CondOption(ctx.GlobalBool("dns.disable-cp"),
 			dns01.DisableCompletePropagationRequirement()),
+		dns01.CondOption(ctx.GlobalBool("dns.delegate-propagation"),
+			dns01.DelegatePropagationCheck()),
+		dns01.CondOption(ctx.GlobalBool("dns.soa-timings"),
+			dns01.UseSOATimings()),
+		dns01.CondOption(ctx.GlobalBool("dns.tcp-only"),
+			dns01.UseTCPOnly()),
+		dns01.CondOption(ctx.GlobalBool("dns.follow-cname"),
+			dns01.FollowCNAME()),
 		dns01.CondOption(ctx.GlobalIsSet("dns-timeout"),
 			dns01.AddDNSTimeout(time.Duration(ctx.GlobalInt("dns-timeout"))*time.Second)),
-	)
+	}
+}
+
+// setupDNSProvider creates the DNS provider:
+// the provider map routes the domains to several providers, the "dns" provider is used for the unmatched domains.
+func setupDNSProvider(ctx *cli.Context) (challenge.Provider, error) {
+	if !ctx.GlobalIsSet("dns.provider-map") {
+		return dns.NewDNSChallengeProviderByName(ctx.GlobalString("dns"))
+	}
+
+	routes, err := router.LoadRoutes(ctx.GlobalString("dns.provider-map"), dns.NewDNSChallengeProviderByName)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
+
+	config := router.NewDefaultConfig()
+	config.Routes = routes
+
+	if ctx.GlobalIsSet("dns") {
+		config.Default, err = dns.NewDNSChallengeProviderByName(ctx.GlobalString("dns"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return router.NewDNSProviderConfig(config)
 }
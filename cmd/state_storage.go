@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/acme/api"
+	"github.com/vostronet/lego/log"
+	"github.com/urfave/cli"
+)
+
+const (
+	stateFileName = "state.json"
+
+	// stateVersion the version of the schema of the state file.
+	// A state file with another version is ignored.
+	stateVersion = 1
+
+	// stateNonceTTL the maximum age of the persisted nonces:
+	// the CAs only accept the nonces during a short period.
+	stateNonceTTL = 1 * time.Minute
+)
+
+// stateFile the content of the state file.
+type stateFile struct {
+	Version   int            `json:"version"`
+	Server    string         `json:"server"`
+	SavedAt   time.Time      `json:"savedAt"`
+	Directory acme.Directory `json:"directory"`
+	KID       string         `json:"kid,omitempty"`
+	Nonces    []string       `json:"nonces,omitempty"`
+}
+
+// StateStorage A storage for the ACME state (directory, account URL and unused nonces) shared by consecutive runs.
+//
+// stateFilePath:
+//
+//     ./.lego/accounts/localhost_14000/hubert@hubert.com/state.json
+//          │      │             │             │             └── state file
+//          │      │             │             └── userID ("email" option)
+//          │      │             └── CA server ("server" option)
+//          │      └── root accounts directory
+//          └── "path" option
+//
+type StateStorage struct {
+	server        string
+	stateFilePath string
+	ttl           time.Duration
+	now           func() time.Time
+}
+
+// NewStateStorage Creates a new StateStorage.
+// Returns nil if the state cache is disabled.
+func NewStateStorage(ctx *cli.Context, accountsStorage *AccountsStorage) *StateStorage {
+	if !ctx.GlobalBool("state-cache") {
+		return nil
+	}
+
+	return &StateStorage{
+		server:        ctx.GlobalString("server"),
+		stateFilePath: filepath.Join(accountsStorage.GetRootUserPath(), stateFileName),
+		ttl:           time.Duration(ctx.GlobalInt("state-cache.ttl")) * time.Second,
+		now:           time.Now,
+	}
+}
+
+// Load loads the persisted state.
+// Returns nil if there is no usable state (missing, stale, from another schema version or CA server).
+func (s *StateStorage) Load() *api.State {
+	if s == nil {
+		return nil
+	}
+
+	fileBytes, err := ioutil.ReadFile(s.stateFilePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warnf("Could not load the state file %s: %v", s.stateFilePath, err)
+		}
+		return nil
+	}
+
+	var state stateFile
+	err = json.Unmarshal(fileBytes, &state)
+	if err != nil {
+		log.Warnf("Could not parse the state file %s: %v", s.stateFilePath, err)
+		return nil
+	}
+
+	if state.Version != stateVersion || state.Server != s.server {
+		return nil
+	}
+
+	age := s.now().Sub(state.SavedAt)
+	if age < 0 || age > s.ttl {
+		return nil
+	}
+
+	result := &api.State{
+		Directory:    state.Directory,
+		DirectoryURL: state.Server,
+		KID:          state.KID,
+	}
+
+	if age <= stateNonceTTL {
+		result.Nonces = state.Nonces
+	}
+
+	return result
+}
+
+// Save persists the state.
+func (s *StateStorage) Save(state api.State) {
+	if s == nil {
+		return
+	}
+
+	content := stateFile{
+		Version:   stateVersion,
+		Server:    s.server,
+		SavedAt:   s.now().UTC(),
+		Directory: state.Directory,
+		KID:       state.KID,
+		Nonces:    state.Nonces,
+	}
+
+	jsonBytes, err := json.MarshalIndent(content, "", "\t")
+	if err != nil {
+		log.Warnf("Could not save the state file %s: %v", s.stateFilePath, err)
+		return
+	}
+
+	err = ioutil.WriteFile(s.stateFilePath, jsonBytes, filePerm)
+	if err != nil {
+		log.Warnf("Could not save the state file %s: %v", s.stateFilePath, err)
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/acme/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-state")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	state := api.State{
+		Directory: acme.Directory{
+			NewNonceURL:   "https://ca.example.com/nonce",
+			NewAccountURL: "https://ca.example.com/account",
+			NewOrderURL:   "https://ca.example.com/order",
+		},
+		KID:    "https://ca.example.com/account/1",
+		Nonces: []string{"nonce1", "nonce2"},
+	}
+
+	savedAt := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		server   string
+		elapsed  time.Duration
+		expected *api.State
+	}{
+		{
+			desc:    "fresh state",
+			server:  "https://ca.example.com/directory",
+			elapsed: 10 * time.Second,
+			expected: &api.State{
+				Directory:    state.Directory,
+				DirectoryURL: "https://ca.example.com/directory",
+				KID:          state.KID,
+				Nonces:       state.Nonces,
+			},
+		},
+		{
+			desc:    "stale nonces",
+			server:  "https://ca.example.com/directory",
+			elapsed: 5 * time.Minute,
+			expected: &api.State{
+				Directory:    state.Directory,
+				DirectoryURL: "https://ca.example.com/directory",
+				KID:          state.KID,
+			},
+		},
+		{
+			desc:    "stale state",
+			server:  "https://ca.example.com/directory",
+			elapsed: 11 * time.Minute,
+		},
+		{
+			desc:    "another server",
+			server:  "https://other.example.com/directory",
+			elapsed: 10 * time.Second,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			storage := &StateStorage{
+				server:        "https://ca.example.com/directory",
+				stateFilePath: filepath.Join(dir, stateFileName),
+				ttl:           10 * time.Minute,
+				now:           func() time.Time { return savedAt },
+			}
+
+			storage.Save(state)
+
+			storage.server = test.server
+			storage.now = func() time.Time { return savedAt.Add(test.elapsed) }
+
+			assert.Equal(t, test.expected, storage.Load())
+		})
+	}
+}
+
+func TestStateStorage_Load_version(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-state")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	storage := &StateStorage{
+		server:        "https://ca.example.com/directory",
+		stateFilePath: filepath.Join(dir, stateFileName),
+		ttl:           10 * time.Minute,
+		now:           time.Now,
+	}
+
+	content := `{"version":999,"server":"https://ca.example.com/directory","savedAt":"` + time.Now().UTC().Format(time.RFC3339) + `"}`
+	err = ioutil.WriteFile(storage.stateFilePath, []byte(content), filePerm)
+	require.NoError(t, err)
+
+	assert.Nil(t, storage.Load())
+}
+
+func TestStateStorage_Load_withoutNewNonceURL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-state")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	now := time.Now()
+
+	storage := &StateStorage{
+		server:        "https://ca.example.com/directory",
+		stateFilePath: filepath.Join(dir, stateFileName),
+		ttl:           10 * time.Minute,
+		now:           func() time.Time { return now },
+	}
+
+	storage.Save(api.State{
+		Directory: acme.Directory{NewAccountURL: "https://ca.example.com/account"},
+		Nonces:    []string{"nonce"},
+	})
+
+	// the persisted nonces are stale: the nonces are taken from the directory.
+	now = now.Add(5 * time.Minute)
+
+	expected := &api.State{
+		Directory:    acme.Directory{NewAccountURL: "https://ca.example.com/account"},
+		DirectoryURL: "https://ca.example.com/directory",
+	}
+	assert.Equal(t, expected, storage.Load())
+}
+
+func TestStateStorage_disabled(t *testing.T) {
+	var storage *StateStorage
+
+	storage.Save(api.State{})
+
+	assert.Nil(t, storage.Load())
+}
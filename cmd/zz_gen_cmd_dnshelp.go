@@ -17,17 +17,23 @@ func allDNSCodes() string {
 	providers := []string{
 		"manual",
 		"acme-dns",
+		"active24",
 		"alidns",
+		"arvancloud",
 		"auroradns",
 		"azure",
+		"baiducloud",
 		"bindman",
 		"bluecat",
+		"bunny",
 		"cloudflare",
 		"cloudns",
 		"cloudxns",
+		"cname",
 		"conoha",
 		"designate",
 		"digitalocean",
+		"dinahosting",
 		"dnsimple",
 		"dnsmadeeasy",
 		"dnspod",
@@ -35,44 +41,65 @@ func allDNSCodes() string {
 		"dreamhost",
 		"duckdns",
 		"dyn",
+		"dynu",
 		"easydns",
+		"epik",
 		"exec",
 		"exoscale",
 		"fastdns",
 		"gandi",
 		"gandiv5",
 		"gcloud",
+		"gcore",
 		"glesys",
 		"godaddy",
 		"hostingde",
+		"hosttech",
 		"httpreq",
+		"hurricane",
 		"iij",
+		"infomaniak",
 		"inwx",
+		"ionos",
 		"joker",
+		"leaseweb",
 		"lightsail",
 		"linode",
 		"linodev4",
+		"loopia",
+		"multi",
 		"mydnsjp",
 		"namecheap",
 		"namedotcom",
+		"namesilo",
 		"netcup",
+		"netlify",
 		"nifcloud",
+		"njalla",
 		"ns1",
 		"oraclecloud",
 		"otc",
 		"ovh",
 		"pdns",
+		"porkbun",
 		"rackspace",
 		"rfc2136",
 		"route53",
 		"sakuracloud",
+		"scaleway",
 		"selectel",
+		"simply",
+		"spaceship",
 		"stackpath",
+		"timewebcloud",
 		"transip",
 		"vegadns",
 		"versio",
+		"volcengine",
 		"vscale",
 		"vultr",
+		"webhook",
+		"websupport",
 		"zoneee",
 	}
 	sort.Strings(providers)
@@ -98,6 +125,27 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/acme-dns`)
 
+	case "active24":
+		// generated from: providers/dns/active24/active24.toml
+		fmt.Fprintln(w, `Configuration for Active24.`)
+		fmt.Fprintln(w, `Code:	'active24'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "ACTIVE24_API_KEY":	API key`)
+		fmt.Fprintln(w, `	- "ACTIVE24_SECRET":	API secret`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "ACTIVE24_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "ACTIVE24_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "ACTIVE24_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "ACTIVE24_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/active24`)
+
 	case "alidns":
 		// generated from: providers/dns/alidns/alidns.toml
 		fmt.Fprintln(w, `Configuration for Alibaba Cloud DNS.`)
@@ -107,18 +155,41 @@ func displayDNSHelp(name string) {
 
 		fmt.Fprintln(w, `Credentials:`)
 		fmt.Fprintln(w, `	- "ALICLOUD_ACCESS_KEY":	Access key ID`)
+		fmt.Fprintln(w, `	- "ALICLOUD_RAM_ROLE":	Your instance RAM role (https://www.alibabacloud.com/help/doc-detail/54579.htm), or the ARN of the RAM role to assume with the access keys`)
 		fmt.Fprintln(w, `	- "ALICLOUD_SECRET_KEY":	Access Key secret`)
 		fmt.Fprintln(w)
 
 		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "ALICLOUD_ENDPOINT":	API endpoint, overrides the endpoint resolved from the region (ex: alidns.ap-southeast-1.aliyuncs.com for the international site)`)
 		fmt.Fprintln(w, `	- "ALICLOUD_HTTP_TIMEOUT":	API request timeout`)
 		fmt.Fprintln(w, `	- "ALICLOUD_POLLING_INTERVAL":	Time between DNS propagation check`)
 		fmt.Fprintln(w, `	- "ALICLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "ALICLOUD_REGION_ID":	Region ID (default: cn-hangzhou)`)
 		fmt.Fprintln(w, `	- "ALICLOUD_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/alidns`)
 
+	case "arvancloud":
+		// generated from: providers/dns/arvancloud/arvancloud.toml
+		fmt.Fprintln(w, `Configuration for ArvanCloud.`)
+		fmt.Fprintln(w, `Code:	'arvancloud'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "ARVANCLOUD_API_KEY":	API key`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "ARVANCLOUD_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "ARVANCLOUD_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "ARVANCLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "ARVANCLOUD_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/arvancloud`)
+
 	case "auroradns":
 		// generated from: providers/dns/auroradns/auroradns.toml
 		fmt.Fprintln(w, `Configuration for Aurora DNS.`)
@@ -165,6 +236,28 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/azure`)
 
+	case "baiducloud":
+		// generated from: providers/dns/baiducloud/baiducloud.toml
+		fmt.Fprintln(w, `Configuration for Baidu Cloud.`)
+		fmt.Fprintln(w, `Code:	'baiducloud'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "BAIDUCLOUD_ACCESS_KEY_ID":	Access key`)
+		fmt.Fprintln(w, `	- "BAIDUCLOUD_SECRET_ACCESS_KEY":	Secret access key`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "BAIDUCLOUD_ENDPOINT":	API endpoint (default: https://dns.baidubce.com)`)
+		fmt.Fprintln(w, `	- "BAIDUCLOUD_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "BAIDUCLOUD_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "BAIDUCLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "BAIDUCLOUD_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/baiducloud`)
+
 	case "bindman":
 		// generated from: providers/dns/bindman/bindman.toml
 		fmt.Fprintln(w, `Configuration for Bindman.`)
@@ -208,6 +301,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/bluecat`)
 
+	case "bunny":
+		// generated from: providers/dns/bunny/bunny.toml
+		fmt.Fprintln(w, `Configuration for Bunny.`)
+		fmt.Fprintln(w, `Code:	'bunny'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "BUNNY_API_KEY":	API key (AccessKey)`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "BUNNY_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "BUNNY_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "BUNNY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "BUNNY_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/bunny`)
+
 	case "cloudflare":
 		// generated from: providers/dns/cloudflare/cloudflare.toml
 		fmt.Fprintln(w, `Configuration for Cloudflare.`)
@@ -222,8 +335,10 @@ func displayDNSHelp(name string) {
 
 		fmt.Fprintln(w, `Additional Configuration:`)
 		fmt.Fprintln(w, `	- "CLOUDFLARE_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "CLOUDFLARE_MAX_CONCURRENCY":	Maximum number of concurrent API calls (unlimited by default)`)
 		fmt.Fprintln(w, `	- "CLOUDFLARE_POLLING_INTERVAL":	Time between DNS propagation check`)
 		fmt.Fprintln(w, `	- "CLOUDFLARE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "CLOUDFLARE_REQUEST_DELAY":	Minimum delay between two API calls, in milliseconds or as a duration, e.g. 500ms (none by default)`)
 		fmt.Fprintln(w, `	- "CLOUDFLARE_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		fmt.Fprintln(w)
@@ -239,6 +354,8 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w, `Credentials:`)
 		fmt.Fprintln(w, `	- "CLOUDNS_AUTH_ID":	The API user ID`)
 		fmt.Fprintln(w, `	- "CLOUDNS_AUTH_PASSWORD":	The password for API user ID`)
+		fmt.Fprintln(w, `	- "CLOUDNS_SUB_AUTH_ID":	The API sub user ID (alternative to 'CLOUDNS_AUTH_ID')`)
+		fmt.Fprintln(w, `	- "CLOUDNS_SUB_AUTH_USER":	The API sub user name (alternative to 'CLOUDNS_AUTH_ID')`)
 		fmt.Fprintln(w)
 
 		fmt.Fprintln(w, `Additional Configuration:`)
@@ -271,6 +388,25 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/cloudxns`)
 
+	case "cname":
+		// generated from: providers/dns/cname/cname.toml
+		fmt.Fprintln(w, `Configuration for CNAME delegation.`)
+		fmt.Fprintln(w, `Code:	'cname'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "CNAME_PROVIDER":	The code of the DNS provider managing the zone of the delegation targets (e.g. 'cloudflare')`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "CNAME_POLLING_INTERVAL":	Time between DNS propagation check (the value of the delegated provider is used if it defines one)`)
+		fmt.Fprintln(w, `	- "CNAME_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation (the value of the delegated provider is used if it defines one)`)
+		fmt.Fprintln(w, `	- "CNAME_TARGET_DOMAIN":	The domain expected to contain the delegation targets, the challenge fails if a CNAME target is outside of this domain`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/cname`)
+
 	case "conoha":
 		// generated from: providers/dns/conoha/conoha.toml
 		fmt.Fprintln(w, `Configuration for ConoHa.`)
@@ -337,6 +473,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/digitalocean`)
 
+	case "dinahosting":
+		// generated from: providers/dns/dinahosting/dinahosting.toml
+		fmt.Fprintln(w, `Configuration for Dinahosting.`)
+		fmt.Fprintln(w, `Code:	'dinahosting'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "DINAHOSTING_PASSWORD":	API password`)
+		fmt.Fprintln(w, `	- "DINAHOSTING_USERNAME":	API username`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "DINAHOSTING_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "DINAHOSTING_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "DINAHOSTING_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/dinahosting`)
+
 	case "dnsimple":
 		// generated from: providers/dns/dnsimple/dnsimple.toml
 		fmt.Fprintln(w, `Configuration for DNSimple.`)
@@ -483,6 +639,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/dyn`)
 
+	case "dynu":
+		// generated from: providers/dns/dynu/dynu.toml
+		fmt.Fprintln(w, `Configuration for Dynu.`)
+		fmt.Fprintln(w, `Code:	'dynu'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "DYNU_API_KEY":	API key`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "DYNU_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "DYNU_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "DYNU_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "DYNU_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/dynu`)
+
 	case "easydns":
 		// generated from: providers/dns/easydns/easydns.toml
 		fmt.Fprintln(w, `Configuration for EasyDNS.`)
@@ -506,6 +682,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/easydns`)
 
+	case "epik":
+		// generated from: providers/dns/epik/epik.toml
+		fmt.Fprintln(w, `Configuration for Epik.`)
+		fmt.Fprintln(w, `Code:	'epik'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "EPIK_SIGNATURE":	Epik API signature (https://registrar.epik.com/account/api-settings/)`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "EPIK_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "EPIK_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "EPIK_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "EPIK_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/epik`)
+
 	case "exec":
 		// generated from: providers/dns/exec/exec.toml
 		fmt.Fprintln(w, `Configuration for External program.`)
@@ -622,6 +818,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/gcloud`)
 
+	case "gcore":
+		// generated from: providers/dns/gcore/gcore.toml
+		fmt.Fprintln(w, `Configuration for G-Core Labs.`)
+		fmt.Fprintln(w, `Code:	'gcore'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "GCORE_PERMANENT_API_TOKEN":	Permanent API token`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "GCORE_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "GCORE_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "GCORE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "GCORE_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/gcore`)
+
 	case "glesys":
 		// generated from: providers/dns/glesys/glesys.toml
 		fmt.Fprintln(w, `Configuration for Glesys.`)
@@ -657,8 +873,10 @@ func displayDNSHelp(name string) {
 
 		fmt.Fprintln(w, `Additional Configuration:`)
 		fmt.Fprintln(w, `	- "GODADDY_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "GODADDY_MAX_CONCURRENCY":	Maximum number of concurrent API calls (unlimited by default)`)
 		fmt.Fprintln(w, `	- "GODADDY_POLLING_INTERVAL":	Time between DNS propagation check`)
 		fmt.Fprintln(w, `	- "GODADDY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "GODADDY_REQUEST_DELAY":	Minimum delay between two API calls, in milliseconds or as a duration, e.g. 500ms (none by default)`)
 		fmt.Fprintln(w, `	- "GODADDY_SEQUENCE_INTERVAL":	Interval between iteration`)
 		fmt.Fprintln(w, `	- "GODADDY_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
@@ -686,6 +904,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/hostingde`)
 
+	case "hosttech":
+		// generated from: providers/dns/hosttech/hosttech.toml
+		fmt.Fprintln(w, `Configuration for Hosttech.`)
+		fmt.Fprintln(w, `Code:	'hosttech'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "HOSTTECH_API_KEY":	API token (https://www.myhosttech.eu/user/dns/api)`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "HOSTTECH_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "HOSTTECH_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "HOSTTECH_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "HOSTTECH_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/hosttech`)
+
 	case "httpreq":
 		// generated from: providers/dns/httpreq/httpreq.toml
 		fmt.Fprintln(w, `Configuration for HTTP request.`)
@@ -708,6 +946,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/httpreq`)
 
+	case "hurricane":
+		// generated from: providers/dns/hurricane/hurricane.toml
+		fmt.Fprintln(w, `Configuration for Hurricane Electric DNS.`)
+		fmt.Fprintln(w, `Code:	'hurricane'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "HURRICANE_TOKENS":	TXT record names and update keys, as comma-separated domain:key pairs`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "HURRICANE_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "HURRICANE_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "HURRICANE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "HURRICANE_SEQUENCE_INTERVAL":	Interval between iteration`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/hurricane`)
+
 	case "iij":
 		// generated from: providers/dns/iij/iij.toml
 		fmt.Fprintln(w, `Configuration for Internet Initiative Japan.`)
@@ -729,6 +987,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/iij`)
 
+	case "infomaniak":
+		// generated from: providers/dns/infomaniak/infomaniak.toml
+		fmt.Fprintln(w, `Configuration for Infomaniak.`)
+		fmt.Fprintln(w, `Code:	'infomaniak'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "INFOMANIAK_ACCESS_TOKEN":	Access token (with the DNS scope)`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "INFOMANIAK_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "INFOMANIAK_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "INFOMANIAK_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "INFOMANIAK_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 300)`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/infomaniak`)
+
 	case "inwx":
 		// generated from: providers/dns/inwx/inwx.toml
 		fmt.Fprintln(w, `Configuration for INWX.`)
@@ -750,6 +1028,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/inwx`)
 
+	case "ionos":
+		// generated from: providers/dns/ionos/ionos.toml
+		fmt.Fprintln(w, `Configuration for IONOS.`)
+		fmt.Fprintln(w, `Code:	'ionos'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "IONOS_API_KEY":	API key '<prefix>.<secret>' https://developer.hosting.ionos.com/docs/getstarted`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "IONOS_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "IONOS_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "IONOS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "IONOS_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/ionos`)
+
 	case "joker":
 		// generated from: providers/dns/joker/joker.toml
 		fmt.Fprintln(w, `Configuration for Joker.`)
@@ -770,6 +1068,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/joker`)
 
+	case "leaseweb":
+		// generated from: providers/dns/leaseweb/leaseweb.toml
+		fmt.Fprintln(w, `Configuration for Leaseweb.`)
+		fmt.Fprintln(w, `Code:	'leaseweb'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "LEASEWEB_API_KEY":	API key (https://secure.leaseweb.com/api-client-management/)`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "LEASEWEB_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "LEASEWEB_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "LEASEWEB_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "LEASEWEB_TTL":	The TTL of the TXT record used for the DNS challenge (60, 300, 1800, 3600, 14400, 28800, 43200 or 86400)`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/leaseweb`)
+
 	case "lightsail":
 		// generated from: providers/dns/lightsail/lightsail.toml
 		fmt.Fprintln(w, `Configuration for Amazon Lightsail.`)
@@ -828,6 +1146,45 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/linodev4`)
 
+	case "loopia":
+		// generated from: providers/dns/loopia/loopia.toml
+		fmt.Fprintln(w, `Configuration for Loopia.`)
+		fmt.Fprintln(w, `Code:	'loopia'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "LOOPIA_API_PASSWORD":	API password`)
+		fmt.Fprintln(w, `	- "LOOPIA_API_USER":	API username`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "LOOPIA_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "LOOPIA_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "LOOPIA_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "LOOPIA_TTL":	The TTL of the TXT record used for the DNS challenge (minimum 300)`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/loopia`)
+
+	case "multi":
+		// generated from: providers/dns/multi/multi.toml
+		fmt.Fprintln(w, `Configuration for Multiple providers.`)
+		fmt.Fprintln(w, `Code:	'multi'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "MULTI_PROVIDERS":	The codes of the DNS providers, comma separated (e.g. 'cloudflare,route53')`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "MULTI_POLLING_INTERVAL":	Time between DNS propagation check (the largest value of the providers is used)`)
+		fmt.Fprintln(w, `	- "MULTI_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation (the largest value of the providers is used)`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/multi`)
+
 	case "mydnsjp":
 		// generated from: providers/dns/mydnsjp/mydnsjp.toml
 		fmt.Fprintln(w, `Configuration for MyDNS.jp.`)
@@ -891,6 +1248,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/namedotcom`)
 
+	case "namesilo":
+		// generated from: providers/dns/namesilo/namesilo.toml
+		fmt.Fprintln(w, `Configuration for Namesilo.`)
+		fmt.Fprintln(w, `Code:	'namesilo'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "NAMESILO_API_KEY":	Namesilo API key (https://www.namesilo.com/account/api-manager)`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "NAMESILO_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "NAMESILO_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "NAMESILO_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "NAMESILO_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/namesilo`)
+
 	case "netcup":
 		// generated from: providers/dns/netcup/netcup.toml
 		fmt.Fprintln(w, `Configuration for Netcup.`)
@@ -913,6 +1290,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/netcup`)
 
+	case "netlify":
+		// generated from: providers/dns/netlify/netlify.toml
+		fmt.Fprintln(w, `Configuration for Netlify.`)
+		fmt.Fprintln(w, `Code:	'netlify'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "NETLIFY_TOKEN":	Personal access token`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "NETLIFY_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "NETLIFY_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "NETLIFY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "NETLIFY_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/netlify`)
+
 	case "nifcloud":
 		// generated from: providers/dns/nifcloud/nifcloud.toml
 		fmt.Fprintln(w, `Configuration for NIFCloud.`)
@@ -934,6 +1331,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/nifcloud`)
 
+	case "njalla":
+		// generated from: providers/dns/njalla/njalla.toml
+		fmt.Fprintln(w, `Configuration for Njalla.`)
+		fmt.Fprintln(w, `Code:	'njalla'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "NJALLA_TOKEN":	API token`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "NJALLA_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "NJALLA_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "NJALLA_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "NJALLA_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/njalla`)
+
 	case "ns1":
 		// generated from: providers/dns/ns1/ns1.toml
 		fmt.Fprintln(w, `Configuration for NS1.`)
@@ -1039,6 +1456,7 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 
 		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "PDNS_API_PREFIX":	Path prefix of the API, when the API is mounted under a sub-path by a reverse proxy (default: no prefix)`)
 		fmt.Fprintln(w, `	- "PDNS_HTTP_TIMEOUT":	API request timeout`)
 		fmt.Fprintln(w, `	- "PDNS_POLLING_INTERVAL":	Time between DNS propagation check`)
 		fmt.Fprintln(w, `	- "PDNS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
@@ -1047,6 +1465,27 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/pdns`)
 
+	case "porkbun":
+		// generated from: providers/dns/porkbun/porkbun.toml
+		fmt.Fprintln(w, `Configuration for Porkbun.`)
+		fmt.Fprintln(w, `Code:	'porkbun'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "PORKBUN_API_KEY":	API key`)
+		fmt.Fprintln(w, `	- "PORKBUN_SECRET_API_KEY":	secret API key`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "PORKBUN_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "PORKBUN_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "PORKBUN_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "PORKBUN_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 600)`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/porkbun`)
+
 	case "rackspace":
 		// generated from: providers/dns/rackspace/rackspace.toml
 		fmt.Fprintln(w, `Configuration for Rackspace.`)
@@ -1077,8 +1516,8 @@ func displayDNSHelp(name string) {
 
 		fmt.Fprintln(w, `Credentials:`)
 		fmt.Fprintln(w, `	- "RFC2136_NAMESERVER":	Network address in the form "host" or "host:port"`)
-		fmt.Fprintln(w, `	- "RFC2136_TSIG_ALGORITHM":	TSIG algorythm. See [miekg/dns#tsig.go](https://github.com/miekg/dns/blob/master/tsig.go) for supported values. To disable TSIG authentication, leave the 'RFC2136_TSIG*' variables unset.`)
-		fmt.Fprintln(w, `	- "RFC2136_TSIG_KEY":	Name of the secret key as defined in DNS server configuration. To disable TSIG authentication, leave the 'RFC2136_TSIG*' variables unset.`)
+		fmt.Fprintln(w, `	- "RFC2136_TSIG_ALGORITHM":	TSIG algorithm: 'hmac-md5' (default), 'hmac-sha1', 'hmac-sha256' or 'hmac-sha512'. See [miekg/dns#tsig.go](https://github.com/miekg/dns/blob/master/tsig.go) for supported values. To disable TSIG authentication, leave the 'RFC2136_TSIG*' variables unset.`)
+		fmt.Fprintln(w, `	- "RFC2136_TSIG_KEY":	Name of the secret key as defined in DNS server configuration (the trailing dot is optional). To disable TSIG authentication, leave the 'RFC2136_TSIG*' variables unset.`)
 		fmt.Fprintln(w, `	- "RFC2136_TSIG_SECRET":	Secret key payload. To disable TSIG authentication, leave the' RFC2136_TSIG*' variables unset.`)
 		fmt.Fprintln(w)
 
@@ -1136,6 +1575,27 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/sakuracloud`)
 
+	case "scaleway":
+		// generated from: providers/dns/scaleway/scaleway.toml
+		fmt.Fprintln(w, `Configuration for Scaleway.`)
+		fmt.Fprintln(w, `Code:	'scaleway'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "SCALEWAY_API_TOKEN":	API token`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "SCALEWAY_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "SCALEWAY_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "SCALEWAY_PROJECT_ID":	Project to use (optional)`)
+		fmt.Fprintln(w, `	- "SCALEWAY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "SCALEWAY_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/scaleway`)
+
 	case "selectel":
 		// generated from: providers/dns/selectel/selectel.toml
 		fmt.Fprintln(w, `Configuration for Selectel.`)
@@ -1157,6 +1617,48 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/selectel`)
 
+	case "simply":
+		// generated from: providers/dns/simply/simply.toml
+		fmt.Fprintln(w, `Configuration for Simply.com.`)
+		fmt.Fprintln(w, `Code:	'simply'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "SIMPLY_ACCOUNT_NAME":	Account name`)
+		fmt.Fprintln(w, `	- "SIMPLY_API_KEY":	API key`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "SIMPLY_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "SIMPLY_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "SIMPLY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "SIMPLY_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/simply`)
+
+	case "spaceship":
+		// generated from: providers/dns/spaceship/spaceship.toml
+		fmt.Fprintln(w, `Configuration for Spaceship.`)
+		fmt.Fprintln(w, `Code:	'spaceship'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "SPACESHIP_API_KEY":	API key`)
+		fmt.Fprintln(w, `	- "SPACESHIP_API_SECRET":	API secret`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "SPACESHIP_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "SPACESHIP_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "SPACESHIP_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "SPACESHIP_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/spaceship`)
+
 	case "stackpath":
 		// generated from: providers/dns/stackpath/stackpath.toml
 		fmt.Fprintln(w, `Configuration for Stackpath.`)
@@ -1178,6 +1680,26 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/stackpath`)
 
+	case "timewebcloud":
+		// generated from: providers/dns/timewebcloud/timewebcloud.toml
+		fmt.Fprintln(w, `Configuration for Timeweb Cloud.`)
+		fmt.Fprintln(w, `Code:	'timewebcloud'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "TIMEWEB_ACCESS_TOKEN":	Access token (https://timeweb.cloud/my/api-keys)`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "TIMEWEB_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "TIMEWEB_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "TIMEWEB_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "TIMEWEB_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/timewebcloud`)
+
 	case "transip":
 		// generated from: providers/dns/transip/transip.toml
 		fmt.Fprintln(w, `Configuration for TransIP.`)
@@ -1242,6 +1764,29 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/versio`)
 
+	case "volcengine":
+		// generated from: providers/dns/volcengine/volcengine.toml
+		fmt.Fprintln(w, `Configuration for Volcano Engine/火山引擎.`)
+		fmt.Fprintln(w, `Code:	'volcengine'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "VOLC_ACCESSKEY":	Access Key ID (AK)`)
+		fmt.Fprintln(w, `	- "VOLC_SECRETKEY":	Secret Access Key (SK)`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "VOLC_HOST":	API host (default: https://open.volcengineapi.com)`)
+		fmt.Fprintln(w, `	- "VOLC_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "VOLC_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "VOLC_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "VOLC_REGION":	Region (default: cn-north-1)`)
+		fmt.Fprintln(w, `	- "VOLC_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/volcengine`)
+
 	case "vscale":
 		// generated from: providers/dns/vscale/vscale.toml
 		fmt.Fprintln(w, `Configuration for Vscale.`)
@@ -1283,6 +1828,47 @@ func displayDNSHelp(name string) {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/vultr`)
 
+	case "webhook":
+		// generated from: providers/dns/webhook/webhook.toml
+		fmt.Fprintln(w, `Configuration for Webhook (HTTP request templates).`)
+		fmt.Fprintln(w, `Code:	'webhook'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "WEBHOOK_SPEC":	The spec of the requests (JSON)`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "WEBHOOK_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "WEBHOOK_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "WEBHOOK_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "WEBHOOK_SPEC_FILE":	The path of a file containing the spec of the requests (JSON)`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/webhook`)
+
+	case "websupport":
+		// generated from: providers/dns/websupport/websupport.toml
+		fmt.Fprintln(w, `Configuration for Websupport.`)
+		fmt.Fprintln(w, `Code:	'websupport'`)
+		fmt.Fprintln(w, `Since:	'v2.7.0'`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Credentials:`)
+		fmt.Fprintln(w, `	- "WEBSUPPORT_API_KEY":	API key`)
+		fmt.Fprintln(w, `	- "WEBSUPPORT_SECRET":	API secret`)
+		fmt.Fprintln(w)
+
+		fmt.Fprintln(w, `Additional Configuration:`)
+		fmt.Fprintln(w, `	- "WEBSUPPORT_HTTP_TIMEOUT":	API request timeout`)
+		fmt.Fprintln(w, `	- "WEBSUPPORT_POLLING_INTERVAL":	Time between DNS propagation check`)
+		fmt.Fprintln(w, `	- "WEBSUPPORT_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		fmt.Fprintln(w, `	- "WEBSUPPORT_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `More information: https://go-acme.github.io/lego/dns/websupport`)
+
 	case "zoneee":
 		// generated from: providers/dns/zoneee/zoneee.toml
 		fmt.Fprintln(w, `Configuration for Zone.ee.`)
@@ -1,12 +1,15 @@
 package lego
 
 import (
+	"crypto"
 	"errors"
 	"net/url"
 
+	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/acme/api"
 	"github.com/vostronet/lego/certificate"
 	"github.com/vostronet/lego/challenge/resolver"
+	"github.com/vostronet/lego/log"
 	"github.com/vostronet/lego/registration"
 )
 
@@ -35,6 +38,30 @@ func NewClient(config *Config) (*Client, error) {
 		return nil, errors.New("the HTTP client cannot be nil")
 	}
 
+	if config.RootCAFile != "" {
+		httpClient, err := rootCAHTTPClient(config.HTTPClient, config.RootCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg := *config
+		cfg.HTTPClient = httpClient
+		config = &cfg
+	}
+
+	if config.InsecureSkipVerify {
+		log.Warnf("acme: the TLS verification of the ACME server %s is DISABLED: use it only with a trusted internal CA", config.CADirURL)
+
+		httpClient, err := insecureHTTPClient(config.HTTPClient)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg := *config
+		cfg.HTTPClient = httpClient
+		config = &cfg
+	}
+
 	privateKey := config.User.GetPrivateKey()
 	if privateKey == nil {
 		return nil, errors.New("private key was nil")
@@ -45,15 +72,35 @@ func NewClient(config *Config) (*Client, error) {
 		kid = reg.URI
 	}
 
-	core, err := api.New(config.HTTPClient, config.UserAgent, config.CADirURL, kid, privateKey)
+	core, err := newCore(config, kid, privateKey)
 	if err != nil {
 		return nil, err
 	}
 
+	certConfig, chlgConfig := applyCADefaults(config)
+
 	solversManager := resolver.NewSolversManager(core)
+	solversManager.SetValidationOptions(resolver.ValidationOptions{
+		Timeout:         chlgConfig.ValidationTimeout,
+		PollingInterval: chlgConfig.ValidationPollingInterval,
+		MaxAttempts:     chlgConfig.ValidationMaxAttempts,
+	})
+	solversManager.SetChallengePreference(chlgConfig.Preference...)
 
 	prober := resolver.NewProber(solversManager)
-	certifier := certificate.NewCertifier(core, prober, certificate.CertifierOptions{KeyType: config.Certificate.KeyType, Timeout: config.Certificate.Timeout})
+	prober.SetAuthorizationRecheck(chlgConfig.RecheckAuthorizations)
+	prober.SetForceChallenge(chlgConfig.ForceChallenge)
+	prober.SetConcurrency(chlgConfig.Concurrency)
+	prober.SetMetrics(core.Metrics())
+	certifier := certificate.NewCertifier(core, prober, certificate.CertifierOptions{
+		KeyType:          certConfig.KeyType,
+		Timeout:          certConfig.Timeout,
+		ObtainedHook:     certConfig.ObtainedHook,
+		OrderCreatedHook: certConfig.OrderCreatedHook,
+		OrderExtensions:  certConfig.OrderExtensions,
+		CAACheck:         certConfig.CAACheck,
+		Metrics:          core.Metrics(),
+	})
 
 	return &Client{
 		Certificate:  certifier,
@@ -63,6 +110,29 @@ func NewClient(config *Config) (*Client, error) {
 	}, nil
 }
 
+// State returns the current state of the client (directory, account URL and unused nonces).
+// It can be used to create a new client without fetching the directory again (see Config.State).
+func (c *Client) State() api.State {
+	return c.core.State()
+}
+
+// JWSAlgorithm returns the algorithm of the signatures of the requests sent to the ACME server.
+func (c *Client) JWSAlgorithm() string {
+	return c.core.JWSAlgorithm()
+}
+
+// GetMeta returns the metadata of the Directory (terms of service, website, CAA identities, EAB requirement).
+func (c *Client) GetMeta() acme.Meta {
+	return c.core.GetMeta()
+}
+
+// RateLimit returns the quota advertised by the rate limit headers of the last response of the CA which contained them
+// (nil if the CA doesn't send rate limit headers).
+// A rate limited request returns an *acme.RateLimitedError with the time from which it can be retried.
+func (c *Client) RateLimit() *acme.RateLimit {
+	return c.core.RateLimit()
+}
+
 // GetToSURL returns the current ToS URL from the Directory
 func (c *Client) GetToSURL() string {
 	return c.core.GetDirectory().Meta.TermsOfService
@@ -72,3 +142,32 @@ func (c *Client) GetToSURL() string {
 func (c *Client) GetExternalAccountRequired() bool {
 	return c.core.GetDirectory().Meta.ExternalAccountRequired
 }
+
+func newCore(config *Config, kid string, privateKey crypto.PrivateKey) (*api.Core, error) {
+	opts := []api.Option{api.WithHeaders(config.Headers), api.WithMetrics(config.Metrics)}
+	if config.NonceStore != nil {
+		opts = append(opts, api.WithNonceStore(config.NonceStore))
+	}
+
+	if config.Certificate.Accept != "" {
+		opts = append(opts, api.WithCertificateAccept(config.Certificate.Accept))
+	}
+
+	if config.JWSAlgorithm != "" {
+		opts = append(opts, api.WithJWSAlgorithm(config.JWSAlgorithm))
+	}
+
+	if config.noRetry {
+		opts = append(opts, api.WithRetryTimeout(0))
+	}
+
+	if config.State == nil {
+		return api.New(config.HTTPClient, config.UserAgent, config.CADirURL, kid, privateKey, opts...)
+	}
+
+	// the account URL is the URI of the current registration: the URL of the state may belong to another account.
+	state := *config.State
+	state.KID = kid
+
+	return api.NewWithState(config.HTTPClient, config.UserAgent, state, privateKey, opts...)
+}
@@ -10,7 +10,11 @@ import (
 	"os"
 	"time"
 
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/acme/api"
 	"github.com/vostronet/lego/certcrypto"
+	"github.com/vostronet/lego/certificate"
+	"github.com/vostronet/lego/challenge"
 	"github.com/vostronet/lego/registration"
 )
 
@@ -32,14 +36,53 @@ const (
 
 	// LEDirectoryStaging URL to the Let's Encrypt staging
 	LEDirectoryStaging = "https://acme-staging-v02.api.letsencrypt.org/directory"
+
+	// BuypassDirectoryProduction URL to the Buypass Go SSL production
+	BuypassDirectoryProduction = "https://api.buypass.com/acme/directory"
+
+	// BuypassDirectoryStaging URL to the Buypass Go SSL staging
+	BuypassDirectoryStaging = "https://api.test4.buypass.no/acme/directory"
+)
+
+const (
+	// defaultCertificateTimeout the default maximum duration to wait for the certificate issuance.
+	defaultCertificateTimeout = 30 * time.Second
+
+	// buypassCertificateTimeout Buypass can take several minutes to issue a certificate.
+	buypassCertificateTimeout = 3 * time.Minute
+
+	// buypassValidationTimeout Buypass is slower than Let's Encrypt to validate the authorizations.
+	buypassValidationTimeout = 10 * time.Minute
 )
 
 type Config struct {
-	CADirURL    string
-	User        registration.User
-	UserAgent   string
+	CADirURL  string
+	User      registration.User
+	UserAgent string
+	// Headers are static headers added to all the requests sent to the ACME server (e.g. a request ID for tracing).
+	Headers     http.Header
 	HTTPClient  *http.Client
 	Certificate CertificateConfig
+	Challenge   ChallengeConfig
+	// State is an optional state exported by a previous client (see Client.State):
+	// the directory is not fetched and the unused nonces are reused.
+	State *api.State
+	// Metrics receives the events of the client (requests, nonce errors, challenges and certificates), optional.
+	Metrics acme.Metrics
+	// NonceStore is an optional external store of the nonces (e.g. a cache shared between short-lived processes).
+	NonceStore acme.NonceStore
+	// InsecureSkipVerify disables the verification of the TLS certificate of the ACME server (e.g. an internal CA).
+	// Never use it with a public CA: the requests to the ACME server can be intercepted.
+	InsecureSkipVerify bool
+	// JWSAlgorithm forces the algorithm of the signatures of the requests (e.g. "PS256" for an RSA key), optional.
+	// It must be compatible with the account key: by default, it's derived from the type of the key (e.g. "RS256" for an RSA key).
+	JWSAlgorithm string
+	// RootCAFile is the path of a PEM file of root CA certificates trusted for the ACME server (e.g. a private PKI),
+	// in addition to the system-wide trusted roots.
+	RootCAFile string
+
+	// noRetry disables the retries of the directory and nonce requests (e.g. a CA followed by another CA in a FailoverClient).
+	noRetry bool
 }
 
 func NewConfig(user registration.User) *Config {
@@ -49,14 +92,173 @@ func NewConfig(user registration.User) *Config {
 		HTTPClient: createDefaultHTTPClient(),
 		Certificate: CertificateConfig{
 			KeyType: certcrypto.RSA2048,
-			Timeout: 30 * time.Second,
 		},
 	}
 }
 
 type CertificateConfig struct {
 	KeyType certcrypto.KeyType
+	// Timeout the maximum duration to wait for the certificate issuance.
+	// If zero, the duration depends on the CA (30 seconds by default).
 	Timeout time.Duration
+	// ObtainedHook is called with every certificate successfully obtained or renewed.
+	ObtainedHook certificate.ObtainedHook
+	// OrderCreatedHook is called with the URL of every new order and the private key of the certificate,
+	// to resume the order if the process is interrupted before the issuance (see certificate.Certifier.Resume).
+	OrderCreatedHook certificate.OrderCreatedHook
+	// OrderExtensions vendor-specific fields added to the newOrder requests.
+	// Advanced usage: the public CAs do not support them.
+	OrderExtensions map[string]interface{}
+	// CAACheck checks the CAA records of the domains before ordering a certificate (disabled by default).
+	// The CA is identified by the CAA identities of its directory metadata.
+	CAACheck certificate.CAACheck
+	// Accept the media type requested for the certificate downloads (e.g. api.MediaTypePKCS7), optional.
+	// The certificates are always returned in PEM.
+	Accept string
+}
+
+type ChallengeConfig struct {
+	// ValidationTimeout the maximum duration to wait for the CA to validate an authorization.
+	// If zero, the duration is inferred from the Retry-After header returned by the CA.
+	ValidationTimeout time.Duration
+	// ValidationPollingInterval the constant interval between two checks of an authorization status.
+	// If zero, the interval is inferred from the Retry-After header returned by the CA and grows exponentially.
+	ValidationPollingInterval time.Duration
+	// ValidationMaxAttempts the maximum number of checks of an authorization status.
+	// If zero, the number of checks is only limited by the validation timeout.
+	ValidationMaxAttempts int
+	// RecheckAuthorizations fetches the status of an authorization just before presenting its challenge:
+	// the challenge is skipped if the authorization has been validated in the meantime (e.g. by a concurrent order).
+	RecheckAuthorizations bool
+	// ForceChallenge presents the challenges even if the authorizations are already valid (e.g. to test a solver).
+	// The CA doesn't validate again a valid authorization: it only exercises the solvers.
+	ForceChallenge bool
+	// Concurrency the maximum number of DNS-01 challenges presented and validated at the same time
+	// (e.g. to speed up a certificate with many identifiers): the DNS provider must be safe for concurrent use.
+	// The challenges are solved one at a time by default.
+	Concurrency int
+	// Preference the order in which the challenge types offered for an authorization are tried (e.g. DNS-01 before HTTP-01),
+	// only the types with a configured provider are used.
+	// The types not listed come after, in the default order (TLS-ALPN-01, HTTP-01, DNS-01).
+	Preference []challenge.Type
+}
+
+// applyCADefaults returns the certificate and challenge configurations adjusted for the CA,
+// only the values left to their defaults are modified.
+func applyCADefaults(config *Config) (CertificateConfig, ChallengeConfig) {
+	certConfig, chlgConfig := config.Certificate, config.Challenge
+
+	if isBuypass(config.CADirURL) {
+		if certConfig.Timeout <= 0 {
+			certConfig.Timeout = buypassCertificateTimeout
+		}
+
+		if chlgConfig.ValidationTimeout <= 0 {
+			chlgConfig.ValidationTimeout = buypassValidationTimeout
+		}
+	}
+
+	if certConfig.Timeout <= 0 {
+		certConfig.Timeout = defaultCertificateTimeout
+	}
+
+	return certConfig, chlgConfig
+}
+
+func isBuypass(caDirURL string) bool {
+	return caDirURL == BuypassDirectoryProduction || caDirURL == BuypassDirectoryStaging
+}
+
+// insecureHTTPClient returns a copy of the HTTP client that doesn't verify the TLS certificates.
+func insecureHTTPClient(client *http.Client) (*http.Client, error) {
+	return withTLSConfig(client, func(tlsConfig *tls.Config) {
+		tlsConfig.InsecureSkipVerify = true
+	})
+}
+
+// rootCAHTTPClient returns a copy of the HTTP client that trusts the PEM certificates of the file,
+// in addition to the system-wide trusted roots and the roots of LEGO_CA_CERTIFICATES.
+func rootCAHTTPClient(client *http.Client, rootCAFile string) (*http.Client, error) {
+	data, err := ioutil.ReadFile(rootCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the root CA file: %v", err)
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil || certPool == nil {
+		certPool = x509.NewCertPool()
+	}
+
+	if customCACertsPath := os.Getenv(caCertificatesEnvVar); customCACertsPath != "" {
+		customCAs, errR := ioutil.ReadFile(customCACertsPath)
+		if errR != nil {
+			return nil, fmt.Errorf("error reading %s=%q: %v", caCertificatesEnvVar, customCACertsPath, errR)
+		}
+
+		certPool.AppendCertsFromPEM(customCAs)
+	}
+
+	if ok := certPool.AppendCertsFromPEM(data); !ok {
+		return nil, fmt.Errorf("no PEM certificate found in the root CA file %s", rootCAFile)
+	}
+
+	return withTLSConfig(client, func(tlsConfig *tls.Config) {
+		tlsConfig.RootCAs = certPool
+	})
+}
+
+// withTLSConfig returns a copy of the HTTP client with a copy of its transport (proxy, timeouts, ...)
+// whose TLS configuration is modified by the function.
+func withTLSConfig(client *http.Client, update func(tlsConfig *tls.Config)) (*http.Client, error) {
+	var transport *http.Transport
+	switch tr := client.Transport.(type) {
+	case nil:
+		transport = copyTransport(http.DefaultTransport.(*http.Transport))
+	case *http.Transport:
+		transport = copyTransport(tr)
+	default:
+		return nil, fmt.Errorf("the TLS configuration cannot be changed with a transport of type %T", client.Transport)
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+
+	update(transport.TLSClientConfig)
+
+	newClient := *client
+	newClient.Transport = transport
+
+	return &newClient, nil
+}
+
+// copyTransport returns a copy of the configuration of the transport (without its connections),
+// the TLS configuration is also copied.
+func copyTransport(tr *http.Transport) *http.Transport {
+	transport := &http.Transport{
+		Proxy:                  tr.Proxy,
+		DialContext:            tr.DialContext,
+		Dial:                   tr.Dial,
+		DialTLS:                tr.DialTLS,
+		TLSHandshakeTimeout:    tr.TLSHandshakeTimeout,
+		DisableKeepAlives:      tr.DisableKeepAlives,
+		DisableCompression:     tr.DisableCompression,
+		MaxIdleConns:           tr.MaxIdleConns,
+		MaxIdleConnsPerHost:    tr.MaxIdleConnsPerHost,
+		MaxConnsPerHost:        tr.MaxConnsPerHost,
+		IdleConnTimeout:        tr.IdleConnTimeout,
+		ResponseHeaderTimeout:  tr.ResponseHeaderTimeout,
+		ExpectContinueTimeout:  tr.ExpectContinueTimeout,
+		TLSNextProto:           tr.TLSNextProto,
+		ProxyConnectHeader:     tr.ProxyConnectHeader,
+		MaxResponseHeaderBytes: tr.MaxResponseHeaderBytes,
+	}
+
+	if tr.TLSClientConfig != nil {
+		transport.TLSClientConfig = tr.TLSClientConfig.Clone()
+	}
+
+	return transport
 }
 
 // createDefaultHTTPClient Creates an HTTP client with a reasonable timeout value
@@ -4,8 +4,18 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/acme/api"
+	"github.com/vostronet/lego/certcrypto"
 	"github.com/vostronet/lego/platform/tester"
 	"github.com/vostronet/lego/registration"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +45,213 @@ func TestNewClient(t *testing.T) {
 	assert.NotNil(t, client)
 }
 
+func TestNewClient_stateKID(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	state := &api.State{
+		Directory: acme.Directory{
+			NewNonceURL:   "https://ca.example.com/nonce",
+			NewAccountURL: "https://ca.example.com/account",
+			NewOrderURL:   "https://ca.example.com/newOrder",
+		},
+		KID: "https://ca.example.com/account/1",
+	}
+
+	testCases := []struct {
+		desc     string
+		regres   *registration.Resource
+		expected string
+	}{
+		{
+			desc:     "registration of the user",
+			regres:   &registration.Resource{URI: "https://ca.example.com/account/2"},
+			expected: "https://ca.example.com/account/2",
+		},
+		{
+			desc: "no registration",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			config := NewConfig(mockUser{email: "test@test.com", regres: test.regres, privatekey: key})
+			config.State = state
+
+			client, err := NewClient(config)
+			require.NoError(t, err)
+
+			// the account URL of the state is never reused: it may belong to another account.
+			assert.Equal(t, test.expected, client.State().KID)
+		})
+	}
+}
+
+func Test_insecureHTTPClient(t *testing.T) {
+	client := createDefaultHTTPClient()
+
+	insecure, err := insecureHTTPClient(client)
+	require.NoError(t, err)
+
+	assert.True(t, insecure.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+	assert.False(t, client.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+	assert.Equal(t, client.Timeout, insecure.Timeout)
+	assert.Equal(t, client.Transport.(*http.Transport).TLSHandshakeTimeout, insecure.Transport.(*http.Transport).TLSHandshakeTimeout)
+}
+
+func Test_insecureHTTPClient_unsupportedTransport(t *testing.T) {
+	client := &http.Client{Transport: roundTripperFunc(nil)}
+
+	_, err := insecureHTTPClient(client)
+	require.EqualError(t, err, "the TLS configuration cannot be changed with a transport of type lego.roundTripperFunc")
+}
+
+func Test_rootCAHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	rootCAFile, tearDown := writeRootCAFile(t, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	defer tearDown()
+
+	client := createDefaultHTTPClient()
+
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+
+	client, err = rootCAHTTPClient(client, rootCAFile)
+	require.NoError(t, err)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func Test_rootCAHTTPClient_caCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	rootCA, err := certcrypto.GeneratePemCert(privateKey, "root.example.com", nil)
+	require.NoError(t, err)
+
+	rootCAFile, tearDown := writeRootCAFile(t, rootCA)
+	defer tearDown()
+
+	// the roots of LEGO_CA_CERTIFICATES are still trusted.
+	caCertificatesFile, tearDownCA := writeRootCAFile(t, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	defer tearDownCA()
+
+	defer os.Unsetenv(caCertificatesEnvVar)
+	require.NoError(t, os.Setenv(caCertificatesEnvVar, caCertificatesFile))
+
+	client := createDefaultHTTPClient()
+
+	client, err = rootCAHTTPClient(client, rootCAFile)
+	require.NoError(t, err)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func Test_rootCAHTTPClient_invalidFile(t *testing.T) {
+	rootCAFile, tearDown := writeRootCAFile(t, []byte("not a certificate"))
+	defer tearDown()
+
+	_, err := rootCAHTTPClient(createDefaultHTTPClient(), rootCAFile)
+	require.EqualError(t, err, "no PEM certificate found in the root CA file "+rootCAFile)
+}
+
+func writeRootCAFile(t *testing.T, content []byte) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "lego-root-ca")
+	require.NoError(t, err)
+
+	filename := filepath.Join(dir, "root.pem")
+	err = ioutil.WriteFile(filename, content, 0600)
+	require.NoError(t, err)
+
+	return filename, func() { _ = os.RemoveAll(dir) }
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_applyCADefaults(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		caDirURL           string
+		certTimeout        time.Duration
+		validationTimeout  time.Duration
+		expectedCert       time.Duration
+		expectedValidation time.Duration
+	}{
+		{
+			desc:         "Let's Encrypt",
+			caDirURL:     LEDirectoryProduction,
+			expectedCert: defaultCertificateTimeout,
+		},
+		{
+			desc:         "Let's Encrypt with custom values",
+			caDirURL:     LEDirectoryProduction,
+			certTimeout:  time.Minute,
+			expectedCert: time.Minute,
+		},
+		{
+			desc:               "Buypass with defaults",
+			caDirURL:           BuypassDirectoryProduction,
+			expectedCert:       buypassCertificateTimeout,
+			expectedValidation: buypassValidationTimeout,
+		},
+		{
+			desc:               "Buypass with the default certificate timeout of the other CAs",
+			caDirURL:           BuypassDirectoryProduction,
+			certTimeout:        defaultCertificateTimeout,
+			expectedCert:       defaultCertificateTimeout,
+			expectedValidation: buypassValidationTimeout,
+		},
+		{
+			desc:               "Buypass with custom values",
+			caDirURL:           BuypassDirectoryStaging,
+			certTimeout:        10 * time.Second,
+			validationTimeout:  time.Minute,
+			expectedCert:       10 * time.Second,
+			expectedValidation: time.Minute,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			config := &Config{
+				CADirURL:    test.caDirURL,
+				Certificate: CertificateConfig{Timeout: test.certTimeout},
+				Challenge:   ChallengeConfig{ValidationTimeout: test.validationTimeout},
+			}
+
+			certConfig, chlgConfig := applyCADefaults(config)
+
+			assert.Equal(t, test.expectedCert, certConfig.Timeout)
+			assert.Equal(t, test.expectedValidation, chlgConfig.ValidationTimeout)
+		})
+	}
+}
+
 type mockUser struct {
 	email      string
 	regres     *registration.Resource
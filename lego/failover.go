@@ -0,0 +1,149 @@
+package lego
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/certificate"
+	"github.com/vostronet/lego/log"
+)
+
+// FailoverClient obtains the certificates from several CAs:
+// the CAs are tried in order, the next CA is used when the directory or the order of the previous one fails
+// (e.g. during an outage of the CA).
+// The directory and nonce requests are not retried on the network and server errors, except for the last CA.
+// The failures of the authorizations and of the validations (e.g. invalid DNS credentials) are returned immediately:
+// the other CAs would fail the same way, and would count the failed validations in their rate limits.
+type FailoverClient struct {
+	configs []*Config
+	setup   func(client *Client) error
+	clients []*Client
+
+	obtain func(client *Client, request certificate.ObtainRequest) (*certificate.Resource, error)
+}
+
+// NewFailoverClient creates a client for an ordered list of configurations, each with its own directory URL and account.
+// The clients are created on the first use, so an unavailable CA doesn't prevent the creation of the FailoverClient.
+// The setup function, if any, is called once for each created client (e.g. to set the challenge providers and to register the account).
+func NewFailoverClient(configs []*Config, setup func(client *Client) error) (*FailoverClient, error) {
+	if len(configs) == 0 {
+		return nil, errors.New("at least one configuration must be provided")
+	}
+
+	for i, config := range configs {
+		if config == nil {
+			return nil, fmt.Errorf("the configuration #%d is nil", i)
+		}
+	}
+
+	return &FailoverClient{
+		configs: configs,
+		setup:   setup,
+		clients: make([]*Client, len(configs)),
+		obtain: func(client *Client, request certificate.ObtainRequest) (*certificate.Resource, error) {
+			return client.Certificate.Obtain(request)
+		},
+	}, nil
+}
+
+// Obtain tries to obtain the certificate from each CA in order,
+// and returns the certificate with the directory URL of the CA which issued it.
+// A certificate returned with an error (e.g. of the obtained hook) is returned as is, the next CAs are not tried.
+func (f *FailoverClient) Obtain(request certificate.ObtainRequest) (*certificate.Resource, string, error) {
+	var errs []string
+
+	for i, config := range f.configs {
+		client, err := f.client(i)
+		if err == nil {
+			var certRes *certificate.Resource
+			certRes, err = f.obtain(client, request)
+			if err == nil || certRes != nil {
+				// the certificate is issued: an error after the issuance (e.g. of the obtained hook) is not a failure of the CA.
+				return certRes, config.CADirURL, err
+			}
+
+			if !isCAFailure(err) {
+				return nil, "", fmt.Errorf("%s: %v", config.CADirURL, err)
+			}
+		}
+
+		log.Warnf("acme: Unable to obtain the certificate from %s: %v", config.CADirURL, err)
+
+		errs = append(errs, fmt.Sprintf("%s: %v", config.CADirURL, err))
+	}
+
+	return nil, "", fmt.Errorf("unable to obtain the certificate from all the CAs:\n%s", strings.Join(errs, "\n"))
+}
+
+// client returns the client of the CA at the index, the client is created on the first call.
+func (f *FailoverClient) client(index int) (*Client, error) {
+	if f.clients[index] != nil {
+		return f.clients[index], nil
+	}
+
+	config := f.configs[index]
+	if index < len(f.configs)-1 {
+		// the next CA is tried instead of retrying the unavailable directory or nonce requests.
+		cfg := *config
+		cfg.noRetry = true
+		config = &cfg
+	}
+
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.setup != nil {
+		err = f.setup(client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f.clients[index] = client
+
+	return client, nil
+}
+
+// domainErrors is implemented by the errors of the authorizations, of the validations and of the finalization of an order.
+type domainErrors interface {
+	DomainErrors() map[string]error
+}
+
+// isCAFailure returns true if an error of Obtain is a failure of the CA:
+// an error of the nonce or of the creation of the order, or transport and server errors during the authorizations.
+func isCAFailure(err error) bool {
+	switch e := err.(type) {
+	case domainErrors:
+		for _, domainErr := range e.DomainErrors() {
+			if !isServerError(domainErr) {
+				return false
+			}
+		}
+
+		return true
+	case *url.Error, *acme.ProblemDetails, *acme.NonceError, *acme.RateLimitedError:
+		// the errors of the requests before the authorizations (i.e. the creation of the order).
+		return true
+	default:
+		// the nonce errors are only available as messages (e.g. the newNonce URL is unavailable).
+		return strings.Contains(err.Error(), "failed to get nonce") || strings.Contains(err.Error(), "no nonce available")
+	}
+}
+
+// isServerError returns true for the transport errors and the 5xx responses.
+func isServerError(err error) bool {
+	switch e := err.(type) {
+	case *url.Error:
+		return true
+	case *acme.ProblemDetails:
+		return e.HTTPStatus >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
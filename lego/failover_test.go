@@ -0,0 +1,193 @@
+package lego
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/acme"
+	"github.com/vostronet/lego/certificate"
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/vostronet/lego/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFailoverClient_noConfig(t *testing.T) {
+	_, err := NewFailoverClient(nil, nil)
+	require.Error(t, err)
+}
+
+// domainErrorsMock mimics the errors of the authorizations and of the validations.
+type domainErrorsMock map[string]error
+
+func (e domainErrorsMock) Error() string                  { return "domain errors" }
+func (e domainErrorsMock) DomainErrors() map[string]error { return e }
+
+func TestFailoverClient_Obtain(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		primaryDown   bool
+		failingCAs    []int
+		obtainErr     error
+		withCert      bool
+		expectedCA    int
+		expectedSetup int
+		expectedErr   bool
+	}{
+		{
+			desc:       "primary CA",
+			expectedCA: 0,
+		},
+		{
+			desc:        "primary CA directory unavailable",
+			primaryDown: true,
+			expectedCA:  1,
+		},
+		{
+			desc:       "primary CA order failure",
+			failingCAs: []int{0},
+			expectedCA: 1,
+		},
+		{
+			desc:       "primary CA order rate limited",
+			failingCAs: []int{0},
+			obtainErr:  &acme.RateLimitedError{ProblemDetails: &acme.ProblemDetails{HTTPStatus: http.StatusTooManyRequests, Type: "urn:ietf:params:acme:error:rateLimited"}},
+			expectedCA: 1,
+		},
+		{
+			desc:       "primary CA nonce failure",
+			failingCAs: []int{0},
+			obtainErr:  errors.New("failed to post JWS message -> failed to sign content -> failed to get nonce from HTTP HEAD -> connection refused"),
+			expectedCA: 1,
+		},
+		{
+			desc:       "primary CA server error during the authorizations",
+			failingCAs: []int{0},
+			obtainErr:  domainErrorsMock{"example.com": &acme.ProblemDetails{HTTPStatus: http.StatusServiceUnavailable}},
+			expectedCA: 1,
+		},
+		{
+			desc:          "validation failure",
+			failingCAs:    []int{0},
+			obtainErr:     domainErrorsMock{"example.com": &acme.ProblemDetails{HTTPStatus: http.StatusForbidden, Type: "urn:ietf:params:acme:error:unauthorized"}},
+			expectedSetup: 1,
+			expectedErr:   true,
+		},
+		{
+			desc:          "obtained hook failure",
+			failingCAs:    []int{0},
+			obtainErr:     errors.New("[example.com] acme: error while running the obtained hook: exit status 1"),
+			withCert:      true,
+			expectedSetup: 1,
+			expectedErr:   true,
+		},
+		{
+			desc:          "not a CA failure",
+			failingCAs:    []int{0},
+			obtainErr:     errors.New("no domains to obtain a certificate for"),
+			expectedSetup: 1,
+			expectedErr:   true,
+		},
+		{
+			desc:          "challenge provider failure",
+			failingCAs:    []int{0},
+			obtainErr:     domainErrorsMock{"example.com": errors.New("[example.com] acme: error presenting token: invalid credentials")},
+			expectedSetup: 1,
+			expectedErr:   true,
+		},
+		{
+			desc:        "all CAs fail",
+			primaryDown: true,
+			failingCAs:  []int{1},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			_, primaryURL, tearDownPrimary := tester.SetupFakeAPI()
+			defer tearDownPrimary()
+
+			if test.primaryDown {
+				// the server is closed: the connections are refused.
+				server := httptest.NewServer(http.NotFoundHandler())
+				server.Close()
+
+				primaryURL = server.URL
+			}
+
+			_, secondaryURL, tearDownSecondary := tester.SetupFakeAPI()
+			defer tearDownSecondary()
+
+			key, err := rsa.GenerateKey(rand.Reader, 512)
+			require.NoError(t, err)
+
+			user := mockUser{email: "test@test.com", regres: new(registration.Resource), privatekey: key}
+
+			var configs []*Config
+			for _, apiURL := range []string{primaryURL, secondaryURL} {
+				config := NewConfig(user)
+				config.CADirURL = apiURL + "/dir"
+				configs = append(configs, config)
+			}
+
+			var setupCalls int
+			failover, err := NewFailoverClient(configs, func(client *Client) error {
+				setupCalls++
+				return nil
+			})
+			require.NoError(t, err)
+
+			failover.obtain = func(client *Client, request certificate.ObtainRequest) (*certificate.Resource, error) {
+				for _, i := range test.failingCAs {
+					if failover.clients[i] == client {
+						if test.withCert {
+							return &certificate.Resource{Domain: request.Domains[0]}, test.obtainErr
+						}
+						if test.obtainErr != nil {
+							return nil, test.obtainErr
+						}
+						return nil, &acme.ProblemDetails{HTTPStatus: http.StatusServiceUnavailable, Detail: "order failure"}
+					}
+				}
+				return &certificate.Resource{Domain: request.Domains[0]}, nil
+			}
+
+			start := time.Now()
+
+			certRes, caDirURL, err := failover.Obtain(certificate.ObtainRequest{Domains: []string{"example.com"}})
+
+			if test.primaryDown {
+				// the unavailable directory is not retried before trying the next CA.
+				assert.WithinDuration(t, start, time.Now(), 5*time.Second)
+			}
+
+			if test.expectedErr {
+				require.Error(t, err)
+
+				if test.withCert {
+					// the certificate of the primary CA is returned with the error.
+					require.NotNil(t, certRes)
+					assert.Equal(t, configs[0].CADirURL, caDirURL)
+				}
+
+				if test.expectedSetup > 0 {
+					// the next CAs are not tried.
+					assert.Equal(t, test.expectedSetup, setupCalls)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, configs[test.expectedCA].CADirURL, caDirURL)
+			assert.Equal(t, len(test.failingCAs)+1, setupCalls)
+			assert.Equal(t, "example.com", certRes.Domain)
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package log
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strings"
 )
 
 // Logger is an optional custom logger.
 var Logger StdLogger = log.New(os.Stdout, "", log.LstdFlags)
 
+// structuredLogger is the logger used for the structured log entries.
+var structuredLogger StructuredLogger = stdStructuredLogger{}
+
 // StdLogger interface for Standard Logger.
 type StdLogger interface {
 	Fatal(args ...interface{})
@@ -57,3 +62,62 @@ func Warnf(format string, args ...interface{}) {
 func Infof(format string, args ...interface{}) {
 	Printf("[INFO] "+format, args...)
 }
+
+// Field is a key/value pair attached to a structured log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F creates a Field.
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// StructuredLogger interface for loggers supporting structured fields (zap, logrus, ...).
+type StructuredLogger interface {
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+}
+
+// SetStructuredLogger sets the logger used for the structured log entries.
+// A nil logger restores the default one, which writes the entries through Logger.
+func SetStructuredLogger(logger StructuredLogger) {
+	if logger == nil {
+		structuredLogger = stdStructuredLogger{}
+		return
+	}
+
+	structuredLogger = logger
+}
+
+// Infow writes a structured log entry.
+// It uses the logger defined by SetStructuredLogger, otherwise the fields are appended to the message written with Logger.
+func Infow(msg string, fields ...Field) {
+	structuredLogger.Info(msg, fields...)
+}
+
+// Warnw writes a structured log entry.
+// It uses the logger defined by SetStructuredLogger, otherwise the fields are appended to the message written with Logger.
+func Warnw(msg string, fields ...Field) {
+	structuredLogger.Warn(msg, fields...)
+}
+
+// stdStructuredLogger writes the structured log entries through Logger.
+type stdStructuredLogger struct{}
+
+func (stdStructuredLogger) Info(msg string, fields ...Field) {
+	Infof("%s%s", msg, formatFields(fields))
+}
+
+func (stdStructuredLogger) Warn(msg string, fields ...Field) {
+	Warnf("%s%s", msg, formatFields(fields))
+}
+
+func formatFields(fields []Field) string {
+	var builder strings.Builder
+	for _, field := range fields {
+		_, _ = fmt.Fprintf(&builder, " %s=%v", field.Key, field.Value)
+	}
+	return builder.String()
+}
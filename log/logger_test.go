@@ -0,0 +1,49 @@
+package log
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfow(t *testing.T) {
+	backupLogger := Logger
+	defer func() {
+		Logger = backupLogger
+	}()
+
+	buf := &bytes.Buffer{}
+	Logger = log.New(buf, "", 0)
+
+	Infow("acme: nonce error, retrying", F("uri", "https://example.com/acme/new-order"), F("attempt", 2))
+
+	assert.Equal(t, "[INFO] acme: nonce error, retrying uri=https://example.com/acme/new-order attempt=2\n", buf.String())
+}
+
+func TestSetStructuredLogger(t *testing.T) {
+	defer SetStructuredLogger(nil)
+
+	logger := &recorder{}
+	SetStructuredLogger(logger)
+
+	Warnw("oops", F("attempt", 1))
+
+	assert.Equal(t, []string{"oops"}, logger.messages)
+	assert.Equal(t, []Field{{Key: "attempt", Value: 1}}, logger.fields)
+}
+
+type recorder struct {
+	messages []string
+	fields   []Field
+}
+
+func (r *recorder) Info(msg string, fields ...Field) {
+	r.messages = append(r.messages, msg)
+	r.fields = append(r.fields, fields...)
+}
+
+func (r *recorder) Warn(msg string, fields ...Field) {
+	r.Info(msg, fields...)
+}
@@ -116,6 +116,51 @@ func GetOrDefaultSecond(envVar string, defaultValue time.Duration) time.Duration
 	return time.Duration(v) * time.Second
 }
 
+// GetOrDefaultMillisecond returns the given environment variable value as an time.Duration:
+// a number of milliseconds (e.g. 500), or a duration (e.g. 500ms, 2s).
+// Returns the default if the envvar cannot be parsed, is negative, or is not found.
+func GetOrDefaultMillisecond(envVar string, defaultValue time.Duration) time.Duration {
+	raw := GetOrFile(envVar)
+
+	if v, err := strconv.Atoi(raw); err == nil {
+		if v < 0 {
+			return defaultValue
+		}
+
+		return time.Duration(v) * time.Millisecond
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return defaultValue
+	}
+
+	return d
+}
+
+// ValidateTTL returns an error if the TTL is lower than the minimum TTL accepted by the provider.
+func ValidateTTL(ttl, minTTL int) error {
+	if ttl < minTTL {
+		return fmt.Errorf("invalid TTL, TTL (%d) must be greater than %d", ttl, minTTL)
+	}
+
+	return nil
+}
+
+// ValidatePropagation returns an error if the propagation timeout is shorter than the polling interval:
+// the propagation would never be checked.
+func ValidatePropagation(timeout, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid polling interval (%s): the polling interval must be positive", interval)
+	}
+
+	if timeout < interval {
+		return fmt.Errorf("invalid propagation timeout (%s): the propagation timeout must be greater than the polling interval (%s)", timeout, interval)
+	}
+
+	return nil
+}
+
 // GetOrDefaultString returns the given environment variable value as a string.
 // Returns the default if the envvar cannot be find.
 func GetOrDefaultString(envVar string, defaultValue string) string {
@@ -201,6 +201,150 @@ func TestGetOrDefaultSecond(t *testing.T) {
 	}
 }
 
+func TestGetOrDefaultMillisecond(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		envValue     string
+		defaultValue time.Duration
+		expected     time.Duration
+	}{
+		{
+			desc:         "milliseconds",
+			envValue:     "250",
+			defaultValue: 2 * time.Second,
+			expected:     250 * time.Millisecond,
+		},
+		{
+			desc:         "duration",
+			envValue:     "1.5s",
+			defaultValue: 2 * time.Second,
+			expected:     1500 * time.Millisecond,
+		},
+		{
+			desc:         "invalid content, use default value",
+			envValue:     "abc123",
+			defaultValue: 2 * time.Second,
+			expected:     2 * time.Second,
+		},
+		{
+			desc:         "invalid content, negative value",
+			envValue:     "-111",
+			defaultValue: 2 * time.Second,
+			expected:     2 * time.Second,
+		},
+		{
+			desc:         "invalid content, negative duration",
+			envValue:     "-1s",
+			defaultValue: 2 * time.Second,
+			expected:     2 * time.Second,
+		},
+		{
+			desc:         "not found, use default value",
+			defaultValue: 2 * time.Second,
+			expected:     2 * time.Second,
+		},
+	}
+
+	var key = "LEGO_ENV_TC"
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer os.Unsetenv(key)
+			err := os.Setenv(key, test.envValue)
+			require.NoError(t, err)
+
+			result := GetOrDefaultMillisecond(key, test.defaultValue)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestValidateTTL(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		ttl      int
+		expected string
+	}{
+		{
+			desc: "valid TTL",
+			ttl:  300,
+		},
+		{
+			desc: "minimum TTL",
+			ttl:  120,
+		},
+		{
+			desc:     "TTL lower than the minimum",
+			ttl:      60,
+			expected: "invalid TTL, TTL (60) must be greater than 120",
+		},
+		{
+			desc:     "negative TTL",
+			ttl:      -1,
+			expected: "invalid TTL, TTL (-1) must be greater than 120",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateTTL(test.ttl, 120)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestValidatePropagation(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		timeout  time.Duration
+		interval time.Duration
+		expected string
+	}{
+		{
+			desc:     "valid timings",
+			timeout:  60 * time.Second,
+			interval: 2 * time.Second,
+		},
+		{
+			desc:     "timeout equal to the interval",
+			timeout:  2 * time.Second,
+			interval: 2 * time.Second,
+		},
+		{
+			desc:     "timeout shorter than the interval",
+			timeout:  1 * time.Second,
+			interval: 2 * time.Second,
+			expected: "invalid propagation timeout (1s): the propagation timeout must be greater than the polling interval (2s)",
+		},
+		{
+			desc:     "zero interval",
+			timeout:  60 * time.Second,
+			expected: "invalid polling interval (0s): the polling interval must be positive",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidatePropagation(test.timeout, test.interval)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
 func TestGetOrDefaultString(t *testing.T) {
 	testCases := []struct {
 		desc         string
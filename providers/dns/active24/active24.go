@@ -0,0 +1,156 @@
+// Package active24 implements a DNS provider for solving the DNS-01 challenge using Active24.
+package active24
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/active24/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	Secret             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("ACTIVE24_TTL", 600),
+		PropagationTimeout: env.GetOrDefaultSecond("ACTIVE24_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("ACTIVE24_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("ACTIVE24_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Active24.
+// Credentials must be passed in the environment variables: ACTIVE24_API_KEY, ACTIVE24_SECRET.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("ACTIVE24_API_KEY", "ACTIVE24_SECRET")
+	if err != nil {
+		return nil, fmt.Errorf("active24: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["ACTIVE24_API_KEY"]
+	config.Secret = values["ACTIVE24_SECRET"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Active24.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("active24: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIKey, config.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("active24: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, name, err := splitFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("active24: %v", err)
+	}
+
+	record := internal.TXTRecord{
+		Name: name,
+		Text: value,
+		TTL:  d.config.TTL,
+	}
+
+	err = d.client.AddTXTRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("active24: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone, fqdn, err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, name, err := splitFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("active24: %v", err)
+	}
+
+	records, err := d.client.GetRecords(zone)
+	if err != nil {
+		return fmt.Errorf("active24: failed to get the records [zone: %q]: %v", zone, err)
+	}
+
+	var found bool
+	for _, record := range records {
+		if record.Type != "TXT" || record.Name != name || record.Text != value {
+			continue
+		}
+
+		found = true
+
+		err = d.client.DeleteRecord(zone, record.HashID)
+		if err != nil {
+			return fmt.Errorf("active24: failed to delete TXT record [zone: %q, hashId: %s]: %v", zone, record.HashID, err)
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("active24: TXT record not found [zone: %q, fqdn: %q]", zone, fqdn)
+	}
+
+	return nil
+}
+
+// splitFqdn returns the zone of the FQDN and the name of the record relative to the zone.
+func splitFqdn(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", fmt.Errorf("could not determine the zone: %v", err)
+	}
+
+	zone := dns01.UnFqdn(authZone)
+
+	return zone, strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone), nil
+}
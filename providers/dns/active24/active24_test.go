@@ -0,0 +1,148 @@
+package active24
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vostronet/lego/platform/tester"
+)
+
+var envTest = tester.NewEnvTest("ACTIVE24_API_KEY", "ACTIVE24_SECRET").
+	WithDomain("ACTIVE24_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"ACTIVE24_API_KEY": "key",
+				"ACTIVE24_SECRET":  "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"ACTIVE24_API_KEY": "",
+				"ACTIVE24_SECRET":  "",
+			},
+			expected: "active24: some credentials information are missing: ACTIVE24_API_KEY,ACTIVE24_SECRET",
+		},
+		{
+			desc: "missing API key",
+			envVars: map[string]string{
+				"ACTIVE24_API_KEY": "",
+				"ACTIVE24_SECRET":  "secret",
+			},
+			expected: "active24: some credentials information are missing: ACTIVE24_API_KEY",
+		},
+		{
+			desc: "missing secret",
+			envVars: map[string]string{
+				"ACTIVE24_API_KEY": "key",
+				"ACTIVE24_SECRET":  "",
+			},
+			expected: "active24: some credentials information are missing: ACTIVE24_SECRET",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		apiKey   string
+		secret   string
+		expected string
+	}{
+		{
+			desc:   "success",
+			apiKey: "key",
+			secret: "secret",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "active24: credentials missing",
+		},
+		{
+			desc:     "missing API key",
+			secret:   "secret",
+			expected: "active24: credentials missing",
+		},
+		{
+			desc:     "missing secret",
+			apiKey:   "key",
+			expected: "active24: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = test.apiKey
+			config.Secret = test.secret
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,182 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://api.active24.com"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int                 `json:"-"`
+	Message    string              `json:"message"`
+	Errors     map[string][]string `json:"errors"`
+}
+
+func (a APIError) Error() string {
+	msg := fmt.Sprintf("[status code: %d] %s", a.StatusCode, a.Message)
+
+	// the validation errors (by field) in a stable order.
+	var fields []string
+	for field, messages := range a.Errors {
+		fields = append(fields, fmt.Sprintf("%s: %s", field, strings.Join(messages, ", ")))
+	}
+
+	sort.Strings(fields)
+
+	if len(fields) > 0 {
+		msg += ": " + strings.Join(fields, "; ")
+	}
+
+	return msg
+}
+
+// TXTRecord a TXT record to create.
+type TXTRecord struct {
+	// Name the name of the record relative to the domain (e.g. "_acme-challenge", "_acme-challenge.sub").
+	Name string `json:"name"`
+	Text string `json:"text"`
+	TTL  int    `json:"ttl"`
+}
+
+// Record a DNS record of a domain.
+type Record struct {
+	HashID string `json:"hashId"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Text   string `json:"text,omitempty"`
+	TTL    int    `json:"ttl"`
+}
+
+// Client the Active24 API client.
+type Client struct {
+	apiKey     string
+	secret     string
+	BaseURL    string
+	HTTPClient *http.Client
+	// now the time of the signatures of the requests.
+	now func() time.Time
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey, secret string) (*Client, error) {
+	if apiKey == "" || secret == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		secret:     secret,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+		now:        time.Now,
+	}, nil
+}
+
+// AddTXTRecord creates a TXT record.
+// https://faq.active24.com/eng/739445-REST-API-rozhran%C3%AD
+func (c *Client) AddTXTRecord(domain string, record TXTRecord) error {
+	return c.do(http.MethodPost, fmt.Sprintf("/dns/%s/txt/v1", url.PathEscape(domain)), record, nil)
+}
+
+// GetRecords lists the DNS records of a domain.
+// https://faq.active24.com/eng/739445-REST-API-rozhran%C3%AD
+func (c *Client) GetRecords(domain string) ([]Record, error) {
+	var records []Record
+	err := c.do(http.MethodGet, fmt.Sprintf("/dns/%s/records/v1", url.PathEscape(domain)), nil, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// DeleteRecord deletes a DNS record by its hash ID.
+// https://faq.active24.com/eng/739445-REST-API-rozhran%C3%AD
+func (c *Client) DeleteRecord(domain, hashID string) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/dns/%s/%s/v1", url.PathEscape(domain), url.PathEscape(hashID)), nil, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(c.BaseURL, "/") + uri)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	c.sign(req, endpoint.EscapedPath(), c.now())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || (apiErr.Message == "" && len(apiErr.Errors) == 0) {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
+
+// sign adds the authentication headers:
+// the API key is sent as bearer token,
+// and the request is signed with the HMAC-SHA1 (hex) of the method, the path and the timestamp, separated by a space.
+// The timestamp is sent in the Date header (ISO 8601 basic format).
+func (c *Client) sign(req *http.Request, path string, now time.Time) {
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Date", now.UTC().Format("20060102T150405Z"))
+	req.Header.Set("X-Signature", signature(c.secret, req.Method, path, now.Unix()))
+}
+
+func signature(secret, method, path string, timestamp int64) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	_, _ = mac.Write([]byte(fmt.Sprintf("%s %s %d", method, path, timestamp)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedTime the time of the signatures in the tests (2019-08-02T12:05:34Z).
+var fixedTime = time.Unix(1564747534, 0)
+
+func setupTest(t *testing.T, method, pattern, expectedSignature string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Date") != "20190802T120534Z" {
+			http.Error(rw, fmt.Sprintf("invalid date: %s", req.Header.Get("Date")), http.StatusBadRequest)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer key" || req.Header.Get("X-Signature") != expectedSignature {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"message":"Unauthorized"}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("key", "secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+	client.now = func() time.Time { return fixedTime }
+
+	return client, server.Close
+}
+
+func Test_signature(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		method   string
+		path     string
+		expected string
+	}{
+		{
+			desc:     "POST",
+			method:   http.MethodPost,
+			path:     "/dns/example.com/txt/v1",
+			expected: "835c0a3abd0e42aef6bde06c4de6d6ba05f940be",
+		},
+		{
+			desc:     "GET",
+			method:   http.MethodGet,
+			path:     "/dns/example.com/records/v1",
+			expected: "660205490bb34801386dd57ff0d33aa35890b25d",
+		},
+		{
+			desc:     "DELETE",
+			method:   http.MethodDelete,
+			path:     "/dns/example.com/abc123/v1",
+			expected: "b0e8a637f805cc2960e420a9d8e2e3585b466c50",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, signature("secret", test.method, test.path, fixedTime.Unix()))
+		})
+	}
+}
+
+func TestClient_AddTXTRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/dns/example.com/txt/v1", "835c0a3abd0e42aef6bde06c4de6d6ba05f940be", func(rw http.ResponseWriter, req *http.Request) {
+		record := TXTRecord{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := TXTRecord{Name: "_acme-challenge", Text: "txtTXTtxt", TTL: 600}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	err := client.AddTXTRecord("example.com", TXTRecord{Name: "_acme-challenge", Text: "txtTXTtxt", TTL: 600})
+	require.NoError(t, err)
+}
+
+func TestClient_AddTXTRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/dns/example.com/txt/v1", "835c0a3abd0e42aef6bde06c4de6d6ba05f940be", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(rw, `{"message":"Validation failed","errors":{"ttl":["TTL is too low"],"text":["Text is required"]}}`)
+	})
+	defer tearDown()
+
+	err := client.AddTXTRecord("example.com", TXTRecord{Name: "_acme-challenge", Text: "txtTXTtxt", TTL: 600})
+	require.EqualError(t, err, "[status code: 400] Validation failed: text: Text is required; ttl: TTL is too low")
+}
+
+func TestClient_AddTXTRecord_unauthorized(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/dns/example.com/txt/v1", "835c0a3abd0e42aef6bde06c4de6d6ba05f940be", nil)
+	defer tearDown()
+
+	client.secret = "invalid"
+
+	err := client.AddTXTRecord("example.com", TXTRecord{Name: "_acme-challenge", Text: "txtTXTtxt", TTL: 600})
+	require.EqualError(t, err, "[status code: 401] Unauthorized")
+}
+
+func TestClient_GetRecords(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dns/example.com/records/v1", "660205490bb34801386dd57ff0d33aa35890b25d", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `[{"hashId":"abc123","type":"TXT","name":"_acme-challenge","text":"txtTXTtxt","ttl":600},{"hashId":"def456","type":"A","name":"www","ip":"192.0.2.1","ttl":3600}]`)
+	})
+	defer tearDown()
+
+	records, err := client.GetRecords("example.com")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{HashID: "abc123", Type: "TXT", Name: "_acme-challenge", Text: "txtTXTtxt", TTL: 600},
+		{HashID: "def456", Type: "A", Name: "www", TTL: 3600},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/dns/example.com/abc123/v1", "b0e8a637f805cc2960e420a9d8e2e3585b466c50", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", "abc123")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/dns/example.com/abc123/v1", "b0e8a637f805cc2960e420a9d8e2e3585b466c50", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(rw, `{"message":"Record not found"}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", "abc123")
+	require.EqualError(t, err, "[status code: 404] Record not found")
+}
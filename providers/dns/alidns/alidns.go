@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
@@ -17,11 +18,18 @@ import (
 
 const defaultRegionID = "cn-hangzhou"
 
+const (
+	ramRoleSessionName       = "lego"
+	ramRoleSessionExpiration = 3600 // seconds
+)
+
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
+	RAMRole            string
 	APIKey             string
 	SecretKey          string
 	RegionID           string
+	Endpoint           string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
@@ -45,17 +53,28 @@ type DNSProvider struct {
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Alibaba Cloud DNS.
-// Credentials must be passed in the environment variables: ALICLOUD_ACCESS_KEY and ALICLOUD_SECRET_KEY.
+// Credentials must be passed in the environment variables: ALICLOUD_ACCESS_KEY and ALICLOUD_SECRET_KEY,
+// or ALICLOUD_RAM_ROLE (the access keys are then optional).
 func NewDNSProvider() (*DNSProvider, error) {
+	config := NewDefaultConfig()
+	config.RAMRole = env.GetOrFile("ALICLOUD_RAM_ROLE")
+	config.RegionID = env.GetOrFile("ALICLOUD_REGION_ID")
+	config.Endpoint = env.GetOrFile("ALICLOUD_ENDPOINT")
+
+	if config.RAMRole != "" {
+		config.APIKey = env.GetOrFile("ALICLOUD_ACCESS_KEY")
+		config.SecretKey = env.GetOrFile("ALICLOUD_SECRET_KEY")
+
+		return NewDNSProviderConfig(config)
+	}
+
 	values, err := env.Get("ALICLOUD_ACCESS_KEY", "ALICLOUD_SECRET_KEY")
 	if err != nil {
 		return nil, fmt.Errorf("alicloud: %v", err)
 	}
 
-	config := NewDefaultConfig()
 	config.APIKey = values["ALICLOUD_ACCESS_KEY"]
 	config.SecretKey = values["ALICLOUD_SECRET_KEY"]
-	config.RegionID = env.GetOrFile("ALICLOUD_REGION_ID")
 
 	return NewDNSProviderConfig(config)
 }
@@ -66,8 +85,9 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("alicloud: the configuration of the DNS provider is nil")
 	}
 
-	if config.APIKey == "" || config.SecretKey == "" {
-		return nil, fmt.Errorf("alicloud: credentials missing")
+	credential, err := getCredential(config)
+	if err != nil {
+		return nil, fmt.Errorf("alicloud: %v", err)
 	}
 
 	if len(config.RegionID) == 0 {
@@ -75,7 +95,6 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	}
 
 	conf := sdk.NewConfig().WithTimeout(config.HTTPTimeout)
-	credential := credentials.NewAccessKeyCredential(config.APIKey, config.SecretKey)
 
 	client, err := alidns.NewClientWithOptions(config.RegionID, conf, credential)
 	if err != nil {
@@ -85,6 +104,25 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	return &DNSProvider{config: config, client: client}, nil
 }
 
+// getCredential returns the credential matching the configuration:
+//  - RAM role and access keys: the RAM role is assumed (STS) with the access keys.
+//  - RAM role without access keys: the RAM role of the ECS instance.
+//  - access keys only: the access keys.
+func getCredential(config *Config) (auth.Credential, error) {
+	hasKeys := config.APIKey != "" && config.SecretKey != ""
+
+	switch {
+	case config.RAMRole != "" && hasKeys:
+		return credentials.NewRamRoleArnCredential(config.APIKey, config.SecretKey, config.RAMRole, ramRoleSessionName, ramRoleSessionExpiration), nil
+	case config.RAMRole != "":
+		return credentials.NewEcsRamRoleCredential(config.RAMRole), nil
+	case hasKeys:
+		return credentials.NewAccessKeyCredential(config.APIKey, config.SecretKey), nil
+	default:
+		return nil, errors.New("credentials missing")
+	}
+}
+
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
 // Adjusting here to cope with spikes in propagation times.
 func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
@@ -126,6 +164,7 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	for _, rec := range records {
 		request := alidns.CreateDeleteDomainRecordRequest()
 		request.RecordId = rec.RecordId
+		d.setEndpoint(request)
 		_, err = d.client.DeleteDomainRecord(request)
 		if err != nil {
 			return fmt.Errorf("alicloud: %v", err)
@@ -136,6 +175,7 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 func (d *DNSProvider) getHostedZone(domain string) (string, string, error) {
 	request := alidns.CreateDescribeDomainsRequest()
+	d.setEndpoint(request)
 
 	var domains []alidns.Domain
 	startPage := 1
@@ -182,6 +222,7 @@ func (d *DNSProvider) newTxtRecord(zone, fqdn, value string) *alidns.AddDomainRe
 	request.RR = d.extractRecordName(fqdn, zone)
 	request.Value = value
 	request.TTL = requests.NewInteger(d.config.TTL)
+	d.setEndpoint(request)
 	return request
 }
 
@@ -194,6 +235,7 @@ func (d *DNSProvider) findTxtRecords(domain, fqdn string) ([]alidns.Record, erro
 	request := alidns.CreateDescribeDomainRecordsRequest()
 	request.DomainName = zoneName
 	request.PageSize = requests.NewInteger(500)
+	d.setEndpoint(request)
 
 	var records []alidns.Record
 
@@ -211,6 +253,13 @@ func (d *DNSProvider) findTxtRecords(domain, fqdn string) ([]alidns.Record, erro
 	return records, nil
 }
 
+// setEndpoint overrides the endpoint resolved from the region (ex: international vs China endpoints).
+func (d *DNSProvider) setEndpoint(request requests.AcsRequest) {
+	if d.config.Endpoint != "" {
+		request.SetDomain(d.config.Endpoint)
+	}
+}
+
 func (d *DNSProvider) extractRecordName(fqdn, domain string) string {
 	name := dns01.UnFqdn(fqdn)
 	if idx := strings.Index(name, "."+domain); idx != -1 {
@@ -9,8 +9,11 @@ import (
 )
 
 var envTest = tester.NewEnvTest(
+	"ALICLOUD_RAM_ROLE",
 	"ALICLOUD_ACCESS_KEY",
-	"ALICLOUD_SECRET_KEY").
+	"ALICLOUD_SECRET_KEY",
+	"ALICLOUD_REGION_ID",
+	"ALICLOUD_ENDPOINT").
 	WithDomain("ALICLOUD_DOMAIN")
 
 func TestNewDNSProvider(t *testing.T) {
@@ -26,6 +29,20 @@ func TestNewDNSProvider(t *testing.T) {
 				"ALICLOUD_SECRET_KEY": "456",
 			},
 		},
+		{
+			desc: "success with RAM role",
+			envVars: map[string]string{
+				"ALICLOUD_RAM_ROLE": "acs:ram::123456789:role/lego",
+			},
+		},
+		{
+			desc: "success with RAM role and access keys",
+			envVars: map[string]string{
+				"ALICLOUD_RAM_ROLE":   "acs:ram::123456789:role/lego",
+				"ALICLOUD_ACCESS_KEY": "123",
+				"ALICLOUD_SECRET_KEY": "456",
+			},
+		},
 		{
 			desc: "missing credentials",
 			envVars: map[string]string{
@@ -76,6 +93,7 @@ func TestNewDNSProvider(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc      string
+		ramRole   string
 		apiKey    string
 		secretKey string
 		expected  string
@@ -85,6 +103,16 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			apiKey:    "123",
 			secretKey: "456",
 		},
+		{
+			desc:    "success with RAM role",
+			ramRole: "acs:ram::123456789:role/lego",
+		},
+		{
+			desc:      "success with RAM role and access keys",
+			ramRole:   "acs:ram::123456789:role/lego",
+			apiKey:    "123",
+			secretKey: "456",
+		},
 		{
 			desc:     "missing credentials",
 			expected: "alicloud: credentials missing",
@@ -104,6 +132,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.RAMRole = test.ramRole
 			config.APIKey = test.apiKey
 			config.SecretKey = test.secretKey
 
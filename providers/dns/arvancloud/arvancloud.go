@@ -0,0 +1,161 @@
+// Package arvancloud implements a DNS provider for solving the DNS-01 challenge using ArvanCloud DNS.
+package arvancloud
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/arvancloud/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("ARVANCLOUD_TTL", 600),
+		PropagationTimeout: env.GetOrDefaultSecond("ARVANCLOUD_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("ARVANCLOUD_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("ARVANCLOUD_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for ArvanCloud.
+// Credentials must be passed in the environment variable: ARVANCLOUD_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("ARVANCLOUD_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("arvancloud: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["ARVANCLOUD_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for ArvanCloud.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("arvancloud: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("arvancloud: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]string{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("arvancloud: %v", err)
+	}
+
+	record := internal.Record{
+		Type:  "txt",
+		Name:  subDomain,
+		Value: internal.TXTRecordValue{Text: value},
+		TTL:   d.config.TTL,
+	}
+
+	newRecord, err := d.client.CreateRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("arvancloud: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone, fqdn, err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = newRecord.ID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	zone, _, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("arvancloud: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("arvancloud: unknown record ID for '%s'", fqdn)
+	}
+
+	err = d.client.DeleteRecord(zone, recordID)
+	if err != nil {
+		return fmt.Errorf("arvancloud: failed to delete TXT record [zone: %q, id: %q]: %v", zone, recordID, err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// splitDomain returns the zone and the sub-domain (relative to the zone) of a FQDN.
+func splitDomain(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", err
+	}
+
+	zone := dns01.UnFqdn(authZone)
+
+	subDomain := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(subDomain, "."+zone); idx != -1 {
+		return zone, subDomain[:idx], nil
+	}
+
+	return zone, "@", nil
+}
@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const defaultBaseURL = "https://napi.arvancloud.ir/cdn/4.0"
+
+const authScheme = "Apikey"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int                 `json:"-"`
+	Message    string              `json:"message"`
+	Errors     map[string][]string `json:"errors,omitempty"`
+}
+
+func (a APIError) Error() string {
+	var fields []string
+	for field := range a.Errors {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	msg := fmt.Sprintf("[status code: %d] %s", a.StatusCode, a.Message)
+	for _, field := range fields {
+		msg += fmt.Sprintf(", %s: %s", field, strings.Join(a.Errors[field], ", "))
+	}
+	return msg
+}
+
+// TXTRecordValue the value of a TXT record.
+type TXTRecordValue struct {
+	Text string `json:"text"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID            string      `json:"id,omitempty"`
+	Type          string      `json:"type"`
+	Name          string      `json:"name"`
+	Value         interface{} `json:"value"`
+	TTL           int         `json:"ttl,omitempty"`
+	UpstreamHTTPS string      `json:"upstream_https,omitempty"`
+}
+
+type apiResponse struct {
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Client the ArvanCloud DNS API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey string) (*Client, error) {
+	if apiKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// CreateRecord creates a DNS record and returns the created record.
+// https://www.arvancloud.ir/docs/api/cdn/4.0#operation/dns_records.create
+func (c *Client) CreateRecord(domain string, record Record) (*Record, error) {
+	result := &Record{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/domains/%s/dns-records", domain), record, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteRecord deletes a DNS record.
+// https://www.arvancloud.ir/docs/api/cdn/4.0#operation/dns_records.remove
+func (c *Client) DeleteRecord(domain, recordID string) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/domains/%s/dns-records/%s", domain, recordID), nil, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.authorization())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Message == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	response := &apiResponse{}
+	err = json.Unmarshal(raw, response)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: [status code: %d] %s", resp.StatusCode, string(raw))
+	}
+
+	return json.Unmarshal(response.Data, result)
+}
+
+// authorization returns the value of the Authorization header.
+// The API keys provided by the ArvanCloud panel can already contain the "Apikey" scheme.
+func (c *Client) authorization() string {
+	if strings.HasPrefix(c.apiKey, authScheme+" ") {
+		return c.apiKey
+	}
+	return authScheme + " " + c.apiKey
+}
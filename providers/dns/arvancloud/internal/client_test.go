@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Apikey secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"message":"Unauthenticated."}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/domains/example.com/dns-records", func(rw http.ResponseWriter, req *http.Request) {
+		body := map[string]interface{}{}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := map[string]interface{}{
+			"type":  "txt",
+			"name":  "_acme-challenge",
+			"value": map[string]interface{}{"text": "txtTXTtxt"},
+			"ttl":   float64(600),
+		}
+		if !assert.ObjectsAreEqual(expected, body) {
+			http.Error(rw, fmt.Sprintf("invalid body: %v", body), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `{"data":{"id":"abc-123","type":"txt","name":"_acme-challenge","value":{"text":"txtTXTtxt"},"ttl":600},"message":"DNS record created"}`)
+	})
+	defer tearDown()
+
+	record := Record{
+		Type:  "txt",
+		Name:  "_acme-challenge",
+		Value: TXTRecordValue{Text: "txtTXTtxt"},
+		TTL:   600,
+	}
+
+	result, err := client.CreateRecord("example.com", record)
+	require.NoError(t, err)
+
+	expected := &Record{
+		ID:    "abc-123",
+		Type:  "txt",
+		Name:  "_acme-challenge",
+		Value: map[string]interface{}{"text": "txtTXTtxt"},
+		TTL:   600,
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestClient_CreateRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/domains/example.com/dns-records", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprint(rw, `{"message":"The given data was invalid.","errors":{"ttl":["The selected ttl is invalid."]}}`)
+	})
+	defer tearDown()
+
+	_, err := client.CreateRecord("example.com", Record{Type: "txt", Name: "_acme-challenge", TTL: 1})
+	require.EqualError(t, err, "[status code: 422] The given data was invalid., ttl: The selected ttl is invalid.")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/domains/example.com/dns-records/abc-123", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"message":"DNS record deleted"}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", "abc-123")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/domains/example.com/dns-records/abc-123", nil)
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	err := client.DeleteRecord("example.com", "abc-123")
+	require.EqualError(t, err, "[status code: 401] Unauthenticated.")
+}
+
+func TestClient_authorization(t *testing.T) {
+	client, err := NewClient("Apikey secret")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Apikey secret", client.authorization())
+}
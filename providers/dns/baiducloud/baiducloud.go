@@ -0,0 +1,174 @@
+// Package baiducloud implements a DNS provider for solving the DNS-01 challenge using Baidu Cloud DNS.
+package baiducloud
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/baiducloud/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	AccessKeyID        string
+	SecretAccessKey    string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("BAIDUCLOUD_TTL", 300),
+		PropagationTimeout: env.GetOrDefaultSecond("BAIDUCLOUD_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("BAIDUCLOUD_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("BAIDUCLOUD_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Baidu Cloud DNS.
+// Credentials must be passed in the environment variables:
+// BAIDUCLOUD_ACCESS_KEY_ID and BAIDUCLOUD_SECRET_ACCESS_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("BAIDUCLOUD_ACCESS_KEY_ID", "BAIDUCLOUD_SECRET_ACCESS_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("baiducloud: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AccessKeyID = values["BAIDUCLOUD_ACCESS_KEY_ID"]
+	config.SecretAccessKey = values["BAIDUCLOUD_SECRET_ACCESS_KEY"]
+	config.BaseURL = env.GetOrFile("BAIDUCLOUD_ENDPOINT")
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Baidu Cloud DNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("baiducloud: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.AccessKeyID, config.SecretAccessKey)
+	if err != nil {
+		return nil, fmt.Errorf("baiducloud: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zoneName, err := d.getZoneName(fqdn)
+	if err != nil {
+		return fmt.Errorf("baiducloud: %v", err)
+	}
+
+	record := internal.Record{
+		RR:          extractRecordName(fqdn, zoneName),
+		Type:        "TXT",
+		Value:       value,
+		TTL:         d.config.TTL,
+		Description: "lego",
+	}
+
+	err = d.client.CreateRecord(zoneName, record)
+	if err != nil {
+		return fmt.Errorf("baiducloud: failed to create record: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zoneName, err := d.getZoneName(fqdn)
+	if err != nil {
+		return fmt.Errorf("baiducloud: %v", err)
+	}
+
+	rr := extractRecordName(fqdn, zoneName)
+
+	records, err := d.client.ListRecords(zoneName, rr)
+	if err != nil {
+		return fmt.Errorf("baiducloud: failed to list records: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Type != "TXT" || record.RR != rr || record.Value != value {
+			continue
+		}
+
+		err = d.client.DeleteRecord(zoneName, record.ID)
+		if err != nil {
+			return fmt.Errorf("baiducloud: failed to delete record %s: %v", record.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// getZoneName returns the name of the Baidu Cloud DNS zone matching the authoritative zone of the FQDN.
+func (d *DNSProvider) getZoneName(fqdn string) (string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", err
+	}
+
+	zoneName := dns01.UnFqdn(authZone)
+
+	zones, err := d.client.ListZones(zoneName)
+	if err != nil {
+		return "", fmt.Errorf("failed to list zones: %v", err)
+	}
+
+	for _, zone := range zones {
+		if strings.EqualFold(dns01.UnFqdn(zone.Name), zoneName) {
+			return zoneName, nil
+		}
+	}
+
+	return "", fmt.Errorf("zone %s not found in Baidu Cloud DNS for domain %s", zoneName, fqdn)
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return "@"
+}
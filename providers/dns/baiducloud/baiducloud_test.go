@@ -0,0 +1,183 @@
+package baiducloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"BAIDUCLOUD_ACCESS_KEY_ID",
+	"BAIDUCLOUD_SECRET_ACCESS_KEY",
+	"BAIDUCLOUD_ENDPOINT").
+	WithDomain("BAIDUCLOUD_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"BAIDUCLOUD_ACCESS_KEY_ID": "123",
+				"BAIDUCLOUD_SECRET_ACCESS_KEY": "456",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"BAIDUCLOUD_ACCESS_KEY_ID": "",
+				"BAIDUCLOUD_SECRET_ACCESS_KEY": "",
+			},
+			expected: "baiducloud: some credentials information are missing: BAIDUCLOUD_ACCESS_KEY_ID,BAIDUCLOUD_SECRET_ACCESS_KEY",
+		},
+		{
+			desc: "missing access key",
+			envVars: map[string]string{
+				"BAIDUCLOUD_ACCESS_KEY_ID": "",
+				"BAIDUCLOUD_SECRET_ACCESS_KEY": "456",
+			},
+			expected: "baiducloud: some credentials information are missing: BAIDUCLOUD_ACCESS_KEY_ID",
+		},
+		{
+			desc: "missing secret key",
+			envVars: map[string]string{
+				"BAIDUCLOUD_ACCESS_KEY_ID": "123",
+				"BAIDUCLOUD_SECRET_ACCESS_KEY": "",
+			},
+			expected: "baiducloud: some credentials information are missing: BAIDUCLOUD_SECRET_ACCESS_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		accessKeyID     string
+		secretAccessKey string
+		expected        string
+	}{
+		{
+			desc:            "success",
+			accessKeyID:     "123",
+			secretAccessKey: "456",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "baiducloud: credentials missing",
+		},
+		{
+			desc:            "missing access key",
+			secretAccessKey: "456",
+			expected:        "baiducloud: credentials missing",
+		},
+		{
+			desc:        "missing secret key",
+			accessKeyID: "123",
+			expected:    "baiducloud: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.AccessKeyID = test.accessKeyID
+			config.SecretAccessKey = test.secretAccessKey
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_extractRecordName(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		fqdn     string
+		zone     string
+		expected string
+	}{
+		{
+			desc:     "root",
+			fqdn:     "_acme-challenge.example.com.",
+			zone:     "example.com",
+			expected: "_acme-challenge",
+		},
+		{
+			desc:     "sub-domain",
+			fqdn:     "_acme-challenge.foo.example.com.",
+			zone:     "example.com",
+			expected: "_acme-challenge.foo",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, extractRecordName(test.fqdn, test.zone))
+		})
+	}
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
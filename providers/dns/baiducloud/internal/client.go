@@ -0,0 +1,220 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://dns.baidubce.com"
+
+// APIError the error returned by the API.
+type APIError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("%s: %s (request ID: %s)", a.Code, a.Message, a.RequestID)
+}
+
+// Zone a DNS zone.
+type Zone struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// ListZonesResponse the response of the zones listing.
+type ListZonesResponse struct {
+	Zones       []Zone `json:"zones"`
+	Marker      string `json:"marker"`
+	IsTruncated bool   `json:"isTruncated"`
+	NextMarker  string `json:"nextMarker"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID          string `json:"id,omitempty"`
+	RR          string `json:"rr"`
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	TTL         int    `json:"ttl,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ListRecordsResponse the response of the records listing.
+type ListRecordsResponse struct {
+	Records     []Record `json:"records"`
+	Marker      string   `json:"marker"`
+	IsTruncated bool     `json:"isTruncated"`
+	NextMarker  string   `json:"nextMarker"`
+}
+
+// Client the Baidu Cloud DNS API client.
+type Client struct {
+	signer     signer
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(accessKeyID, secretAccessKey string) (*Client, error) {
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		signer: signer{
+			accessKeyID:     accessKeyID,
+			secretAccessKey: secretAccessKey,
+			expiration:      defaultExpiration,
+		},
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// ListZones lists the zones matching the name.
+// https://cloud.baidu.com/doc/DNS/s/El4s7lssr
+func (c *Client) ListZones(name string) ([]Zone, error) {
+	query := url.Values{}
+	if name != "" {
+		query.Set("name", name)
+	}
+
+	var zones []Zone
+
+	for {
+		result := &ListZonesResponse{}
+		err := c.do(http.MethodGet, "/v1/dns/zone", query, nil, result)
+		if err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, result.Zones...)
+
+		if !result.IsTruncated || result.NextMarker == "" {
+			return zones, nil
+		}
+
+		query.Set("marker", result.NextMarker)
+	}
+}
+
+// CreateRecord creates a DNS record.
+// https://cloud.baidu.com/doc/DNS/s/El4s7lssr
+func (c *Client) CreateRecord(zoneName string, record Record) error {
+	query := url.Values{}
+	query.Set("clientToken", newClientToken())
+
+	return c.do(http.MethodPost, fmt.Sprintf("/v1/dns/zone/%s/record", zoneName), query, record, nil)
+}
+
+// ListRecords lists the DNS records of a zone matching the RR.
+// https://cloud.baidu.com/doc/DNS/s/El4s7lssr
+func (c *Client) ListRecords(zoneName, rr string) ([]Record, error) {
+	query := url.Values{}
+	if rr != "" {
+		query.Set("rr", rr)
+	}
+
+	var records []Record
+
+	for {
+		result := &ListRecordsResponse{}
+		err := c.do(http.MethodGet, fmt.Sprintf("/v1/dns/zone/%s/record", zoneName), query, nil, result)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, result.Records...)
+
+		if !result.IsTruncated || result.NextMarker == "" {
+			return records, nil
+		}
+
+		query.Set("marker", result.NextMarker)
+	}
+}
+
+// DeleteRecord deletes a DNS record.
+// https://cloud.baidu.com/doc/DNS/s/El4s7lssr
+func (c *Client) DeleteRecord(zoneName, recordID string) error {
+	query := url.Values{}
+	query.Set("clientToken", newClientToken())
+
+	return c.do(http.MethodDelete, fmt.Sprintf("/v1/dns/zone/%s/record/%s", zoneName, recordID), query, nil, nil)
+}
+
+func (c *Client) do(method, uri string, query url.Values, payload, result interface{}) error {
+	endpoint, err := url.Parse(strings.TrimSuffix(c.BaseURL, "/") + uri)
+	if err != nil {
+		return err
+	}
+
+	endpoint.RawQuery = query.Encode()
+
+	var body io.Reader
+	if payload != nil {
+		raw, errM := json.Marshal(payload)
+		if errM != nil {
+			return errM
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	c.signer.sign(req, time.Now())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Code == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
+
+// newClientToken generates the token used to ensure the idempotence of a request.
+func newClientToken() string {
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	return hex.EncodeToString(token)
+}
@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		auth := req.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "bce-auth-v1/user/") {
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprintf(rw, `{"code":"AccessDenied","message":"invalid authorization: %s","requestId":"abc"}`, auth)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("user", "secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_ListZones(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/v1/dns/zone", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("marker") {
+		case "":
+			_, _ = fmt.Fprint(rw, `{"zones":[{"id":"1","name":"example.org.","status":"running"}],"isTruncated":true,"nextMarker":"1"}`)
+		case "1":
+			_, _ = fmt.Fprint(rw, `{"zones":[{"id":"2","name":"example.com.","status":"running"}],"isTruncated":false}`)
+		default:
+			http.Error(rw, "invalid marker", http.StatusBadRequest)
+		}
+	})
+	defer tearDown()
+
+	zones, err := client.ListZones("example.com")
+	require.NoError(t, err)
+
+	expected := []Zone{
+		{ID: "1", Name: "example.org.", Status: "running"},
+		{ID: "2", Name: "example.com.", Status: "running"},
+	}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/v1/dns/zone/example.com/record", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("clientToken") == "" {
+			http.Error(rw, "missing client token", http.StatusBadRequest)
+			return
+		}
+
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{RR: "_acme-challenge", Type: "TXT", Value: "txtTXTtxt", TTL: 300}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %v", record), http.StatusBadRequest)
+			return
+		}
+	})
+	defer tearDown()
+
+	err := client.CreateRecord("example.com", Record{RR: "_acme-challenge", Type: "TXT", Value: "txtTXTtxt", TTL: 300})
+	require.NoError(t, err)
+}
+
+func TestClient_ListRecords(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/v1/dns/zone/example.com/record", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("rr") != "_acme-challenge" {
+			http.Error(rw, "invalid rr", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"records":[{"id":"123","rr":"_acme-challenge","status":"running","type":"TXT","value":"txtTXTtxt","ttl":300}],"isTruncated":false}`)
+	})
+	defer tearDown()
+
+	records, err := client.ListRecords("example.com", "_acme-challenge")
+	require.NoError(t, err)
+
+	expected := []Record{{ID: "123", RR: "_acme-challenge", Type: "TXT", Value: "txtTXTtxt", TTL: 300}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/v1/dns/zone/example.com/record/123", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("clientToken") == "" {
+			http.Error(rw, "missing client token", http.StatusBadRequest)
+			return
+		}
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", "123")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/v1/dns/zone/example.com/record/123", nil)
+	defer tearDown()
+
+	client.signer.accessKeyID = "invalid"
+
+	err := client.DeleteRecord("example.com", "123")
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "AccessDenied: invalid authorization: bce-auth-v1/invalid/"), err.Error())
+}
+
+func Test_signer_sign(t *testing.T) {
+	s := signer{accessKeyID: "AK", secretAccessKey: "SK", expiration: 1800}
+
+	req, err := http.NewRequest(http.MethodGet, "https://dns.baidubce.com/v1/dns/zone?name=example.com", nil)
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	s.sign(req, time.Date(2021, time.January, 2, 3, 4, 5, 0, time.UTC))
+
+	auth := req.Header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(auth, "bce-auth-v1/AK/2021-01-02T03:04:05Z/1800/content-type;host/"), auth)
+}
+
+func Test_uriEncode(t *testing.T) {
+	assert.Equal(t, "/v1/dns/zone/example.com", uriEncode("/v1/dns/zone/example.com", false))
+	assert.Equal(t, "%2Fa%20b~c", uriEncode("/a b~c", true))
+}
@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	authVersion         = "bce-auth-v1"
+	defaultExpiration   = 1800 // seconds
+	authTimestampFormat = "2006-01-02T15:04:05Z"
+)
+
+// signer signs the requests with the BCE authentication scheme (bce-auth-v1).
+// https://cloud.baidu.com/doc/Reference/s/njwvz1yfu
+type signer struct {
+	accessKeyID     string
+	secretAccessKey string
+	expiration      int
+}
+
+// sign adds the Authorization header to the request.
+func (s signer) sign(req *http.Request, now time.Time) {
+	authStringPrefix := fmt.Sprintf("%s/%s/%s/%d", authVersion, s.accessKeyID, now.UTC().Format(authTimestampFormat), s.expiration)
+
+	signingKey := hmacSHA256Hex(s.secretAccessKey, authStringPrefix)
+
+	signedHeaders, canonicalHeaders := canonicalizeHeaders(req)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+	}, "\n")
+
+	signature := hmacSHA256Hex(signingKey, canonicalRequest)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s/%s/%s", authStringPrefix, signedHeaders, signature))
+}
+
+func canonicalizeHeaders(req *http.Request) (signed string, canonical string) {
+	headers := map[string]string{
+		"host": req.URL.Host,
+	}
+
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || lower == "content-md5" || strings.HasPrefix(lower, "x-bce-") {
+			headers[lower] = strings.TrimSpace(req.Header.Get(name))
+		}
+	}
+
+	var names []string
+	var lines []string
+	for name, value := range headers {
+		if value == "" {
+			continue
+		}
+
+		names = append(names, name)
+		lines = append(lines, uriEncode(name, true)+":"+uriEncode(value, true))
+	}
+
+	sort.Strings(names)
+	sort.Strings(lines)
+
+	return strings.Join(names, ";"), strings.Join(lines, "\n")
+}
+
+func canonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return uriEncode(path, false)
+}
+
+func canonicalQuery(query url.Values) string {
+	var parts []string
+	for key, values := range query {
+		if strings.ToLower(key) == "authorization" {
+			continue
+		}
+
+		for _, value := range values {
+			parts = append(parts, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+
+	sort.Strings(parts)
+
+	return strings.Join(parts, "&")
+}
+
+// uriEncode encodes a string following RFC 3986 (only the unreserved characters are kept).
+func uriEncode(value string, encodeSlash bool) string {
+	var builder strings.Builder
+
+	for _, b := range []byte(value) {
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			builder.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			builder.WriteByte(b)
+		default:
+			builder.WriteString(fmt.Sprintf("%%%02X", b))
+		}
+	}
+
+	return builder.String()
+}
+
+func hmacSHA256Hex(key, data string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,182 @@
+// Package bunny implements a DNS provider for solving the DNS-01 challenge using Bunny.net DNS.
+package bunny
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/bunny/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("BUNNY_TTL", 120),
+		PropagationTimeout: env.GetOrDefaultSecond("BUNNY_PROPAGATION_TIMEOUT", 120*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("BUNNY_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("BUNNY_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordInfo struct {
+	zoneID   int64
+	recordID int64
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]recordInfo
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Bunny.net DNS.
+// Credentials must be passed in the environment variable: BUNNY_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("BUNNY_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("bunny: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["BUNNY_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Bunny.net DNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("bunny: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("bunny: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]recordInfo{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("bunny: %v", err)
+	}
+
+	record := internal.Record{
+		Type:  internal.RecordTypeTXT,
+		Name:  subDomain(fqdn, zone.Domain),
+		Value: value,
+		TTL:   d.config.TTL,
+	}
+
+	newRecord, err := d.client.AddRecord(zone.ID, record)
+	if err != nil {
+		return fmt.Errorf("bunny: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone.Domain, fqdn, err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordInfo{zoneID: zone.ID, recordID: newRecord.ID}
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	info, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("bunny: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(info.zoneID, info.recordID)
+	if err != nil {
+		return fmt.Errorf("bunny: failed to delete TXT record [zone: %d, id: %d]: %v", info.zoneID, info.recordID, err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the zone with the longest domain matching the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (*internal.Zone, error) {
+	zones, err := d.client.ListZones()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %v", err)
+	}
+
+	domain := strings.ToLower(dns01.UnFqdn(fqdn))
+
+	var zone *internal.Zone
+	for i, z := range zones {
+		name := strings.ToLower(z.Domain)
+		if domain != name && !strings.HasSuffix(domain, "."+name) {
+			continue
+		}
+
+		if zone == nil || len(name) > len(zone.Domain) {
+			zone = &zones[i]
+		}
+	}
+
+	if zone == nil {
+		return nil, fmt.Errorf("no zone found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+// subDomain returns the name of the record relative to the zone.
+func subDomain(fqdn, zoneDomain string) string {
+	name := dns01.UnFqdn(fqdn)
+	if len(name) <= len(zoneDomain) {
+		return ""
+	}
+
+	return name[:len(name)-len(zoneDomain)-1]
+}
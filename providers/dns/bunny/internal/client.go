@@ -0,0 +1,187 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.bunny.net"
+
+// RecordType the numeric type of a DNS record.
+type RecordType int
+
+// The record types of the Bunny.net DNS API.
+const (
+	RecordTypeA RecordType = iota
+	RecordTypeAAAA
+	RecordTypeCNAME
+	RecordTypeTXT
+	RecordTypeMX
+	RecordTypeRedirect
+	RecordTypeFlatten
+	RecordTypePullZone
+	RecordTypeSRV
+	RecordTypeCAA
+	RecordTypePTR
+	RecordTypeScript
+	RecordTypeNS
+)
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	ErrorKey   string `json:"ErrorKey"`
+	Field      string `json:"Field"`
+	Message    string `json:"Message"`
+}
+
+func (a APIError) Error() string {
+	msg := fmt.Sprintf("[status code: %d] %s: %s", a.StatusCode, a.ErrorKey, a.Message)
+	if a.Field != "" {
+		msg += fmt.Sprintf(" (field: %s)", a.Field)
+	}
+	return msg
+}
+
+// Zone a DNS zone.
+type Zone struct {
+	ID     int64  `json:"Id"`
+	Domain string `json:"Domain"`
+}
+
+// ZonesResponse a page of the DNS zones listing.
+type ZonesResponse struct {
+	Items        []Zone `json:"Items"`
+	CurrentPage  int    `json:"CurrentPage"`
+	TotalItems   int    `json:"TotalItems"`
+	HasMoreItems bool   `json:"HasMoreItems"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID    int64      `json:"Id,omitempty"`
+	Type  RecordType `json:"Type"`
+	Name  string     `json:"Name"`
+	Value string     `json:"Value"`
+	TTL   int        `json:"Ttl,omitempty"`
+}
+
+// Client the Bunny.net DNS API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey string) (*Client, error) {
+	if apiKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// ListZones lists all the DNS zones of the account.
+// https://docs.bunny.net/reference/dnszonepublic_index
+func (c *Client) ListZones() ([]Zone, error) {
+	var zones []Zone
+
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("perPage", "1000")
+
+		result := &ZonesResponse{}
+		err := c.do(http.MethodGet, "/dnszone?"+query.Encode(), nil, result)
+		if err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, result.Items...)
+
+		if !result.HasMoreItems || len(result.Items) == 0 {
+			return zones, nil
+		}
+	}
+}
+
+// AddRecord adds a DNS record to a zone and returns the created record.
+// https://docs.bunny.net/reference/dnszonepublic_addrecord
+func (c *Client) AddRecord(zoneID int64, record Record) (*Record, error) {
+	result := &Record{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/dnszone/%d/records", zoneID), record, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteRecord deletes a DNS record of a zone.
+// https://docs.bunny.net/reference/dnszonepublic_deleterecord
+func (c *Client) DeleteRecord(zoneID, recordID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/dnszone/%d/records/%d", zoneID, recordID), nil, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("AccessKey", c.apiKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Message == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
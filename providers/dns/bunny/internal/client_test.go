@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("AccessKey") != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"ErrorKey":"unauthorized","Message":"Authorization has been denied for this request."}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_ListZones(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dnszone", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("page") {
+		case "1":
+			_, _ = fmt.Fprint(rw, `{"Items":[{"Id":1,"Domain":"example.com"}],"CurrentPage":1,"TotalItems":2,"HasMoreItems":true}`)
+		case "2":
+			_, _ = fmt.Fprint(rw, `{"Items":[{"Id":2,"Domain":"example.org"}],"CurrentPage":2,"TotalItems":2,"HasMoreItems":false}`)
+		default:
+			http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+		}
+	})
+	defer tearDown()
+
+	zones, err := client.ListZones()
+	require.NoError(t, err)
+
+	expected := []Zone{{ID: 1, Domain: "example.com"}, {ID: 2, Domain: "example.org"}}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_ListZones_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dnszone", nil)
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	_, err := client.ListZones()
+	require.EqualError(t, err, "[status code: 401] unauthorized: Authorization has been denied for this request.")
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/dnszone/1/records", func(rw http.ResponseWriter, req *http.Request) {
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Type: RecordTypeTXT, Name: "_acme-challenge", Value: "txtTXTtxt", TTL: 120}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `{"Id":123,"Type":3,"Name":"_acme-challenge","Value":"txtTXTtxt","Ttl":120}`)
+	})
+	defer tearDown()
+
+	record := Record{Type: RecordTypeTXT, Name: "_acme-challenge", Value: "txtTXTtxt", TTL: 120}
+
+	result, err := client.AddRecord(1, record)
+	require.NoError(t, err)
+
+	expected := &Record{ID: 123, Type: RecordTypeTXT, Name: "_acme-challenge", Value: "txtTXTtxt", TTL: 120}
+	assert.Equal(t, expected, result)
+}
+
+func TestClient_AddRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/dnszone/1/records", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(rw, `{"ErrorKey":"validation_error","Field":"Ttl","Message":"The TTL is invalid."}`)
+	})
+	defer tearDown()
+
+	_, err := client.AddRecord(1, Record{Type: RecordTypeTXT, Name: "_acme-challenge", TTL: 1})
+	require.EqualError(t, err, "[status code: 400] validation_error: The TTL is invalid. (field: Ttl)")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/dnszone/1/records/123", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord(1, 123)
+	require.NoError(t, err)
+}
@@ -25,6 +25,10 @@ type Config struct {
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	HTTPClient         *http.Client
+	// MaxConcurrency the maximum number of concurrent API calls (unlimited if lower than 1).
+	MaxConcurrency int
+	// RequestDelay the minimum delay between two API calls.
+	RequestDelay time.Duration
 }
 
 // NewDefaultConfig returns a default configuration for the DNSProvider
@@ -36,6 +40,8 @@ func NewDefaultConfig() *Config {
 		HTTPClient: &http.Client{
 			Timeout: env.GetOrDefaultSecond("CLOUDFLARE_HTTP_TIMEOUT", 30*time.Second),
 		},
+		MaxConcurrency: env.GetOrDefaultInt("CLOUDFLARE_MAX_CONCURRENCY", 0),
+		RequestDelay:   env.GetOrDefaultMillisecond("CLOUDFLARE_REQUEST_DELAY", 0),
 	}
 }
 
@@ -69,11 +75,24 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("cloudflare: the configuration of the DNS provider is nil")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("cloudflare: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL)
+	if err != nil {
+		return nil, fmt.Errorf("cloudflare: %v", err)
+	}
+
+	httpClient := &http.Client{}
+	if config.HTTPClient != nil {
+		// copy the client to not alter the transport of the configuration.
+		clientCopy := *config.HTTPClient
+		httpClient = &clientCopy
 	}
 
-	client, err := cloudflare.New(config.AuthKey, config.AuthEmail, cloudflare.HTTPClient(config.HTTPClient))
+	if config.MaxConcurrency > 0 || config.RequestDelay > 0 {
+		limiter := dns01.NewRateLimiter(config.MaxConcurrency, config.RequestDelay)
+		httpClient.Transport = limiter.Transport(httpClient.Transport)
+	}
+
+	client, err := cloudflare.New(config.AuthKey, config.AuthEmail, cloudflare.HTTPClient(httpClient))
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +106,20 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// Check verifies the credentials and the access to the zones.
+func (d *DNSProvider) Check() error {
+	zones, err := d.client.ListZones()
+	if err != nil {
+		return fmt.Errorf("cloudflare: failed to list the zones: %v", err)
+	}
+
+	if len(zones) == 0 {
+		return errors.New("cloudflare: the credentials don't give access to any zone")
+	}
+
+	return nil
+}
+
 // Present creates a TXT record to fulfill the dns-01 challenge
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
@@ -1,6 +1,9 @@
 package cloudflare
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -122,6 +125,55 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_Check(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		response string
+		expected string
+	}{
+		{
+			desc:     "success",
+			response: `{"success":true,"errors":[],"messages":[],"result":[{"id":"abc","name":"example.com"}]}`,
+		},
+		{
+			desc:     "no zone",
+			response: `{"success":true,"errors":[],"messages":[],"result":[]}`,
+			expected: "cloudflare: the credentials don't give access to any zone",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/zones" {
+					http.NotFound(rw, req)
+					return
+				}
+
+				rw.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(rw, test.response)
+			}))
+			defer server.Close()
+
+			config := NewDefaultConfig()
+			config.AuthEmail = "test@example.com"
+			config.AuthKey = "123"
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			p.client.BaseURL = server.URL
+
+			err = p.Check()
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
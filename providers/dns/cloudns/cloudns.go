@@ -14,7 +14,10 @@ import (
 
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
+	// AuthID, SubAuthID and SubAuthUser the auth identifiers: exactly one must be set.
 	AuthID             string
+	SubAuthID          string
+	SubAuthUser        string
 	AuthPassword       string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
@@ -42,15 +45,23 @@ type DNSProvider struct {
 
 // NewDNSProvider returns a DNSProvider instance configured for ClouDNS.
 // Credentials must be passed in the environment variables:
-// CLOUDNS_AUTH_ID and CLOUDNS_AUTH_PASSWORD.
+// CLOUDNS_AUTH_ID (or CLOUDNS_SUB_AUTH_ID, or CLOUDNS_SUB_AUTH_USER for a sub-user) and CLOUDNS_AUTH_PASSWORD.
 func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get("CLOUDNS_AUTH_ID", "CLOUDNS_AUTH_PASSWORD")
+	config := NewDefaultConfig()
+	config.AuthID = env.GetOrFile("CLOUDNS_AUTH_ID")
+	config.SubAuthID = env.GetOrFile("CLOUDNS_SUB_AUTH_ID")
+	config.SubAuthUser = env.GetOrFile("CLOUDNS_SUB_AUTH_USER")
+
+	required := []string{"CLOUDNS_AUTH_PASSWORD"}
+	if config.SubAuthID == "" && config.SubAuthUser == "" {
+		required = []string{"CLOUDNS_AUTH_ID", "CLOUDNS_AUTH_PASSWORD"}
+	}
+
+	values, err := env.Get(required...)
 	if err != nil {
 		return nil, fmt.Errorf("ClouDNS: %v", err)
 	}
 
-	config := NewDefaultConfig()
-	config.AuthID = values["CLOUDNS_AUTH_ID"]
 	config.AuthPassword = values["CLOUDNS_AUTH_PASSWORD"]
 
 	return NewDNSProviderConfig(config)
@@ -62,7 +73,12 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("ClouDNS: the configuration of the DNS provider is nil")
 	}
 
-	client, err := internal.NewClient(config.AuthID, config.AuthPassword)
+	client, err := internal.NewClient(internal.Credentials{
+		AuthID:       config.AuthID,
+		SubAuthID:    config.SubAuthID,
+		SubAuthUser:  config.SubAuthUser,
+		AuthPassword: config.AuthPassword,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("ClouDNS: %v", err)
 	}
@@ -10,6 +10,8 @@ import (
 
 var envTest = tester.NewEnvTest(
 	"CLOUDNS_AUTH_ID",
+	"CLOUDNS_SUB_AUTH_ID",
+	"CLOUDNS_SUB_AUTH_USER",
 	"CLOUDNS_AUTH_PASSWORD").
 	WithDomain("CLOUDNS_DOMAIN")
 
@@ -26,6 +28,37 @@ func TestNewDNSProvider(t *testing.T) {
 				"CLOUDNS_AUTH_PASSWORD": "456",
 			},
 		},
+		{
+			desc: "success sub-auth-id",
+			envVars: map[string]string{
+				"CLOUDNS_SUB_AUTH_ID":   "123",
+				"CLOUDNS_AUTH_PASSWORD": "456",
+			},
+		},
+		{
+			desc: "success sub-auth-user",
+			envVars: map[string]string{
+				"CLOUDNS_SUB_AUTH_USER": "user",
+				"CLOUDNS_AUTH_PASSWORD": "456",
+			},
+		},
+		{
+			desc: "several auth identifiers",
+			envVars: map[string]string{
+				"CLOUDNS_AUTH_ID":       "123",
+				"CLOUDNS_SUB_AUTH_ID":   "123",
+				"CLOUDNS_AUTH_PASSWORD": "456",
+			},
+			expected: "ClouDNS: only one of authID, subAuthID and subAuthUser must be set",
+		},
+		{
+			desc: "missing sub-auth password",
+			envVars: map[string]string{
+				"CLOUDNS_SUB_AUTH_ID":   "123",
+				"CLOUDNS_AUTH_PASSWORD": "",
+			},
+			expected: "ClouDNS: some credentials information are missing: CLOUDNS_AUTH_PASSWORD",
+		},
 		{
 			desc: "missing credentials",
 			envVars: map[string]string{
@@ -77,6 +110,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc         string
 		authID       string
+		subAuthID    string
 		authPassword string
 		expected     string
 	}{
@@ -85,14 +119,26 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			authID:       "123",
 			authPassword: "456",
 		},
+		{
+			desc:         "success sub-auth-id",
+			subAuthID:    "123",
+			authPassword: "456",
+		},
+		{
+			desc:         "several auth identifiers",
+			authID:       "123",
+			subAuthID:    "123",
+			authPassword: "456",
+			expected:     "ClouDNS: only one of authID, subAuthID and subAuthUser must be set",
+		},
 		{
 			desc:     "missing credentials",
-			expected: "ClouDNS: credentials missing: authID",
+			expected: "ClouDNS: credentials missing: authID, subAuthID or subAuthUser",
 		},
 		{
 			desc:         "missing auth-id",
 			authPassword: "456",
-			expected:     "ClouDNS: credentials missing: authID",
+			expected:     "ClouDNS: credentials missing: authID, subAuthID or subAuthUser",
 		},
 		{
 			desc:     "missing auth-password",
@@ -105,6 +151,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
 			config.AuthID = test.authID
+			config.SubAuthID = test.subAuthID
 			config.AuthPassword = test.authPassword
 
 			p, err := NewDNSProviderConfig(config)
@@ -40,13 +40,39 @@ type TXTRecord struct {
 
 type TXTRecords map[string]TXTRecord
 
+// Credentials the API credentials: exactly one of the auth identifiers (API user, sub-user ID or sub-user name) must be set.
+// https://www.cloudns.net/wiki/article/45/
+type Credentials struct {
+	AuthID       string
+	SubAuthID    string
+	SubAuthUser  string
+	AuthPassword string
+}
+
 // NewClient creates a ClouDNS client
-func NewClient(authID string, authPassword string) (*Client, error) {
-	if authID == "" {
-		return nil, fmt.Errorf("credentials missing: authID")
+func NewClient(credentials Credentials) (*Client, error) {
+	var authParam, authValue string
+	for param, value := range map[string]string{
+		"auth-id":       credentials.AuthID,
+		"sub-auth-id":   credentials.SubAuthID,
+		"sub-auth-user": credentials.SubAuthUser,
+	} {
+		if value == "" {
+			continue
+		}
+
+		if authParam != "" {
+			return nil, errors.New("only one of authID, subAuthID and subAuthUser must be set")
+		}
+
+		authParam, authValue = param, value
+	}
+
+	if authParam == "" {
+		return nil, fmt.Errorf("credentials missing: authID, subAuthID or subAuthUser")
 	}
 
-	if authPassword == "" {
+	if credentials.AuthPassword == "" {
 		return nil, fmt.Errorf("credentials missing: authPassword")
 	}
 
@@ -56,8 +82,9 @@ func NewClient(authID string, authPassword string) (*Client, error) {
 	}
 
 	return &Client{
-		authID:       authID,
-		authPassword: authPassword,
+		authParam:    authParam,
+		authValue:    authValue,
+		authPassword: credentials.AuthPassword,
 		HTTPClient:   &http.Client{},
 		BaseURL:      baseURL,
 	}, nil
@@ -65,7 +92,9 @@ func NewClient(authID string, authPassword string) (*Client, error) {
 
 // Client ClouDNS client
 type Client struct {
-	authID       string
+	// authParam the name of the auth identifier parameter: auth-id, sub-auth-id or sub-auth-user.
+	authParam    string
+	authValue    string
 	authPassword string
 	HTTPClient   *http.Client
 	BaseURL      *url.URL
@@ -229,7 +258,7 @@ func (c *Client) doRequest(method string, url *url.URL) (json.RawMessage, error)
 
 func (c *Client) buildRequest(method string, url *url.URL) (*http.Request, error) {
 	q := url.Query()
-	q.Add("auth-id", c.authID)
+	q.Add(c.authParam, c.authValue)
 	q.Add("auth-password", c.authPassword)
 	url.RawQuery = q.Encode()
 
@@ -62,7 +62,7 @@ func TestClientGetZone(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			server := httptest.NewServer(handlerMock(http.MethodGet, test.apiResponse))
 
-			client, _ := NewClient("myAuthID", "myAuthPassword")
+			client, _ := NewClient(Credentials{AuthID: "myAuthID", AuthPassword: "myAuthPassword"})
 			mockBaseURL, _ := url.Parse(fmt.Sprintf("%s/", server.URL))
 			client.BaseURL = mockBaseURL
 
@@ -140,7 +140,7 @@ func TestClientFindTxtRecord(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			server := httptest.NewServer(handlerMock(http.MethodGet, test.apiResponse))
 
-			client, _ := NewClient("myAuthID", "myAuthPassword")
+			client, _ := NewClient(Credentials{AuthID: "myAuthID", AuthPassword: "myAuthPassword"})
 			mockBaseURL, _ := url.Parse(fmt.Sprintf("%s/", server.URL))
 			client.BaseURL = mockBaseURL
 
@@ -231,7 +231,7 @@ func TestClientAddTxtRecord(t *testing.T) {
 				handlerMock(http.MethodPost, test.apiResponse).ServeHTTP(rw, req)
 			}))
 
-			client, _ := NewClient("myAuthID", "myAuthPassword")
+			client, _ := NewClient(Credentials{AuthID: "myAuthID", AuthPassword: "myAuthPassword"})
 			mockBaseURL, _ := url.Parse(fmt.Sprintf("%s/", server.URL))
 			client.BaseURL = mockBaseURL
 
@@ -245,3 +245,63 @@ func TestClientAddTxtRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClient(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		credentials   Credentials
+		expectedParam url.Values
+		expectedError string
+	}{
+		{
+			desc:          "auth-id",
+			credentials:   Credentials{AuthID: "id", AuthPassword: "secret"},
+			expectedParam: url.Values{"auth-id": {"id"}, "auth-password": {"secret"}},
+		},
+		{
+			desc:          "sub-auth-id",
+			credentials:   Credentials{SubAuthID: "id", AuthPassword: "secret"},
+			expectedParam: url.Values{"sub-auth-id": {"id"}, "auth-password": {"secret"}},
+		},
+		{
+			desc:          "sub-auth-user",
+			credentials:   Credentials{SubAuthUser: "user", AuthPassword: "secret"},
+			expectedParam: url.Values{"sub-auth-user": {"user"}, "auth-password": {"secret"}},
+		},
+		{
+			desc:          "several auth identifiers",
+			credentials:   Credentials{AuthID: "id", SubAuthUser: "user", AuthPassword: "secret"},
+			expectedError: "only one of authID, subAuthID and subAuthUser must be set",
+		},
+		{
+			desc:          "missing auth identifier",
+			credentials:   Credentials{AuthPassword: "secret"},
+			expectedError: "credentials missing: authID, subAuthID or subAuthUser",
+		},
+		{
+			desc:          "missing password",
+			credentials:   Credentials{SubAuthID: "id"},
+			expectedError: "credentials missing: authPassword",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(test.credentials)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			req, err := client.buildRequest(http.MethodGet, &url.URL{Scheme: "https", Host: "example.com"})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedParam, req.URL.Query())
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Package cname implements a DNS provider for solving the DNS-01 challenge through a static CNAME delegation.
+// The TXT records are managed by another DNS provider operating on the zone of the delegation target.
+package cname
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+)
+
+const challengePrefix = "_acme-challenge."
+
+// ProviderFactory creates a DNS provider from its name.
+type ProviderFactory func(name string) (challenge.Provider, error)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	// Provider the DNS provider managing the TXT records in the zone of the delegation target.
+	Provider challenge.Provider
+	// TargetDomain the domain expected to contain the delegation targets (optional).
+	TargetDomain       string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TargetDomain:       env.GetOrFile("CNAME_TARGET_DOMAIN"),
+		PropagationTimeout: env.GetOrDefaultSecond("CNAME_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("CNAME_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	lookupCNAME func(fqdn string) (string, error)
+}
+
+// NewDNSProvider returns a DNSProvider instance delegating the TXT records to another DNS provider.
+// The name of the delegated provider must be passed in the environment variable: CNAME_PROVIDER.
+// The delegated provider is created by the factory, and is configured by its own environment variables.
+func NewDNSProvider(factory ProviderFactory) (*DNSProvider, error) {
+	values, err := env.Get("CNAME_PROVIDER")
+	if err != nil {
+		return nil, fmt.Errorf("cname: %v", err)
+	}
+
+	name := values["CNAME_PROVIDER"]
+	if name == "cname" {
+		return nil, errors.New("cname: the delegated provider cannot be the cname provider")
+	}
+
+	provider, err := factory(name)
+	if err != nil {
+		return nil, fmt.Errorf("cname: failed to create the delegated provider %q: %v", name, err)
+	}
+
+	config := NewDefaultConfig()
+	config.Provider = provider
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance delegating the TXT records to another DNS provider.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("cname: the configuration of the DNS provider is nil")
+	}
+
+	if config.Provider == nil {
+		return nil, errors.New("cname: the delegated provider is missing")
+	}
+
+	return &DNSProvider{config: config, lookupCNAME: dns01.LookupCNAME}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// The values of the delegated provider are used if it defines them.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	if provider, ok := d.config.Provider.(challenge.ProviderTimeout); ok {
+		return provider.Timeout()
+	}
+
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Sequential returns the interval between two challenges of the delegated provider, if it's sequential (see IsSequential).
+func (d *DNSProvider) Sequential() time.Duration {
+	_, interval := dns01.IsSequential(d.config.Provider)
+	return interval
+}
+
+// IsSequential returns true if the delegated provider solves the challenges one after the other.
+func (d *DNSProvider) IsSequential() bool {
+	ok, _ := dns01.IsSequential(d.config.Provider)
+	return ok
+}
+
+// Check verifies the credentials of the delegated provider (see challenge.CredentialChecker).
+// It returns challenge.ErrCheckNotSupported if the delegated provider doesn't support it.
+func (d *DNSProvider) Check() error {
+	checker, ok := d.config.Provider.(challenge.CredentialChecker)
+	if !ok {
+		return challenge.ErrCheckNotSupported
+	}
+
+	err := checker.Check()
+	if err != nil {
+		return fmt.Errorf("cname: %v", err)
+	}
+
+	return nil
+}
+
+// Present creates the TXT record on the delegation target, through the delegated provider.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	target, err := d.delegationDomain(domain)
+	if err != nil {
+		return fmt.Errorf("cname: %v", err)
+	}
+
+	err = d.config.Provider.Present(target, token, keyAuth)
+	if err != nil {
+		return fmt.Errorf("cname: [delegation target: %s] %v", target, err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record of the delegation target, through the delegated provider.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	target, err := d.delegationDomain(domain)
+	if err != nil {
+		return fmt.Errorf("cname: %v", err)
+	}
+
+	err = d.config.Provider.CleanUp(target, token, keyAuth)
+	if err != nil {
+		return fmt.Errorf("cname: [delegation target: %s] %v", target, err)
+	}
+
+	return nil
+}
+
+// delegationDomain verifies the CNAME record of the challenge name of the domain,
+// and returns the domain on which the delegated provider has to create the TXT record.
+//
+//   _acme-challenge.example.com. CNAME _acme-challenge.example.com.delegated.org.
+//                   └── domain                         └── delegation domain
+//
+func (d *DNSProvider) delegationDomain(domain string) (string, error) {
+	fqdn := dns01.ToFqdn(challengePrefix + domain)
+
+	target, err := d.lookupCNAME(fqdn)
+	if err != nil {
+		return "", fmt.Errorf("the CNAME record of %s is missing: %v", fqdn, err)
+	}
+
+	target = strings.ToLower(dns01.ToFqdn(target))
+
+	if !strings.HasPrefix(target, challengePrefix) {
+		return "", fmt.Errorf("the CNAME target of %s must start with %q: %s", fqdn, challengePrefix, target)
+	}
+
+	if d.config.TargetDomain != "" {
+		targetDomain := strings.ToLower(dns01.ToFqdn(d.config.TargetDomain))
+		if !strings.HasSuffix(target, "."+targetDomain) {
+			return "", fmt.Errorf("the CNAME target of %s is not in the domain %s: %s", fqdn, d.config.TargetDomain, target)
+		}
+	}
+
+	return dns01.UnFqdn(strings.TrimPrefix(target, challengePrefix)), nil
+}
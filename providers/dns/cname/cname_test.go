@@ -0,0 +1,278 @@
+package cname
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("CNAME_PROVIDER", "CNAME_TARGET_DOMAIN")
+
+type providerMock struct {
+	presented []string
+	cleaned   []string
+	err       error
+}
+
+func (p *providerMock) Present(domain, token, keyAuth string) error {
+	p.presented = append(p.presented, domain)
+	return p.err
+}
+
+func (p *providerMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleaned = append(p.cleaned, domain)
+	return p.err
+}
+
+type providerTimeoutMock struct {
+	providerMock
+}
+
+func (p *providerTimeoutMock) Timeout() (timeout, interval time.Duration) {
+	return 10 * time.Minute, 20 * time.Second
+}
+
+type providerSequentialMock struct {
+	providerMock
+	interval time.Duration
+}
+
+func (p *providerSequentialMock) Sequential() time.Duration {
+	return p.interval
+}
+
+type providerCheckerMock struct {
+	providerMock
+	err error
+}
+
+func (p *providerCheckerMock) Check() error {
+	return p.err
+}
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"CNAME_PROVIDER": "mock",
+			},
+		},
+		{
+			desc: "missing provider",
+			envVars: map[string]string{
+				"CNAME_PROVIDER": "",
+			},
+			expected: "cname: some credentials information are missing: CNAME_PROVIDER",
+		},
+		{
+			desc: "recursive provider",
+			envVars: map[string]string{
+				"CNAME_PROVIDER": "cname",
+			},
+			expected: "cname: the delegated provider cannot be the cname provider",
+		},
+		{
+			desc: "unknown provider",
+			envVars: map[string]string{
+				"CNAME_PROVIDER": "foo",
+			},
+			expected: `cname: failed to create the delegated provider "foo": unrecognized DNS provider: foo`,
+		},
+	}
+
+	factory := func(name string) (challenge.Provider, error) {
+		if name != "mock" {
+			return nil, fmt.Errorf("unrecognized DNS provider: %s", name)
+		}
+		return &providerMock{}, nil
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider(factory)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.config.Provider)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	_, err := NewDNSProviderConfig(nil)
+	require.EqualError(t, err, "cname: the configuration of the DNS provider is nil")
+
+	_, err = NewDNSProviderConfig(NewDefaultConfig())
+	require.EqualError(t, err, "cname: the delegated provider is missing")
+}
+
+func TestDNSProvider_Timeout(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Provider = &providerMock{}
+	config.PropagationTimeout = time.Minute
+	config.PollingInterval = time.Second
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	timeout, interval := p.Timeout()
+	assert.Equal(t, time.Minute, timeout)
+	assert.Equal(t, time.Second, interval)
+
+	config.Provider = &providerTimeoutMock{}
+
+	timeout, interval = p.Timeout()
+	assert.Equal(t, 10*time.Minute, timeout)
+	assert.Equal(t, 20*time.Second, interval)
+}
+
+func TestDNSProvider_Sequential(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Provider = &providerMock{}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	sequential, _ := dns01.IsSequential(p)
+	assert.False(t, sequential)
+
+	config.Provider = &providerSequentialMock{interval: time.Second}
+
+	sequential, interval := dns01.IsSequential(p)
+	assert.True(t, sequential)
+	assert.Equal(t, time.Second, interval)
+}
+
+func TestDNSProvider_Check(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Provider = &providerCheckerMock{}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.Check()
+	require.NoError(t, err)
+
+	config.Provider = &providerCheckerMock{err: errors.New("oops")}
+
+	err = p.Check()
+	require.EqualError(t, err, "cname: oops")
+
+	config.Provider = &providerMock{}
+
+	err = p.Check()
+	assert.Equal(t, challenge.ErrCheckNotSupported, err)
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		targetDomain string
+		cname        string
+		lookupErr    error
+		providerErr  error
+		expected     string
+		expectedErr  string
+	}{
+		{
+			desc:     "success",
+			cname:    "_acme-challenge.example.com.delegated.org.",
+			expected: "example.com.delegated.org",
+		},
+		{
+			desc:         "success with target domain",
+			targetDomain: "delegated.org",
+			cname:        "_acme-challenge.Example.com.Delegated.org.",
+			expected:     "example.com.delegated.org",
+		},
+		{
+			desc:        "missing CNAME",
+			lookupErr:   errors.New("no CNAME record found for '_acme-challenge.example.com.'"),
+			expectedErr: "cname: the CNAME record of _acme-challenge.example.com. is missing: no CNAME record found for '_acme-challenge.example.com.'",
+		},
+		{
+			desc:        "invalid CNAME target",
+			cname:       "example.com.delegated.org.",
+			expectedErr: `cname: the CNAME target of _acme-challenge.example.com. must start with "_acme-challenge.": example.com.delegated.org.`,
+		},
+		{
+			desc:         "CNAME target outside of the target domain",
+			targetDomain: "delegated.org",
+			cname:        "_acme-challenge.example.com.other.org.",
+			expectedErr:  "cname: the CNAME target of _acme-challenge.example.com. is not in the domain delegated.org: _acme-challenge.example.com.other.org.",
+		},
+		{
+			desc:        "delegated provider error",
+			cname:       "_acme-challenge.example.com.delegated.org.",
+			providerErr: errors.New("oops"),
+			expected:    "example.com.delegated.org",
+			expectedErr: "cname: [delegation target: example.com.delegated.org] oops",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			provider := &providerMock{err: test.providerErr}
+
+			config := NewDefaultConfig()
+			config.Provider = provider
+			config.TargetDomain = test.targetDomain
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			p.lookupCNAME = func(fqdn string) (string, error) {
+				if fqdn != "_acme-challenge.example.com." {
+					return "", fmt.Errorf("unexpected FQDN: %s", fqdn)
+				}
+				return test.cname, test.lookupErr
+			}
+
+			err = p.Present("example.com", "token", "keyAuth")
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if test.expected != "" {
+				assert.Equal(t, []string{test.expected}, provider.presented)
+			} else {
+				assert.Empty(t, provider.presented)
+			}
+
+			err = p.CleanUp("example.com", "token", "keyAuth")
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, []string{test.expected}, provider.cleaned)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package dinahosting implements a DNS provider for solving the DNS-01 challenge using Dinahosting.
+package dinahosting
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/dinahosting/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	Username           string
+	Password           string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		PropagationTimeout: env.GetOrDefaultSecond("DINAHOSTING_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("DINAHOSTING_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("DINAHOSTING_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Dinahosting.
+// Credentials must be passed in the environment variables: DINAHOSTING_USERNAME, DINAHOSTING_PASSWORD.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("DINAHOSTING_USERNAME", "DINAHOSTING_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("dinahosting: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Username = values["DINAHOSTING_USERNAME"]
+	config.Password = values["DINAHOSTING_PASSWORD"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Dinahosting.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("dinahosting: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.Username, config.Password)
+	if err != nil {
+		return nil, fmt.Errorf("dinahosting: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, hostname, err := splitFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("dinahosting: %v", err)
+	}
+
+	err = d.client.AddTXTRecord(zone, hostname, value)
+	if err != nil {
+		return fmt.Errorf("dinahosting: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone, fqdn, err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, hostname, err := splitFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("dinahosting: %v", err)
+	}
+
+	err = d.client.DeleteTXTRecord(zone, hostname, value)
+	if err != nil {
+		return fmt.Errorf("dinahosting: failed to delete TXT record [zone: %q, fqdn: %q]: %v", zone, fqdn, err)
+	}
+
+	return nil
+}
+
+// splitFqdn returns the zone of the FQDN and the name of the record relative to the zone.
+func splitFqdn(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", fmt.Errorf("could not determine the zone: %v", err)
+	}
+
+	zone := dns01.UnFqdn(authZone)
+
+	return zone, strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone), nil
+}
@@ -0,0 +1,148 @@
+package dinahosting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vostronet/lego/platform/tester"
+)
+
+var envTest = tester.NewEnvTest("DINAHOSTING_USERNAME", "DINAHOSTING_PASSWORD").
+	WithDomain("DINAHOSTING_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"DINAHOSTING_USERNAME": "user",
+				"DINAHOSTING_PASSWORD": "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"DINAHOSTING_USERNAME": "",
+				"DINAHOSTING_PASSWORD": "",
+			},
+			expected: "dinahosting: some credentials information are missing: DINAHOSTING_USERNAME,DINAHOSTING_PASSWORD",
+		},
+		{
+			desc: "missing username",
+			envVars: map[string]string{
+				"DINAHOSTING_USERNAME": "",
+				"DINAHOSTING_PASSWORD": "secret",
+			},
+			expected: "dinahosting: some credentials information are missing: DINAHOSTING_USERNAME",
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				"DINAHOSTING_USERNAME": "user",
+				"DINAHOSTING_PASSWORD": "",
+			},
+			expected: "dinahosting: some credentials information are missing: DINAHOSTING_PASSWORD",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		username string
+		password string
+		expected string
+	}{
+		{
+			desc:     "success",
+			username: "user",
+			password: "secret",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "dinahosting: credentials missing",
+		},
+		{
+			desc:     "missing username",
+			password: "secret",
+			expected: "dinahosting: credentials missing",
+		},
+		{
+			desc:     "missing password",
+			username: "user",
+			expected: "dinahosting: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Username = test.username
+			config.Password = test.password
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultBaseURL = "https://dinahosting.com/special/api.php"
+
+// ResponseCodeSuccess the response code of the successful commands.
+const ResponseCodeSuccess = 1000
+
+// ErrorDetail an error of a command.
+type ErrorDetail struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// APIResponse the response of a command.
+type APIResponse struct {
+	TrID         string          `json:"trId"`
+	ResponseCode int             `json:"responseCode"`
+	Message      string          `json:"message"`
+	Command      string          `json:"command"`
+	Errors       []ErrorDetail   `json:"errors"`
+	Data         json.RawMessage `json:"data"`
+}
+
+// APIError the error returned by a command.
+type APIError struct {
+	APIResponse
+}
+
+func (a APIError) Error() string {
+	msg := fmt.Sprintf("%s: [response code: %d] %s", a.Command, a.ResponseCode, a.Message)
+
+	var details []string
+	for _, detail := range a.Errors {
+		details = append(details, fmt.Sprintf("%d: %s", detail.Code, detail.Message))
+	}
+
+	if len(details) > 0 {
+		msg += ": " + strings.Join(details, ", ")
+	}
+
+	return msg
+}
+
+// Client the Dinahosting API client.
+type Client struct {
+	username   string
+	password   string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(username, password string) (*Client, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		username:   username,
+		password:   password,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// AddTXTRecord adds a TXT record to a domain.
+// https://en.dinahosting.com/api/documentation
+func (c *Client) AddTXTRecord(domain, hostname, text string) error {
+	params := url.Values{}
+	params.Set("domain", domain)
+	params.Set("hostname", hostname)
+	params.Set("text", text)
+
+	return c.do("Domain_Zone_AddTypeTXT", params)
+}
+
+// DeleteTXTRecord deletes a TXT record of a domain.
+// https://en.dinahosting.com/api/documentation
+func (c *Client) DeleteTXTRecord(domain, hostname, value string) error {
+	params := url.Values{}
+	params.Set("domain", domain)
+	params.Set("hostname", hostname)
+	params.Set("value", value)
+
+	return c.do("Domain_Zone_DeleteTypeTXT", params)
+}
+
+func (c *Client) do(command string, params url.Values) error {
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL, strings.NewReader(encodeCommand(command, params)))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(c.username, c.password)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+	}
+
+	result := APIResponse{}
+	err = json.Unmarshal(raw, &result)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: [status code: %d] %s: %v", resp.StatusCode, string(raw), err)
+	}
+
+	if result.ResponseCode != ResponseCodeSuccess {
+		if result.Command == "" {
+			result.Command = command
+		}
+
+		return &APIError{APIResponse: result}
+	}
+
+	return nil
+}
+
+// encodeCommand encodes the command and its parameters as a form, the response is requested in JSON.
+func encodeCommand(command string, params url.Values) string {
+	values := url.Values{}
+	for key, value := range params {
+		values[key] = value
+	}
+
+	values.Set("command", command)
+	values.Set("responseType", "Json")
+
+	return values.Encode()
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, expectedForm string, response string) (*Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			http.Error(rw, fmt.Sprintf("invalid content type: %s", req.Header.Get("Content-Type")), http.StatusBadRequest)
+			return
+		}
+
+		user, password, ok := req.BasicAuth()
+		if !ok || user != "user" || password != "secret" {
+			http.Error(rw, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		raw, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if string(raw) != expectedForm {
+			http.Error(rw, fmt.Sprintf("invalid form: %s", string(raw)), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, response)
+	}))
+
+	client, err := NewClient("user", "secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func Test_encodeCommand(t *testing.T) {
+	params := url.Values{}
+	params.Set("domain", "example.com")
+	params.Set("hostname", "_acme-challenge.sub")
+	params.Set("text", "a+b/c=")
+
+	form := encodeCommand("Domain_Zone_AddTypeTXT", params)
+
+	assert.Equal(t, "command=Domain_Zone_AddTypeTXT&domain=example.com&hostname=_acme-challenge.sub&responseType=Json&text=a%2Bb%2Fc%3D", form)
+
+	// the parameters are not modified.
+	assert.Equal(t, url.Values{"domain": {"example.com"}, "hostname": {"_acme-challenge.sub"}, "text": {"a+b/c="}}, params)
+}
+
+func TestClient_AddTXTRecord(t *testing.T) {
+	client, tearDown := setupTest(t,
+		"command=Domain_Zone_AddTypeTXT&domain=example.com&hostname=_acme-challenge&responseType=Json&text=txtTXTtxt",
+		`{"trId":"dinahosting.1.2","responseCode":1000,"message":"Success.","data":true,"command":"Domain_Zone_AddTypeTXT"}`)
+	defer tearDown()
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", "txtTXTtxt")
+	require.NoError(t, err)
+}
+
+func TestClient_AddTXTRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t,
+		"command=Domain_Zone_AddTypeTXT&domain=example.com&hostname=_acme-challenge&responseType=Json&text=txtTXTtxt",
+		`{"trId":"dinahosting.1.2","responseCode":2302,"message":"Object exists.","errors":[{"code":2302,"message":"The record already exists."}],"command":"Domain_Zone_AddTypeTXT"}`)
+	defer tearDown()
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", "txtTXTtxt")
+	require.EqualError(t, err, "Domain_Zone_AddTypeTXT: [response code: 2302] Object exists.: 2302: The record already exists.")
+}
+
+func TestClient_AddTXTRecord_unauthorized(t *testing.T) {
+	client, tearDown := setupTest(t, "", "")
+	defer tearDown()
+
+	client.password = "invalid"
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", "txtTXTtxt")
+	require.EqualError(t, err, "unexpected status code: [status code: 401] Unauthorized\n")
+}
+
+func TestClient_DeleteTXTRecord(t *testing.T) {
+	client, tearDown := setupTest(t,
+		"command=Domain_Zone_DeleteTypeTXT&domain=example.com&hostname=_acme-challenge&responseType=Json&value=txtTXTtxt",
+		`{"trId":"dinahosting.1.3","responseCode":1000,"message":"Success.","data":true,"command":"Domain_Zone_DeleteTypeTXT"}`)
+	defer tearDown()
+
+	err := client.DeleteTXTRecord("example.com", "_acme-challenge", "txtTXTtxt")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteTXTRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t,
+		"command=Domain_Zone_DeleteTypeTXT&domain=example.com&hostname=_acme-challenge&responseType=Json&value=txtTXTtxt",
+		`{"trId":"dinahosting.1.3","responseCode":2303,"message":"Object does not exist.","command":"Domain_Zone_DeleteTypeTXT"}`)
+	defer tearDown()
+
+	err := client.DeleteTXTRecord("example.com", "_acme-challenge", "txtTXTtxt")
+	require.EqualError(t, err, "Domain_Zone_DeleteTypeTXT: [response code: 2303] Object does not exist.")
+}
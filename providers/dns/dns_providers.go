@@ -5,18 +5,25 @@ import (
 
 	"github.com/vostronet/lego/challenge"
 	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
 	"github.com/vostronet/lego/providers/dns/acmedns"
+	"github.com/vostronet/lego/providers/dns/active24"
 	"github.com/vostronet/lego/providers/dns/alidns"
+	"github.com/vostronet/lego/providers/dns/arvancloud"
 	"github.com/vostronet/lego/providers/dns/auroradns"
 	"github.com/vostronet/lego/providers/dns/azure"
+	"github.com/vostronet/lego/providers/dns/baiducloud"
 	"github.com/vostronet/lego/providers/dns/bindman"
 	"github.com/vostronet/lego/providers/dns/bluecat"
+	"github.com/vostronet/lego/providers/dns/bunny"
 	"github.com/vostronet/lego/providers/dns/cloudflare"
 	"github.com/vostronet/lego/providers/dns/cloudns"
 	"github.com/vostronet/lego/providers/dns/cloudxns"
+	"github.com/vostronet/lego/providers/dns/cname"
 	"github.com/vostronet/lego/providers/dns/conoha"
 	"github.com/vostronet/lego/providers/dns/designate"
 	"github.com/vostronet/lego/providers/dns/digitalocean"
+	"github.com/vostronet/lego/providers/dns/dinahosting"
 	"github.com/vostronet/lego/providers/dns/dnsimple"
 	"github.com/vostronet/lego/providers/dns/dnsmadeeasy"
 	"github.com/vostronet/lego/providers/dns/dnspod"
@@ -24,74 +31,124 @@ import (
 	"github.com/vostronet/lego/providers/dns/dreamhost"
 	"github.com/vostronet/lego/providers/dns/duckdns"
 	"github.com/vostronet/lego/providers/dns/dyn"
+	"github.com/vostronet/lego/providers/dns/dynu"
 	"github.com/vostronet/lego/providers/dns/easydns"
+	"github.com/vostronet/lego/providers/dns/epik"
 	"github.com/vostronet/lego/providers/dns/exec"
 	"github.com/vostronet/lego/providers/dns/exoscale"
 	"github.com/vostronet/lego/providers/dns/fastdns"
 	"github.com/vostronet/lego/providers/dns/gandi"
 	"github.com/vostronet/lego/providers/dns/gandiv5"
 	"github.com/vostronet/lego/providers/dns/gcloud"
+	"github.com/vostronet/lego/providers/dns/gcore"
 	"github.com/vostronet/lego/providers/dns/glesys"
 	"github.com/vostronet/lego/providers/dns/godaddy"
 	"github.com/vostronet/lego/providers/dns/hostingde"
+	"github.com/vostronet/lego/providers/dns/hosttech"
 	"github.com/vostronet/lego/providers/dns/httpreq"
+	"github.com/vostronet/lego/providers/dns/hurricane"
 	"github.com/vostronet/lego/providers/dns/iij"
+	"github.com/vostronet/lego/providers/dns/infomaniak"
 	"github.com/vostronet/lego/providers/dns/inwx"
+	"github.com/vostronet/lego/providers/dns/ionos"
 	"github.com/vostronet/lego/providers/dns/joker"
+	"github.com/vostronet/lego/providers/dns/leaseweb"
 	"github.com/vostronet/lego/providers/dns/lightsail"
 	"github.com/vostronet/lego/providers/dns/linode"
 	"github.com/vostronet/lego/providers/dns/linodev4"
+	"github.com/vostronet/lego/providers/dns/loopia"
+	"github.com/vostronet/lego/providers/dns/multi"
 	"github.com/vostronet/lego/providers/dns/mydnsjp"
 	"github.com/vostronet/lego/providers/dns/namecheap"
 	"github.com/vostronet/lego/providers/dns/namedotcom"
+	"github.com/vostronet/lego/providers/dns/namesilo"
 	"github.com/vostronet/lego/providers/dns/netcup"
+	"github.com/vostronet/lego/providers/dns/netlify"
 	"github.com/vostronet/lego/providers/dns/nifcloud"
+	"github.com/vostronet/lego/providers/dns/njalla"
 	"github.com/vostronet/lego/providers/dns/ns1"
 	"github.com/vostronet/lego/providers/dns/oraclecloud"
 	"github.com/vostronet/lego/providers/dns/otc"
 	"github.com/vostronet/lego/providers/dns/ovh"
 	"github.com/vostronet/lego/providers/dns/pdns"
+	"github.com/vostronet/lego/providers/dns/porkbun"
 	"github.com/vostronet/lego/providers/dns/rackspace"
 	"github.com/vostronet/lego/providers/dns/rfc2136"
 	"github.com/vostronet/lego/providers/dns/route53"
 	"github.com/vostronet/lego/providers/dns/sakuracloud"
+	"github.com/vostronet/lego/providers/dns/scaleway"
 	"github.com/vostronet/lego/providers/dns/selectel"
+	"github.com/vostronet/lego/providers/dns/simply"
+	"github.com/vostronet/lego/providers/dns/spaceship"
 	"github.com/vostronet/lego/providers/dns/stackpath"
+	"github.com/vostronet/lego/providers/dns/timewebcloud"
 	"github.com/vostronet/lego/providers/dns/transip"
 	"github.com/vostronet/lego/providers/dns/vegadns"
 	"github.com/vostronet/lego/providers/dns/versio"
+	"github.com/vostronet/lego/providers/dns/volcengine"
 	"github.com/vostronet/lego/providers/dns/vscale"
 	"github.com/vostronet/lego/providers/dns/vultr"
+	"github.com/vostronet/lego/providers/dns/webhook"
+	"github.com/vostronet/lego/providers/dns/websupport"
 	"github.com/vostronet/lego/providers/dns/zoneee"
 )
 
 // NewDNSChallengeProviderByName Factory for DNS providers
 func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
+	provider, err := newDNSChallengeProviderByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// the propagation timings are checked when the provider is created, rather than during the issuance.
+	if p, ok := provider.(challenge.ProviderTimeout); ok {
+		err = env.ValidatePropagation(p.Timeout())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	return provider, nil
+}
+
+func newDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 	switch name {
 	case "acme-dns":
 		return acmedns.NewDNSProvider()
+	case "active24":
+		return active24.NewDNSProvider()
 	case "alidns":
 		return alidns.NewDNSProvider()
+	case "arvancloud":
+		return arvancloud.NewDNSProvider()
 	case "azure":
 		return azure.NewDNSProvider()
 	case "auroradns":
 		return auroradns.NewDNSProvider()
+	case "baiducloud":
+		return baiducloud.NewDNSProvider()
 	case "bindman":
 		return bindman.NewDNSProvider()
 	case "bluecat":
 		return bluecat.NewDNSProvider()
+	case "bunny":
+		return bunny.NewDNSProvider()
 	case "cloudflare":
 		return cloudflare.NewDNSProvider()
 	case "cloudns":
 		return cloudns.NewDNSProvider()
 	case "cloudxns":
 		return cloudxns.NewDNSProvider()
+	case "cname":
+		return cname.NewDNSProvider(NewDNSChallengeProviderByName)
 	case "conoha":
 		return conoha.NewDNSProvider()
 	case "designate":
 		return designate.NewDNSProvider()
 	case "digitalocean":
 		return digitalocean.NewDNSProvider()
+	case "dinahosting":
+		return dinahosting.NewDNSProvider()
 	case "dnsimple":
 		return dnsimple.NewDNSProvider()
 	case "dnsmadeeasy":
@@ -106,10 +163,14 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return duckdns.NewDNSProvider()
 	case "dyn":
 		return dyn.NewDNSProvider()
+	case "dynu":
+		return dynu.NewDNSProvider()
 	case "fastdns":
 		return fastdns.NewDNSProvider()
 	case "easydns":
 		return easydns.NewDNSProvider()
+	case "epik":
+		return epik.NewDNSProvider()
 	case "exec":
 		return exec.NewDNSProvider()
 	case "exoscale":
@@ -122,36 +183,58 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return glesys.NewDNSProvider()
 	case "gcloud":
 		return gcloud.NewDNSProvider()
+	case "gcore":
+		return gcore.NewDNSProvider()
 	case "godaddy":
 		return godaddy.NewDNSProvider()
 	case "hostingde":
 		return hostingde.NewDNSProvider()
+	case "hosttech":
+		return hosttech.NewDNSProvider()
 	case "httpreq":
 		return httpreq.NewDNSProvider()
+	case "hurricane":
+		return hurricane.NewDNSProvider()
 	case "iij":
 		return iij.NewDNSProvider()
+	case "infomaniak":
+		return infomaniak.NewDNSProvider()
 	case "inwx":
 		return inwx.NewDNSProvider()
+	case "ionos":
+		return ionos.NewDNSProvider()
 	case "joker":
 		return joker.NewDNSProvider()
+	case "leaseweb":
+		return leaseweb.NewDNSProvider()
 	case "lightsail":
 		return lightsail.NewDNSProvider()
 	case "linode":
 		return linode.NewDNSProvider()
 	case "linodev4":
 		return linodev4.NewDNSProvider()
+	case "loopia":
+		return loopia.NewDNSProvider()
 	case "manual":
 		return dns01.NewDNSProviderManual()
+	case "multi":
+		return multi.NewDNSProvider(NewDNSChallengeProviderByName)
 	case "mydnsjp":
 		return mydnsjp.NewDNSProvider()
 	case "namecheap":
 		return namecheap.NewDNSProvider()
 	case "namedotcom":
 		return namedotcom.NewDNSProvider()
+	case "namesilo":
+		return namesilo.NewDNSProvider()
 	case "netcup":
 		return netcup.NewDNSProvider()
+	case "netlify":
+		return netlify.NewDNSProvider()
 	case "nifcloud":
 		return nifcloud.NewDNSProvider()
+	case "njalla":
+		return njalla.NewDNSProvider()
 	case "ns1":
 		return ns1.NewDNSProvider()
 	case "oraclecloud":
@@ -162,6 +245,8 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return ovh.NewDNSProvider()
 	case "pdns":
 		return pdns.NewDNSProvider()
+	case "porkbun":
+		return porkbun.NewDNSProvider()
 	case "rackspace":
 		return rackspace.NewDNSProvider()
 	case "route53":
@@ -170,20 +255,34 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return rfc2136.NewDNSProvider()
 	case "sakuracloud":
 		return sakuracloud.NewDNSProvider()
+	case "scaleway":
+		return scaleway.NewDNSProvider()
 	case "stackpath":
 		return stackpath.NewDNSProvider()
 	case "selectel":
 		return selectel.NewDNSProvider()
+	case "simply":
+		return simply.NewDNSProvider()
+	case "spaceship":
+		return spaceship.NewDNSProvider()
+	case "timewebcloud":
+		return timewebcloud.NewDNSProvider()
 	case "transip":
 		return transip.NewDNSProvider()
 	case "vegadns":
 		return vegadns.NewDNSProvider()
 	case "versio":
 		return versio.NewDNSProvider()
+	case "volcengine":
+		return volcengine.NewDNSProvider()
 	case "vultr":
 		return vultr.NewDNSProvider()
 	case "vscale":
 		return vscale.NewDNSProvider()
+	case "webhook":
+		return webhook.NewDNSProvider()
+	case "websupport":
+		return websupport.NewDNSProvider()
 	case "zoneee":
 		return zoneee.NewDNSProvider()
 	default:
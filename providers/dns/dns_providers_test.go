@@ -9,7 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var envTest = tester.NewEnvTest("EXEC_PATH")
+var envTest = tester.NewEnvTest("EXEC_PATH", "EXEC_PROPAGATION_TIMEOUT", "EXEC_POLLING_INTERVAL")
 
 func TestKnownDNSProviderSuccess(t *testing.T) {
 	defer envTest.RestoreEnv()
@@ -33,6 +33,21 @@ func TestKnownDNSProviderError(t *testing.T) {
 	assert.Nil(t, provider)
 }
 
+func TestKnownDNSProviderInvalidPropagation(t *testing.T) {
+	defer envTest.RestoreEnv()
+	envTest.ClearEnv()
+
+	envTest.Apply(map[string]string{
+		"EXEC_PATH":                "abc",
+		"EXEC_PROPAGATION_TIMEOUT": "1",
+		"EXEC_POLLING_INTERVAL":    "2",
+	})
+
+	provider, err := NewDNSChallengeProviderByName("exec")
+	require.EqualError(t, err, "exec: invalid propagation timeout (1s): the propagation timeout must be greater than the polling interval (2s)")
+	assert.Nil(t, provider)
+}
+
 func TestUnknownDNSProvider(t *testing.T) {
 	provider, err := NewDNSChallengeProviderByName("foobar")
 	assert.Error(t, err)
@@ -0,0 +1,157 @@
+// Package dynu implements a DNS provider for solving the DNS-01 challenge using Dynu DNS.
+package dynu
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/dynu/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("DYNU_TTL", 300),
+		PropagationTimeout: env.GetOrDefaultSecond("DYNU_PROPAGATION_TIMEOUT", 120*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("DYNU_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("DYNU_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordInfo struct {
+	domainID int64
+	recordID int64
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]recordInfo
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Dynu DNS.
+// Credentials must be passed in the environment variable: DYNU_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("DYNU_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("dynu: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["DYNU_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Dynu DNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("dynu: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("dynu: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]recordInfo{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	root, err := d.client.GetRootDomain(dns01.UnFqdn(fqdn))
+	if err != nil {
+		return fmt.Errorf("dynu: could not find the root domain for %s: %v", fqdn, err)
+	}
+
+	record := internal.DNSRecord{
+		NodeName:   nodeName(fqdn, root.DomainName),
+		RecordType: "TXT",
+		TTL:        d.config.TTL,
+		State:      true,
+		TextData:   value,
+	}
+
+	newRecord, err := d.client.AddRecord(root.ID, record)
+	if err != nil {
+		return fmt.Errorf("dynu: failed to add TXT record [domain: %q, fqdn: %q]: %v", root.DomainName, fqdn, err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordInfo{domainID: root.ID, recordID: newRecord.ID}
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	info, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("dynu: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(info.domainID, info.recordID)
+	if err != nil {
+		return fmt.Errorf("dynu: failed to delete TXT record [domain id: %d, id: %d]: %v", info.domainID, info.recordID, err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// nodeName returns the node name of the record: the name of the record relative to the root domain.
+func nodeName(fqdn, rootDomain string) string {
+	name := strings.ToLower(dns01.UnFqdn(fqdn))
+	root := strings.ToLower(dns01.UnFqdn(rootDomain))
+
+	if name == root || !strings.HasSuffix(name, "."+root) {
+		return ""
+	}
+
+	return strings.TrimSuffix(name, "."+root)
+}
@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.dynu.com/v2"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int    `json:"statusCode"`
+	Type       string `json:"type"`
+	Message    string `json:"message"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("[status code: %d] %s: %s", a.StatusCode, a.Type, a.Message)
+}
+
+// DNSHostname the root domain of a hostname.
+type DNSHostname struct {
+	ID         int64  `json:"id"`
+	DomainName string `json:"domainName"`
+	Hostname   string `json:"hostname"`
+	Node       string `json:"node"`
+}
+
+// DNSRecord a DNS record.
+type DNSRecord struct {
+	ID         int64  `json:"id,omitempty"`
+	DomainID   int64  `json:"domainId,omitempty"`
+	NodeName   string `json:"nodeName"`
+	RecordType string `json:"recordType"`
+	TTL        int    `json:"ttl,omitempty"`
+	State      bool   `json:"state"`
+	TextData   string `json:"textData,omitempty"`
+}
+
+// Client the Dynu API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey string) (*Client, error) {
+	if apiKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// GetRootDomain returns the root domain (the DNS service) managing a hostname.
+// https://www.dynu.com/Support/API#/dns/dnsGetRootDomainHostnameGet
+func (c *Client) GetRootDomain(hostname string) (*DNSHostname, error) {
+	result := &DNSHostname{}
+	err := c.do(http.MethodGet, fmt.Sprintf("/dns/getroot/%s", hostname), nil, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AddRecord adds a DNS record to a root domain and returns the created record.
+// https://www.dynu.com/Support/API#/dns/dnsIdRecordPost
+func (c *Client) AddRecord(domainID int64, record DNSRecord) (*DNSRecord, error) {
+	result := &DNSRecord{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/dns/%d/record", domainID), record, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteRecord deletes a DNS record of a root domain.
+// https://www.dynu.com/Support/API#/dns/dnsIdRecordDnsRecordIdDelete
+func (c *Client) DeleteRecord(domainID, recordID int64) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/dns/%d/record/%d", domainID, recordID), nil, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("API-Key", c.apiKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Message == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		apiErr.StatusCode = resp.StatusCode
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("API-Key") != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"statusCode":401,"type":"Authentication Exception","message":"API-Key is invalid."}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_GetRootDomain(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dns/getroot/_acme-challenge.sub.example.com", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"statusCode":200,"id":123,"domainName":"example.com","hostname":"_acme-challenge.sub.example.com","node":"_acme-challenge.sub"}`)
+	})
+	defer tearDown()
+
+	root, err := client.GetRootDomain("_acme-challenge.sub.example.com")
+	require.NoError(t, err)
+
+	expected := &DNSHostname{ID: 123, DomainName: "example.com", Hostname: "_acme-challenge.sub.example.com", Node: "_acme-challenge.sub"}
+	assert.Equal(t, expected, root)
+}
+
+func TestClient_GetRootDomain_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dns/getroot/example.com", nil)
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	_, err := client.GetRootDomain("example.com")
+	require.EqualError(t, err, "[status code: 401] Authentication Exception: API-Key is invalid.")
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/dns/123/record", func(rw http.ResponseWriter, req *http.Request) {
+		record := DNSRecord{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := DNSRecord{NodeName: "_acme-challenge.sub", RecordType: "TXT", TTL: 300, State: true, TextData: "txtTXTtxt"}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %v", record), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"statusCode":200,"id":456,"domainId":123,"nodeName":"_acme-challenge.sub","recordType":"TXT","ttl":300,"state":true,"textData":"txtTXTtxt"}`)
+	})
+	defer tearDown()
+
+	record := DNSRecord{NodeName: "_acme-challenge.sub", RecordType: "TXT", TTL: 300, State: true, TextData: "txtTXTtxt"}
+
+	newRecord, err := client.AddRecord(123, record)
+	require.NoError(t, err)
+
+	expected := &DNSRecord{ID: 456, DomainID: 123, NodeName: "_acme-challenge.sub", RecordType: "TXT", TTL: 300, State: true, TextData: "txtTXTtxt"}
+	assert.Equal(t, expected, newRecord)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/dns/123/record/456", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"statusCode":200}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord(123, 456)
+	require.NoError(t, err)
+}
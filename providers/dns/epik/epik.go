@@ -0,0 +1,150 @@
+// Package epik implements a DNS provider for solving the DNS-01 challenge using Epik.
+package epik
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/epik/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	Signature          string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("EPIK_TTL", 3600),
+		PropagationTimeout: env.GetOrDefaultSecond("EPIK_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("EPIK_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("EPIK_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Epik.
+// Credentials must be passed in the environment variable: EPIK_SIGNATURE.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("EPIK_SIGNATURE")
+	if err != nil {
+		return nil, fmt.Errorf("epik: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Signature = values["EPIK_SIGNATURE"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Epik.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("epik: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("epik: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("epik: %v", err)
+	}
+
+	record := internal.RecordRequest{
+		Host: subDomain,
+		Type: "TXT",
+		Data: value,
+		TTL:  d.config.TTL,
+	}
+
+	_, err = d.client.CreateHostRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("epik: failed to create record: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+// The API doesn't return the ID of the created record: the record is identified by its host and its value.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("epik: %v", err)
+	}
+
+	records, err := d.client.GetDNSRecords(zone)
+	if err != nil {
+		return fmt.Errorf("epik: failed to retrieve records: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Type != "TXT" || !strings.EqualFold(record.Name, subDomain) || record.Data != value {
+			continue
+		}
+
+		_, err = d.client.RemoveHostRecord(zone, record.ID)
+		if err != nil {
+			return fmt.Errorf("epik: failed to delete record %s: %v", record.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// splitDomain returns the zone and the sub-domain (relative to the zone) of a FQDN.
+func splitDomain(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", err
+	}
+
+	zone := dns01.UnFqdn(authZone)
+	subDomain := strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone)
+
+	return zone, subDomain, nil
+}
@@ -0,0 +1,200 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultBaseURL = "https://usersapiv2.epik.com/v2"
+
+// Error an error of the API.
+type Error struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
+}
+
+// APIError the errors returned by the API.
+type APIError struct {
+	StatusCode int      `json:"-"`
+	Errors     []*Error `json:"errors"`
+}
+
+func (a APIError) Error() string {
+	var msg []string
+	for _, e := range a.Errors {
+		m := fmt.Sprintf("%s: %s", e.Code, e.Message)
+		if e.Description != "" {
+			m += fmt.Sprintf(" (%s)", e.Description)
+		}
+		msg = append(msg, m)
+	}
+
+	return fmt.Sprintf("[status code: %d] %s", a.StatusCode, strings.Join(msg, ", "))
+}
+
+// Record a DNS host record.
+type Record struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+	Aux  int    `json:"aux"`
+	TTL  int    `json:"ttl"`
+}
+
+// RecordRequest the payload of a host record creation.
+type RecordRequest struct {
+	Host string `json:"HOST"`
+	Type string `json:"TYPE"`
+	Data string `json:"DATA"`
+	Aux  int    `json:"AUX"`
+	TTL  int    `json:"TTL"`
+}
+
+// CreateHostRecords the request of a host record creation.
+type CreateHostRecords struct {
+	Payload RecordRequest `json:"create_host_records_payload"`
+}
+
+// RecordsData the host records of a domain.
+type RecordsData struct {
+	Name    string   `json:"name"`
+	Code    int      `json:"code"`
+	Records []Record `json:"records"`
+}
+
+// RecordsResponse the response of the host records listing.
+type RecordsResponse struct {
+	Data *RecordsData `json:"data"`
+}
+
+// Data the result of a host records modification.
+type Data struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Client the Epik API client.
+type Client struct {
+	signature  string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(signature string) (*Client, error) {
+	if signature == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		signature:  signature,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// GetDNSRecords lists the host records of a domain.
+// https://docs-userapi.epik.com/v2/#/DNS%20Host%20Records/getDnsRecord
+func (c *Client) GetDNSRecords(domain string) ([]Record, error) {
+	result := &RecordsResponse{}
+	err := c.do(http.MethodGet, fmt.Sprintf("/domains/%s/records", domain), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Data == nil {
+		return nil, nil
+	}
+
+	return result.Data.Records, nil
+}
+
+// CreateHostRecord creates a host record of a domain.
+// https://docs-userapi.epik.com/v2/#/DNS%20Host%20Records/createHostRecord
+func (c *Client) CreateHostRecord(domain string, record RecordRequest) (*Data, error) {
+	result := &Data{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/domains/%s/records", domain), nil, CreateHostRecords{Payload: record}, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RemoveHostRecord removes a host record of a domain.
+// https://docs-userapi.epik.com/v2/#/DNS%20Host%20Records/removeHostRecord
+func (c *Client) RemoveHostRecord(domain, recordID string) (*Data, error) {
+	query := url.Values{}
+	query.Set("ID", recordID)
+
+	result := &Data{}
+	err := c.do(http.MethodDelete, fmt.Sprintf("/domains/%s/records", domain), query, nil, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) do(method, uri string, query url.Values, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("SIGNATURE", c.signature)
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri + "?" + query.Encode()
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || len(apiErr.Errors) == 0 {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
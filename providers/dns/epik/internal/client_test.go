@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.URL.Query().Get("SIGNATURE") != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"errors":[{"code":"AUTH-1","message":"Invalid signature"}]}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_GetDNSRecords(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"data":{"name":"example.com","code":1000,"records":[
+			{"id":"abc","name":"_acme-challenge","type":"TXT","data":"txtTXTtxt","aux":0,"ttl":3600},
+			{"id":"def","name":"www","type":"A","data":"127.0.0.1","aux":0,"ttl":3600}
+		]}}`)
+	})
+	defer tearDown()
+
+	records, err := client.GetDNSRecords("example.com")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{ID: "abc", Name: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 3600},
+		{ID: "def", Name: "www", Type: "A", Data: "127.0.0.1", TTL: 3600},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_GetDNSRecords_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/domains/example.com/records", nil)
+	defer tearDown()
+
+	client.signature = "invalid"
+
+	_, err := client.GetDNSRecords("example.com")
+	require.EqualError(t, err, "[status code: 401] AUTH-1: Invalid signature")
+}
+
+func TestClient_CreateHostRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		request := CreateHostRecords{}
+		err := json.NewDecoder(req.Body).Decode(&request)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := RecordRequest{Host: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 3600}
+		if request.Payload != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %v", request.Payload), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"code":1000,"message":"Command completed successfully."}`)
+	})
+	defer tearDown()
+
+	data, err := client.CreateHostRecord("example.com", RecordRequest{Host: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 3600})
+	require.NoError(t, err)
+
+	assert.Equal(t, &Data{Code: 1000, Message: "Command completed successfully."}, data)
+}
+
+func TestClient_RemoveHostRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/domains/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("ID") != "abc" {
+			http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"code":1000,"message":"Command completed successfully."}`)
+	})
+	defer tearDown()
+
+	data, err := client.RemoveHostRecord("example.com", "abc")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Data{Code: 1000, Message: "Command completed successfully."}, data)
+}
@@ -0,0 +1,231 @@
+// Package gcore implements a DNS provider for solving the DNS-01 challenge using Gcore DNS.
+package gcore
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/gcore/internal"
+)
+
+const txtRecordType = "TXT"
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIToken           string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("GCORE_TTL", 120),
+		PropagationTimeout: env.GetOrDefaultSecond("GCORE_PROPAGATION_TIMEOUT", 360*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("GCORE_POLLING_INTERVAL", 20*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("GCORE_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+	// rrSetMu serializes the updates of the RRSets: the values are added and removed by replacing the whole RRSet.
+	rrSetMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Gcore DNS.
+// Credentials must be passed in the environment variable: GCORE_PERMANENT_API_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("GCORE_PERMANENT_API_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("gcore: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIToken = values["GCORE_PERMANENT_API_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Gcore DNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("gcore: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIToken)
+	if err != nil {
+		return nil, fmt.Errorf("gcore: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+// The value is added to the TXT RRSet of the name, the other values are kept.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("gcore: %v", err)
+	}
+
+	name := dns01.UnFqdn(fqdn)
+
+	d.rrSetMu.Lock()
+	defer d.rrSetMu.Unlock()
+
+	rrSet, err := d.client.GetRRSet(zone, name, txtRecordType)
+	if err != nil {
+		return fmt.Errorf("gcore: failed to get TXT RRSet [zone: %q, name: %q]: %v", zone, name, err)
+	}
+
+	if rrSet == nil {
+		newRRSet := internal.RRSet{
+			TTL:     d.config.TTL,
+			Records: []internal.ResourceRecord{{Content: []interface{}{value}}},
+		}
+
+		err = d.client.CreateRRSet(zone, name, txtRecordType, newRRSet)
+		if err != nil {
+			return fmt.Errorf("gcore: failed to create TXT RRSet [zone: %q, name: %q]: %v", zone, name, err)
+		}
+
+		return nil
+	}
+
+	if containsValue(rrSet.Records, value) {
+		return nil
+	}
+
+	rrSet.Records = append(rrSet.Records, internal.ResourceRecord{Content: []interface{}{value}})
+
+	err = d.client.UpdateRRSet(zone, name, txtRecordType, *rrSet)
+	if err != nil {
+		return fmt.Errorf("gcore: failed to update TXT RRSet [zone: %q, name: %q]: %v", zone, name, err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+// The RRSet is deleted if the value is the last value of the RRSet.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("gcore: %v", err)
+	}
+
+	name := dns01.UnFqdn(fqdn)
+
+	d.rrSetMu.Lock()
+	defer d.rrSetMu.Unlock()
+
+	rrSet, err := d.client.GetRRSet(zone, name, txtRecordType)
+	if err != nil {
+		return fmt.Errorf("gcore: failed to get TXT RRSet [zone: %q, name: %q]: %v", zone, name, err)
+	}
+
+	if rrSet == nil {
+		return nil
+	}
+
+	var records []internal.ResourceRecord
+	for _, record := range rrSet.Records {
+		if !containsValue([]internal.ResourceRecord{record}, value) {
+			records = append(records, record)
+		}
+	}
+
+	if len(records) == len(rrSet.Records) {
+		return nil
+	}
+
+	if len(records) == 0 {
+		err = d.client.DeleteRRSet(zone, name, txtRecordType)
+		if err != nil {
+			return fmt.Errorf("gcore: failed to delete TXT RRSet [zone: %q, name: %q]: %v", zone, name, err)
+		}
+
+		return nil
+	}
+
+	rrSet.Records = records
+
+	err = d.client.UpdateRRSet(zone, name, txtRecordType, *rrSet)
+	if err != nil {
+		return fmt.Errorf("gcore: failed to update TXT RRSet [zone: %q, name: %q]: %v", zone, name, err)
+	}
+
+	return nil
+}
+
+// findZone returns the name of the zone with the longest name matching the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	zones, err := d.client.ListZones()
+	if err != nil {
+		return "", fmt.Errorf("failed to list zones: %v", err)
+	}
+
+	domain := strings.ToLower(dns01.UnFqdn(fqdn))
+
+	var zone string
+	for _, z := range zones {
+		name := strings.ToLower(dns01.UnFqdn(z.Name))
+		if domain != name && !strings.HasSuffix(domain, "."+name) {
+			continue
+		}
+
+		if len(name) > len(zone) {
+			zone = name
+		}
+	}
+
+	if zone == "" {
+		return "", fmt.Errorf("no zone found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+func containsValue(records []internal.ResourceRecord, value string) bool {
+	for _, record := range records {
+		for _, content := range record.Content {
+			if fmt.Sprint(content) == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.gcore.com/dns"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"error"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("[status code: %d] %s", a.StatusCode, a.Message)
+}
+
+// Zone a DNS zone.
+type Zone struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// ZonesResponse a page of the DNS zones listing.
+type ZonesResponse struct {
+	Zones       []Zone `json:"zones"`
+	TotalAmount int    `json:"total_amount"`
+}
+
+// RRSet a resource record set: the records of a name and a type.
+type RRSet struct {
+	TTL     int              `json:"ttl"`
+	Records []ResourceRecord `json:"resource_records"`
+}
+
+// ResourceRecord a record of a RRSet.
+type ResourceRecord struct {
+	Content []interface{} `json:"content"`
+}
+
+// Client the Gcore DNS API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(token string) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		token:      token,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// ListZones lists all the DNS zones of the account.
+// https://api.gcore.com/docs/dns#tag/zones/operation/Zones
+func (c *Client) ListZones() ([]Zone, error) {
+	const limit = 1000
+
+	var zones []Zone
+
+	for offset := 0; ; offset += limit {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(limit))
+		query.Set("offset", strconv.Itoa(offset))
+
+		result := &ZonesResponse{}
+		err := c.do(http.MethodGet, "/v2/zones?"+query.Encode(), nil, result)
+		if err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, result.Zones...)
+
+		if len(result.Zones) == 0 || len(zones) >= result.TotalAmount {
+			return zones, nil
+		}
+	}
+}
+
+// GetRRSet returns the RRSet of a name and a type.
+// Returns nil if the RRSet doesn't exist.
+// https://api.gcore.com/docs/dns#tag/rrsets/operation/RRSet
+func (c *Client) GetRRSet(zone, name, recordType string) (*RRSet, error) {
+	result := &RRSet{}
+	err := c.do(http.MethodGet, rrSetURI(zone, name, recordType), nil, result)
+	if err != nil {
+		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateRRSet creates the RRSet of a name and a type.
+// https://api.gcore.com/docs/dns#tag/rrsets/operation/CreateRRSet
+func (c *Client) CreateRRSet(zone, name, recordType string, rrSet RRSet) error {
+	return c.do(http.MethodPost, rrSetURI(zone, name, recordType), rrSet, nil)
+}
+
+// UpdateRRSet replaces the RRSet of a name and a type.
+// https://api.gcore.com/docs/dns#tag/rrsets/operation/UpdateRRSet
+func (c *Client) UpdateRRSet(zone, name, recordType string, rrSet RRSet) error {
+	return c.do(http.MethodPut, rrSetURI(zone, name, recordType), rrSet, nil)
+}
+
+// DeleteRRSet deletes the RRSet of a name and a type.
+// https://api.gcore.com/docs/dns#tag/rrsets/operation/DeleteRRSet
+func (c *Client) DeleteRRSet(zone, name, recordType string) error {
+	return c.do(http.MethodDelete, rrSetURI(zone, name, recordType), nil, nil)
+}
+
+func rrSetURI(zone, name, recordType string) string {
+	return fmt.Sprintf("/v2/zones/%s/%s/%s", zone, name, recordType)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "APIKey "+c.token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Message == "" {
+			apiErr.Message = strings.TrimSpace(string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
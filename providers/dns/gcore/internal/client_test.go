@@ -0,0 +1,132 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "APIKey secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"error":"invalid api key"}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_ListZones(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/v2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("offset") != "0" {
+			http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"zones":[{"id":1,"name":"example.com"},{"id":2,"name":"example.org"}],"total_amount":2}`)
+	})
+	defer tearDown()
+
+	zones, err := client.ListZones()
+	require.NoError(t, err)
+
+	expected := []Zone{{ID: 1, Name: "example.com"}, {ID: 2, Name: "example.org"}}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_ListZones_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/v2/zones", nil)
+	defer tearDown()
+
+	client.token = "invalid"
+
+	_, err := client.ListZones()
+	require.EqualError(t, err, "[status code: 401] invalid api key")
+}
+
+func TestClient_GetRRSet(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/v2/zones/example.com/_acme-challenge.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"ttl":300,"resource_records":[{"content":["txtTXTtxt"]}]}`)
+	})
+	defer tearDown()
+
+	rrSet, err := client.GetRRSet("example.com", "_acme-challenge.example.com", "TXT")
+	require.NoError(t, err)
+
+	expected := &RRSet{TTL: 300, Records: []ResourceRecord{{Content: []interface{}{"txtTXTtxt"}}}}
+	assert.Equal(t, expected, rrSet)
+}
+
+func TestClient_GetRRSet_notFound(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/v2/zones/example.com/_acme-challenge.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(rw, `{"error":"record is not found"}`)
+	})
+	defer tearDown()
+
+	rrSet, err := client.GetRRSet("example.com", "_acme-challenge.example.com", "TXT")
+	require.NoError(t, err)
+
+	assert.Nil(t, rrSet)
+}
+
+func TestClient_UpdateRRSet(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPut, "/v2/zones/example.com/_acme-challenge.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		rrSet := RRSet{}
+		err := json.NewDecoder(req.Body).Decode(&rrSet)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if rrSet.TTL != 300 || len(rrSet.Records) != 2 {
+			http.Error(rw, fmt.Sprintf("invalid RRSet: %v", rrSet), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{}`)
+	})
+	defer tearDown()
+
+	rrSet := RRSet{
+		TTL:     300,
+		Records: []ResourceRecord{{Content: []interface{}{"a"}}, {Content: []interface{}{"b"}}},
+	}
+
+	err := client.UpdateRRSet("example.com", "_acme-challenge.example.com", "TXT", rrSet)
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRRSet(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/v2/zones/example.com/_acme-challenge.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	err := client.DeleteRRSet("example.com", "_acme-challenge.example.com", "TXT")
+	require.NoError(t, err)
+}
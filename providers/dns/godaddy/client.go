@@ -49,5 +49,12 @@ func (d *DNSProvider) makeRequest(method, uri string, body io.Reader) (*http.Res
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("sso-key %s:%s", d.config.APIKey, d.config.APISecret))
 
-	return d.config.HTTPClient.Do(req)
+	var resp *http.Response
+	err = d.limiter.Do(func() error {
+		var errD error
+		resp, errD = d.config.HTTPClient.Do(req)
+		return errD
+	})
+
+	return resp, err
 }
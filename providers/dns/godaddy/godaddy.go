@@ -27,6 +27,10 @@ type Config struct {
 	SequenceInterval   time.Duration
 	TTL                int
 	HTTPClient         *http.Client
+	// MaxConcurrency the maximum number of concurrent API calls (unlimited if lower than 1).
+	MaxConcurrency int
+	// RequestDelay the minimum delay between two API calls.
+	RequestDelay time.Duration
 }
 
 // NewDefaultConfig returns a default configuration for the DNSProvider
@@ -39,12 +43,15 @@ func NewDefaultConfig() *Config {
 		HTTPClient: &http.Client{
 			Timeout: env.GetOrDefaultSecond("GODADDY_HTTP_TIMEOUT", 30*time.Second),
 		},
+		MaxConcurrency: env.GetOrDefaultInt("GODADDY_MAX_CONCURRENCY", 0),
+		RequestDelay:   env.GetOrDefaultMillisecond("GODADDY_REQUEST_DELAY", 0),
 	}
 }
 
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
-	config *Config
+	config  *Config
+	limiter *dns01.RateLimiter
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for godaddy.
@@ -73,11 +80,15 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("godaddy: credentials missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("godaddy: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL)
+	if err != nil {
+		return nil, fmt.Errorf("godaddy: %v", err)
 	}
 
-	return &DNSProvider{config: config}, nil
+	return &DNSProvider{
+		config:  config,
+		limiter: dns01.NewRateLimiter(config.MaxConcurrency, config.RequestDelay),
+	}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS
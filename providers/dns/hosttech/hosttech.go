@@ -0,0 +1,170 @@
+// Package hosttech implements a DNS provider for solving the DNS-01 challenge using Hosttech DNS.
+package hosttech
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/hosttech/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("HOSTTECH_TTL", 3600),
+		PropagationTimeout: env.GetOrDefaultSecond("HOSTTECH_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("HOSTTECH_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("HOSTTECH_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordInfo struct {
+	zoneID   int
+	recordID int
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]recordInfo
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Hosttech DNS.
+// Credentials must be passed in the environment variable: HOSTTECH_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("HOSTTECH_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("hosttech: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["HOSTTECH_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Hosttech DNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("hosttech: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("hosttech: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]recordInfo{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("hosttech: %v", err)
+	}
+
+	// the name of the record is relative to the zone.
+	subDomain := strings.TrimSuffix(strings.TrimSuffix(dns01.UnFqdn(fqdn), dns01.UnFqdn(zone.Name)), ".")
+
+	record := internal.Record{
+		Type: "TXT",
+		Name: subDomain,
+		Text: value,
+		TTL:  d.config.TTL,
+	}
+
+	newRecord, err := d.client.AddRecord(zone.ID, record)
+	if err != nil {
+		return fmt.Errorf("hosttech: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone.Name, fqdn, err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordInfo{zoneID: zone.ID, recordID: newRecord.ID}
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	info, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("hosttech: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(info.zoneID, info.recordID)
+	if err != nil {
+		return fmt.Errorf("hosttech: failed to delete TXT record [zone: %d, id: %d]: %v", info.zoneID, info.recordID, err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the zone of the FQDN, from the list of the zones of the account.
+func (d *DNSProvider) findZone(fqdn string) (*internal.Zone, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine the zone: %v", err)
+	}
+
+	name := dns01.UnFqdn(authZone)
+
+	zones, err := d.client.GetZones(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %v", err)
+	}
+
+	for i, zone := range zones {
+		if strings.EqualFold(dns01.UnFqdn(zone.Name), name) {
+			return &zones[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("zone %s not found", name)
+}
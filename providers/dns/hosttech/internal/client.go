@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.ns1.hosttech.eu/api/user/v1"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int                 `json:"-"`
+	Message    string              `json:"message"`
+	Errors     map[string][]string `json:"errors"`
+}
+
+func (a APIError) Error() string {
+	msg := fmt.Sprintf("[status code: %d] %s", a.StatusCode, a.Message)
+
+	var fields []string
+	for field := range a.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		msg += fmt.Sprintf(", %s: %s", field, strings.Join(a.Errors[field], " "))
+	}
+
+	return msg
+}
+
+// Zone a DNS zone.
+type Zone struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	TTL  int    `json:"ttl"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID      int    `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Text    string `json:"text"`
+	TTL     int    `json:"ttl,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type zonesResponse struct {
+	Data []Zone `json:"data"`
+}
+
+type recordResponse struct {
+	Data *Record `json:"data"`
+}
+
+// Client the Hosttech DNS API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey string) (*Client, error) {
+	if apiKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// GetZones lists the DNS zones matching the query.
+// https://api.ns1.hosttech.eu/api/documentation/#/Zones/get_api_user_v1_zones
+func (c *Client) GetZones(query string) ([]Zone, error) {
+	values := url.Values{}
+	values.Set("query", query)
+
+	result := &zonesResponse{}
+	err := c.do(http.MethodGet, "/zones?"+values.Encode(), nil, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// AddRecord creates a DNS record in a zone and returns the created record.
+// The name of the record is relative to the zone (e.g. "_acme-challenge").
+// https://api.ns1.hosttech.eu/api/documentation/#/Records/post_api_user_v1_zones__zoneId__records
+func (c *Client) AddRecord(zoneID int, record Record) (*Record, error) {
+	result := &recordResponse{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/zones/%d/records", zoneID), record, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Data == nil {
+		return nil, errors.New("the created record is missing in the response")
+	}
+
+	return result.Data, nil
+}
+
+// DeleteRecord deletes a DNS record of a zone.
+// https://api.ns1.hosttech.eu/api/documentation/#/Records/delete_api_user_v1_zones__zoneId__records__recordId_
+func (c *Client) DeleteRecord(zoneID, recordID int) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/zones/%d/records/%d", zoneID, recordID), nil, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Message == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"message":"Unauthenticated."}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_GetZones(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/zones", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("query") != "example.com" {
+			http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"data":[{"id":10,"name":"example.com","email":"test@example.com","ttl":10800,"nameserver":"ns1.hosttech.ch","dnssec":false}]}`)
+	})
+	defer tearDown()
+
+	zones, err := client.GetZones("example.com")
+	require.NoError(t, err)
+
+	expected := []Zone{{ID: 10, Name: "example.com", TTL: 10800}}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_GetZones_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/zones", nil)
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	_, err := client.GetZones("example.com")
+	require.EqualError(t, err, "[status code: 401] Unauthenticated.")
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/zones/10/records", func(rw http.ResponseWriter, req *http.Request) {
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Type: "TXT", Name: "_acme-challenge", Text: "txtTXTtxt", TTL: 3600}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `{"data":{"id":12,"type":"TXT","name":"_acme-challenge","text":"txtTXTtxt","ttl":3600,"comment":""}}`)
+	})
+	defer tearDown()
+
+	record, err := client.AddRecord(10, Record{Type: "TXT", Name: "_acme-challenge", Text: "txtTXTtxt", TTL: 3600})
+	require.NoError(t, err)
+
+	expected := &Record{ID: 12, Type: "TXT", Name: "_acme-challenge", Text: "txtTXTtxt", TTL: 3600}
+	assert.Equal(t, expected, record)
+}
+
+func TestClient_AddRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/zones/10/records", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprint(rw, `{"message":"The given data was invalid.","errors":{"text":["The text field is required."],"name":["The name is invalid."]}}`)
+	})
+	defer tearDown()
+
+	_, err := client.AddRecord(10, Record{Type: "TXT", Name: "_acme-challenge.example.com"})
+	require.EqualError(t, err, "[status code: 422] The given data was invalid., name: The name is invalid., text: The text field is required.")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/zones/10/records/12", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord(10, 12)
+	require.NoError(t, err)
+}
@@ -0,0 +1,153 @@
+// Package hurricane implements a DNS provider for solving the DNS-01 challenge using Hurricane Electric (dns.he.net).
+package hurricane
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/hurricane/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	// Credentials the update keys of the TXT records, by domain.
+	Credentials        map[string]string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	SequenceInterval   time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		PropagationTimeout: env.GetOrDefaultSecond("HURRICANE_PROPAGATION_TIMEOUT", 300*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("HURRICANE_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		SequenceInterval:   env.GetOrDefaultSecond("HURRICANE_SEQUENCE_INTERVAL", dns01.DefaultPropagationTimeout),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("HURRICANE_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Hurricane Electric.
+// Credentials must be passed in the environment variable: HURRICANE_TOKENS,
+// as comma-separated domain:key pairs (e.g. "example.com:key1,example.org:key2").
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("HURRICANE_TOKENS")
+	if err != nil {
+		return nil, fmt.Errorf("hurricane: %v", err)
+	}
+
+	credentials, err := parseCredentials(values["HURRICANE_TOKENS"])
+	if err != nil {
+		return nil, fmt.Errorf("hurricane: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Credentials = credentials
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Hurricane Electric.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("hurricane: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.Credentials)
+	if err != nil {
+		return nil, fmt.Errorf("hurricane: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Sequential All DNS challenges for this provider will be resolved sequentially.
+// Returns the interval between each iteration.
+func (d *DNSProvider) Sequential() time.Duration {
+	return d.config.SequenceInterval
+}
+
+// SingleValueTXT The provider can only publish one value per TXT record name:
+// an update replaces the value of the record (e.g. "example.com" and "*.example.com" cannot be validated at the same time).
+func (d *DNSProvider) SingleValueTXT() bool {
+	return true
+}
+
+// Present updates the TXT record to fulfill the dns-01 challenge.
+// The TXT record must already exist, with the dynamic DNS enabled.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	err := d.client.UpdateTxtRecord(credentialDomain(domain), dns01.UnFqdn(fqdn), value)
+	if err != nil {
+		return fmt.Errorf("hurricane: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp resets the value of the TXT record: the records cannot be deleted through the dynamic DNS.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	err := d.client.UpdateTxtRecord(credentialDomain(domain), dns01.UnFqdn(fqdn), ".")
+	if err != nil {
+		return fmt.Errorf("hurricane: %v", err)
+	}
+
+	return nil
+}
+
+// credentialDomain returns the domain used to find the update key (the wildcard prefix is removed).
+func credentialDomain(domain string) string {
+	return strings.ToLower(dns01.UnFqdn(strings.TrimPrefix(domain, "*.")))
+}
+
+// parseCredentials parses the comma-separated domain:key pairs.
+func parseCredentials(raw string) (map[string]string, error) {
+	credentials := map[string]string{}
+
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		data := strings.SplitN(pair, ":", 2)
+		if len(data) != 2 || strings.TrimSpace(data[0]) == "" || strings.TrimSpace(data[1]) == "" {
+			return nil, fmt.Errorf("incorrect credential pair: %s", pair)
+		}
+
+		credentials[credentialDomain(strings.TrimSpace(data[0]))] = strings.TrimSpace(data[1])
+	}
+
+	return credentials, nil
+}
@@ -0,0 +1,139 @@
+package hurricane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vostronet/lego/platform/tester"
+)
+
+var envTest = tester.NewEnvTest("HURRICANE_TOKENS").
+	WithDomain("HURRICANE_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"HURRICANE_TOKENS": "example.org:123",
+			},
+		},
+		{
+			desc: "success multiple domains",
+			envVars: map[string]string{
+				"HURRICANE_TOKENS": "example.org:123, example.com:456",
+			},
+		},
+		{
+			desc: "invalid credentials",
+			envVars: map[string]string{
+				"HURRICANE_TOKENS": "example.org:123,example.com",
+			},
+			expected: "hurricane: incorrect credential pair: example.com",
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"HURRICANE_TOKENS": "",
+			},
+			expected: "hurricane: some credentials information are missing: HURRICANE_TOKENS",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		credentials map[string]string
+		expected    string
+	}{
+		{
+			desc:        "success",
+			credentials: map[string]string{"example.org": "123"},
+		},
+		{
+			desc:     "missing credentials",
+			expected: "hurricane: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Credentials = test.credentials
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_parseCredentials(t *testing.T) {
+	credentials, err := parseCredentials("Example.org:123, *.example.com:456,")
+	require.NoError(t, err)
+
+	expected := map[string]string{"example.org": "123", "example.com": "456"}
+	assert.Equal(t, expected, credentials)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
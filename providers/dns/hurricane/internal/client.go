@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultBaseURL = "https://dyn.dns.he.net"
+
+// Client the Hurricane Electric dynamic DNS client.
+type Client struct {
+	credentials map[string]string
+	BaseURL     string
+	HTTPClient  *http.Client
+}
+
+// NewClient creates a new Client.
+// The credentials map the domains to the update keys of their TXT records.
+func NewClient(credentials map[string]string) (*Client, error) {
+	if len(credentials) == 0 {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		credentials: credentials,
+		BaseURL:     defaultBaseURL,
+		HTTPClient:  &http.Client{},
+	}, nil
+}
+
+// UpdateTxtRecord replaces the value of the TXT record of a hostname (e.g. "_acme-challenge.example.com").
+// The domain is used to find the update key of the record.
+// https://dns.he.net/docs.html
+func (c *Client) UpdateTxtRecord(domain, hostname, txt string) error {
+	key, ok := c.credentials[domain]
+	if !ok {
+		return fmt.Errorf("no update key for the domain %s", domain)
+	}
+
+	data := url.Values{}
+	data.Set("password", key)
+	data.Set("hostname", hostname)
+	data.Set("txt", txt)
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + "/nic/update"
+
+	resp, err := c.HTTPClient.PostForm(endpoint, data)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	body := strings.TrimSpace(string(raw))
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, body)
+	}
+
+	return evaluateBody(body, hostname)
+}
+
+// evaluateBody checks the response of an update: "good <value>" or "nochg <value>" on success, an error code otherwise.
+func evaluateBody(body, hostname string) error {
+	var code string
+	if fields := strings.Fields(body); len(fields) > 0 {
+		code = fields[0]
+	}
+
+	switch code {
+	case "good", "nochg":
+		return nil
+	case "abuse":
+		return fmt.Errorf("%s: blocked hostname for abuse", hostname)
+	case "badagent":
+		return fmt.Errorf("%s: user agent not sent or HTTP method not recognized", hostname)
+	case "badauth":
+		return fmt.Errorf("%s: wrong authentication", hostname)
+	case "interval":
+		return fmt.Errorf("%s: TXT records update exceeded API rate limit", hostname)
+	case "nohost":
+		return fmt.Errorf("%s: the record provided does not exist in this account", hostname)
+	case "notfqdn":
+		return fmt.Errorf("%s: the record provided isn't an FQDN", hostname)
+	default:
+		return fmt.Errorf("%s: unexpected response: %s", hostname, body)
+	}
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/nic/update", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.FormValue("password") != "secret" {
+			_, _ = fmt.Fprint(rw, "badauth")
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient(map[string]string{"example.com": "secret", "example.org": "invalid"})
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestNewClient_missingCredentials(t *testing.T) {
+	_, err := NewClient(map[string]string{})
+	require.EqualError(t, err, "credentials missing")
+}
+
+func TestClient_UpdateTxtRecord(t *testing.T) {
+	client, tearDown := setupTest(t, func(rw http.ResponseWriter, req *http.Request) {
+		if req.FormValue("hostname") != "_acme-challenge.example.com" {
+			_, _ = fmt.Fprint(rw, "nohost")
+			return
+		}
+
+		_, _ = fmt.Fprintf(rw, "good %s", req.FormValue("txt"))
+	})
+	defer tearDown()
+
+	err := client.UpdateTxtRecord("example.com", "_acme-challenge.example.com", "value")
+	require.NoError(t, err)
+}
+
+func TestClient_UpdateTxtRecord_errors(t *testing.T) {
+	client, tearDown := setupTest(t, func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, "nohost")
+	})
+	defer tearDown()
+
+	err := client.UpdateTxtRecord("example.com", "_acme-challenge.example.com", "value")
+	require.EqualError(t, err, "_acme-challenge.example.com: the record provided does not exist in this account")
+
+	err = client.UpdateTxtRecord("example.org", "_acme-challenge.example.org", "value")
+	require.EqualError(t, err, "_acme-challenge.example.org: wrong authentication")
+
+	err = client.UpdateTxtRecord("example.net", "_acme-challenge.example.net", "value")
+	require.EqualError(t, err, "no update key for the domain example.net")
+}
+
+func Test_evaluateBody(t *testing.T) {
+	testCases := []struct {
+		body     string
+		expected string
+	}{
+		{body: "good value"},
+		{body: "nochg value"},
+		{body: "abuse", expected: "example.com: blocked hostname for abuse"},
+		{body: "badagent", expected: "example.com: user agent not sent or HTTP method not recognized"},
+		{body: "interval", expected: "example.com: TXT records update exceeded API rate limit"},
+		{body: "notfqdn", expected: "example.com: the record provided isn't an FQDN"},
+		{body: "", expected: "example.com: unexpected response: "},
+		{body: "911", expected: "example.com: unexpected response: 911"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.body, func(t *testing.T) {
+			t.Parallel()
+
+			err := evaluateBody(test.body, "example.com")
+			if test.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
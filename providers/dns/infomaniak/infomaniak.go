@@ -0,0 +1,189 @@
+// Package infomaniak implements a DNS provider for solving the DNS-01 challenge using Infomaniak DNS.
+package infomaniak
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/infomaniak/internal"
+)
+
+// minTTL the minimum TTL accepted by the Infomaniak API.
+const minTTL = 300
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	AccessToken        string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("INFOMANIAK_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("INFOMANIAK_PROPAGATION_TIMEOUT", 120*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("INFOMANIAK_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("INFOMANIAK_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordInfo struct {
+	zone     string
+	recordID int64
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]recordInfo
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Infomaniak DNS.
+// Credentials must be passed in the environment variable: INFOMANIAK_ACCESS_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("INFOMANIAK_ACCESS_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("infomaniak: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AccessToken = values["INFOMANIAK_ACCESS_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Infomaniak DNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("infomaniak: the configuration of the DNS provider is nil")
+	}
+
+	if config.TTL < minTTL {
+		config.TTL = minTTL
+	}
+
+	client, err := internal.NewClient(config.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("infomaniak: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]recordInfo{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("infomaniak: %v", err)
+	}
+
+	record := internal.Record{
+		Source: subDomain(fqdn, zone),
+		Type:   "TXT",
+		Target: value,
+		TTL:    d.config.TTL,
+	}
+
+	newRecord, err := d.client.CreateRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("infomaniak: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone, fqdn, err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordInfo{zone: zone, recordID: newRecord.ID}
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	info, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("infomaniak: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(info.zone, info.recordID)
+	if err != nil {
+		return fmt.Errorf("infomaniak: failed to delete TXT record [zone: %q, id: %d]: %v", info.zone, info.recordID, err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the name of the zone with the longest domain matching the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	zones, err := d.client.ListZones()
+	if err != nil {
+		return "", fmt.Errorf("failed to list zones: %v", err)
+	}
+
+	domain := strings.ToLower(dns01.UnFqdn(fqdn))
+
+	var zone string
+	for _, z := range zones {
+		name := strings.ToLower(dns01.UnFqdn(z.FQDN))
+		if domain != name && !strings.HasSuffix(domain, "."+name) {
+			continue
+		}
+
+		if len(name) > len(zone) {
+			zone = name
+		}
+	}
+
+	if zone == "" {
+		return "", fmt.Errorf("no zone found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+// subDomain returns the name of the record relative to the zone ("." for the apex of the zone).
+func subDomain(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if len(name) <= len(zone) {
+		return "."
+	}
+
+	return name[:len(name)-len(zone)-1]
+}
@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.infomaniak.com"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("[status code: %d] %s: %s", a.StatusCode, a.Code, a.Description)
+}
+
+// APIResponse the envelope of the API responses.
+type APIResponse struct {
+	Result string          `json:"result"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	Error  *APIError       `json:"error,omitempty"`
+	Page   int             `json:"page,omitempty"`
+	Pages  int             `json:"pages,omitempty"`
+}
+
+// Zone a DNS zone.
+type Zone struct {
+	ID   int64  `json:"id"`
+	FQDN string `json:"fqdn"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID     int64  `json:"id,omitempty"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	TTL    int    `json:"ttl,omitempty"`
+}
+
+// Client the Infomaniak DNS API client.
+type Client struct {
+	accessToken string
+	BaseURL     string
+	HTTPClient  *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(accessToken string) (*Client, error) {
+	if accessToken == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		accessToken: accessToken,
+		BaseURL:     defaultBaseURL,
+		HTTPClient:  &http.Client{},
+	}, nil
+}
+
+// ListZones lists all the DNS zones of the account.
+// https://developer.infomaniak.com/docs/api/get/2/zones
+func (c *Client) ListZones() ([]Zone, error) {
+	var zones []Zone
+
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", "100")
+
+		var items []Zone
+		resp, err := c.do(http.MethodGet, "/2/zones?"+query.Encode(), nil, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, items...)
+
+		if page >= resp.Pages || len(items) == 0 {
+			return zones, nil
+		}
+	}
+}
+
+// CreateRecord creates a DNS record in a zone and returns the created record.
+// https://developer.infomaniak.com/docs/api/post/2/zones/%7Bzone%7D/records
+func (c *Client) CreateRecord(zone string, record Record) (*Record, error) {
+	result := &Record{}
+	_, err := c.do(http.MethodPost, fmt.Sprintf("/2/zones/%s/records", zone), record, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteRecord deletes a DNS record of a zone.
+// https://developer.infomaniak.com/docs/api/delete/2/zones/%7Bzone%7D/records/%7Brecord%7D
+func (c *Client) DeleteRecord(zone string, recordID int64) error {
+	_, err := c.do(http.MethodDelete, fmt.Sprintf("/2/zones/%s/records/%d", zone, recordID), nil, nil)
+	return err
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) (*APIResponse, error) {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	apiResp := &APIResponse{}
+	errU := json.Unmarshal(raw, apiResp)
+
+	if resp.StatusCode/100 != 2 || apiResp.Result == "error" {
+		if errU != nil || apiResp.Error == nil {
+			return nil, fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		apiResp.Error.StatusCode = resp.StatusCode
+		return nil, apiResp.Error
+	}
+
+	if errU != nil {
+		return nil, fmt.Errorf("unable to parse response body: %v: %s", errU, string(raw))
+	}
+
+	if result == nil || len(apiResp.Data) == 0 {
+		return apiResp, nil
+	}
+
+	err = json.Unmarshal(apiResp.Data, result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse response data: %v", err)
+	}
+
+	return apiResp, nil
+}
@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"result":"error","error":{"code":"not_authorized","description":"Authorization required"}}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_ListZones(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("page") {
+		case "1":
+			_, _ = fmt.Fprint(rw, `{"result":"success","data":[{"id":1,"fqdn":"example.com"}],"page":1,"pages":2}`)
+		case "2":
+			_, _ = fmt.Fprint(rw, `{"result":"success","data":[{"id":2,"fqdn":"example.org"}],"page":2,"pages":2}`)
+		default:
+			http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+		}
+	})
+	defer tearDown()
+
+	zones, err := client.ListZones()
+	require.NoError(t, err)
+
+	expected := []Zone{{ID: 1, FQDN: "example.com"}, {ID: 2, FQDN: "example.org"}}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_ListZones_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/2/zones", nil)
+	defer tearDown()
+
+	client.accessToken = "invalid"
+
+	_, err := client.ListZones()
+	require.EqualError(t, err, "[status code: 401] not_authorized: Authorization required")
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/2/zones/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Source: "_acme-challenge", Type: "TXT", Target: "txtTXTtxt", TTL: 300}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %v", record), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"result":"success","data":{"id":123,"source":"_acme-challenge","type":"TXT","target":"txtTXTtxt","ttl":300}}`)
+	})
+	defer tearDown()
+
+	record := Record{Source: "_acme-challenge", Type: "TXT", Target: "txtTXTtxt", TTL: 300}
+
+	newRecord, err := client.CreateRecord("example.com", record)
+	require.NoError(t, err)
+
+	expected := &Record{ID: 123, Source: "_acme-challenge", Type: "TXT", Target: "txtTXTtxt", TTL: 300}
+	assert.Equal(t, expected, newRecord)
+}
+
+func TestClient_CreateRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/2/zones/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprint(rw, `{"result":"error","error":{"code":"validation_failed","description":"The ttl must be at least 300."}}`)
+	})
+	defer tearDown()
+
+	_, err := client.CreateRecord("example.com", Record{Source: "_acme-challenge", Type: "TXT", Target: "txtTXTtxt", TTL: 60})
+	require.EqualError(t, err, "[status code: 422] validation_failed: The ttl must be at least 300.")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/2/zones/example.com/records/123", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"result":"success","data":true}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", 123)
+	require.NoError(t, err)
+}
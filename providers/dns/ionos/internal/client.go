@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.hosting.ionos.com/dns"
+
+// ErrorDetail an error of the API.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// APIError the errors returned by the API.
+type APIError struct {
+	StatusCode int
+	Errors     []ErrorDetail
+}
+
+func (a APIError) Error() string {
+	var msgs []string
+	for _, detail := range a.Errors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", detail.Code, detail.Message))
+	}
+
+	return fmt.Sprintf("[status code: %d] %s", a.StatusCode, strings.Join(msgs, ", "))
+}
+
+// Zone a DNS zone.
+type Zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID string `json:"id,omitempty"`
+	// Name the fully qualified name of the record, without the trailing dot (e.g. "_acme-challenge.example.com").
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	TTL      int    `json:"ttl,omitempty"`
+	Prio     int    `json:"prio"`
+	Disabled bool   `json:"disabled"`
+}
+
+// Client the IONOS DNS API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+// The API key is the public prefix and the secret of the key, separated by a dot.
+func NewClient(apiKey string) (*Client, error) {
+	if apiKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	prefix, secret := splitAPIKey(apiKey)
+	if prefix == "" || secret == "" {
+		return nil, errors.New("invalid API key: the expected format is <prefix>.<secret>")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+func splitAPIKey(apiKey string) (string, string) {
+	parts := strings.SplitN(apiKey, ".", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// ListZones lists all the DNS zones of the customer.
+// https://developer.hosting.ionos.com/docs/dns
+func (c *Client) ListZones() ([]Zone, error) {
+	var zones []Zone
+	err := c.do(http.MethodGet, "/v1/zones", nil, &zones)
+	if err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// AddRecord adds a DNS record to a zone and returns the created record.
+// https://developer.hosting.ionos.com/docs/dns
+func (c *Client) AddRecord(zoneID string, record Record) (*Record, error) {
+	var records []Record
+	err := c.do(http.MethodPost, fmt.Sprintf("/v1/zones/%s/records", url.PathEscape(zoneID)), []Record{record}, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 || records[0].ID == "" {
+		return nil, errors.New("no record in the response")
+	}
+
+	return &records[0], nil
+}
+
+// DeleteRecord deletes a DNS record of a zone.
+// https://developer.hosting.ionos.com/docs/dns
+func (c *Client) DeleteRecord(zoneID, recordID string) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/v1/zones/%s/records/%s", url.PathEscape(zoneID), url.PathEscape(recordID)), nil, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", c.apiKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, &apiErr.Errors); errU != nil || len(apiErr.Errors) == 0 {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
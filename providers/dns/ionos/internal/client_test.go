@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("X-API-Key") != "prefix.secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `[{"code":"UNAUTHORIZED","message":"The customer is not authorized to do this operation."}]`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("prefix.secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestNewClient(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		apiKey   string
+		expected string
+	}{
+		{
+			desc:   "success",
+			apiKey: "prefix.secret",
+		},
+		{
+			desc:     "missing API key",
+			expected: "credentials missing",
+		},
+		{
+			desc:     "missing secret",
+			apiKey:   "prefix",
+			expected: "invalid API key: the expected format is <prefix>.<secret>",
+		},
+		{
+			desc:     "empty prefix",
+			apiKey:   ".secret",
+			expected: "invalid API key: the expected format is <prefix>.<secret>",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(test.apiKey)
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				require.NotNil(t, client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestClient_ListZones(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/v1/zones", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `[{"name":"example.com","id":"11af3414-ebba-11e9-8df5-66fbe8a334b4","type":"NATIVE"},{"name":"example.org","id":"22af3414-ebba-11e9-8df5-66fbe8a334b4","type":"NATIVE"}]`)
+	})
+	defer tearDown()
+
+	zones, err := client.ListZones()
+	require.NoError(t, err)
+
+	expected := []Zone{
+		{ID: "11af3414-ebba-11e9-8df5-66fbe8a334b4", Name: "example.com", Type: "NATIVE"},
+		{ID: "22af3414-ebba-11e9-8df5-66fbe8a334b4", Name: "example.org", Type: "NATIVE"},
+	}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_ListZones_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/v1/zones", nil)
+	defer tearDown()
+
+	client.apiKey = "prefix.invalid"
+
+	_, err := client.ListZones()
+	require.EqualError(t, err, "[status code: 401] UNAUTHORIZED: The customer is not authorized to do this operation.")
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/v1/zones/11af3414-ebba-11e9-8df5-66fbe8a334b4/records", func(rw http.ResponseWriter, req *http.Request) {
+		var records []Record
+		err := json.NewDecoder(req.Body).Decode(&records)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := []Record{{Name: "_acme-challenge.example.com", Type: "TXT", Content: "txtTXTtxt", TTL: 300}}
+		if len(records) != 1 || records[0] != expected[0] {
+			http.Error(rw, fmt.Sprintf("invalid records: %+v", records), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `[{"name":"_acme-challenge.example.com","rootName":"example.com","type":"TXT","content":"\"txtTXTtxt\"","changeDate":"2019-12-09T13:04:25.772Z","ttl":300,"prio":0,"disabled":false,"id":"22af3414-abbe-9e11-5df5-66fbe8e334b4"}]`)
+	})
+	defer tearDown()
+
+	record, err := client.AddRecord("11af3414-ebba-11e9-8df5-66fbe8a334b4", Record{Name: "_acme-challenge.example.com", Type: "TXT", Content: "txtTXTtxt", TTL: 300})
+	require.NoError(t, err)
+
+	expected := &Record{ID: "22af3414-abbe-9e11-5df5-66fbe8e334b4", Name: "_acme-challenge.example.com", Type: "TXT", Content: `"txtTXTtxt"`, TTL: 300}
+	assert.Equal(t, expected, record)
+}
+
+func TestClient_AddRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/v1/zones/11af3414-ebba-11e9-8df5-66fbe8a334b4/records", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(rw, `[{"code":"INVALID_RECORD","message":"Record is invalid."}]`)
+	})
+	defer tearDown()
+
+	_, err := client.AddRecord("11af3414-ebba-11e9-8df5-66fbe8a334b4", Record{Name: "_acme-challenge.example.com", Type: "TXT", Content: "txtTXTtxt", TTL: 300})
+	require.EqualError(t, err, "[status code: 400] INVALID_RECORD: Record is invalid.")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/v1/zones/11af3414-ebba-11e9-8df5-66fbe8a334b4/records/22af3414-abbe-9e11-5df5-66fbe8e334b4", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("11af3414-ebba-11e9-8df5-66fbe8a334b4", "22af3414-abbe-9e11-5df5-66fbe8e334b4")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/v1/zones/11af3414-ebba-11e9-8df5-66fbe8a334b4/records/22af3414-abbe-9e11-5df5-66fbe8e334b4", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(rw, `[{"code":"RECORD_NOT_FOUND","message":"Record does not exist."}]`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("11af3414-ebba-11e9-8df5-66fbe8a334b4", "22af3414-abbe-9e11-5df5-66fbe8e334b4")
+	require.EqualError(t, err, "[status code: 404] RECORD_NOT_FOUND: Record does not exist.")
+}
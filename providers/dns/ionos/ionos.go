@@ -0,0 +1,179 @@
+// Package ionos implements a DNS provider for solving the DNS-01 challenge using IONOS.
+package ionos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/ionos/internal"
+)
+
+// minTTL the minimal TTL accepted by IONOS.
+const minTTL = 300
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("IONOS_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("IONOS_PROPAGATION_TIMEOUT", 120*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("IONOS_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("IONOS_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordInfo struct {
+	zoneID   string
+	recordID string
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]recordInfo
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for IONOS.
+// Credentials must be passed in the environment variable: IONOS_API_KEY (public prefix and secret, separated by a dot).
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("IONOS_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("ionos: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["IONOS_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for IONOS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("ionos: the configuration of the DNS provider is nil")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("ionos: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client, err := internal.NewClient(config.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("ionos: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]recordInfo{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("ionos: %v", err)
+	}
+
+	record := internal.Record{
+		Name:    dns01.UnFqdn(fqdn),
+		Type:    "TXT",
+		Content: value,
+		TTL:     d.config.TTL,
+	}
+
+	newRecord, err := d.client.AddRecord(zone.ID, record)
+	if err != nil {
+		return fmt.Errorf("ionos: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone.Name, fqdn, err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordInfo{zoneID: zone.ID, recordID: newRecord.ID}
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	info, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("ionos: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(info.zoneID, info.recordID)
+	if err != nil {
+		return fmt.Errorf("ionos: failed to delete TXT record [zone: %s, id: %s]: %v", info.zoneID, info.recordID, err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the zone with the longest name matching the FQDN (e.g. "sub.example.com" rather than "example.com").
+func (d *DNSProvider) findZone(fqdn string) (*internal.Zone, error) {
+	zones, err := d.client.ListZones()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %v", err)
+	}
+
+	domain := strings.ToLower(dns01.UnFqdn(fqdn))
+
+	var zone *internal.Zone
+	for i, z := range zones {
+		name := strings.ToLower(z.Name)
+		if domain != name && !strings.HasSuffix(domain, "."+name) {
+			continue
+		}
+
+		if zone == nil || len(name) > len(zone.Name) {
+			zone = &zones[i]
+		}
+	}
+
+	if zone == nil {
+		return nil, fmt.Errorf("no zone found for %s", fqdn)
+	}
+
+	return zone, nil
+}
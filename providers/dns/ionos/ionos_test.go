@@ -0,0 +1,185 @@
+package ionos
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vostronet/lego/platform/tester"
+)
+
+var envTest = tester.NewEnvTest("IONOS_API_KEY").
+	WithDomain("IONOS_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"IONOS_API_KEY": "prefix.secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"IONOS_API_KEY": "",
+			},
+			expected: "ionos: some credentials information are missing: IONOS_API_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		apiKey   string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:   "success",
+			apiKey: "prefix.secret",
+			ttl:    minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "ionos: credentials missing",
+		},
+		{
+			desc:     "invalid API key",
+			apiKey:   "secret",
+			ttl:      minTTL,
+			expected: "ionos: invalid API key: the expected format is <prefix>.<secret>",
+		},
+		{
+			desc:     "invalid TTL",
+			apiKey:   "prefix.secret",
+			ttl:      60,
+			expected: "ionos: invalid TTL, TTL (60) must be greater than 300",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = test.apiKey
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestDNSProvider_findZone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `[{"name":"example.com","id":"1","type":"NATIVE"},{"name":"sub.example.com","id":"2","type":"NATIVE"},{"name":"example.org","id":"3","type":"NATIVE"}]`)
+	}))
+	defer server.Close()
+
+	config := NewDefaultConfig()
+	config.APIKey = "prefix.secret"
+	config.BaseURL = server.URL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		fqdn     string
+		expected string
+	}{
+		{
+			desc:     "zone",
+			fqdn:     "_acme-challenge.example.com.",
+			expected: "1",
+		},
+		{
+			desc:     "longest matching zone",
+			fqdn:     "_acme-challenge.www.sub.example.com.",
+			expected: "2",
+		},
+		{
+			desc:     "sibling of a zone",
+			fqdn:     "_acme-challenge.notsub.example.com.",
+			expected: "1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			zone, err := provider.findZone(test.fqdn)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, zone.ID)
+		})
+	}
+
+	_, err = provider.findZone("_acme-challenge.example.net.")
+	require.EqualError(t, err, "no zone found for _acme-challenge.example.net.")
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
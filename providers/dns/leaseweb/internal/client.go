@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.leaseweb.com/hosting/v2"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode    int    `json:"-"`
+	ErrorCode     string `json:"errorCode"`
+	ErrorMessage  string `json:"errorMessage"`
+	CorrelationID string `json:"correlationId"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("[status code: %d] %s: %s (correlation ID: %s)", a.StatusCode, a.ErrorCode, a.ErrorMessage, a.CorrelationID)
+}
+
+// IsNotFound returns true if the error is an API error with the status code 404.
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// ResourceRecordSet a set of DNS records: the records with the same name and the same type.
+type ResourceRecordSet struct {
+	// Name the FQDN of the records, with a trailing dot (e.g. "_acme-challenge.example.com.").
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	Content []string `json:"content"`
+	TTL     int      `json:"ttl"`
+}
+
+// Client the Leaseweb DNS API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey string) (*Client, error) {
+	if apiKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// GetRRSet gets the resource record set of a domain matching the name and the type.
+// https://developer.leaseweb.com/api-docs/domains_v2.html#operation/getResourceRecordSet
+func (c *Client) GetRRSet(domain, name, recordType string) (*ResourceRecordSet, error) {
+	result := &ResourceRecordSet{}
+	err := c.do(http.MethodGet, rrSetURI(domain, name, recordType), nil, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateRRSet creates a resource record set in a domain.
+// https://developer.leaseweb.com/api-docs/domains_v2.html#operation/createResourceRecordSet
+func (c *Client) CreateRRSet(domain string, rrSet ResourceRecordSet) error {
+	return c.do(http.MethodPost, fmt.Sprintf("/domains/%s/resourceRecordSets", url.PathEscape(domain)), rrSet, nil)
+}
+
+// UpdateRRSet replaces the content of a resource record set.
+// https://developer.leaseweb.com/api-docs/domains_v2.html#operation/updateResourceRecordSet
+func (c *Client) UpdateRRSet(domain string, rrSet ResourceRecordSet) error {
+	payload := ResourceRecordSet{Content: rrSet.Content, TTL: rrSet.TTL}
+	return c.do(http.MethodPut, rrSetURI(domain, rrSet.Name, rrSet.Type), payload, nil)
+}
+
+// DeleteRRSet deletes a resource record set.
+// https://developer.leaseweb.com/api-docs/domains_v2.html#operation/deleteResourceRecordSet
+func (c *Client) DeleteRRSet(domain, name, recordType string) error {
+	return c.do(http.MethodDelete, rrSetURI(domain, name, recordType), nil, nil)
+}
+
+func rrSetURI(domain, name, recordType string) string {
+	return fmt.Sprintf("/domains/%s/resourceRecordSets/%s/%s", url.PathEscape(domain), url.PathEscape(name), url.PathEscape(recordType))
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-LSW-Auth", c.apiKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.ErrorMessage == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
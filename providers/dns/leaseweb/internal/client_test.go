@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("X-LSW-Auth") != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"errorCode":"401","errorMessage":"You are not authorized to view this resource.","correlationId":"945bef2e-1caf-4027-bd0a-8976848f3dee"}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_GetRRSet(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/domains/example.com/resourceRecordSets/_acme-challenge.example.com./TXT", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"name":"_acme-challenge.example.com.","type":"TXT","content":["\"foo\"","bar"],"ttl":60,"editable":true}`)
+	})
+	defer tearDown()
+
+	rrSet, err := client.GetRRSet("example.com", "_acme-challenge.example.com.", "TXT")
+	require.NoError(t, err)
+
+	expected := &ResourceRecordSet{Name: "_acme-challenge.example.com.", Type: "TXT", Content: []string{`"foo"`, "bar"}, TTL: 60}
+	assert.Equal(t, expected, rrSet)
+}
+
+func TestClient_GetRRSet_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/domains/example.com/resourceRecordSets/_acme-challenge.example.com./TXT", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(rw, `{"errorCode":"404","errorMessage":"Resource record set not found.","correlationId":"7d1a2f0c-3b4e-4f5a-9c8d-1e2f3a4b5c6d"}`)
+	})
+	defer tearDown()
+
+	_, err := client.GetRRSet("example.com", "_acme-challenge.example.com.", "TXT")
+	require.EqualError(t, err, "[status code: 404] 404: Resource record set not found. (correlation ID: 7d1a2f0c-3b4e-4f5a-9c8d-1e2f3a4b5c6d)")
+	assert.True(t, IsNotFound(err))
+
+	client.apiKey = "invalid"
+
+	_, err = client.GetRRSet("example.com", "_acme-challenge.example.com.", "TXT")
+	require.Error(t, err)
+	assert.False(t, IsNotFound(err))
+}
+
+func TestClient_CreateRRSet(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/domains/example.com/resourceRecordSets", func(rw http.ResponseWriter, req *http.Request) {
+		rrSet := ResourceRecordSet{}
+		err := json.NewDecoder(req.Body).Decode(&rrSet)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := ResourceRecordSet{Name: "_acme-challenge.example.com.", Type: "TXT", Content: []string{"txtTXTtxt"}, TTL: 60}
+		if !reflect.DeepEqual(rrSet, expected) {
+			http.Error(rw, fmt.Sprintf("invalid resource record set: %+v", rrSet), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `{"name":"_acme-challenge.example.com.","type":"TXT","content":["txtTXTtxt"],"ttl":60,"editable":true}`)
+	})
+	defer tearDown()
+
+	err := client.CreateRRSet("example.com", ResourceRecordSet{Name: "_acme-challenge.example.com.", Type: "TXT", Content: []string{"txtTXTtxt"}, TTL: 60})
+	require.NoError(t, err)
+}
+
+func TestClient_UpdateRRSet(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPut, "/domains/example.com/resourceRecordSets/_acme-challenge.example.com./TXT", func(rw http.ResponseWriter, req *http.Request) {
+		rrSet := ResourceRecordSet{}
+		err := json.NewDecoder(req.Body).Decode(&rrSet)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := ResourceRecordSet{Content: []string{"foo", "txtTXTtxt"}, TTL: 60}
+		if !reflect.DeepEqual(rrSet, expected) {
+			http.Error(rw, fmt.Sprintf("invalid resource record set: %+v", rrSet), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"name":"_acme-challenge.example.com.","type":"TXT","content":["foo","txtTXTtxt"],"ttl":60,"editable":true}`)
+	})
+	defer tearDown()
+
+	err := client.UpdateRRSet("example.com", ResourceRecordSet{Name: "_acme-challenge.example.com.", Type: "TXT", Content: []string{"foo", "txtTXTtxt"}, TTL: 60})
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRRSet(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/domains/example.com/resourceRecordSets/_acme-challenge.example.com./TXT", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	err := client.DeleteRRSet("example.com", "_acme-challenge.example.com.", "TXT")
+	require.NoError(t, err)
+}
@@ -0,0 +1,209 @@
+// Package leaseweb implements a DNS provider for solving the DNS-01 challenge using Leaseweb.
+package leaseweb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/leaseweb/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		// the TTL must be one of: 60, 300, 1800, 3600, 14400, 28800, 43200, 86400.
+		TTL:                env.GetOrDefaultInt("LEASEWEB_TTL", 60),
+		PropagationTimeout: env.GetOrDefaultSecond("LEASEWEB_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("LEASEWEB_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("LEASEWEB_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+	// the resource record sets are updated as a whole: the updates of a set are serialized.
+	mu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Leaseweb.
+// Credentials must be passed in the environment variable: LEASEWEB_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("LEASEWEB_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("leaseweb: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["LEASEWEB_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Leaseweb.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("leaseweb: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("leaseweb: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+// The value is added to the TXT resource record set of the FQDN (the set is created if it doesn't exist).
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("leaseweb: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	rrSet, err := d.client.GetRRSet(zone, fqdn, "TXT")
+	if err != nil {
+		if !internal.IsNotFound(err) {
+			return fmt.Errorf("leaseweb: failed to get the resource record set %s: %v", fqdn, err)
+		}
+
+		rrSet = &internal.ResourceRecordSet{Name: fqdn, Type: "TXT", Content: []string{value}, TTL: d.config.TTL}
+
+		err = d.client.CreateRRSet(zone, *rrSet)
+		if err != nil {
+			return fmt.Errorf("leaseweb: failed to create the resource record set %s: %v", fqdn, err)
+		}
+
+		return nil
+	}
+
+	if containsValue(rrSet.Content, value) {
+		return nil
+	}
+
+	rrSet.Name, rrSet.Type = fqdn, "TXT"
+	rrSet.Content = append(rrSet.Content, value)
+
+	err = d.client.UpdateRRSet(zone, *rrSet)
+	if err != nil {
+		return fmt.Errorf("leaseweb: failed to update the resource record set %s: %v", fqdn, err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+// The resource record set is deleted if the value is its last value.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("leaseweb: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	rrSet, err := d.client.GetRRSet(zone, fqdn, "TXT")
+	if err != nil {
+		return fmt.Errorf("leaseweb: failed to get the resource record set %s: %v", fqdn, err)
+	}
+
+	var content []string
+	for _, v := range rrSet.Content {
+		if unquote(v) != value {
+			content = append(content, v)
+		}
+	}
+
+	if len(content) == len(rrSet.Content) {
+		return nil
+	}
+
+	if len(content) == 0 {
+		err = d.client.DeleteRRSet(zone, fqdn, "TXT")
+		if err != nil {
+			return fmt.Errorf("leaseweb: failed to delete the resource record set %s: %v", fqdn, err)
+		}
+
+		return nil
+	}
+
+	rrSet.Name, rrSet.Type = fqdn, "TXT"
+	rrSet.Content = content
+
+	err = d.client.UpdateRRSet(zone, *rrSet)
+	if err != nil {
+		return fmt.Errorf("leaseweb: failed to update the resource record set %s: %v", fqdn, err)
+	}
+
+	return nil
+}
+
+// findZone returns the domain (zone) of the FQDN, without trailing dot.
+func findZone(fqdn string) (string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", fmt.Errorf("could not determine the zone: %v", err)
+	}
+
+	return dns01.UnFqdn(authZone), nil
+}
+
+func containsValue(content []string, value string) bool {
+	for _, v := range content {
+		if unquote(v) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unquote removes the quotes of a TXT value, if any.
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
@@ -68,8 +68,9 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("linode: credentials missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("linode: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL)
+	if err != nil {
+		return nil, fmt.Errorf("linode: %v", err)
 	}
 
 	return &DNSProvider{
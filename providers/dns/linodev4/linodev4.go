@@ -74,8 +74,9 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("linodev4: Linode Access Token missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("linodev4: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL)
+	if err != nil {
+		return nil, fmt.Errorf("linodev4: %v", err)
 	}
 
 	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: config.Token})
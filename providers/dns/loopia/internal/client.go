@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const defaultBaseURL = "https://api.loopia.se/RPCSERV"
+
+// statusOK the status returned by the API methods on success.
+const statusOK = "OK"
+
+// RPCError an XML-RPC fault.
+type RPCError struct {
+	FaultCode   int
+	FaultString string
+}
+
+func (e RPCError) Error() string {
+	return fmt.Sprintf("RPC error: (%d) %s", e.FaultCode, e.FaultString)
+}
+
+// StatusError an unsuccessful status returned by the API (e.g. AUTH_ERROR, RATE_LIMITED, BAD_INDATA, UNKNOWN_ERROR).
+type StatusError struct {
+	Method string
+	Status string
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("%s: unexpected status: %s", e.Method, e.Status)
+}
+
+// Client the Loopia XML-RPC API client.
+type Client struct {
+	user       string
+	password   string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(user, password string) (*Client, error) {
+	if user == "" || password == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		user:       user,
+		password:   password,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// AddTXTRecord adds a TXT record to a subdomain (relative to the domain, e.g. "_acme-challenge").
+// The subdomain is created if it doesn't exist.
+// https://www.loopia.com/api/addzonerecord/
+func (c *Client) AddTXTRecord(domain, subdomain string, ttl int, value string) error {
+	record := RecordObj{Type: "TXT", TTL: ttl, Rdata: value}
+
+	return c.callStatus("addZoneRecord", stringValue(domain), stringValue(subdomain), record.toValue())
+}
+
+// GetTXTRecords returns the TXT records of a subdomain.
+// https://www.loopia.com/api/getzonerecords/
+func (c *Client) GetTXTRecords(domain, subdomain string) ([]RecordObj, error) {
+	result, err := c.call("getZoneRecords", stringValue(domain), stringValue(subdomain))
+	if err != nil {
+		return nil, err
+	}
+
+	// the errors are returned as a status string, sometimes inside an array.
+	if result.Array == nil {
+		return nil, StatusError{Method: "getZoneRecords", Status: result.str()}
+	}
+
+	var records []RecordObj
+	for _, item := range result.Array.Values {
+		if item.Struct == nil {
+			return nil, StatusError{Method: "getZoneRecords", Status: item.str()}
+		}
+
+		record := newRecordObj(item)
+		if record.Type == "TXT" {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// RemoveTXTRecord removes a record of a subdomain.
+// https://www.loopia.com/api/removezonerecord/
+func (c *Client) RemoveTXTRecord(domain, subdomain string, recordID int) error {
+	return c.callStatus("removeZoneRecord", stringValue(domain), stringValue(subdomain), intValue(recordID))
+}
+
+// RemoveSubdomain removes a subdomain and its records.
+// https://www.loopia.com/api/removesubdomain/
+func (c *Client) RemoveSubdomain(domain, subdomain string) error {
+	return c.callStatus("removeSubdomain", stringValue(domain), stringValue(subdomain))
+}
+
+// callStatus calls a method returning a status string.
+func (c *Client) callStatus(method string, args ...value) error {
+	result, err := c.call(method, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.str() != statusOK {
+		return StatusError{Method: method, Status: result.str()}
+	}
+
+	return nil
+}
+
+// call calls a method with the credentials (and an empty customer number) followed by the arguments,
+// and returns the value of the response.
+func (c *Client) call(method string, args ...value) (value, error) {
+	call := &methodCall{
+		MethodName: method,
+		Params: []param{
+			{Value: stringValue(c.user)},
+			{Value: stringValue(c.password)},
+			// the customer number is only used by the resellers.
+			{Value: stringValue("")},
+		},
+	}
+
+	for _, arg := range args {
+		call.Params = append(call.Params, param{Value: arg})
+	}
+
+	resp, err := c.rpcCall(call)
+	if err != nil {
+		return value{}, err
+	}
+
+	if len(resp.Params) == 0 {
+		return value{}, fmt.Errorf("%s: empty response", method)
+	}
+
+	return resp.Params[0].Value, nil
+}
+
+// rpcCall sends the XML-RPC method call, and returns the response (or the fault as an error).
+func (c *Client) rpcCall(call *methodCall) (*methodResponse, error) {
+	raw, err := xml.MarshalIndent(call, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %v", err)
+	}
+
+	raw = append([]byte(xml.Header), raw...)
+
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL, bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "text/xml")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(body))
+	}
+
+	result := &methodResponse{}
+	err = xml.Unmarshal(body, result)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal error: %v", err)
+	}
+
+	if result.Fault != nil {
+		return nil, RPCError{
+			FaultCode:   result.Fault.Value.member("faultCode").int(),
+			FaultString: result.Fault.Value.member("faultString").str(),
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,383 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, expectedRequest, response string) (*Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Content-Type") != "text/xml" {
+			http.Error(rw, fmt.Sprintf("invalid content type: %s", req.Header.Get("Content-Type")), http.StatusBadRequest)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if string(body) != expectedRequest {
+			http.Error(rw, fmt.Sprintf("unexpected request: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, response)
+	}))
+
+	client, err := NewClient("user@loopiaapi", "secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_AddTXTRecord(t *testing.T) {
+	client, tearDown := setupTest(t, addZoneRecordRequest, statusResponse("OK"))
+	defer tearDown()
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", 300, "txtTXTtxt")
+	require.NoError(t, err)
+}
+
+func TestClient_AddTXTRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, addZoneRecordRequest, statusResponse("AUTH_ERROR"))
+	defer tearDown()
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", 300, "txtTXTtxt")
+	require.EqualError(t, err, "addZoneRecord: unexpected status: AUTH_ERROR")
+}
+
+func TestClient_AddTXTRecord_fault(t *testing.T) {
+	client, tearDown := setupTest(t, addZoneRecordRequest, faultResponse)
+	defer tearDown()
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", 300, "txtTXTtxt")
+	require.EqualError(t, err, "RPC error: (201) Method signature error: 42")
+}
+
+func TestClient_GetTXTRecords(t *testing.T) {
+	client, tearDown := setupTest(t, getZoneRecordsRequest, getZoneRecordsResponse)
+	defer tearDown()
+
+	records, err := client.GetTXTRecords("example.com", "_acme-challenge")
+	require.NoError(t, err)
+
+	expected := []RecordObj{
+		{Type: "TXT", TTL: 300, Rdata: "txtTXTtxt", RecordID: 12345678},
+		{Type: "TXT", TTL: 3600, Rdata: "other", RecordID: 12345679},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_GetTXTRecords_empty(t *testing.T) {
+	client, tearDown := setupTest(t, getZoneRecordsRequest, emptyArrayResponse)
+	defer tearDown()
+
+	records, err := client.GetTXTRecords("example.com", "_acme-challenge")
+	require.NoError(t, err)
+
+	assert.Empty(t, records)
+}
+
+func TestClient_GetTXTRecords_error(t *testing.T) {
+	client, tearDown := setupTest(t, getZoneRecordsRequest, statusArrayResponse("UNKNOWN_ERROR"))
+	defer tearDown()
+
+	_, err := client.GetTXTRecords("example.com", "_acme-challenge")
+	require.EqualError(t, err, "getZoneRecords: unexpected status: UNKNOWN_ERROR")
+}
+
+func TestClient_RemoveTXTRecord(t *testing.T) {
+	client, tearDown := setupTest(t, removeZoneRecordRequest, statusResponse("OK"))
+	defer tearDown()
+
+	err := client.RemoveTXTRecord("example.com", "_acme-challenge", 12345678)
+	require.NoError(t, err)
+}
+
+func TestClient_RemoveTXTRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, removeZoneRecordRequest, statusResponse("BAD_INDATA"))
+	defer tearDown()
+
+	err := client.RemoveTXTRecord("example.com", "_acme-challenge", 12345678)
+	require.EqualError(t, err, "removeZoneRecord: unexpected status: BAD_INDATA")
+}
+
+func TestClient_RemoveSubdomain(t *testing.T) {
+	client, tearDown := setupTest(t, removeSubdomainRequest, statusResponse("OK"))
+	defer tearDown()
+
+	err := client.RemoveSubdomain("example.com", "_acme-challenge")
+	require.NoError(t, err)
+}
+
+func TestClient_RemoveSubdomain_error(t *testing.T) {
+	client, tearDown := setupTest(t, removeSubdomainRequest, statusResponse("RATE_LIMITED"))
+	defer tearDown()
+
+	err := client.RemoveSubdomain("example.com", "_acme-challenge")
+	require.EqualError(t, err, "removeSubdomain: unexpected status: RATE_LIMITED")
+}
+
+func statusResponse(status string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+<params>
+<param>
+<value><string>` + status + `</string></value>
+</param>
+</params>
+</methodResponse>
+`
+}
+
+func statusArrayResponse(status string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+<params>
+<param>
+<value><array><data><value><string>` + status + `</string></value></data></array></value>
+</param>
+</params>
+</methodResponse>
+`
+}
+
+const addZoneRecordRequest = `<?xml version="1.0" encoding="UTF-8"?>
+<methodCall>
+  <methodName>addZoneRecord</methodName>
+  <params>
+    <param>
+      <value>
+        <string>user@loopiaapi</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>secret</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string></string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>example.com</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>_acme-challenge</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <struct>
+          <member>
+            <name>type</name>
+            <value>
+              <string>TXT</string>
+            </value>
+          </member>
+          <member>
+            <name>ttl</name>
+            <value>
+              <int>300</int>
+            </value>
+          </member>
+          <member>
+            <name>priority</name>
+            <value>
+              <int>0</int>
+            </value>
+          </member>
+          <member>
+            <name>rdata</name>
+            <value>
+              <string>txtTXTtxt</string>
+            </value>
+          </member>
+          <member>
+            <name>record_id</name>
+            <value>
+              <int>0</int>
+            </value>
+          </member>
+        </struct>
+      </value>
+    </param>
+  </params>
+</methodCall>`
+
+const getZoneRecordsRequest = `<?xml version="1.0" encoding="UTF-8"?>
+<methodCall>
+  <methodName>getZoneRecords</methodName>
+  <params>
+    <param>
+      <value>
+        <string>user@loopiaapi</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>secret</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string></string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>example.com</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>_acme-challenge</string>
+      </value>
+    </param>
+  </params>
+</methodCall>`
+
+const removeZoneRecordRequest = `<?xml version="1.0" encoding="UTF-8"?>
+<methodCall>
+  <methodName>removeZoneRecord</methodName>
+  <params>
+    <param>
+      <value>
+        <string>user@loopiaapi</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>secret</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string></string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>example.com</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>_acme-challenge</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <int>12345678</int>
+      </value>
+    </param>
+  </params>
+</methodCall>`
+
+const removeSubdomainRequest = `<?xml version="1.0" encoding="UTF-8"?>
+<methodCall>
+  <methodName>removeSubdomain</methodName>
+  <params>
+    <param>
+      <value>
+        <string>user@loopiaapi</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>secret</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string></string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>example.com</string>
+      </value>
+    </param>
+    <param>
+      <value>
+        <string>_acme-challenge</string>
+      </value>
+    </param>
+  </params>
+</methodCall>`
+
+// the types of the values are mixed (i4, int, untyped string) like in the responses of the API.
+const getZoneRecordsResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+<params>
+<param>
+<value><array><data>
+<value><struct>
+<member><name>type</name><value><string>TXT</string></value></member>
+<member><name>ttl</name><value><int>300</int></value></member>
+<member><name>priority</name><value><int>0</int></value></member>
+<member><name>rdata</name><value><string>txtTXTtxt</string></value></member>
+<member><name>record_id</name><value><int>12345678</int></value></member>
+</struct></value>
+<value><struct>
+<member><name>type</name><value><string>A</string></value></member>
+<member><name>ttl</name><value><int>3600</int></value></member>
+<member><name>priority</name><value><int>0</int></value></member>
+<member><name>rdata</name><value><string>192.0.2.1</string></value></member>
+<member><name>record_id</name><value><int>12345677</int></value></member>
+</struct></value>
+<value><struct>
+<member><name>type</name><value>TXT</value></member>
+<member><name>ttl</name><value><i4>3600</i4></value></member>
+<member><name>priority</name><value><i4>0</i4></value></member>
+<member><name>rdata</name><value>other</value></member>
+<member><name>record_id</name><value><i4>12345679</i4></value></member>
+</struct></value>
+</data></array></value>
+</param>
+</params>
+</methodResponse>
+`
+
+const emptyArrayResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+<params>
+<param>
+<value><array><data></data></array></value>
+</param>
+</params>
+</methodResponse>
+`
+
+const faultResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse>
+<fault>
+<value><struct>
+<member><name>faultCode</name><value><int>201</int></value></member>
+<member><name>faultString</name><value><string>Method signature error: 42</string></value></member>
+</struct></value>
+</fault>
+</methodResponse>
+`
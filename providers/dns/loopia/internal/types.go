@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// types of the XML-RPC method calls and responses.
+// http://xmlrpc.com/spec.md
+
+type methodCall struct {
+	XMLName    xml.Name `xml:"methodCall"`
+	MethodName string   `xml:"methodName"`
+	Params     []param  `xml:"params>param"`
+}
+
+type methodResponse struct {
+	XMLName xml.Name `xml:"methodResponse"`
+	Params  []param  `xml:"params>param"`
+	Fault   *param   `xml:"fault"`
+}
+
+type param struct {
+	Value value `xml:"value"`
+}
+
+// value an XML-RPC value: only one of the fields is set.
+type value struct {
+	String *string    `xml:"string,omitempty"`
+	Int    *int       `xml:"int,omitempty"`
+	I4     *int       `xml:"i4,omitempty"`
+	Struct *xmlStruct `xml:"struct,omitempty"`
+	Array  *xmlArray  `xml:"array,omitempty"`
+	// a value without type is a string.
+	Raw string `xml:",chardata"`
+}
+
+type xmlStruct struct {
+	Members []member `xml:"member"`
+}
+
+type xmlArray struct {
+	Values []value `xml:"data>value"`
+}
+
+type member struct {
+	Name  string `xml:"name"`
+	Value value  `xml:"value"`
+}
+
+func stringValue(s string) value {
+	return value{String: &s}
+}
+
+func intValue(i int) value {
+	return value{Int: &i}
+}
+
+func structValue(members ...member) value {
+	return value{Struct: &xmlStruct{Members: members}}
+}
+
+func (v value) str() string {
+	if v.String != nil {
+		return *v.String
+	}
+
+	return strings.TrimSpace(v.Raw)
+}
+
+func (v value) int() int {
+	switch {
+	case v.Int != nil:
+		return *v.Int
+	case v.I4 != nil:
+		return *v.I4
+	default:
+		return 0
+	}
+}
+
+func (v value) member(name string) value {
+	if v.Struct == nil {
+		return value{}
+	}
+
+	for _, m := range v.Struct.Members {
+		if m.Name == name {
+			return m.Value
+		}
+	}
+
+	return value{}
+}
+
+// RecordObj a DNS record of a subdomain.
+// https://www.loopia.com/api/record_obj/
+type RecordObj struct {
+	Type     string
+	TTL      int
+	Priority int
+	Rdata    string
+	RecordID int
+}
+
+func (r RecordObj) toValue() value {
+	return structValue(
+		member{Name: "type", Value: stringValue(r.Type)},
+		member{Name: "ttl", Value: intValue(r.TTL)},
+		member{Name: "priority", Value: intValue(r.Priority)},
+		member{Name: "rdata", Value: stringValue(r.Rdata)},
+		member{Name: "record_id", Value: intValue(r.RecordID)},
+	)
+}
+
+func newRecordObj(v value) RecordObj {
+	return RecordObj{
+		Type:     v.member("type").str(),
+		TTL:      v.member("ttl").int(),
+		Priority: v.member("priority").int(),
+		Rdata:    v.member("rdata").str(),
+		RecordID: v.member("record_id").int(),
+	}
+}
@@ -0,0 +1,176 @@
+// Package loopia implements a DNS provider for solving the DNS-01 challenge using Loopia.
+package loopia
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/loopia/internal"
+)
+
+// minTTL the minimal TTL accepted by Loopia.
+const minTTL = 300
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIUser            string
+	APIPassword        string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("LOOPIA_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("LOOPIA_PROPAGATION_TIMEOUT", 40*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("LOOPIA_POLLING_INTERVAL", 60*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("LOOPIA_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+	// the records of a subdomain are read then removed in CleanUp.
+	mu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Loopia.
+// Credentials must be passed in the environment variables: LOOPIA_API_USER, LOOPIA_API_PASSWORD.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("LOOPIA_API_USER", "LOOPIA_API_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("loopia: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIUser = values["LOOPIA_API_USER"]
+	config.APIPassword = values["LOOPIA_API_PASSWORD"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Loopia.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("loopia: the configuration of the DNS provider is nil")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("loopia: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client, err := internal.NewClient(config.APIUser, config.APIPassword)
+	if err != nil {
+		return nil, fmt.Errorf("loopia: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subdomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("loopia: %v", err)
+	}
+
+	err = d.client.AddTXTRecord(zone, subdomain, d.config.TTL, value)
+	if err != nil {
+		return fmt.Errorf("loopia: failed to add TXT record [zone: %q, subdomain: %q]: %v", zone, subdomain, err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+// The subdomain is removed when it has no more records.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subdomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("loopia: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	records, err := d.client.GetTXTRecords(zone, subdomain)
+	if err != nil {
+		return fmt.Errorf("loopia: failed to get TXT records [zone: %q, subdomain: %q]: %v", zone, subdomain, err)
+	}
+
+	var remaining int
+	var found bool
+	for _, record := range records {
+		if found || record.Rdata != value {
+			remaining++
+			continue
+		}
+
+		err = d.client.RemoveTXTRecord(zone, subdomain, record.RecordID)
+		if err != nil {
+			return fmt.Errorf("loopia: failed to remove TXT record [zone: %q, subdomain: %q, id: %d]: %v", zone, subdomain, record.RecordID, err)
+		}
+
+		found = true
+	}
+
+	if !found {
+		return fmt.Errorf("loopia: TXT record not found [zone: %q, subdomain: %q]", zone, subdomain)
+	}
+
+	if remaining > 0 {
+		return nil
+	}
+
+	err = d.client.RemoveSubdomain(zone, subdomain)
+	if err != nil {
+		return fmt.Errorf("loopia: failed to remove subdomain [zone: %q, subdomain: %q]: %v", zone, subdomain, err)
+	}
+
+	return nil
+}
+
+// splitDomain returns the zone (the domain registered at Loopia) and the subdomain relative to the zone.
+func splitDomain(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", fmt.Errorf("could not determine the zone: %v", err)
+	}
+
+	zone := dns01.UnFqdn(authZone)
+	subdomain := strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone)
+
+	return zone, subdomain, nil
+}
@@ -0,0 +1,149 @@
+package loopia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vostronet/lego/platform/tester"
+)
+
+var envTest = tester.NewEnvTest("LOOPIA_API_USER", "LOOPIA_API_PASSWORD").
+	WithDomain("LOOPIA_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"LOOPIA_API_USER":     "user@loopiaapi",
+				"LOOPIA_API_PASSWORD": "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"LOOPIA_API_USER":     "",
+				"LOOPIA_API_PASSWORD": "",
+			},
+			expected: "loopia: some credentials information are missing: LOOPIA_API_USER,LOOPIA_API_PASSWORD",
+		},
+		{
+			desc: "missing user",
+			envVars: map[string]string{
+				"LOOPIA_API_USER":     "",
+				"LOOPIA_API_PASSWORD": "secret",
+			},
+			expected: "loopia: some credentials information are missing: LOOPIA_API_USER",
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				"LOOPIA_API_USER":     "user@loopiaapi",
+				"LOOPIA_API_PASSWORD": "",
+			},
+			expected: "loopia: some credentials information are missing: LOOPIA_API_PASSWORD",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		user     string
+		password string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:     "success",
+			user:     "user@loopiaapi",
+			password: "secret",
+			ttl:      300,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      300,
+			expected: "loopia: credentials missing",
+		},
+		{
+			desc:     "invalid TTL",
+			user:     "user@loopiaapi",
+			password: "secret",
+			ttl:      60,
+			expected: "loopia: invalid TTL, TTL (60) must be greater than 300",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIUser = test.user
+			config.APIPassword = test.password
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,211 @@
+// Package multi implements a DNS provider for solving the DNS-01 challenge by publishing the TXT records with several DNS providers.
+package multi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+)
+
+// ProviderFactory creates a DNS provider from its name.
+type ProviderFactory func(name string) (challenge.Provider, error)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	// Providers the DNS providers publishing the TXT records, by name.
+	Providers          map[string]challenge.Provider
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		Providers:          map[string]challenge.Provider{},
+		PropagationTimeout: env.GetOrDefaultSecond("MULTI_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("MULTI_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	names  []string
+}
+
+// NewDNSProvider returns a DNSProvider instance publishing the TXT records with several DNS providers.
+// The names of the providers must be passed in the environment variable: MULTI_PROVIDERS (comma separated).
+// The providers are created by the factory, and are configured by their own environment variables.
+func NewDNSProvider(factory ProviderFactory) (*DNSProvider, error) {
+	values, err := env.Get("MULTI_PROVIDERS")
+	if err != nil {
+		return nil, fmt.Errorf("multi: %v", err)
+	}
+
+	config := NewDefaultConfig()
+
+	for _, name := range strings.Split(values["MULTI_PROVIDERS"], ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if name == "multi" {
+			return nil, errors.New("multi: the multi provider cannot be one of the providers")
+		}
+
+		provider, err := factory(name)
+		if err != nil {
+			return nil, fmt.Errorf("multi: failed to create the provider %q: %v", name, err)
+		}
+
+		config.Providers[name] = provider
+	}
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance publishing the TXT records with several DNS providers.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("multi: the configuration of the DNS provider is nil")
+	}
+
+	if len(config.Providers) == 0 {
+		return nil, errors.New("multi: no DNS provider")
+	}
+
+	var names []string
+	for name := range config.Providers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return &DNSProvider{config: config, names: names}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// The largest values of the providers are used.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	timeout, interval = d.config.PropagationTimeout, d.config.PollingInterval
+
+	for _, provider := range d.config.Providers {
+		p, ok := provider.(challenge.ProviderTimeout)
+		if !ok {
+			continue
+		}
+
+		t, i := p.Timeout()
+		if t > timeout {
+			timeout = t
+		}
+		if i > interval {
+			interval = i
+		}
+	}
+
+	return timeout, interval
+}
+
+// SingleValueTXT returns true if one of the providers can only publish one value per TXT record name.
+func (d *DNSProvider) SingleValueTXT() bool {
+	for _, provider := range d.config.Providers {
+		if p, ok := provider.(interface{ SingleValueTXT() bool }); ok && p.SingleValueTXT() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sequential returns the largest interval between two challenges of the sequential providers (see IsSequential).
+func (d *DNSProvider) Sequential() time.Duration {
+	var interval time.Duration
+	for _, provider := range d.config.Providers {
+		if ok, i := dns01.IsSequential(provider); ok && i > interval {
+			interval = i
+		}
+	}
+
+	return interval
+}
+
+// IsSequential returns true if one of the providers solves the challenges one after the other.
+func (d *DNSProvider) IsSequential() bool {
+	for _, provider := range d.config.Providers {
+		if ok, _ := dns01.IsSequential(provider); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check verifies the credentials of all the providers (see challenge.CredentialChecker).
+// It returns challenge.ErrCheckNotSupported if one of the providers doesn't support it,
+// and fails if one of the checks fails.
+func (d *DNSProvider) Check() error {
+	for _, name := range d.names {
+		if _, ok := d.config.Providers[name].(challenge.CredentialChecker); !ok {
+			return challenge.ErrCheckNotSupported
+		}
+	}
+
+	err := d.each(func(provider challenge.Provider) error {
+		return provider.(challenge.CredentialChecker).Check()
+	})
+	if err != nil {
+		return fmt.Errorf("multi: failed to check the credentials: %v", err)
+	}
+
+	return nil
+}
+
+// Present creates the TXT record with all the providers.
+// It fails if one of the providers fails.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	err := d.each(func(provider challenge.Provider) error {
+		return provider.Present(domain, token, keyAuth)
+	})
+	if err != nil {
+		return fmt.Errorf("multi: failed to create the TXT record: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record with all the providers.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	err := d.each(func(provider challenge.Provider) error {
+		return provider.CleanUp(domain, token, keyAuth)
+	})
+	if err != nil {
+		return fmt.Errorf("multi: failed to remove the TXT record: %v", err)
+	}
+
+	return nil
+}
+
+// each calls fn for all the providers, and aggregates the errors.
+func (d *DNSProvider) each(fn func(provider challenge.Provider) error) error {
+	var errs []string
+
+	for _, name := range d.names {
+		if err := fn(d.config.Providers[name]); err != nil {
+			errs = append(errs, fmt.Sprintf("[%s] %v", name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
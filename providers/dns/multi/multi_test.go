@@ -0,0 +1,265 @@
+package multi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("MULTI_PROVIDERS")
+
+type providerMock struct {
+	presented []string
+	cleaned   []string
+	err       error
+}
+
+func (p *providerMock) Present(domain, token, keyAuth string) error {
+	p.presented = append(p.presented, domain)
+	return p.err
+}
+
+func (p *providerMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleaned = append(p.cleaned, domain)
+	return p.err
+}
+
+type providerTimeoutMock struct {
+	providerMock
+	timeout, interval time.Duration
+}
+
+func (p *providerTimeoutMock) Timeout() (timeout, interval time.Duration) {
+	return p.timeout, p.interval
+}
+
+type providerSingleValueMock struct {
+	providerMock
+}
+
+func (p *providerSingleValueMock) SingleValueTXT() bool {
+	return true
+}
+
+type providerSequentialMock struct {
+	providerMock
+	interval time.Duration
+}
+
+func (p *providerSequentialMock) Sequential() time.Duration {
+	return p.interval
+}
+
+type providerCheckerMock struct {
+	providerMock
+	err error
+}
+
+func (p *providerCheckerMock) Check() error {
+	return p.err
+}
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		envVars       map[string]string
+		expectedNames []string
+		expected      string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"MULTI_PROVIDERS": "a, b",
+			},
+			expectedNames: []string{"a", "b"},
+		},
+		{
+			desc: "missing providers",
+			envVars: map[string]string{
+				"MULTI_PROVIDERS": "",
+			},
+			expected: "multi: some credentials information are missing: MULTI_PROVIDERS",
+		},
+		{
+			desc: "empty list",
+			envVars: map[string]string{
+				"MULTI_PROVIDERS": " , ",
+			},
+			expected: "multi: no DNS provider",
+		},
+		{
+			desc: "recursive provider",
+			envVars: map[string]string{
+				"MULTI_PROVIDERS": "a,multi",
+			},
+			expected: "multi: the multi provider cannot be one of the providers",
+		},
+		{
+			desc: "unknown provider",
+			envVars: map[string]string{
+				"MULTI_PROVIDERS": "a,foo",
+			},
+			expected: `multi: failed to create the provider "foo": unrecognized DNS provider: foo`,
+		},
+	}
+
+	factory := func(name string) (challenge.Provider, error) {
+		if name != "a" && name != "b" {
+			return nil, fmt.Errorf("unrecognized DNS provider: %s", name)
+		}
+		return &providerMock{}, nil
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider(factory)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				assert.Equal(t, test.expectedNames, p.names)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	_, err := NewDNSProviderConfig(nil)
+	require.EqualError(t, err, "multi: the configuration of the DNS provider is nil")
+
+	_, err = NewDNSProviderConfig(NewDefaultConfig())
+	require.EqualError(t, err, "multi: no DNS provider")
+}
+
+func TestDNSProvider_Timeout(t *testing.T) {
+	config := NewDefaultConfig()
+	config.PropagationTimeout = time.Minute
+	config.PollingInterval = 5 * time.Second
+	config.Providers["a"] = &providerMock{}
+	config.Providers["b"] = &providerTimeoutMock{timeout: 10 * time.Minute, interval: time.Second}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	timeout, interval := p.Timeout()
+	assert.Equal(t, 10*time.Minute, timeout)
+	assert.Equal(t, 5*time.Second, interval)
+}
+
+func TestDNSProvider_SingleValueTXT(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Providers["a"] = &providerMock{}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	assert.False(t, p.SingleValueTXT())
+
+	config.Providers["b"] = &providerSingleValueMock{}
+
+	assert.True(t, p.SingleValueTXT())
+}
+
+func TestDNSProvider_Sequential(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Providers["a"] = &providerMock{}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	sequential, _ := dns01.IsSequential(p)
+	assert.False(t, sequential)
+
+	config.Providers["b"] = &providerSequentialMock{interval: time.Second}
+	config.Providers["c"] = &providerSequentialMock{interval: 2 * time.Second}
+
+	sequential, interval := dns01.IsSequential(p)
+	assert.True(t, sequential)
+	assert.Equal(t, 2*time.Second, interval)
+}
+
+func TestDNSProvider_Check(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Providers["a"] = &providerCheckerMock{}
+	config.Providers["b"] = &providerCheckerMock{}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.Check()
+	require.NoError(t, err)
+
+	config.Providers["b"] = &providerCheckerMock{err: errors.New("oops b")}
+
+	err = p.Check()
+	require.EqualError(t, err, "multi: failed to check the credentials: [b] oops b")
+
+	// the credentials of a are not verified.
+	config.Providers["a"] = &providerMock{}
+
+	err = p.Check()
+	assert.Equal(t, challenge.ErrCheckNotSupported, err)
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	a := &providerMock{}
+	b := &providerMock{}
+
+	config := NewDefaultConfig()
+	config.Providers["a"] = a
+	config.Providers["b"] = b
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = p.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	for _, provider := range []*providerMock{a, b} {
+		assert.Equal(t, []string{"example.com"}, provider.presented)
+		assert.Equal(t, []string{"example.com"}, provider.cleaned)
+	}
+}
+
+func TestDNSProvider_Present_error(t *testing.T) {
+	a := &providerMock{err: errors.New("oops a")}
+	b := &providerMock{}
+	c := &providerMock{err: errors.New("oops c")}
+
+	config := NewDefaultConfig()
+	config.Providers["a"] = a
+	config.Providers["b"] = b
+	config.Providers["c"] = c
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "multi: failed to create the TXT record: [a] oops a, [c] oops c")
+
+	err = p.CleanUp("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "multi: failed to remove the TXT record: [a] oops a, [c] oops c")
+
+	for _, provider := range []*providerMock{a, b, c} {
+		assert.Equal(t, []string{"example.com"}, provider.presented)
+		assert.Equal(t, []string{"example.com"}, provider.cleaned)
+	}
+}
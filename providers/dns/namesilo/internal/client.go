@@ -0,0 +1,202 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultBaseURL = "https://www.namesilo.com/api"
+
+// successCode the code of a successful operation.
+const successCode = 300
+
+// Reply the common fields of the replies of the API.
+type Reply struct {
+	Code   int    `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// APIError an error of the API.
+type APIError struct {
+	Operation string
+	Reply
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("%s: [code: %d] %s", a.Operation, a.Code, a.Detail)
+}
+
+// Record a DNS resource record.
+type Record struct {
+	ID    string `json:"record_id"`
+	Type  string `json:"type"`
+	Host  string `json:"host"`
+	Value string `json:"value"`
+}
+
+// Records a list of resource records.
+// The API returns an object instead of an array when there is only one record.
+type Records []Record
+
+// UnmarshalJSON decodes an array of records or a single record.
+func (r *Records) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var record Record
+		err := json.Unmarshal(data, &record)
+		if err != nil {
+			return err
+		}
+
+		*r = Records{record}
+		return nil
+	}
+
+	var records []Record
+	err := json.Unmarshal(data, &records)
+	if err != nil {
+		return err
+	}
+
+	*r = records
+	return nil
+}
+
+// ListRecordsReply the reply of the dnsListRecords operation.
+type ListRecordsReply struct {
+	Reply
+	ResourceRecords Records `json:"resource_record"`
+}
+
+// AddRecordReply the reply of the dnsAddRecord operation.
+type AddRecordReply struct {
+	Reply
+	RecordID string `json:"record_id"`
+}
+
+type response struct {
+	Reply json.RawMessage `json:"reply"`
+}
+
+// Client the Namesilo API client.
+type Client struct {
+	apiKey     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey string) (*Client, error) {
+	if apiKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// ListRecords lists the resource records of a domain.
+// https://www.namesilo.com/api-reference#dns/dns-list-records
+func (c *Client) ListRecords(domain string) ([]Record, error) {
+	query := url.Values{}
+	query.Set("domain", domain)
+
+	result := &ListRecordsReply{}
+	err := c.do("dnsListRecords", query, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.ResourceRecords, nil
+}
+
+// AddRecord adds a resource record to a domain, and returns the ID of the record.
+// The host is relative to the domain (e.g. "_acme-challenge").
+// https://www.namesilo.com/api-reference#dns/dns-add-record
+func (c *Client) AddRecord(domain, rrType, host, value string, ttl int) (string, error) {
+	query := url.Values{}
+	query.Set("domain", domain)
+	query.Set("rrtype", rrType)
+	query.Set("rrhost", host)
+	query.Set("rrvalue", value)
+	query.Set("rrttl", strconv.Itoa(ttl))
+
+	result := &AddRecordReply{}
+	err := c.do("dnsAddRecord", query, result)
+	if err != nil {
+		return "", err
+	}
+
+	return result.RecordID, nil
+}
+
+// DeleteRecord deletes a resource record of a domain.
+// https://www.namesilo.com/api-reference#dns/dns-delete-record
+func (c *Client) DeleteRecord(domain, recordID string) error {
+	query := url.Values{}
+	query.Set("domain", domain)
+	query.Set("rrid", recordID)
+
+	return c.do("dnsDeleteRecord", query, nil)
+}
+
+func (c *Client) do(operation string, query url.Values, result interface{}) error {
+	query.Set("version", "1")
+	query.Set("type", "json")
+	query.Set("key", c.apiKey)
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + "/" + operation + "?" + query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+	}
+
+	var r response
+	err = json.Unmarshal(raw, &r)
+	if err != nil || len(r.Reply) == 0 {
+		return fmt.Errorf("unable to decode the response: %s", string(raw))
+	}
+
+	reply := Reply{}
+	err = json.Unmarshal(r.Reply, &reply)
+	if err != nil {
+		return fmt.Errorf("unable to decode the reply: %v", err)
+	}
+
+	if reply.Code != successCode {
+		return &APIError{Operation: operation, Reply: reply}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(r.Reply, result)
+}
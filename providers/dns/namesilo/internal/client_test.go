@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, operation string, expected url.Values, response string) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/"+operation, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := req.URL.Query()
+
+		if query.Get("key") != "secret" {
+			_, _ = fmt.Fprintf(rw, `{"request":{"operation":%q},"reply":{"code":110,"detail":"Invalid API Key"}}`, operation)
+			return
+		}
+
+		if query.Get("version") != "1" || query.Get("type") != "json" {
+			http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		for k := range expected {
+			if query.Get(k) != expected.Get(k) {
+				http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+				return
+			}
+		}
+
+		_, _ = fmt.Fprint(rw, response)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_ListRecords(t *testing.T) {
+	client, tearDown := setupTest(t, "dnsListRecords", url.Values{"domain": {"example.com"}},
+		`{"request":{"operation":"dnsListRecords","ip":"127.0.0.1"},"reply":{"code":300,"detail":"success","resource_record":[
+			{"record_id":"abc","type":"TXT","host":"_acme-challenge.example.com","value":"txtTXTtxt","ttl":3600,"distance":0},
+			{"record_id":"def","type":"A","host":"www.example.com","value":"127.0.0.1","ttl":7207,"distance":0}
+		]}}`)
+	defer tearDown()
+
+	records, err := client.ListRecords("example.com")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{ID: "abc", Type: "TXT", Host: "_acme-challenge.example.com", Value: "txtTXTtxt"},
+		{ID: "def", Type: "A", Host: "www.example.com", Value: "127.0.0.1"},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_ListRecords_single(t *testing.T) {
+	client, tearDown := setupTest(t, "dnsListRecords", url.Values{"domain": {"example.com"}},
+		`{"request":{"operation":"dnsListRecords","ip":"127.0.0.1"},"reply":{"code":300,"detail":"success","resource_record":
+			{"record_id":"abc","type":"TXT","host":"_acme-challenge.example.com","value":"txtTXTtxt","ttl":3600,"distance":0}
+		}}`)
+	defer tearDown()
+
+	records, err := client.ListRecords("example.com")
+	require.NoError(t, err)
+
+	expected := []Record{{ID: "abc", Type: "TXT", Host: "_acme-challenge.example.com", Value: "txtTXTtxt"}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_ListRecords_error(t *testing.T) {
+	client, tearDown := setupTest(t, "dnsListRecords", nil, "")
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	_, err := client.ListRecords("example.com")
+	require.EqualError(t, err, "dnsListRecords: [code: 110] Invalid API Key")
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	expected := url.Values{
+		"domain":  {"example.com"},
+		"rrtype":  {"TXT"},
+		"rrhost":  {"_acme-challenge"},
+		"rrvalue": {"txtTXTtxt"},
+		"rrttl":   {"3600"},
+	}
+
+	client, tearDown := setupTest(t, "dnsAddRecord", expected,
+		`{"request":{"operation":"dnsAddRecord","ip":"127.0.0.1"},"reply":{"code":300,"detail":"success","record_id":"abc"}}`)
+	defer tearDown()
+
+	recordID, err := client.AddRecord("example.com", "TXT", "_acme-challenge", "txtTXTtxt", 3600)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc", recordID)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, "dnsDeleteRecord", url.Values{"domain": {"example.com"}, "rrid": {"abc"}},
+		`{"request":{"operation":"dnsDeleteRecord","ip":"127.0.0.1"},"reply":{"code":300,"detail":"success"}}`)
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", "abc")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, "dnsDeleteRecord", nil,
+		`{"request":{"operation":"dnsDeleteRecord","ip":"127.0.0.1"},"reply":{"code":280,"detail":"Invalid record ID"}}`)
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", "abc")
+	require.EqualError(t, err, "dnsDeleteRecord: [code: 280] Invalid record ID")
+}
@@ -0,0 +1,156 @@
+// Package namesilo implements a DNS provider for solving the DNS-01 challenge using Namesilo.
+package namesilo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/namesilo/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		// 3600 is the minimum TTL of Namesilo.
+		TTL: env.GetOrDefaultInt("NAMESILO_TTL", 3600),
+		// the nameservers of Namesilo are updated every 15 minutes,
+		// and the API has strict rate limits: the propagation is checked conservatively.
+		PropagationTimeout: env.GetOrDefaultSecond("NAMESILO_PROPAGATION_TIMEOUT", time.Hour),
+		PollingInterval:    env.GetOrDefaultSecond("NAMESILO_POLLING_INTERVAL", time.Minute),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("NAMESILO_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Namesilo.
+// Credentials must be passed in the environment variable: NAMESILO_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("NAMESILO_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("namesilo: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["NAMESILO_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Namesilo.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("namesilo: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("namesilo: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("namesilo: %v", err)
+	}
+
+	_, err = d.client.AddRecord(zone, "TXT", subDomain, value, d.config.TTL)
+	if err != nil {
+		return fmt.Errorf("namesilo: failed to add record: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+// The record is identified by its host and its value.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, _, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("namesilo: %v", err)
+	}
+
+	recordID, err := d.findRecordID(zone, fqdn, value)
+	if err != nil {
+		return fmt.Errorf("namesilo: %v", err)
+	}
+
+	err = d.client.DeleteRecord(zone, recordID)
+	if err != nil {
+		return fmt.Errorf("namesilo: failed to delete record %s: %v", recordID, err)
+	}
+
+	return nil
+}
+
+// findRecordID returns the ID of the TXT record matching the FQDN and the value.
+func (d *DNSProvider) findRecordID(zone, fqdn, value string) (string, error) {
+	records, err := d.client.ListRecords(zone)
+	if err != nil {
+		return "", fmt.Errorf("failed to list records: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Type == "TXT" && strings.EqualFold(dns01.UnFqdn(record.Host), dns01.UnFqdn(fqdn)) && record.Value == value {
+			return record.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("no TXT record found for %s", fqdn)
+}
+
+// splitDomain returns the zone and the sub-domain (relative to the zone) of a FQDN.
+func splitDomain(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", err
+	}
+
+	zone := dns01.UnFqdn(authZone)
+	subDomain := strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone)
+
+	return zone, subDomain, nil
+}
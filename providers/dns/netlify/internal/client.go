@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.netlify.com/api/v1"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int    `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("[status code: %d] %s", a.StatusCode, a.Message)
+}
+
+// Zone a DNS zone.
+type Zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID       string `json:"id,omitempty"`
+	Hostname string `json:"hostname"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	TTL      int    `json:"ttl,omitempty"`
+}
+
+// Client the Netlify DNS API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(token string) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		token:      token,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// ListZones lists all the DNS zones of the account.
+// https://open-api.netlify.com/#operation/getDnsZones
+func (c *Client) ListZones() ([]Zone, error) {
+	var zones []Zone
+	err := c.do(http.MethodGet, "/dns_zones", nil, &zones)
+	if err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// CreateRecord creates a DNS record in a zone and returns the created record.
+// https://open-api.netlify.com/#operation/createDnsRecord
+func (c *Client) CreateRecord(zoneID string, record Record) (*Record, error) {
+	result := &Record{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/dns_zones/%s/dns_records", zoneID), record, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteRecord deletes a DNS record of a zone.
+// https://open-api.netlify.com/#operation/deleteDnsRecord
+func (c *Client) DeleteRecord(zoneID, recordID string) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/dns_zones/%s/dns_records/%s", zoneID, recordID), nil, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Message == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		apiErr.StatusCode = resp.StatusCode
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
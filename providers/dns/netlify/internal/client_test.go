@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"code":401,"message":"Access Denied"}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_ListZones(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dns_zones", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `[{"id":"abc","name":"example.com"},{"id":"def","name":"example.org"}]`)
+	})
+	defer tearDown()
+
+	zones, err := client.ListZones()
+	require.NoError(t, err)
+
+	expected := []Zone{{ID: "abc", Name: "example.com"}, {ID: "def", Name: "example.org"}}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_ListZones_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dns_zones", nil)
+	defer tearDown()
+
+	client.token = "invalid"
+
+	_, err := client.ListZones()
+	require.EqualError(t, err, "[status code: 401] Access Denied")
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/dns_zones/abc/dns_records", func(rw http.ResponseWriter, req *http.Request) {
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Hostname: "_acme-challenge.example.com", Type: "TXT", Value: "txtTXTtxt", TTL: 300}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `{"id":"123","hostname":"_acme-challenge.example.com","type":"TXT","value":"txtTXTtxt","ttl":300,"dns_zone_id":"abc"}`)
+	})
+	defer tearDown()
+
+	record := Record{Hostname: "_acme-challenge.example.com", Type: "TXT", Value: "txtTXTtxt", TTL: 300}
+
+	result, err := client.CreateRecord("abc", record)
+	require.NoError(t, err)
+
+	expected := &Record{ID: "123", Hostname: "_acme-challenge.example.com", Type: "TXT", Value: "txtTXTtxt", TTL: 300}
+	assert.Equal(t, expected, result)
+}
+
+func TestClient_CreateRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/dns_zones/abc/dns_records", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprint(rw, `{"code":422,"message":"Hostname is not part of the DNS zone"}`)
+	})
+	defer tearDown()
+
+	_, err := client.CreateRecord("abc", Record{Hostname: "_acme-challenge.example.org", Type: "TXT", Value: "txtTXTtxt"})
+	require.EqualError(t, err, "[status code: 422] Hostname is not part of the DNS zone")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/dns_zones/abc/dns_records/123", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("abc", "123")
+	require.NoError(t, err)
+}
@@ -0,0 +1,173 @@
+// Package netlify implements a DNS provider for solving the DNS-01 challenge using Netlify DNS.
+package netlify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/netlify/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	Token              string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL: env.GetOrDefaultInt("NETLIFY_TTL", 300),
+		// the propagation of the records to the nameservers of Netlify can be slow.
+		PropagationTimeout: env.GetOrDefaultSecond("NETLIFY_PROPAGATION_TIMEOUT", 10*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("NETLIFY_POLLING_INTERVAL", 10*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("NETLIFY_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordInfo struct {
+	zoneID   string
+	recordID string
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]recordInfo
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Netlify DNS.
+// Credentials must be passed in the environment variable: NETLIFY_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("NETLIFY_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("netlify: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Token = values["NETLIFY_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Netlify DNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("netlify: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.Token)
+	if err != nil {
+		return nil, fmt.Errorf("netlify: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]recordInfo{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("netlify: %v", err)
+	}
+
+	record := internal.Record{
+		Hostname: dns01.UnFqdn(fqdn),
+		Type:     "TXT",
+		Value:    value,
+		TTL:      d.config.TTL,
+	}
+
+	newRecord, err := d.client.CreateRecord(zone.ID, record)
+	if err != nil {
+		return fmt.Errorf("netlify: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone.Name, fqdn, err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordInfo{zoneID: zone.ID, recordID: newRecord.ID}
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	info, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("netlify: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(info.zoneID, info.recordID)
+	if err != nil {
+		return fmt.Errorf("netlify: failed to delete TXT record [zone: %s, id: %s]: %v", info.zoneID, info.recordID, err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// findZone returns the zone with the longest name matching the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (*internal.Zone, error) {
+	zones, err := d.client.ListZones()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %v", err)
+	}
+
+	domain := strings.ToLower(dns01.UnFqdn(fqdn))
+
+	var zone *internal.Zone
+	for i, z := range zones {
+		name := strings.ToLower(dns01.UnFqdn(z.Name))
+		if domain != name && !strings.HasSuffix(domain, "."+name) {
+			continue
+		}
+
+		if zone == nil || len(name) > len(dns01.UnFqdn(zone.Name)) {
+			zone = &zones[i]
+		}
+	}
+
+	if zone == nil {
+		return nil, fmt.Errorf("no zone found for %s", fqdn)
+	}
+
+	return zone, nil
+}
@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const defaultBaseURL = "https://njal.la/api/1/"
+
+// APIRequest a JSON-RPC request.
+type APIRequest struct {
+	Method string      `json:"method"`
+	Params interface{} `json:"params,omitempty"`
+}
+
+// APIResponse a JSON-RPC response.
+type APIResponse struct {
+	ID     string          `json:"id,omitempty"`
+	RPC    string          `json:"jsonrpc,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *APIError       `json:"error,omitempty"`
+}
+
+// APIError a JSON-RPC error.
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("code: %d, message: %s", a.Code, a.Message)
+}
+
+// Record a DNS record.
+type Record struct {
+	ID      string `json:"id,omitempty"`
+	Domain  string `json:"domain,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Content string `json:"content,omitempty"`
+	TTL     int    `json:"ttl,omitempty"`
+}
+
+// Records a list of DNS records.
+type Records struct {
+	Records []Record `json:"records,omitempty"`
+}
+
+// Client the Njalla API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(token string) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		token:      token,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// AddRecord adds a DNS record.
+func (c *Client) AddRecord(record Record) (*Record, error) {
+	data := APIRequest{
+		Method: "add-record",
+		Params: record,
+	}
+
+	result := &Record{}
+	err := c.do(data, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RemoveRecord removes a DNS record.
+func (c *Client) RemoveRecord(id, domain string) error {
+	data := APIRequest{
+		Method: "remove-record",
+		Params: Record{
+			ID:     id,
+			Domain: domain,
+		},
+	}
+
+	return c.do(data, nil)
+}
+
+// ListRecords lists the DNS records of a domain.
+func (c *Client) ListRecords(domain string) ([]Record, error) {
+	data := APIRequest{
+		Method: "list-records",
+		Params: Record{
+			Domain: domain,
+		},
+	}
+
+	result := &Records{}
+	err := c.do(data, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Records, nil
+}
+
+func (c *Client) do(data APIRequest, result interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Njalla "+c.token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: unable to read response body: %v", data.Method, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status code: [status code: %d] %s", data.Method, resp.StatusCode, string(raw))
+	}
+
+	apiResponse := &APIResponse{}
+	err = json.Unmarshal(raw, apiResponse)
+	if err != nil {
+		return fmt.Errorf("%s: unable to unmarshal response: %s", data.Method, string(raw))
+	}
+
+	if apiResponse.Error != nil {
+		return fmt.Errorf("%s: %v", data.Method, apiResponse.Error)
+	}
+
+	if result == nil || len(apiResponse.Result) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(apiResponse.Result, result)
+}
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method string, handler func(rw http.ResponseWriter, params map[string]interface{})) (*Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Njalla secret" {
+			_, _ = fmt.Fprint(rw, `{"jsonrpc":"2.0","error":{"code":403,"message":"Invalid token."}}`)
+			return
+		}
+
+		apiReq := struct {
+			Method string                 `json:"method"`
+			Params map[string]interface{} `json:"params"`
+		}{}
+
+		err := json.NewDecoder(req.Body).Decode(&apiReq)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if apiReq.Method != method {
+			http.Error(rw, fmt.Sprintf("invalid method: %s", apiReq.Method), http.StatusBadRequest)
+			return
+		}
+
+		handler(rw, apiReq.Params)
+	}))
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, tearDown := setupTest(t, "add-record", func(rw http.ResponseWriter, params map[string]interface{}) {
+		expected := map[string]interface{}{
+			"domain":  "example.com",
+			"name":    "_acme-challenge",
+			"type":    "TXT",
+			"content": "txtTXTtxt",
+			"ttl":     float64(300),
+		}
+		if !assert.ObjectsAreEqual(expected, params) {
+			http.Error(rw, fmt.Sprintf("invalid params: %v", params), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"jsonrpc":"2.0","result":{"id":"123","name":"_acme-challenge","type":"TXT","content":"txtTXTtxt","ttl":300}}`)
+	})
+	defer tearDown()
+
+	record := Record{
+		Domain:  "example.com",
+		Name:    "_acme-challenge",
+		Type:    "TXT",
+		Content: "txtTXTtxt",
+		TTL:     300,
+	}
+
+	result, err := client.AddRecord(record)
+	require.NoError(t, err)
+
+	expected := &Record{
+		ID:      "123",
+		Name:    "_acme-challenge",
+		Type:    "TXT",
+		Content: "txtTXTtxt",
+		TTL:     300,
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestClient_AddRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, "add-record", nil)
+	defer tearDown()
+
+	client.token = "invalid"
+
+	_, err := client.AddRecord(Record{Domain: "example.com"})
+	require.EqualError(t, err, "add-record: code: 403, message: Invalid token.")
+}
+
+func TestClient_ListRecords(t *testing.T) {
+	client, tearDown := setupTest(t, "list-records", func(rw http.ResponseWriter, params map[string]interface{}) {
+		if params["domain"] != "example.com" {
+			http.Error(rw, fmt.Sprintf("invalid params: %v", params), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"jsonrpc":"2.0","result":{"records":[{"id":"1","name":"_acme-challenge","type":"TXT","content":"txtTXTtxt","ttl":300},{"id":"2","name":"www","type":"A","content":"127.0.0.1","ttl":3600}]}}`)
+	})
+	defer tearDown()
+
+	records, err := client.ListRecords("example.com")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{ID: "1", Name: "_acme-challenge", Type: "TXT", Content: "txtTXTtxt", TTL: 300},
+		{ID: "2", Name: "www", Type: "A", Content: "127.0.0.1", TTL: 3600},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_RemoveRecord(t *testing.T) {
+	client, tearDown := setupTest(t, "remove-record", func(rw http.ResponseWriter, params map[string]interface{}) {
+		if params["domain"] != "example.com" || params["id"] != "123" {
+			http.Error(rw, fmt.Sprintf("invalid params: %v", params), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"jsonrpc":"2.0","result":{}}`)
+	})
+	defer tearDown()
+
+	err := client.RemoveRecord("123", "example.com")
+	require.NoError(t, err)
+}
@@ -0,0 +1,150 @@
+// Package njalla implements a DNS provider for solving the DNS-01 challenge using Njalla.
+package njalla
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/njalla/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	Token              string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("NJALLA_TTL", 300),
+		PropagationTimeout: env.GetOrDefaultSecond("NJALLA_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("NJALLA_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("NJALLA_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Njalla.
+// Credentials must be passed in the environment variable: NJALLA_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("NJALLA_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("njalla: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Token = values["NJALLA_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Njalla.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("njalla: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.Token)
+	if err != nil {
+		return nil, fmt.Errorf("njalla: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("njalla: %v", err)
+	}
+
+	record := internal.Record{
+		Domain:  zone,
+		Name:    subDomain,
+		Type:    "TXT",
+		Content: value,
+		TTL:     d.config.TTL,
+	}
+
+	_, err = d.client.AddRecord(record)
+	if err != nil {
+		return fmt.Errorf("njalla: failed to add record: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("njalla: %v", err)
+	}
+
+	records, err := d.client.ListRecords(zone)
+	if err != nil {
+		return fmt.Errorf("njalla: failed to list records: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Type != "TXT" || record.Name != subDomain || record.Content != value {
+			continue
+		}
+
+		err = d.client.RemoveRecord(record.ID, zone)
+		if err != nil {
+			return fmt.Errorf("njalla: failed to remove record %s: %v", record.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// splitDomain returns the zone and the sub-domain (relative to the zone) of a FQDN.
+func splitDomain(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", err
+	}
+
+	zone := dns01.UnFqdn(authZone)
+	subDomain := strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone)
+
+	return zone, subDomain, nil
+}
@@ -204,10 +204,21 @@ func (d *DNSProvider) makeRequest(method, uri string, body io.Reader) (*http.Req
 		uri = "/" + uri
 	}
 
+	// the URLs returned by the API (e.g. zone URLs) usually don't contain the prefix.
+	var prefix string
+	if p := strings.Trim(d.config.APIPrefix, "/"); p != "" {
+		prefix = "/" + p
+		if strings.HasPrefix(uri, prefix+"/") {
+			uri = strings.TrimPrefix(uri, prefix)
+		}
+	}
+
 	if d.apiVersion > 0 && !strings.HasPrefix(uri, "/api/v") {
 		uri = "/api/v" + strconv.Itoa(d.apiVersion) + uri
 	}
 
+	uri = prefix + uri
+
 	u := d.config.Host.Scheme + "://" + d.config.Host.Host + path + uri
 	req, err := http.NewRequest(method, u, body)
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 type Config struct {
 	APIKey             string
 	Host               *url.URL
+	APIPrefix          string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
@@ -28,6 +29,7 @@ type Config struct {
 // NewDefaultConfig returns a default configuration for the DNSProvider
 func NewDefaultConfig() *Config {
 	return &Config{
+		APIPrefix:          env.GetOrDefaultString("PDNS_API_PREFIX", ""),
 		TTL:                env.GetOrDefaultInt("PDNS_TTL", dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("PDNS_PROPAGATION_TIMEOUT", 120*time.Second),
 		PollingInterval:    env.GetOrDefaultSecond("PDNS_POLLING_INTERVAL", 2*time.Second),
@@ -95,9 +97,14 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// TransformRecordValue the API expects the value of the TXT record to be quoted.
+func (d *DNSProvider) TransformRecordValue(value string) string {
+	return dns01.QuoteRecordValue(value)
+}
+
 // Present creates a TXT record to fulfill the dns-01 challenge
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
-	fqdn, value := dns01.GetRecord(domain, keyAuth)
+	fqdn, value := dns01.GetRecordForProvider(d, domain, keyAuth)
 
 	zone, err := d.getHostedZone(fqdn)
 	if err != nil {
@@ -112,7 +119,7 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	}
 
 	rec := Record{
-		Content:  "\"" + value + "\"",
+		Content:  value,
 		Disabled: false,
 
 		// pre-v1 API
@@ -1,16 +1,19 @@
 package pdns
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
 
 	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 var envTest = tester.NewEnvTest(
 	"PDNS_API_URL",
-	"PDNS_API_KEY").
+	"PDNS_API_KEY",
+	"PDNS_API_PREFIX").
 	WithDomain("PDNS_DOMAIN")
 
 func TestNewDNSProvider(t *testing.T) {
@@ -126,6 +129,83 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func Test_makeRequest(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		host       string
+		apiPrefix  string
+		apiVersion int
+		uri        string
+		expected   string
+	}{
+		{
+			desc:     "no prefix",
+			host:     "http://example.com",
+			uri:      "/api",
+			expected: "http://example.com/api",
+		},
+		{
+			desc:       "API version",
+			host:       "http://example.com",
+			apiVersion: 1,
+			uri:        "/servers/localhost/zones",
+			expected:   "http://example.com/api/v1/servers/localhost/zones",
+		},
+		{
+			desc:       "prefix",
+			host:       "http://example.com",
+			apiPrefix:  "/proxy/pdns/",
+			apiVersion: 1,
+			uri:        "/servers/localhost/zones",
+			expected:   "http://example.com/proxy/pdns/api/v1/servers/localhost/zones",
+		},
+		{
+			desc:       "prefix with zone URL",
+			host:       "http://example.com",
+			apiPrefix:  "proxy",
+			apiVersion: 1,
+			uri:        "/api/v1/servers/localhost/zones/example.com.",
+			expected:   "http://example.com/proxy/api/v1/servers/localhost/zones/example.com.",
+		},
+		{
+			desc:       "prefix already in the URI",
+			host:       "http://example.com",
+			apiPrefix:  "proxy",
+			apiVersion: 1,
+			uri:        "/proxy/api/v1/servers/localhost/zones/example.com.",
+			expected:   "http://example.com/proxy/api/v1/servers/localhost/zones/example.com.",
+		},
+		{
+			desc:       "prefix and host path",
+			host:       "http://example.com/base",
+			apiPrefix:  "proxy",
+			apiVersion: 1,
+			uri:        "/servers/localhost/zones",
+			expected:   "http://example.com/base/proxy/api/v1/servers/localhost/zones",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			host, err := url.Parse(test.host)
+			require.NoError(t, err)
+
+			config := NewDefaultConfig()
+			config.APIKey = "secret"
+			config.Host = host
+			config.APIPrefix = test.apiPrefix
+
+			d := &DNSProvider{config: config, apiVersion: test.apiVersion}
+
+			req, err := d.makeRequest(http.MethodGet, test.uri, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, req.URL.String())
+			assert.Equal(t, "secret", req.Header.Get("X-API-Key"))
+		})
+	}
+}
+
 func TestLivePresentAndCleanup(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
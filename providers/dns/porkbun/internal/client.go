@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const defaultBaseURL = "https://porkbun.com/api/json/v3"
+
+const statusSuccess = "SUCCESS"
+
+// Record a DNS record.
+type Record struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Content string `json:"content,omitempty"`
+	TTL     string `json:"ttl,omitempty"`
+	Prio    string `json:"prio,omitempty"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+type authRequest struct {
+	APIKey       string `json:"apikey"`
+	SecretAPIKey string `json:"secretapikey"`
+}
+
+type createRecordRequest struct {
+	authRequest
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     string `json:"ttl,omitempty"`
+}
+
+type apiResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type createRecordResponse struct {
+	apiResponse
+	ID json.Number `json:"id"`
+}
+
+type retrieveRecordsResponse struct {
+	apiResponse
+	Records []Record `json:"records"`
+}
+
+// Client the Porkbun API client.
+type Client struct {
+	apiKey       string
+	secretAPIKey string
+	BaseURL      string
+	HTTPClient   *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey, secretAPIKey string) (*Client, error) {
+	if apiKey == "" || secretAPIKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:       apiKey,
+		secretAPIKey: secretAPIKey,
+		BaseURL:      defaultBaseURL,
+		HTTPClient:   &http.Client{},
+	}, nil
+}
+
+// CreateRecord creates a DNS record and returns its ID.
+// https://porkbun.com/api/json/v3/documentation#DNS%20Create%20Record
+func (c *Client) CreateRecord(domain string, record Record) (string, error) {
+	payload := createRecordRequest{
+		authRequest: c.auth(),
+		Name:        record.Name,
+		Type:        record.Type,
+		Content:     record.Content,
+		TTL:         record.TTL,
+	}
+
+	result := &createRecordResponse{}
+	err := c.do(fmt.Sprintf("/dns/create/%s", domain), payload, result)
+	if err != nil {
+		return "", err
+	}
+
+	return result.ID.String(), nil
+}
+
+// RetrieveRecords retrieves the DNS records of a domain matching the type and the sub-domain.
+// https://porkbun.com/api/json/v3/documentation#DNS%20Retrieve%20Records%20by%20Domain,%20Subdomain%20and%20Type
+func (c *Client) RetrieveRecords(domain, recordType, subDomain string) ([]Record, error) {
+	result := &retrieveRecordsResponse{}
+	err := c.do(fmt.Sprintf("/dns/retrieveByNameType/%s/%s/%s", domain, recordType, subDomain), c.auth(), result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Records, nil
+}
+
+// DeleteRecord deletes a DNS record.
+// https://porkbun.com/api/json/v3/documentation#DNS%20Delete%20Record%20by%20Domain%20and%20ID
+func (c *Client) DeleteRecord(domain, recordID string) error {
+	return c.do(fmt.Sprintf("/dns/delete/%s/%s", domain, recordID), c.auth(), &apiResponse{})
+}
+
+func (c *Client) auth() authRequest {
+	return authRequest{APIKey: c.apiKey, SecretAPIKey: c.secretAPIKey}
+}
+
+func (c *Client) do(uri string, payload, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	status := &apiResponse{}
+	err = json.Unmarshal(raw, status)
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal response: [status code: %d] %s", resp.StatusCode, string(raw))
+	}
+
+	if status.Status != statusSuccess {
+		return fmt.Errorf("API error: [status code: %d] %s: %s", resp.StatusCode, status.Status, status.Message)
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, pattern string, handler func(rw http.ResponseWriter, body map[string]string)) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body := map[string]string{}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if body["apikey"] != "key" || body["secretapikey"] != "secret" {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(rw, `{"status":"ERROR","message":"Invalid API key."}`)
+			return
+		}
+
+		handler(rw, body)
+	})
+
+	client, err := NewClient("key", "secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, tearDown := setupTest(t, "/dns/create/example.com", func(rw http.ResponseWriter, body map[string]string) {
+		if body["name"] != "_acme-challenge" || body["type"] != "TXT" || body["content"] != "txtTXTtxt" || body["ttl"] != "600" {
+			http.Error(rw, fmt.Sprintf("invalid body: %v", body), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"status":"SUCCESS","id":106926659}`)
+	})
+	defer tearDown()
+
+	id, err := client.CreateRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Content: "txtTXTtxt", TTL: "600"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "106926659", id)
+}
+
+func TestClient_CreateRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, "/dns/create/example.com", nil)
+	defer tearDown()
+
+	client.secretAPIKey = "invalid"
+
+	_, err := client.CreateRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Content: "txtTXTtxt"})
+	require.EqualError(t, err, "API error: [status code: 400] ERROR: Invalid API key.")
+}
+
+func TestClient_RetrieveRecords(t *testing.T) {
+	client, tearDown := setupTest(t, "/dns/retrieveByNameType/example.com/TXT/_acme-challenge", func(rw http.ResponseWriter, _ map[string]string) {
+		_, _ = fmt.Fprint(rw, `{"status":"SUCCESS","records":[{"id":"106926659","name":"_acme-challenge.example.com","type":"TXT","content":"txtTXTtxt","ttl":"600","prio":"0","notes":""}]}`)
+	})
+	defer tearDown()
+
+	records, err := client.RetrieveRecords("example.com", "TXT", "_acme-challenge")
+	require.NoError(t, err)
+
+	expected := []Record{{
+		ID:      "106926659",
+		Name:    "_acme-challenge.example.com",
+		Type:    "TXT",
+		Content: "txtTXTtxt",
+		TTL:     "600",
+		Prio:    "0",
+	}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, "/dns/delete/example.com/106926659", func(rw http.ResponseWriter, _ map[string]string) {
+		_, _ = fmt.Fprint(rw, `{"status":"SUCCESS"}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", "106926659")
+	require.NoError(t, err)
+}
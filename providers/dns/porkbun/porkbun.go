@@ -0,0 +1,161 @@
+// Package porkbun implements a DNS provider for solving the DNS-01 challenge using Porkbun.
+package porkbun
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/porkbun/internal"
+)
+
+// minTTL is the minimum TTL accepted by Porkbun.
+const minTTL = 600
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	SecretAPIKey       string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("PORKBUN_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("PORKBUN_PROPAGATION_TIMEOUT", 10*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond("PORKBUN_POLLING_INTERVAL", 10*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("PORKBUN_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Porkbun.
+// Credentials must be passed in the environment variables:
+// PORKBUN_API_KEY and PORKBUN_SECRET_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("PORKBUN_API_KEY", "PORKBUN_SECRET_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("porkbun: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["PORKBUN_API_KEY"]
+	config.SecretAPIKey = values["PORKBUN_SECRET_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Porkbun.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("porkbun: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIKey, config.SecretAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("porkbun: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("porkbun: %v", err)
+	}
+
+	ttl := d.config.TTL
+	if ttl < minTTL {
+		ttl = minTTL // 600 is the Porkbun minimum value for the TTL
+	}
+
+	record := internal.Record{
+		Name:    subDomain,
+		Type:    "TXT",
+		Content: value,
+		TTL:     strconv.Itoa(ttl),
+	}
+
+	_, err = d.client.CreateRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("porkbun: failed to create record: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("porkbun: %v", err)
+	}
+
+	records, err := d.client.RetrieveRecords(zone, "TXT", subDomain)
+	if err != nil {
+		return fmt.Errorf("porkbun: failed to retrieve records: %v", err)
+	}
+
+	for _, record := range records {
+		if record.Content != value {
+			continue
+		}
+
+		err = d.client.DeleteRecord(zone, record.ID)
+		if err != nil {
+			return fmt.Errorf("porkbun: failed to delete record %s: %v", record.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// splitDomain returns the zone and the sub-domain (relative to the zone) of a FQDN.
+func splitDomain(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", err
+	}
+
+	zone := dns01.UnFqdn(authZone)
+	subDomain := strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone)
+
+	return zone, subDomain, nil
+}
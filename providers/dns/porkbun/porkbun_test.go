@@ -0,0 +1,150 @@
+package porkbun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"PORKBUN_API_KEY",
+	"PORKBUN_SECRET_API_KEY").
+	WithDomain("PORKBUN_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"PORKBUN_API_KEY": "123",
+				"PORKBUN_SECRET_API_KEY": "456",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"PORKBUN_API_KEY": "",
+				"PORKBUN_SECRET_API_KEY": "",
+			},
+			expected: "porkbun: some credentials information are missing: PORKBUN_API_KEY,PORKBUN_SECRET_API_KEY",
+		},
+		{
+			desc: "missing API key",
+			envVars: map[string]string{
+				"PORKBUN_API_KEY": "",
+				"PORKBUN_SECRET_API_KEY": "456",
+			},
+			expected: "porkbun: some credentials information are missing: PORKBUN_API_KEY",
+		},
+		{
+			desc: "missing secret API key",
+			envVars: map[string]string{
+				"PORKBUN_API_KEY": "123",
+				"PORKBUN_SECRET_API_KEY": "",
+			},
+			expected: "porkbun: some credentials information are missing: PORKBUN_SECRET_API_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		apiKey       string
+		secretAPIKey string
+		expected  string
+	}{
+		{
+			desc:      "success",
+			apiKey:       "123",
+			secretAPIKey: "456",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "porkbun: credentials missing",
+		},
+		{
+			desc:         "missing API key",
+			secretAPIKey: "456",
+			expected:     "porkbun: credentials missing",
+		},
+		{
+			desc:     "missing secret API key",
+			apiKey:   "123",
+			expected: "porkbun: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = test.apiKey
+			config.SecretAPIKey = test.secretAPIKey
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/miekg/dns"
 )
 
+// tsigAlgorithms the TSIG algorithms supported by miekg/dns.
+var tsigAlgorithms = []string{dns.HmacMD5, dns.HmacSHA1, dns.HmacSHA256, dns.HmacSHA512}
+
 // Config is used to configure the creation of the DNSProvider
 type Config struct {
 	Nameserver         string
@@ -48,8 +51,8 @@ type DNSProvider struct {
 // dynamic update. Configured with environment variables:
 // RFC2136_NAMESERVER: Network address in the form "host" or "host:port".
 // RFC2136_TSIG_ALGORITHM: Defaults to hmac-md5.sig-alg.reg.int. (HMAC-MD5).
-// See https://github.com/miekg/dns/blob/master/tsig.go for supported values.
-// RFC2136_TSIG_KEY: Name of the secret key as defined in DNS server configuration.
+// Supported values: hmac-md5, hmac-sha1, hmac-sha256, hmac-sha512.
+// RFC2136_TSIG_KEY: Name of the secret key as defined in DNS server configuration (e.g. "tsig-key." or "tsig-key").
 // RFC2136_TSIG_SECRET: Secret key payload.
 // RFC2136_PROPAGATION_TIMEOUT: DNS propagation timeout in time.ParseDuration format. (60s)
 // To disable TSIG authentication, leave the RFC2136_TSIG* variables unset.
@@ -81,6 +84,12 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		config.TSIGAlgorithm = dns.HmacMD5
 	}
 
+	algorithm, err := tsigAlgorithm(config.TSIGAlgorithm)
+	if err != nil {
+		return nil, fmt.Errorf("rfc2136: %v", err)
+	}
+	config.TSIGAlgorithm = algorithm
+
 	// Append the default DNS port if none is specified.
 	if _, _, err := net.SplitHostPort(config.Nameserver); err != nil {
 		if strings.Contains(err.Error(), "missing port") {
@@ -181,3 +190,17 @@ func (d *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 
 	return nil
 }
+
+// tsigAlgorithm returns the miekg/dns name of a TSIG algorithm.
+// The names are case insensitive, and the trailing dot and the suffix of HMAC-MD5 are optional (e.g. "HMAC-SHA512").
+func tsigAlgorithm(name string) (string, error) {
+	fqdn := dns.Fqdn(strings.ToLower(strings.TrimSpace(name)))
+
+	for _, algorithm := range tsigAlgorithms {
+		if fqdn == algorithm || algorithm == dns.HmacMD5 && fqdn == "hmac-md5." {
+			return algorithm, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported TSIG algorithm %q (supported: %s)", name, strings.Join(tsigAlgorithms, ", "))
+}
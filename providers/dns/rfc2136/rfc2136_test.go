@@ -110,6 +110,64 @@ func TestTsigClient(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestTsigClient_algorithm(t *testing.T) {
+	dns01.ClearFqdnCache()
+	dns.HandleFunc(envTestZone, serverHandlerReturnSuccess)
+	defer dns.HandleRemove(envTestZone)
+
+	server, addr, err := runLocalDNSTestServer(true)
+	require.NoError(t, err, "Failed to start test server")
+	defer func() { _ = server.Shutdown() }()
+
+	config := NewDefaultConfig()
+	config.Nameserver = addr
+	config.TSIGAlgorithm = "HMAC-SHA512"
+	config.TSIGKey = dns01.UnFqdn(envTestTsigKey)
+	config.TSIGSecret = envTestTsigSecret
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, dns.HmacSHA512, provider.config.TSIGAlgorithm)
+
+	err = provider.Present(envTestDomain, "", envTestKeyAuth)
+	require.NoError(t, err)
+}
+
+func TestNewDNSProviderConfig_tsigAlgorithm(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		algorithm string
+		expected  string
+		expectErr bool
+	}{
+		{desc: "default", algorithm: "", expected: dns.HmacMD5},
+		{desc: "miekg/dns name", algorithm: dns.HmacSHA256, expected: dns.HmacSHA256},
+		{desc: "without trailing dot", algorithm: "hmac-sha512", expected: dns.HmacSHA512},
+		{desc: "upper case", algorithm: "HMAC-SHA1", expected: dns.HmacSHA1},
+		{desc: "short HMAC-MD5 name", algorithm: "hmac-md5", expected: dns.HmacMD5},
+		{desc: "unsupported", algorithm: "hmac-sha3", expectErr: true},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Nameserver = "127.0.0.1"
+			config.TSIGAlgorithm = test.algorithm
+
+			provider, err := NewDNSProviderConfig(config)
+			if test.expectErr {
+				require.EqualError(t, err, fmt.Sprintf(`rfc2136: unsupported TSIG algorithm %q (supported: hmac-md5.sig-alg.reg.int., hmac-sha1., hmac-sha256., hmac-sha512.)`, test.algorithm))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, provider.config.TSIGAlgorithm)
+		})
+	}
+}
+
 func TestValidUpdatePacket(t *testing.T) {
 	var reqChan = make(chan *dns.Msg, 10)
 
@@ -212,7 +270,7 @@ func serverHandlerReturnSuccess(w dns.ResponseWriter, req *dns.Msg) {
 	if t := req.IsTsig(); t != nil {
 		if w.TsigStatus() == nil {
 			// Validated
-			m.SetTsig(envTestZone, dns.HmacMD5, 300, time.Now().Unix())
+			m.SetTsig(envTestZone, t.Algorithm, 300, time.Now().Unix())
 		}
 	}
 
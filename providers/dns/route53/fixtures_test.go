@@ -37,3 +37,45 @@ const GetChangeResponse = `<?xml version="1.0" encoding="UTF-8"?>
       <SubmittedAt>2016-02-10T01:36:41.958Z</SubmittedAt>
    </ChangeInfo>
 </GetChangeResponse>`
+
+const GetHostedZoneResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<GetHostedZoneResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+   <HostedZone>
+      <Id>/hostedzone/ABCDEFG</Id>
+      <Name>example.com.</Name>
+      <CallerReference>D2224C5B-684A-DB4A-BB9A-E09E3BAFEA7A</CallerReference>
+      <Config>
+         <Comment>Test comment</Comment>
+         <PrivateZone>false</PrivateZone>
+      </Config>
+      <ResourceRecordSetCount>10</ResourceRecordSetCount>
+   </HostedZone>
+</GetHostedZoneResponse>`
+
+const ListHostedZonesResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<ListHostedZonesResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+   <HostedZones>
+      <HostedZone>
+         <Id>/hostedzone/ABCDEFG</Id>
+         <Name>example.com.</Name>
+         <CallerReference>D2224C5B-684A-DB4A-BB9A-E09E3BAFEA7A</CallerReference>
+         <Config>
+            <Comment>Test comment</Comment>
+            <PrivateZone>false</PrivateZone>
+         </Config>
+         <ResourceRecordSetCount>10</ResourceRecordSetCount>
+      </HostedZone>
+   </HostedZones>
+   <IsTruncated>false</IsTruncated>
+   <MaxItems>1</MaxItems>
+</ListHostedZonesResponse>`
+
+const AccessDeniedResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<ErrorResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+   <Error>
+      <Type>Sender</Type>
+      <Code>AccessDenied</Code>
+      <Message>User is not authorized to perform: route53:ListHostedZones</Message>
+   </Error>
+   <RequestId>a1b2c3d4</RequestId>
+</ErrorResponse>`
@@ -105,6 +105,25 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// Check verifies the credentials and the access to the hosted zones.
+func (d *DNSProvider) Check() error {
+	if d.config.HostedZoneID != "" {
+		_, err := d.client.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(d.config.HostedZoneID)})
+		if err != nil {
+			return fmt.Errorf("route53: failed to get the hosted zone %s: %v", d.config.HostedZoneID, err)
+		}
+
+		return nil
+	}
+
+	_, err := d.client.ListHostedZones(&route53.ListHostedZonesInput{MaxItems: aws.String("1")})
+	if err != nil {
+		return fmt.Errorf("route53: failed to list the hosted zones: %v", err)
+	}
+
+	return nil
+}
+
 // Present creates a TXT record using the specified parameters
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
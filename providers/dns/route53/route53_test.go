@@ -175,3 +175,50 @@ func TestDNSProvider_Present(t *testing.T) {
 	err := provider.Present(domain, "", keyAuth)
 	require.NoError(t, err, "Expected Present to return no error")
 }
+
+func TestDNSProvider_Check(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		hostedZoneID string
+		responses    MockResponseMap
+		expectError  bool
+	}{
+		{
+			desc: "list hosted zones",
+			responses: MockResponseMap{
+				"/2013-04-01/hostedzone": {StatusCode: 200, Body: ListHostedZonesResponse},
+			},
+		},
+		{
+			desc:         "get hosted zone",
+			hostedZoneID: "ABCDEFG",
+			responses: MockResponseMap{
+				"/2013-04-01/hostedzone/ABCDEFG": {StatusCode: 200, Body: GetHostedZoneResponse},
+			},
+		},
+		{
+			desc: "access denied",
+			responses: MockResponseMap{
+				"/2013-04-01/hostedzone": {StatusCode: 403, Body: AccessDeniedResponse},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			ts := newMockServer(t, test.responses)
+			defer ts.Close()
+
+			provider := makeTestProvider(ts)
+			provider.config.HostedZoneID = test.hostedZoneID
+
+			err := provider.Check()
+			if test.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
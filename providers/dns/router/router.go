@@ -0,0 +1,322 @@
+// Package router implements a DNS provider for solving the DNS-01 challenge by routing the domains to several DNS providers,
+// according to domain suffix rules (e.g. a zone managed by Route 53 and another managed by Cloudflare).
+package router
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/challenge/dns01"
+)
+
+// ProviderFactory creates a DNS provider from its name.
+type ProviderFactory func(name string) (challenge.Provider, error)
+
+// Route routes the domains matching a suffix to a DNS provider.
+type Route struct {
+	// Suffix the domain suffix (e.g. "example.com" matches "example.com" and "foo.example.com").
+	Suffix   string
+	Provider challenge.Provider
+}
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	Routes []Route
+	// Default the DNS provider used for the domains not matching any route (optional).
+	Default            challenge.Provider
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		PropagationTimeout: dns01.DefaultPropagationTimeout,
+		PollingInterval:    dns01.DefaultPollingInterval,
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	routes []Route
+}
+
+// NewDNSProvider returns a DNSProvider instance routing the domains with the rules of a provider map file.
+//
+// Each line of the file maps a domain suffix to the name of a DNS provider:
+//
+//     # comment
+//     example.com     route53
+//     example.org     cloudflare
+//
+// The providers are created by the factory, and are configured by their own environment variables.
+// A provider used by several routes is only created once.
+func NewDNSProvider(filename string, factory ProviderFactory) (*DNSProvider, error) {
+	routes, err := LoadRoutes(filename, factory)
+	if err != nil {
+		return nil, err
+	}
+
+	config := NewDefaultConfig()
+	config.Routes = routes
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance routing the domains to several DNS providers.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("router: the configuration of the DNS provider is nil")
+	}
+
+	if len(config.Routes) == 0 {
+		return nil, errors.New("router: no route")
+	}
+
+	var routes []Route
+	for _, route := range config.Routes {
+		suffix := normalize(route.Suffix)
+		if suffix == "" {
+			return nil, errors.New("router: a route has an empty suffix")
+		}
+
+		if route.Provider == nil {
+			return nil, fmt.Errorf("router: the provider of the route %q is missing", route.Suffix)
+		}
+
+		routes = append(routes, Route{Suffix: suffix, Provider: route.Provider})
+	}
+
+	// the longest suffixes first: the most specific route wins.
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i].Suffix) > len(routes[j].Suffix)
+	})
+
+	return &DNSProvider{config: config, routes: routes}, nil
+}
+
+// MapRoutes creates the routes of a map of domain suffixes to DNS providers:
+//
+//     router.MapRoutes(map[string]challenge.Provider{
+//         "example.com": route53Provider,
+//         "example.org": cloudflareProvider,
+//     })
+//
+// The order of the routes doesn't matter: the most specific route wins.
+func MapRoutes(providers map[string]challenge.Provider) []Route {
+	var routes []Route
+	for suffix, provider := range providers {
+		routes = append(routes, Route{Suffix: suffix, Provider: provider})
+	}
+
+	// the map iteration order is random: sorts the routes to keep the providers deterministic.
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Suffix < routes[j].Suffix
+	})
+
+	return routes
+}
+
+// LoadRoutes loads the routes of a provider map file (see NewDNSProvider for the format).
+func LoadRoutes(filename string, factory ProviderFactory) ([]Route, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("router: %v", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	routes, err := readRoutes(file, factory)
+	if err != nil {
+		return nil, fmt.Errorf("router: %s: %v", filename, err)
+	}
+
+	return routes, nil
+}
+
+func readRoutes(r io.Reader, factory ProviderFactory) ([]Route, error) {
+	providers := map[string]challenge.Provider{}
+
+	var routes []Route
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: invalid route %q, expected: <suffix> <provider>", line, text)
+		}
+
+		suffix, name := fields[0], fields[1]
+
+		provider, ok := providers[name]
+		if !ok {
+			var err error
+			provider, err = factory(name)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: failed to create the provider %q: %v", line, name, err)
+			}
+
+			providers[name] = provider
+		}
+
+		routes = append(routes, Route{Suffix: suffix, Provider: provider})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// The largest values of the providers are used.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	timeout, interval = d.config.PropagationTimeout, d.config.PollingInterval
+
+	for _, provider := range d.providers() {
+		p, ok := provider.(challenge.ProviderTimeout)
+		if !ok {
+			continue
+		}
+
+		t, i := p.Timeout()
+		if t > timeout {
+			timeout = t
+		}
+		if i > interval {
+			interval = i
+		}
+	}
+
+	return timeout, interval
+}
+
+// SingleValueTXT returns true if one of the providers can only publish one value per TXT record name.
+func (d *DNSProvider) SingleValueTXT() bool {
+	for _, provider := range d.providers() {
+		if p, ok := provider.(interface{ SingleValueTXT() bool }); ok && p.SingleValueTXT() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sequential returns the largest interval between two challenges of the sequential providers (see IsSequential).
+func (d *DNSProvider) Sequential() time.Duration {
+	var interval time.Duration
+	for _, provider := range d.providers() {
+		if ok, i := dns01.IsSequential(provider); ok && i > interval {
+			interval = i
+		}
+	}
+
+	return interval
+}
+
+// IsSequential returns true if one of the providers solves the challenges one after the other.
+func (d *DNSProvider) IsSequential() bool {
+	for _, provider := range d.providers() {
+		if ok, _ := dns01.IsSequential(provider); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check verifies the credentials of all the providers (see challenge.CredentialChecker).
+// It returns challenge.ErrCheckNotSupported if one of the providers doesn't support it,
+// and fails if one of the checks fails.
+func (d *DNSProvider) Check() error {
+	providers := d.providers()
+
+	for _, provider := range providers {
+		if _, ok := provider.(challenge.CredentialChecker); !ok {
+			return challenge.ErrCheckNotSupported
+		}
+	}
+
+	for _, provider := range providers {
+		err := provider.(challenge.CredentialChecker).Check()
+		if err != nil {
+			return fmt.Errorf("router: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Present creates the TXT record with the provider routed for the domain.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	provider, err := d.route(domain)
+	if err != nil {
+		return fmt.Errorf("router: %v", err)
+	}
+
+	return provider.Present(domain, token, keyAuth)
+}
+
+// CleanUp removes the TXT record with the provider routed for the domain.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	provider, err := d.route(domain)
+	if err != nil {
+		return fmt.Errorf("router: %v", err)
+	}
+
+	return provider.CleanUp(domain, token, keyAuth)
+}
+
+// route returns the provider of the most specific route matching the domain.
+func (d *DNSProvider) route(domain string) (challenge.Provider, error) {
+	name := normalize(strings.TrimPrefix(domain, "*."))
+
+	for _, route := range d.routes {
+		if name == route.Suffix || strings.HasSuffix(name, "."+route.Suffix) {
+			return route.Provider, nil
+		}
+	}
+
+	if d.config.Default != nil {
+		return d.config.Default, nil
+	}
+
+	return nil, fmt.Errorf("no route for the domain %s", domain)
+}
+
+// providers returns the distinct providers of the routes and the default provider.
+func (d *DNSProvider) providers() []challenge.Provider {
+	var providers []challenge.Provider
+
+	seen := map[challenge.Provider]bool{}
+	for _, route := range d.routes {
+		if !seen[route.Provider] {
+			seen[route.Provider] = true
+			providers = append(providers, route.Provider)
+		}
+	}
+
+	if d.config.Default != nil && !seen[d.config.Default] {
+		providers = append(providers, d.config.Default)
+	}
+
+	return providers
+}
+
+func normalize(domain string) string {
+	return strings.ToLower(dns01.UnFqdn(strings.TrimSpace(domain)))
+}
@@ -0,0 +1,286 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/challenge"
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerMock struct {
+	presented []string
+	cleaned   []string
+}
+
+func (p *providerMock) Present(domain, token, keyAuth string) error {
+	p.presented = append(p.presented, domain)
+	return nil
+}
+
+func (p *providerMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleaned = append(p.cleaned, domain)
+	return nil
+}
+
+type providerTimeoutMock struct {
+	providerMock
+	timeout, interval time.Duration
+}
+
+func (p *providerTimeoutMock) Timeout() (timeout, interval time.Duration) {
+	return p.timeout, p.interval
+}
+
+type providerSequentialMock struct {
+	providerMock
+	interval time.Duration
+}
+
+func (p *providerSequentialMock) Sequential() time.Duration {
+	return p.interval
+}
+
+type providerCheckerMock struct {
+	providerMock
+	err error
+}
+
+func (p *providerCheckerMock) Check() error {
+	return p.err
+}
+
+func TestNewDNSProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego-router")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	filename := filepath.Join(dir, "providers.map")
+	content := `
+# comment
+example.com     a
+sub.example.com b
+example.org     a
+`
+	err = ioutil.WriteFile(filename, []byte(content), 0600)
+	require.NoError(t, err)
+
+	created := map[string]*providerMock{}
+	factory := func(name string) (challenge.Provider, error) {
+		created[name] = &providerMock{}
+		return created[name], nil
+	}
+
+	provider, err := NewDNSProvider(filename, factory)
+	require.NoError(t, err)
+
+	assert.Len(t, created, 2)
+
+	var suffixes []string
+	for _, route := range provider.routes {
+		suffixes = append(suffixes, route.Suffix)
+	}
+	assert.Equal(t, []string{"sub.example.com", "example.com", "example.org"}, suffixes)
+}
+
+func TestNewDNSProvider_missingFile(t *testing.T) {
+	_, err := NewDNSProvider(filepath.Join("fixtures", "missing.map"), nil)
+	require.Error(t, err)
+}
+
+func Test_readRoutes(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		content  string
+		factory  ProviderFactory
+		expected string
+	}{
+		{
+			desc:     "invalid route",
+			content:  "example.com",
+			expected: `line 1: invalid route "example.com", expected: <suffix> <provider>`,
+		},
+		{
+			desc:    "factory error",
+			content: "# comment\nexample.com unknown",
+			factory: func(name string) (challenge.Provider, error) {
+				return nil, fmt.Errorf("unrecognized DNS provider: %s", name)
+			},
+			expected: `line 2: failed to create the provider "unknown": unrecognized DNS provider: unknown`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := readRoutes(strings.NewReader(test.content), test.factory)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		routes   []Route
+		expected string
+	}{
+		{
+			desc:     "no route",
+			expected: "router: no route",
+		},
+		{
+			desc:     "empty suffix",
+			routes:   []Route{{Suffix: " ", Provider: &providerMock{}}},
+			expected: "router: a route has an empty suffix",
+		},
+		{
+			desc:     "missing provider",
+			routes:   []Route{{Suffix: "example.com"}},
+			expected: `router: the provider of the route "example.com" is missing`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Routes = test.routes
+
+			_, err := NewDNSProviderConfig(config)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	a, b, c := &providerMock{}, &providerMock{}, &providerMock{}
+
+	config := NewDefaultConfig()
+	config.Routes = []Route{
+		{Suffix: "Example.com.", Provider: a},
+		{Suffix: "sub.example.com", Provider: b},
+	}
+	config.Default = c
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	for _, domain := range []string{"example.com", "*.www.example.com", "sub.example.com", "foo.sub.example.com", "notexample.com"} {
+		err = provider.Present(domain, "token", "keyAuth")
+		require.NoError(t, err)
+
+		err = provider.CleanUp(domain, "token", "keyAuth")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"example.com", "*.www.example.com"}, a.presented)
+	assert.Equal(t, []string{"sub.example.com", "foo.sub.example.com"}, b.presented)
+	assert.Equal(t, []string{"notexample.com"}, c.presented)
+	assert.Equal(t, a.presented, a.cleaned)
+	assert.Equal(t, b.presented, b.cleaned)
+	assert.Equal(t, c.presented, c.cleaned)
+}
+
+func TestDNSProvider_Sequential(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Routes = []Route{
+		{Suffix: "example.com", Provider: &providerMock{}},
+	}
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	sequential, _ := dns01.IsSequential(provider)
+	assert.False(t, sequential)
+
+	config.Default = &providerSequentialMock{interval: time.Second}
+
+	sequential, interval := dns01.IsSequential(provider)
+	assert.True(t, sequential)
+	assert.Equal(t, time.Second, interval)
+}
+
+func TestDNSProvider_Check(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Routes = []Route{
+		{Suffix: "example.com", Provider: &providerCheckerMock{}},
+		{Suffix: "example.org", Provider: &providerCheckerMock{}},
+	}
+	config.Default = &providerCheckerMock{err: errors.New("oops")}
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.Check()
+	require.EqualError(t, err, "router: oops")
+
+	config.Default = nil
+
+	err = provider.Check()
+	require.NoError(t, err)
+
+	// the credentials of the provider of example.com are not verified.
+	config.Routes[0].Provider = &providerMock{}
+
+	provider, err = NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.Check()
+	assert.Equal(t, challenge.ErrCheckNotSupported, err)
+}
+
+func TestMapRoutes(t *testing.T) {
+	a, b := &providerMock{}, &providerMock{}
+
+	config := NewDefaultConfig()
+	config.Routes = MapRoutes(map[string]challenge.Provider{
+		"example.com":     a,
+		"foo.example.com": b,
+	})
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Present("bar.foo.example.com", "token", "keyAuth"))
+	require.NoError(t, provider.Present("*.example.com", "token", "keyAuth"))
+
+	assert.Equal(t, []string{"*.example.com"}, a.presented)
+	assert.Equal(t, []string{"bar.foo.example.com"}, b.presented)
+}
+
+func TestDNSProvider_Present_noRoute(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Routes = []Route{{Suffix: "example.com", Provider: &providerMock{}}}
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.Present("example.org", "token", "keyAuth")
+	require.EqualError(t, err, "router: no route for the domain example.org")
+}
+
+func TestDNSProvider_Timeout(t *testing.T) {
+	config := NewDefaultConfig()
+	config.PropagationTimeout = 10 * time.Second
+	config.PollingInterval = 2 * time.Second
+	config.Routes = []Route{
+		{Suffix: "example.com", Provider: &providerTimeoutMock{timeout: 30 * time.Second, interval: 1 * time.Second}},
+		{Suffix: "example.org", Provider: &providerMock{}},
+	}
+	config.Default = &providerTimeoutMock{timeout: 5 * time.Second, interval: 5 * time.Second}
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	timeout, interval := provider.Timeout()
+	assert.Equal(t, 30*time.Second, timeout)
+	assert.Equal(t, 5*time.Second, interval)
+}
+
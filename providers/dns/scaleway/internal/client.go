@@ -0,0 +1,185 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.scaleway.com/domain/v2beta1"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Type       string `json:"type"`
+	Message    string `json:"message"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("[status code: %d] %s: %s", a.StatusCode, a.Type, a.Message)
+}
+
+// DNSZone a DNS zone.
+type DNSZone struct {
+	Domain    string `json:"domain"`
+	Subdomain string `json:"subdomain"`
+	ProjectID string `json:"project_id"`
+	Status    string `json:"status"`
+}
+
+// Name returns the fully qualified name of the zone (without the trailing dot).
+func (z DNSZone) Name() string {
+	if z.Subdomain == "" {
+		return z.Domain
+	}
+	return z.Subdomain + "." + z.Domain
+}
+
+// ListDNSZonesResponse the response of the DNS zones listing.
+type ListDNSZonesResponse struct {
+	DNSZones   []DNSZone `json:"dns_zones"`
+	TotalCount int       `json:"total_count"`
+}
+
+// Record a DNS record.
+type Record struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Data    string `json:"data"`
+	TTL     int    `json:"ttl,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// RecordIdentifier identifies the records targeted by a change.
+type RecordIdentifier struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Data string `json:"data,omitempty"`
+}
+
+// RecordChangeSet replaces the records matching IDFields by Records.
+type RecordChangeSet struct {
+	IDFields RecordIdentifier `json:"id_fields"`
+	Records  []Record         `json:"records"`
+}
+
+// RecordChangeDelete deletes the records matching IDFields.
+type RecordChangeDelete struct {
+	IDFields RecordIdentifier `json:"id_fields"`
+}
+
+// RecordChange a change operation on the records of a zone.
+type RecordChange struct {
+	Set    *RecordChangeSet    `json:"set,omitempty"`
+	Delete *RecordChangeDelete `json:"delete,omitempty"`
+}
+
+// UpdateDNSZoneRecordsRequest the request to update the records of a zone.
+type UpdateDNSZoneRecordsRequest struct {
+	Changes          []RecordChange `json:"changes"`
+	ReturnAllRecords bool           `json:"return_all_records"`
+}
+
+// Client the Scaleway Domains API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(token string) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		token:      token,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// ListDNSZones lists the DNS zones matching the zone name, optionally filtered by project.
+// https://developers.scaleway.com/en/products/domain/dns/api/#get-ba4ff7
+func (c *Client) ListDNSZones(dnsZone, projectID string) ([]DNSZone, error) {
+	query := url.Values{}
+	query.Set("dns_zone", dnsZone)
+	if projectID != "" {
+		query.Set("project_id", projectID)
+	}
+
+	result := &ListDNSZonesResponse{}
+	err := c.do(http.MethodGet, "/dns-zones?"+query.Encode(), nil, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.DNSZones, nil
+}
+
+// UpdateDNSZoneRecords applies a set of changes to the records of a zone.
+// https://developers.scaleway.com/en/products/domain/dns/api/#patch-7a5ff5
+func (c *Client) UpdateDNSZoneRecords(dnsZone string, changes ...RecordChange) error {
+	payload := UpdateDNSZoneRecordsRequest{
+		Changes:          changes,
+		ReturnAllRecords: false,
+	}
+
+	return c.do(http.MethodPatch, fmt.Sprintf("/dns-zones/%s/records", dnsZone), payload, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Auth-Token", c.token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Message == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("X-Auth-Token") != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"message":"authentication is denied","type":"denied_authentication"}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_ListDNSZones(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dns-zones", func(rw http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		if query.Get("dns_zone") != "example.com" || query.Get("project_id") != "abc" {
+			http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"dns_zones":[{"domain":"example.com","subdomain":"","project_id":"abc","status":"active"}],"total_count":1}`)
+	})
+	defer tearDown()
+
+	zones, err := client.ListDNSZones("example.com", "abc")
+	require.NoError(t, err)
+
+	expected := []DNSZone{{Domain: "example.com", ProjectID: "abc", Status: "active"}}
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_UpdateDNSZoneRecords(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPatch, "/dns-zones/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		payload := UpdateDNSZoneRecordsRequest{}
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if len(payload.Changes) != 1 || payload.Changes[0].Delete == nil {
+			http.Error(rw, fmt.Sprintf("invalid payload: %v", payload), http.StatusBadRequest)
+			return
+		}
+
+		expected := RecordIdentifier{Name: "_acme-challenge", Type: "TXT", Data: `"txtTXTtxt"`}
+		if payload.Changes[0].Delete.IDFields != expected {
+			http.Error(rw, fmt.Sprintf("invalid id fields: %v", payload.Changes[0].Delete.IDFields), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"records":[]}`)
+	})
+	defer tearDown()
+
+	change := RecordChange{
+		Delete: &RecordChangeDelete{
+			IDFields: RecordIdentifier{Name: "_acme-challenge", Type: "TXT", Data: `"txtTXTtxt"`},
+		},
+	}
+
+	err := client.UpdateDNSZoneRecords("example.com", change)
+	require.NoError(t, err)
+}
+
+func TestClient_UpdateDNSZoneRecords_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPatch, "/dns-zones/example.com/records", nil)
+	defer tearDown()
+
+	client.token = "invalid"
+
+	err := client.UpdateDNSZoneRecords("example.com")
+	require.EqualError(t, err, "[status code: 401] denied_authentication: authentication is denied")
+}
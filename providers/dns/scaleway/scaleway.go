@@ -0,0 +1,185 @@
+// Package scaleway implements a DNS provider for solving the DNS-01 challenge using Scaleway Domains API.
+package scaleway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/scaleway/internal"
+)
+
+const minTTL = 60
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	Token              string
+	ProjectID          string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("SCALEWAY_TTL", minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("SCALEWAY_PROPAGATION_TIMEOUT", 120*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("SCALEWAY_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("SCALEWAY_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Scaleway Domains API.
+// Credentials must be passed in the environment variable: SCALEWAY_API_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("SCALEWAY_API_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("scaleway: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Token = values["SCALEWAY_API_TOKEN"]
+	config.ProjectID = env.GetOrFile("SCALEWAY_PROJECT_ID")
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Scaleway Domains API.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("scaleway: the configuration of the DNS provider is nil")
+	}
+
+	if config.TTL < minTTL {
+		config.TTL = minTTL
+	}
+
+	client, err := internal.NewClient(config.Token)
+	if err != nil {
+		return nil, fmt.Errorf("scaleway: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subName, err := d.splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("scaleway: %v", err)
+	}
+
+	id := txtRecordIdentifier(subName, value)
+
+	// the "set" operation only replaces the record matching the name, the type and the value:
+	// the other TXT records of the sub-name (e.g. the wildcard challenge) are kept.
+	change := internal.RecordChange{
+		Set: &internal.RecordChangeSet{
+			IDFields: id,
+			Records: []internal.Record{{
+				Name:    id.Name,
+				Type:    id.Type,
+				Data:    id.Data,
+				TTL:     d.config.TTL,
+				Comment: "used by lego",
+			}},
+		},
+	}
+
+	err = d.client.UpdateDNSZoneRecords(zone, change)
+	if err != nil {
+		return fmt.Errorf("scaleway: failed to set TXT record [zone: %q, name: %q]: %v", zone, subName, err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subName, err := d.splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("scaleway: %v", err)
+	}
+
+	change := internal.RecordChange{
+		Delete: &internal.RecordChangeDelete{
+			IDFields: txtRecordIdentifier(subName, value),
+		},
+	}
+
+	err = d.client.UpdateDNSZoneRecords(zone, change)
+	if err != nil {
+		return fmt.Errorf("scaleway: failed to delete TXT record [zone: %q, name: %q]: %v", zone, subName, err)
+	}
+
+	return nil
+}
+
+// splitDomain returns the Scaleway DNS zone and the sub-name (relative to the zone) of a FQDN.
+func (d *DNSProvider) splitDomain(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", err
+	}
+
+	zoneName := dns01.UnFqdn(authZone)
+
+	zones, err := d.client.ListDNSZones(zoneName, d.config.ProjectID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list DNS zones: %v", err)
+	}
+
+	for _, zone := range zones {
+		if !strings.EqualFold(zone.Name(), zoneName) {
+			continue
+		}
+
+		subName := strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zoneName)
+
+		return zoneName, subName, nil
+	}
+
+	return "", "", fmt.Errorf("DNS zone %s not found for domain %s", zoneName, fqdn)
+}
+
+func txtRecordIdentifier(subName, value string) internal.RecordIdentifier {
+	return internal.RecordIdentifier{
+		Name: subName,
+		Type: "TXT",
+		Data: strconv.Quote(value),
+	}
+}
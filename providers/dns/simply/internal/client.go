@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.simply.com/2"
+
+// APIError the error returned by the API.
+type APIError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("[status code: %d] %s", a.Status, a.Message)
+}
+
+// Record a DNS record.
+type Record struct {
+	// Name the name of the record relative to the zone (e.g. "_acme-challenge", "_acme-challenge.sub").
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Data string `json:"data"`
+	TTL  int    `json:"ttl,omitempty"`
+}
+
+type recordResponse struct {
+	Record struct {
+		ID int `json:"id"`
+	} `json:"record"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// Client the Simply.com API client.
+type Client struct {
+	accountName string
+	apiKey      string
+	BaseURL     string
+	HTTPClient  *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(accountName, apiKey string) (*Client, error) {
+	if accountName == "" || apiKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		accountName: accountName,
+		apiKey:      apiKey,
+		BaseURL:     defaultBaseURL,
+		HTTPClient:  &http.Client{},
+	}, nil
+}
+
+// AddRecord adds a record to a zone (product object) and returns the ID of the new record.
+// https://www.simply.com/en/docs/api/
+func (c *Client) AddRecord(zone string, record Record) (int, error) {
+	result := &recordResponse{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/my/products/%s/dns/records", url.PathEscape(zone)), record, result)
+	if err != nil {
+		return 0, err
+	}
+
+	if result.Record.ID == 0 {
+		return 0, fmt.Errorf("no record ID in the response: [status code: %d] %s", result.Status, result.Message)
+	}
+
+	return result.Record.ID, nil
+}
+
+// DeleteRecord deletes a record of a zone (product object).
+// https://www.simply.com/en/docs/api/
+func (c *Client) DeleteRecord(zone string, recordID int) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/my/products/%s/dns/records/%d", url.PathEscape(zone), recordID), nil, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.BaseURL, "/")+uri, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.SetBasicAuth(c.accountName, c.apiKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Message == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		apiErr.Status = resp.StatusCode
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		user, password, ok := req.BasicAuth()
+		if !ok || user != "S123456" || password != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"status":401,"message":"Invalid account credentials"}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("S123456", "secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/my/products/example.com/dns/records", func(rw http.ResponseWriter, req *http.Request) {
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Name: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 120}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"record":{"id":123},"status":200,"message":"OK"}`)
+	})
+	defer tearDown()
+
+	recordID, err := client.AddRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 120})
+	require.NoError(t, err)
+
+	assert.Equal(t, 123, recordID)
+}
+
+func TestClient_AddRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/my/products/example.com/dns/records", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(rw, `{"status":400,"message":"Invalid TTL"}`)
+	})
+	defer tearDown()
+
+	_, err := client.AddRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 1})
+	require.EqualError(t, err, "[status code: 400] Invalid TTL")
+}
+
+func TestClient_AddRecord_unauthorized(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/my/products/example.com/dns/records", nil)
+	defer tearDown()
+
+	client.apiKey = "invalid"
+
+	_, err := client.AddRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 120})
+	require.EqualError(t, err, "[status code: 401] Invalid account credentials")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/my/products/example.com/dns/records/123", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"status":200,"message":"OK"}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", 123)
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/my/products/example.com/dns/records/123", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(rw, `{"status":404,"message":"Record not found"}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", 123)
+	require.EqualError(t, err, "[status code: 404] Record not found")
+}
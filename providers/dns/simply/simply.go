@@ -0,0 +1,148 @@
+// Package simply implements a DNS provider for solving the DNS-01 challenge using Simply.com.
+package simply
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/simply/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	AccountName        string
+	APIKey             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("SIMPLY_TTL", dns01.DefaultTTL),
+		PropagationTimeout: env.GetOrDefaultSecond("SIMPLY_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("SIMPLY_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("SIMPLY_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordInfo struct {
+	zone     string
+	recordID int
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]recordInfo
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Simply.com.
+// Credentials must be passed in the environment variables: SIMPLY_ACCOUNT_NAME, SIMPLY_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("SIMPLY_ACCOUNT_NAME", "SIMPLY_API_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("simply: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AccountName = values["SIMPLY_ACCOUNT_NAME"]
+	config.APIKey = values["SIMPLY_API_KEY"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Simply.com.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("simply: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.AccountName, config.APIKey)
+	if err != nil {
+		return nil, fmt.Errorf("simply: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]recordInfo{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("simply: could not determine the zone: %v", err)
+	}
+
+	zone := dns01.UnFqdn(authZone)
+
+	record := internal.Record{
+		Name: strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone),
+		Type: "TXT",
+		Data: value,
+		TTL:  d.config.TTL,
+	}
+
+	recordID, err := d.client.AddRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("simply: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone, fqdn, err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordInfo{zone: zone, recordID: recordID}
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	info, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("simply: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(info.zone, info.recordID)
+	if err != nil {
+		return fmt.Errorf("simply: failed to delete TXT record [zone: %s, id: %d]: %v", info.zone, info.recordID, err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package simply
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vostronet/lego/platform/tester"
+)
+
+var envTest = tester.NewEnvTest("SIMPLY_ACCOUNT_NAME", "SIMPLY_API_KEY").
+	WithDomain("SIMPLY_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"SIMPLY_ACCOUNT_NAME": "S123456",
+				"SIMPLY_API_KEY":      "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"SIMPLY_ACCOUNT_NAME": "",
+				"SIMPLY_API_KEY":      "",
+			},
+			expected: "simply: some credentials information are missing: SIMPLY_ACCOUNT_NAME,SIMPLY_API_KEY",
+		},
+		{
+			desc: "missing account name",
+			envVars: map[string]string{
+				"SIMPLY_ACCOUNT_NAME": "",
+				"SIMPLY_API_KEY":      "secret",
+			},
+			expected: "simply: some credentials information are missing: SIMPLY_ACCOUNT_NAME",
+		},
+		{
+			desc: "missing API key",
+			envVars: map[string]string{
+				"SIMPLY_ACCOUNT_NAME": "S123456",
+				"SIMPLY_API_KEY":      "",
+			},
+			expected: "simply: some credentials information are missing: SIMPLY_API_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		accountName string
+		apiKey      string
+		expected    string
+	}{
+		{
+			desc:        "success",
+			accountName: "S123456",
+			apiKey:      "secret",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "simply: credentials missing",
+		},
+		{
+			desc:     "missing account name",
+			apiKey:   "secret",
+			expected: "simply: credentials missing",
+		},
+		{
+			desc:        "missing API key",
+			accountName: "S123456",
+			expected:    "simply: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.AccountName = test.accountName
+			config.APIKey = test.apiKey
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
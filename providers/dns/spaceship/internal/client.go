@@ -0,0 +1,204 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultBaseURL = "https://spaceship.dev/api/v1"
+
+// pageSize the maximum number of records returned by a listing request.
+const pageSize = 500
+
+// ErrorDetail a detail of an error of the API.
+type ErrorDetail struct {
+	Field   string `json:"field"`
+	Details string `json:"details"`
+}
+
+// APIError an error of the API.
+type APIError struct {
+	StatusCode int           `json:"-"`
+	Detail     string        `json:"detail"`
+	Data       []ErrorDetail `json:"data"`
+}
+
+func (a APIError) Error() string {
+	msg := fmt.Sprintf("[status code: %d] %s", a.StatusCode, a.Detail)
+	for _, d := range a.Data {
+		msg += fmt.Sprintf(", %s: %s", d.Field, d.Details)
+	}
+
+	return msg
+}
+
+// Record a DNS record.
+// Only the fields of the TXT records are decoded:
+// the other records are sent back as they have been received.
+type Record struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+	TTL   int    `json:"ttl,omitempty"`
+
+	raw json.RawMessage
+}
+
+// UnmarshalJSON keeps the original JSON of the record.
+func (r *Record) UnmarshalJSON(data []byte) error {
+	type record Record
+
+	var rec record
+	err := json.Unmarshal(data, &rec)
+	if err != nil {
+		return err
+	}
+
+	*r = Record(rec)
+	r.raw = append(json.RawMessage(nil), data...)
+
+	return nil
+}
+
+// MarshalJSON returns the original JSON of the record, if any.
+func (r Record) MarshalJSON() ([]byte, error) {
+	if r.raw != nil {
+		return r.raw, nil
+	}
+
+	type record Record
+
+	return json.Marshal(record(r))
+}
+
+// RecordsResponse the response of the records listing.
+type RecordsResponse struct {
+	Items []Record `json:"items"`
+	Total int      `json:"total"`
+}
+
+// SaveRequest the request of the records saving.
+type SaveRequest struct {
+	Force bool     `json:"force"`
+	Items []Record `json:"items"`
+}
+
+// Client the Spaceship API client.
+type Client struct {
+	apiKey     string
+	apiSecret  string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey, apiSecret string) (*Client, error) {
+	if apiKey == "" || apiSecret == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		apiSecret:  apiSecret,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// GetRecords lists all the records of a domain.
+// https://docs.spaceship.dev/#tag/DNS-records/operation/listResourceRecords
+func (c *Client) GetRecords(domain string) ([]Record, error) {
+	var records []Record
+
+	for {
+		query := url.Values{}
+		query.Set("take", strconv.Itoa(pageSize))
+		query.Set("skip", strconv.Itoa(len(records)))
+
+		result := &RecordsResponse{}
+		err := c.do(http.MethodGet, fmt.Sprintf("/dns/records/%s", domain), query, nil, result)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, result.Items...)
+
+		if len(result.Items) == 0 || len(records) >= result.Total {
+			return records, nil
+		}
+	}
+}
+
+// SaveRecords saves the records of a domain.
+// https://docs.spaceship.dev/#tag/DNS-records/operation/saveRecords
+func (c *Client) SaveRecords(domain string, records []Record) error {
+	return c.do(http.MethodPut, fmt.Sprintf("/dns/records/%s", domain), nil, SaveRequest{Force: true, Items: records}, nil)
+}
+
+// DeleteRecords deletes records of a domain.
+// https://docs.spaceship.dev/#tag/DNS-records/operation/deleteRecords
+func (c *Client) DeleteRecords(domain string, records []Record) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/dns/records/%s", domain), nil, records, nil)
+}
+
+func (c *Client) do(method, uri string, query url.Values, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-API-Key", c.apiKey)
+	req.Header.Set("X-API-Secret", c.apiSecret)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.Detail == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
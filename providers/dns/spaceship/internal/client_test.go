@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("X-API-Key") != "key" || req.Header.Get("X-API-Secret") != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"detail":"Invalid API key or secret"}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("key", "secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_GetRecords(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dns/records/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("skip") {
+		case "0":
+			_, _ = fmt.Fprint(rw, `{"items":[{"type":"TXT","name":"_acme-challenge","value":"txtTXTtxt","ttl":600}],"total":2}`)
+		case "1":
+			_, _ = fmt.Fprint(rw, `{"items":[{"type":"A","name":"www","address":"127.0.0.1","ttl":3600}],"total":2}`)
+		default:
+			http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+		}
+	})
+	defer tearDown()
+
+	records, err := client.GetRecords("example.com")
+	require.NoError(t, err)
+
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "TXT", records[0].Type)
+	assert.Equal(t, "_acme-challenge", records[0].Name)
+	assert.Equal(t, "txtTXTtxt", records[0].Value)
+	assert.Equal(t, 600, records[0].TTL)
+
+	assert.Equal(t, "A", records[1].Type)
+	assert.Equal(t, "www", records[1].Name)
+}
+
+func TestClient_GetRecords_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodGet, "/dns/records/example.com", nil)
+	defer tearDown()
+
+	client.apiSecret = "invalid"
+
+	_, err := client.GetRecords("example.com")
+	require.EqualError(t, err, "[status code: 401] Invalid API key or secret")
+}
+
+func TestClient_SaveRecords(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPut, "/dns/records/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		raw, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// the fields of the existing records are kept.
+		expected := `{"force":true,"items":[{"type":"A","name":"www","address":"127.0.0.1","ttl":3600},{"type":"TXT","name":"_acme-challenge","value":"txtTXTtxt","ttl":600}]}`
+		if string(raw) != expected {
+			http.Error(rw, fmt.Sprintf("invalid request: %s", string(raw)), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	var existing Record
+	err := json.Unmarshal([]byte(`{"type":"A","name":"www","address":"127.0.0.1","ttl":3600}`), &existing)
+	require.NoError(t, err)
+
+	records := []Record{existing, {Type: "TXT", Name: "_acme-challenge", Value: "txtTXTtxt", TTL: 600}}
+
+	err = client.SaveRecords("example.com", records)
+	require.NoError(t, err)
+}
+
+func TestClient_SaveRecords_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPut, "/dns/records/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprint(rw, `{"detail":"Validation error","data":[{"field":"items[0].ttl","details":"must be greater than or equal to 60"}]}`)
+	})
+	defer tearDown()
+
+	err := client.SaveRecords("example.com", []Record{{Type: "TXT", Name: "_acme-challenge", Value: "txtTXTtxt", TTL: 1}})
+	require.EqualError(t, err, "[status code: 422] Validation error, items[0].ttl: must be greater than or equal to 60")
+}
+
+func TestClient_DeleteRecords(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/dns/records/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		raw, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `[{"type":"TXT","name":"_acme-challenge","value":"txtTXTtxt"}]`
+		if string(raw) != expected {
+			http.Error(rw, fmt.Sprintf("invalid request: %s", string(raw)), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecords("example.com", []Record{{Type: "TXT", Name: "_acme-challenge", Value: "txtTXTtxt"}})
+	require.NoError(t, err)
+}
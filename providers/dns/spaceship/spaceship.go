@@ -0,0 +1,167 @@
+// Package spaceship implements a DNS provider for solving the DNS-01 challenge using Spaceship.
+package spaceship
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/spaceship/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	APIKey             string
+	APISecret          string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("SPACESHIP_TTL", 600),
+		PropagationTimeout: env.GetOrDefaultSecond("SPACESHIP_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("SPACESHIP_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("SPACESHIP_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	// the records of a domain are read and saved as a whole.
+	mu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Spaceship.
+// Credentials must be passed in the environment variables: SPACESHIP_API_KEY and SPACESHIP_API_SECRET.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("SPACESHIP_API_KEY", "SPACESHIP_API_SECRET")
+	if err != nil {
+		return nil, fmt.Errorf("spaceship: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values["SPACESHIP_API_KEY"]
+	config.APISecret = values["SPACESHIP_API_SECRET"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Spaceship.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("spaceship: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.APIKey, config.APISecret)
+	if err != nil {
+		return nil, fmt.Errorf("spaceship: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+// The saving of the records replaces the records of the domain:
+// the TXT record is added to the existing records.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("spaceship: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	records, err := d.client.GetRecords(zone)
+	if err != nil {
+		return fmt.Errorf("spaceship: failed to retrieve records: %v", err)
+	}
+
+	for _, record := range records {
+		if isChallengeRecord(record, subDomain, value) {
+			return nil
+		}
+	}
+
+	records = append(records, internal.Record{Type: "TXT", Name: subDomain, Value: value, TTL: d.config.TTL})
+
+	err = d.client.SaveRecords(zone, records)
+	if err != nil {
+		return fmt.Errorf("spaceship: failed to save records: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, subDomain, err := splitDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("spaceship: %v", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err = d.client.DeleteRecords(zone, []internal.Record{{Type: "TXT", Name: subDomain, Value: value}})
+	if err != nil {
+		return fmt.Errorf("spaceship: failed to delete record: %v", err)
+	}
+
+	return nil
+}
+
+func isChallengeRecord(record internal.Record, subDomain, value string) bool {
+	return strings.EqualFold(record.Type, "TXT") && strings.EqualFold(record.Name, subDomain) && record.Value == value
+}
+
+// splitDomain returns the zone and the sub-domain (relative to the zone, "@" for the apex) of a FQDN.
+func splitDomain(fqdn string) (string, string, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", err
+	}
+
+	zone := dns01.UnFqdn(authZone)
+	subDomain := strings.TrimSuffix(dns01.UnFqdn(fqdn), zone)
+	subDomain = strings.TrimSuffix(subDomain, ".")
+
+	if subDomain == "" {
+		subDomain = "@"
+	}
+
+	return zone, subDomain, nil
+}
@@ -0,0 +1,148 @@
+package spaceship
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vostronet/lego/platform/tester"
+)
+
+var envTest = tester.NewEnvTest("SPACESHIP_API_KEY", "SPACESHIP_API_SECRET").
+	WithDomain("SPACESHIP_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"SPACESHIP_API_KEY":    "key",
+				"SPACESHIP_API_SECRET": "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"SPACESHIP_API_KEY":    "",
+				"SPACESHIP_API_SECRET": "",
+			},
+			expected: "spaceship: some credentials information are missing: SPACESHIP_API_KEY,SPACESHIP_API_SECRET",
+		},
+		{
+			desc: "missing API key",
+			envVars: map[string]string{
+				"SPACESHIP_API_KEY":    "",
+				"SPACESHIP_API_SECRET": "secret",
+			},
+			expected: "spaceship: some credentials information are missing: SPACESHIP_API_KEY",
+		},
+		{
+			desc: "missing API secret",
+			envVars: map[string]string{
+				"SPACESHIP_API_KEY":    "key",
+				"SPACESHIP_API_SECRET": "",
+			},
+			expected: "spaceship: some credentials information are missing: SPACESHIP_API_SECRET",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		apiKey    string
+		apiSecret string
+		expected  string
+	}{
+		{
+			desc:      "success",
+			apiKey:    "key",
+			apiSecret: "secret",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "spaceship: credentials missing",
+		},
+		{
+			desc:      "missing API key",
+			apiSecret: "secret",
+			expected:  "spaceship: credentials missing",
+		},
+		{
+			desc:     "missing API secret",
+			apiKey:   "key",
+			expected: "spaceship: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = test.apiKey
+			config.APISecret = test.apiSecret
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
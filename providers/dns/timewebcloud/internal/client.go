@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const defaultBaseURL = "https://api.timeweb.cloud/api/v1"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int    `json:"status_code"`
+	ErrorCode  string `json:"error_code"`
+	Message    string `json:"message"`
+	ResponseID string `json:"response_id"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("[status code: %d] %s: %s (response ID: %s)", a.StatusCode, a.ErrorCode, a.Message, a.ResponseID)
+}
+
+// DNSRecord a DNS record of a domain.
+type DNSRecord struct {
+	ID        int    `json:"id,omitempty"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	SubDomain string `json:"subdomain,omitempty"`
+	TTL       int    `json:"ttl,omitempty"`
+}
+
+type dnsRecordResponse struct {
+	DNSRecord *DNSRecord `json:"dns_record"`
+}
+
+// Client the Timeweb Cloud API client.
+type Client struct {
+	token      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(token string) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		token:      token,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// CreateRecord creates a DNS record of a domain and returns the created record.
+// The sub-domain of the record is relative to the domain (e.g. "_acme-challenge").
+// https://timeweb.cloud/api-docs#tag/Domeny/operation/createDomainDNSRecord
+func (c *Client) CreateRecord(domain string, record DNSRecord) (*DNSRecord, error) {
+	result := &dnsRecordResponse{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/domains/%s/dns-records", domain), record, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.DNSRecord == nil {
+		return nil, errors.New("the created record is missing in the response")
+	}
+
+	return result.DNSRecord, nil
+}
+
+// DeleteRecord deletes a DNS record of a domain.
+// https://timeweb.cloud/api-docs#tag/Domeny/operation/deleteDomainDNSRecord
+func (c *Client) DeleteRecord(domain string, recordID int) error {
+	return c.do(http.MethodDelete, fmt.Sprintf("/domains/%s/dns-records/%d", domain, recordID), nil, nil)
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint := strings.TrimSuffix(c.BaseURL, "/") + uri
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || apiErr.ErrorCode == "" {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		apiErr.StatusCode = resp.StatusCode
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, method, pattern string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"status_code":401,"error_code":"unauthorized","message":"Unauthorized","response_id":"15095f25-aac3-4d60-a788-96cb5136f186"}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/domains/example.com/dns-records", func(rw http.ResponseWriter, req *http.Request) {
+		record := DNSRecord{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := DNSRecord{Type: "TXT", Value: "txtTXTtxt", SubDomain: "_acme-challenge", TTL: 600}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `{"dns_record":{"type":"TXT","id":123,"data":{"subdomain":"_acme-challenge","value":"txtTXTtxt"}}}`)
+	})
+	defer tearDown()
+
+	record := DNSRecord{Type: "TXT", Value: "txtTXTtxt", SubDomain: "_acme-challenge", TTL: 600}
+
+	newRecord, err := client.CreateRecord("example.com", record)
+	require.NoError(t, err)
+
+	assert.Equal(t, 123, newRecord.ID)
+}
+
+func TestClient_CreateRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/domains/example.com/dns-records", nil)
+	defer tearDown()
+
+	client.token = "invalid"
+
+	_, err := client.CreateRecord("example.com", DNSRecord{Type: "TXT", Value: "txtTXTtxt"})
+	require.EqualError(t, err, "[status code: 401] unauthorized: Unauthorized (response ID: 15095f25-aac3-4d60-a788-96cb5136f186)")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/domains/example.com/dns-records/123", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", 123)
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/domains/example.com/dns-records/123", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(rw, `{"status_code":404,"error_code":"not_found","message":"DNS record not found","response_id":"d5a2b1c0-5c2b-4a3c-9c8e-2a3b4c5d6e7f"}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", 123)
+	require.EqualError(t, err, "[status code: 404] not_found: DNS record not found (response ID: d5a2b1c0-5c2b-4a3c-9c8e-2a3b4c5d6e7f)")
+}
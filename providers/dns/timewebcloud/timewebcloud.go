@@ -0,0 +1,146 @@
+// Package timewebcloud implements a DNS provider for solving the DNS-01 challenge using Timeweb Cloud.
+package timewebcloud
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/timewebcloud/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	AccessToken        string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("TIMEWEB_TTL", 600),
+		PropagationTimeout: env.GetOrDefaultSecond("TIMEWEB_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("TIMEWEB_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("TIMEWEB_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+type recordInfo struct {
+	zone     string
+	recordID int
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]recordInfo
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Timeweb Cloud.
+// Credentials must be passed in the environment variable: TIMEWEB_ACCESS_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("TIMEWEB_ACCESS_TOKEN")
+	if err != nil {
+		return nil, fmt.Errorf("timewebcloud: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AccessToken = values["TIMEWEB_ACCESS_TOKEN"]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Timeweb Cloud.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("timewebcloud: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("timewebcloud: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]recordInfo{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("timewebcloud: could not determine the zone: %v", err)
+	}
+
+	zone := dns01.UnFqdn(authZone)
+
+	record := internal.DNSRecord{
+		Type:      "TXT",
+		Value:     value,
+		SubDomain: strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone),
+		TTL:       d.config.TTL,
+	}
+
+	newRecord, err := d.client.CreateRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("timewebcloud: failed to create TXT record [zone: %q, fqdn: %q]: %v", zone, fqdn, err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordInfo{zone: zone, recordID: newRecord.ID}
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	info, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("timewebcloud: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(info.zone, info.recordID)
+	if err != nil {
+		return fmt.Errorf("timewebcloud: failed to delete TXT record [zone: %s, id: %d]: %v", info.zone, info.recordID, err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultBaseURL = "https://open.volcengineapi.com"
+	defaultRegion  = "cn-north-1"
+	serviceName    = "DNS"
+	apiVersion     = "2018-08-01"
+)
+
+// ResponseMetadata contains the information about the request.
+type ResponseMetadata struct {
+	RequestID string    `json:"RequestId"`
+	Action    string    `json:"Action"`
+	Error     *APIError `json:"Error,omitempty"`
+}
+
+// APIError is the error returned by the API.
+type APIError struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("%s: %s", a.Code, a.Message)
+}
+
+// Zone a DNS zone.
+type Zone struct {
+	ZID      int64  `json:"ZID"`
+	ZoneName string `json:"ZoneName"`
+}
+
+// ListZonesRequest the parameters of the ListZones action.
+type ListZonesRequest struct {
+	Key        string `json:"Key,omitempty"`
+	SearchMode string `json:"SearchMode,omitempty"`
+	PageNumber int    `json:"PageNumber,omitempty"`
+	PageSize   int    `json:"PageSize,omitempty"`
+}
+
+// ListZonesResult the result of the ListZones action.
+type ListZonesResult struct {
+	Zones []Zone `json:"Zones"`
+	Total int    `json:"Total"`
+}
+
+// CreateRecordRequest the parameters of the CreateRecord action.
+type CreateRecordRequest struct {
+	ZID    int64  `json:"ZID"`
+	Host   string `json:"Host"`
+	Type   string `json:"Type"`
+	Value  string `json:"Value"`
+	TTL    int    `json:"TTL,omitempty"`
+	Remark string `json:"Remark,omitempty"`
+}
+
+// CreateRecordResult the result of the CreateRecord action.
+type CreateRecordResult struct {
+	RecordID string `json:"RecordID"`
+}
+
+// DeleteRecordRequest the parameters of the DeleteRecord action.
+type DeleteRecordRequest struct {
+	RecordID string `json:"RecordID"`
+}
+
+type apiResponse struct {
+	ResponseMetadata ResponseMetadata `json:"ResponseMetadata"`
+	Result           json.RawMessage  `json:"Result"`
+}
+
+// Client the Volcengine DNS API client.
+type Client struct {
+	signer     signer
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates a new Client.
+func NewClient(accessKey, secretKey string) (*Client, error) {
+	if accessKey == "" || secretKey == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		signer: signer{
+			accessKey: accessKey,
+			secretKey: secretKey,
+			region:    defaultRegion,
+			service:   serviceName,
+		},
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+	}, nil
+}
+
+// SetRegion sets the region used to sign the requests.
+func (c *Client) SetRegion(region string) {
+	c.signer.region = region
+}
+
+// ListZones lists the zones matching the request.
+func (c *Client) ListZones(request ListZonesRequest) (*ListZonesResult, error) {
+	result := &ListZonesResult{}
+	err := c.do("ListZones", request, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateRecord creates a DNS record and returns its ID.
+func (c *Client) CreateRecord(request CreateRecordRequest) (string, error) {
+	result := &CreateRecordResult{}
+	err := c.do("CreateRecord", request, result)
+	if err != nil {
+		return "", err
+	}
+
+	return result.RecordID, nil
+}
+
+// DeleteRecord deletes a DNS record.
+func (c *Client) DeleteRecord(recordID string) error {
+	return c.do("DeleteRecord", DeleteRecordRequest{RecordID: recordID}, nil)
+}
+
+func (c *Client) do(action string, payload, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	query := endpoint.Query()
+	query.Set("Action", action)
+	query.Set("Version", apiVersion)
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	c.signer.sign(req, body, time.Now())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: unable to read response body: %v", action, err)
+	}
+
+	apiResp := &apiResponse{}
+	err = json.Unmarshal(raw, apiResp)
+	if err != nil {
+		return fmt.Errorf("%s: unable to unmarshal response: [status code: %d] %s", action, resp.StatusCode, string(raw))
+	}
+
+	if apiResp.ResponseMetadata.Error != nil {
+		return fmt.Errorf("%s: %v", action, apiResp.ResponseMetadata.Error)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status code: %d: %s", action, resp.StatusCode, string(raw))
+	}
+
+	if result == nil || len(apiResp.Result) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(apiResp.Result, result)
+}
@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, action string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.URL.Query().Get("Action") != action || req.URL.Query().Get("Version") != apiVersion {
+			http.Error(rw, fmt.Sprintf("invalid query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		auth := req.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "HMAC-SHA256 Credential=user/") || req.Header.Get("X-Date") == "" {
+			http.Error(rw, fmt.Sprintf("invalid authorization: %s", auth), http.StatusUnauthorized)
+			return
+		}
+
+		handler(rw, req)
+	}))
+
+	client, err := NewClient("user", "secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+
+	return client, server.Close
+}
+
+func TestClient_ListZones(t *testing.T) {
+	client, tearDown := setupTest(t, "ListZones", func(rw http.ResponseWriter, req *http.Request) {
+		request := ListZonesRequest{}
+		err := json.NewDecoder(req.Body).Decode(&request)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if request.Key != "example.com" {
+			http.Error(rw, fmt.Sprintf("invalid key: %s", request.Key), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"ResponseMetadata":{"RequestId":"abc","Action":"ListZones"},"Result":{"Zones":[{"ZID":123,"ZoneName":"example.com"}],"Total":1}}`)
+	})
+	defer tearDown()
+
+	result, err := client.ListZones(ListZonesRequest{Key: "example.com", SearchMode: "exact"})
+	require.NoError(t, err)
+
+	expected := &ListZonesResult{
+		Zones: []Zone{{ZID: 123, ZoneName: "example.com"}},
+		Total: 1,
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, tearDown := setupTest(t, "CreateRecord", func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"ZID":123,"Host":"_acme-challenge","Type":"TXT","Value":"txtTXTtxt","TTL":600}`
+		if string(body) != expected {
+			http.Error(rw, fmt.Sprintf("invalid body: %s", string(body)), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"ResponseMetadata":{"RequestId":"abc","Action":"CreateRecord"},"Result":{"RecordID":"456"}}`)
+	})
+	defer tearDown()
+
+	recordID, err := client.CreateRecord(CreateRecordRequest{
+		ZID:   123,
+		Host:  "_acme-challenge",
+		Type:  "TXT",
+		Value: "txtTXTtxt",
+		TTL:   600,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "456", recordID)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, "DeleteRecord", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"ResponseMetadata":{"RequestId":"abc","Action":"DeleteRecord"}}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("456")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, "DeleteRecord", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(rw, `{"ResponseMetadata":{"RequestId":"abc","Action":"DeleteRecord","Error":{"Code":"RecordNotFound","Message":"record not found"}}}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("456")
+	require.EqualError(t, err, "DeleteRecord: RecordNotFound: record not found")
+}
+
+func Test_signer_sign(t *testing.T) {
+	s := signer{accessKey: "AK", secretKey: "SK", region: "cn-north-1", service: "DNS"}
+
+	req, err := http.NewRequest(http.MethodPost, "https://open.volcengineapi.com/?Action=ListZones&Version=2018-08-01", nil)
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+
+	s.sign(req, []byte("{}"), time.Date(2021, time.January, 2, 3, 4, 5, 0, time.UTC))
+
+	assert.Equal(t, "20210102T030405Z", req.Header.Get("X-Date"))
+	assert.Equal(t, "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", req.Header.Get("X-Content-Sha256"))
+
+	auth := req.Header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(auth, "HMAC-SHA256 Credential=AK/20210102/cn-north-1/DNS/request, SignedHeaders=content-type;host;x-content-sha256;x-date, Signature="), auth)
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm = "HMAC-SHA256"
+	signingRequest   = "request"
+	timeFormat       = "20060102T150405Z"
+	dateFormat       = "20060102"
+)
+
+// signer signs the requests with the Volcengine V4 signature (AK/SK).
+// https://www.volcengine.com/docs/6369/67269
+type signer struct {
+	accessKey string
+	secretKey string
+	region    string
+	service   string
+}
+
+// sign adds the authentication headers to the request.
+func (s signer) sign(req *http.Request, body []byte, now time.Time) {
+	xDate := now.UTC().Format(timeFormat)
+	shortDate := now.UTC().Format(dateFormat)
+
+	payloadHash := hashSHA256(body)
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Date", xDate)
+	req.Header.Set("X-Content-Sha256", payloadHash)
+
+	signedHeaders, canonicalHeaders := canonicalizeHeaders(req)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath(req.URL),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{shortDate, s.region, s.service, signingRequest}, "/")
+
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		xDate,
+		scope,
+		hashSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte(s.secretKey), shortDate)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s.service)
+	key = hmacSHA256(key, signingRequest)
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.accessKey, scope, signedHeaders, signature))
+}
+
+func canonicalizeHeaders(req *http.Request) (signed string, canonical string) {
+	var names []string
+	values := make(map[string]string)
+
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower != "host" && lower != "content-type" && !strings.HasPrefix(lower, "x-") {
+			continue
+		}
+
+		names = append(names, lower)
+		values[lower] = strings.TrimSpace(req.Header.Get(name))
+	}
+
+	sort.Strings(names)
+
+	var lines []string
+	for _, name := range names {
+		lines = append(lines, name+":"+values[name])
+	}
+
+	return strings.Join(names, ";"), strings.Join(lines, "\n") + "\n"
+}
+
+func canonicalPath(u *url.URL) string {
+	if u.EscapedPath() == "" {
+		return "/"
+	}
+	return u.EscapedPath()
+}
+
+func canonicalQuery(query url.Values) string {
+	// url.Values.Encode sorts the keys but encodes the spaces as "+".
+	return strings.Replace(query.Encode(), "+", "%20", -1)
+}
+
+func hashSHA256(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,181 @@
+// Package volcengine implements a DNS provider for solving the DNS-01 challenge using Volcengine DNS.
+package volcengine
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+	"github.com/vostronet/lego/providers/dns/volcengine/internal"
+)
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	AccessKey          string
+	SecretKey          string
+	Region             string
+	BaseURL            string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt("VOLC_TTL", 600),
+		PropagationTimeout: env.GetOrDefaultSecond("VOLC_PROPAGATION_TIMEOUT", 240*time.Second),
+		PollingInterval:    env.GetOrDefaultSecond("VOLC_POLLING_INTERVAL", 10*time.Second),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("VOLC_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config      *Config
+	client      *internal.Client
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Volcengine DNS.
+// Credentials must be passed in the environment variables:
+// VOLC_ACCESSKEY and VOLC_SECRETKEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("VOLC_ACCESSKEY", "VOLC_SECRETKEY")
+	if err != nil {
+		return nil, fmt.Errorf("volcengine: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AccessKey = values["VOLC_ACCESSKEY"]
+	config.SecretKey = values["VOLC_SECRETKEY"]
+	config.Region = env.GetOrFile("VOLC_REGION")
+	config.BaseURL = env.GetOrFile("VOLC_HOST")
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Volcengine DNS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("volcengine: the configuration of the DNS provider is nil")
+	}
+
+	client, err := internal.NewClient(config.AccessKey, config.SecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("volcengine: %v", err)
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.Region != "" {
+		client.SetRegion(config.Region)
+	}
+
+	return &DNSProvider{config: config, client: client, recordIDs: map[string]string{}}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.getZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("volcengine: %v", err)
+	}
+
+	record := internal.CreateRecordRequest{
+		ZID:    zone.ZID,
+		Host:   extractRecordName(fqdn, zone.ZoneName),
+		Type:   "TXT",
+		Value:  value,
+		TTL:    d.config.TTL,
+		Remark: "lego",
+	}
+
+	recordID, err := d.client.CreateRecord(record)
+	if err != nil {
+		return fmt.Errorf("volcengine: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordID
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("volcengine: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(recordID)
+	if err != nil {
+		return fmt.Errorf("volcengine: %v", err)
+	}
+
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
+	return nil
+}
+
+func (d *DNSProvider) getZone(fqdn string) (internal.Zone, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return internal.Zone{}, err
+	}
+
+	zoneName := dns01.UnFqdn(authZone)
+
+	result, err := d.client.ListZones(internal.ListZonesRequest{Key: zoneName, SearchMode: "exact"})
+	if err != nil {
+		return internal.Zone{}, err
+	}
+
+	for _, zone := range result.Zones {
+		if strings.EqualFold(dns01.UnFqdn(zone.ZoneName), zoneName) {
+			return zone, nil
+		}
+	}
+
+	return internal.Zone{}, fmt.Errorf("zone %s not found in Volcengine DNS for domain %s", zoneName, fqdn)
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+dns01.UnFqdn(zone)); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,185 @@
+package volcengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(
+	"VOLC_ACCESSKEY",
+	"VOLC_SECRETKEY",
+	"VOLC_REGION",
+	"VOLC_HOST").
+	WithDomain("VOLC_DOMAIN")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"VOLC_ACCESSKEY": "123",
+				"VOLC_SECRETKEY": "456",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				"VOLC_ACCESSKEY": "",
+				"VOLC_SECRETKEY": "",
+			},
+			expected: "volcengine: some credentials information are missing: VOLC_ACCESSKEY,VOLC_SECRETKEY",
+		},
+		{
+			desc: "missing access key",
+			envVars: map[string]string{
+				"VOLC_ACCESSKEY": "",
+				"VOLC_SECRETKEY": "456",
+			},
+			expected: "volcengine: some credentials information are missing: VOLC_ACCESSKEY",
+		},
+		{
+			desc: "missing secret key",
+			envVars: map[string]string{
+				"VOLC_ACCESSKEY": "123",
+				"VOLC_SECRETKEY": "",
+			},
+			expected: "volcengine: some credentials information are missing: VOLC_SECRETKEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		accessKey string
+		secretKey string
+		expected  string
+	}{
+		{
+			desc:      "success",
+			accessKey: "123",
+			secretKey: "456",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "volcengine: credentials missing",
+		},
+		{
+			desc:      "missing access key",
+			secretKey: "456",
+			expected:  "volcengine: credentials missing",
+		},
+		{
+			desc:      "missing secret key",
+			accessKey: "123",
+			expected:  "volcengine: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.AccessKey = test.accessKey
+			config.SecretKey = test.secretKey
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+				require.NotNil(t, p.recordIDs)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func Test_extractRecordName(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		fqdn     string
+		zone     string
+		expected string
+	}{
+		{
+			desc:     "root",
+			fqdn:     "_acme-challenge.example.com.",
+			zone:     "example.com",
+			expected: "_acme-challenge",
+		},
+		{
+			desc:     "sub-domain",
+			fqdn:     "_acme-challenge.foo.example.com.",
+			zone:     "example.com.",
+			expected: "_acme-challenge.foo",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, extractRecordName(test.fqdn, test.zone))
+		})
+	}
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
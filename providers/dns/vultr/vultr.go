@@ -80,7 +80,7 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 // Present creates a TXT record to fulfill the DNS-01 challenge.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
-	fqdn, value := dns01.GetRecord(domain, keyAuth)
+	fqdn, value := dns01.GetRecordForProvider(d, domain, keyAuth)
 
 	zoneDomain, err := d.getHostedZone(domain)
 	if err != nil {
@@ -89,7 +89,7 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 
 	name := d.extractRecordName(fqdn, zoneDomain)
 
-	err = d.client.CreateDNSRecord(zoneDomain, name, "TXT", `"`+value+`"`, 0, d.config.TTL)
+	err = d.client.CreateDNSRecord(zoneDomain, name, "TXT", value, 0, d.config.TTL)
 	if err != nil {
 		return fmt.Errorf("vultr: API call failed: %v", err)
 	}
@@ -127,6 +127,11 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// TransformRecordValue the API expects the value of the TXT record to be quoted.
+func (d *DNSProvider) TransformRecordValue(value string) string {
+	return dns01.QuoteRecordValue(value)
+}
+
 func (d *DNSProvider) getHostedZone(domain string) (string, error) {
 	domains, err := d.client.GetDNSDomains()
 	if err != nil {
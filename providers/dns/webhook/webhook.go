@@ -0,0 +1,273 @@
+// Package webhook implements a DNS provider for solving the DNS-01 challenge through HTTP requests built from templates.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/vostronet/lego/challenge/dns01"
+	"github.com/vostronet/lego/platform/config/env"
+)
+
+// RequestSpec the templates of a HTTP request.
+// The URL, the values of the headers and the body are Go templates (see templateData).
+type RequestSpec struct {
+	// Method the HTTP method (default: POST).
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// Spec the requests sent to create and to remove the TXT record.
+type Spec struct {
+	Present RequestSpec `json:"present"`
+	CleanUp RequestSpec `json:"cleanup"`
+}
+
+// templateData the data of the templates.
+type templateData struct {
+	Domain  string
+	Token   string
+	KeyAuth string
+	// Fqdn the FQDN of the TXT record (with a trailing dot).
+	Fqdn string
+	// Value the value of the TXT record.
+	Value string
+}
+
+// Config is used to configure the creation of the DNSProvider
+type Config struct {
+	Spec               *Spec
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider
+func NewDefaultConfig() *Config {
+	return &Config{
+		PropagationTimeout: env.GetOrDefaultSecond("WEBHOOK_PROPAGATION_TIMEOUT", dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond("WEBHOOK_POLLING_INTERVAL", dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: env.GetOrDefaultSecond("WEBHOOK_HTTP_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	config  *Config
+	present *requestTemplate
+	cleanUp *requestTemplate
+}
+
+// NewDNSProvider returns a DNSProvider instance.
+// The spec of the requests (JSON) must be passed in the environment variable WEBHOOK_SPEC (or in the file defined by WEBHOOK_SPEC_FILE).
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get("WEBHOOK_SPEC")
+	if err != nil {
+		return nil, fmt.Errorf("webhook: %v", err)
+	}
+
+	spec := &Spec{}
+	err = json.Unmarshal([]byte(values["WEBHOOK_SPEC"]), spec)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: invalid spec: %v", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Spec = spec
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured with the spec of the requests.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("webhook: the configuration of the DNS provider is nil")
+	}
+
+	if config.Spec == nil {
+		return nil, errors.New("webhook: the spec is missing")
+	}
+
+	present, err := newRequestTemplate("present", config.Spec.Present)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: %v", err)
+	}
+
+	cleanUp, err := newRequestTemplate("cleanup", config.Spec.CleanUp)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: %v", err)
+	}
+
+	return &DNSProvider{config: config, present: present, cleanUp: cleanUp}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	err := d.do(d.present, newTemplateData(domain, token, keyAuth))
+	if err != nil {
+		return fmt.Errorf("webhook: present: %v", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	err := d.do(d.cleanUp, newTemplateData(domain, token, keyAuth))
+	if err != nil {
+		return fmt.Errorf("webhook: cleanup: %v", err)
+	}
+
+	return nil
+}
+
+func (d *DNSProvider) do(tmpl *requestTemplate, data templateData) error {
+	req, err := tmpl.newRequest(data)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("%d: failed to read response body: %v", resp.StatusCode, err)
+		}
+
+		return fmt.Errorf("%d: request failed: %v", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func newTemplateData(domain, token, keyAuth string) templateData {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	return templateData{
+		Domain:  domain,
+		Token:   token,
+		KeyAuth: keyAuth,
+		Fqdn:    fqdn,
+		Value:   value,
+	}
+}
+
+// requestTemplate the parsed templates of a request.
+type requestTemplate struct {
+	method  string
+	url     *template.Template
+	headers map[string]*template.Template
+	body    *template.Template
+}
+
+// templateFuncs the functions available in the templates.
+var templateFuncs = template.FuncMap{
+	// json encodes a value in JSON (e.g. a quoted and escaped string).
+	"json": func(v interface{}) (string, error) {
+		raw, err := json.Marshal(v)
+		return string(raw), err
+	},
+	// unFqdn removes the trailing dot of a FQDN.
+	"unFqdn": dns01.UnFqdn,
+}
+
+func newRequestTemplate(name string, spec RequestSpec) (*requestTemplate, error) {
+	if spec.URL == "" {
+		return nil, fmt.Errorf("%s: the URL is missing", name)
+	}
+
+	method := strings.ToUpper(spec.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	urlTmpl, err := template.New(name + " URL").Funcs(templateFuncs).Parse(spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid URL template: %v", name, err)
+	}
+
+	headers := map[string]*template.Template{}
+	for key, value := range spec.Headers {
+		headers[key], err = template.New(name + " header " + key).Funcs(templateFuncs).Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid template of the header %s: %v", name, key, err)
+		}
+	}
+
+	var bodyTmpl *template.Template
+	if spec.Body != "" {
+		bodyTmpl, err = template.New(name + " body").Funcs(templateFuncs).Parse(spec.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid body template: %v", name, err)
+		}
+	}
+
+	return &requestTemplate{method: method, url: urlTmpl, headers: headers, body: bodyTmpl}, nil
+}
+
+func (r *requestTemplate) newRequest(data templateData) (*http.Request, error) {
+	endpoint, err := execute(r.url, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if r.body != nil {
+		raw, errB := execute(r.body, data)
+		if errB != nil {
+			return nil, errB
+		}
+
+		body = strings.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(r.method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, tmpl := range r.headers {
+		value, errH := execute(tmpl, data)
+		if errH != nil {
+			return nil, errH
+		}
+
+		req.Header.Set(key, value)
+	}
+
+	return req, nil
+}
+
+func execute(tmpl *template.Template, data templateData) (string, error) {
+	buf := &bytes.Buffer{}
+
+	err := tmpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vostronet/lego/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest("WEBHOOK_SPEC", "WEBHOOK_SPEC_FILE")
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				"WEBHOOK_SPEC": `{"present":{"url":"http://localhost:8090/{{.Fqdn}}"},"cleanup":{"method":"DELETE","url":"http://localhost:8090/{{.Fqdn}}"}}`,
+			},
+		},
+		{
+			desc: "invalid JSON",
+			envVars: map[string]string{
+				"WEBHOOK_SPEC": `{"present":`,
+			},
+			expected: "webhook: invalid spec: unexpected end of JSON input",
+		},
+		{
+			desc: "missing cleanup URL",
+			envVars: map[string]string{
+				"WEBHOOK_SPEC": `{"present":{"url":"http://localhost:8090/{{.Fqdn}}"}}`,
+			},
+			expected: "webhook: cleanup: the URL is missing",
+		},
+		{
+			desc: "missing spec",
+			envVars: map[string]string{
+				"WEBHOOK_SPEC": "",
+			},
+			expected: "webhook: some credentials information are missing: WEBHOOK_SPEC",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		spec     *Spec
+		expected string
+	}{
+		{
+			desc: "success",
+			spec: &Spec{
+				Present: RequestSpec{URL: "http://localhost:8090/{{.Fqdn}}"},
+				CleanUp: RequestSpec{URL: "http://localhost:8090/{{.Fqdn}}"},
+			},
+		},
+		{
+			desc:     "missing spec",
+			expected: "webhook: the spec is missing",
+		},
+		{
+			desc: "invalid template",
+			spec: &Spec{
+				Present: RequestSpec{URL: "http://localhost:8090/{{.Fqdn}}", Body: "{{.Value"},
+				CleanUp: RequestSpec{URL: "http://localhost:8090/{{.Fqdn}}"},
+			},
+			expected: `webhook: present: invalid body template: template: present body:1: unclosed action`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Spec = test.spec
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/zones/example.com/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(rw, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := `{"name":"_acme-challenge.example.com","type":"TXT","content":"LHDhK3oGRvkiefQnx7OOczTY5Tic_xZ6HcMOc_gmtoM"}`
+		if string(body) != expected {
+			http.Error(rw, fmt.Sprintf("invalid body: %s", body), http.StatusBadRequest)
+			return
+		}
+	})
+
+	config := NewDefaultConfig()
+	config.Spec = &Spec{
+		Present: RequestSpec{
+			Method:  "put",
+			URL:     server.URL + "/zones/{{.Domain}}/records",
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+			Body:    `{"name":{{unFqdn .Fqdn | json}},"type":"TXT","content":{{json .Value}}}`,
+		},
+		CleanUp: RequestSpec{URL: server.URL + "/unused"},
+	}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.Present("example.com", "token", "key")
+	require.NoError(t, err)
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/records/_acme-challenge.example.com.", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(rw, "record not found")
+	})
+
+	config := NewDefaultConfig()
+	config.Spec = &Spec{
+		Present: RequestSpec{URL: server.URL + "/unused"},
+		CleanUp: RequestSpec{Method: http.MethodDelete, URL: server.URL + "/records/{{.Fqdn}}"},
+	}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = p.CleanUp("example.com", "token", "key")
+	assert.EqualError(t, err, "webhook: cleanup: 404: request failed: record not found")
+}
@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://rest.websupport.sk"
+
+// StatusSuccess the status of the successful responses.
+const StatusSuccess = "success"
+
+// APIError the error returned by the API.
+type APIError struct {
+	StatusCode int                 `json:"-"`
+	Code       int                 `json:"code"`
+	Message    string              `json:"message"`
+	Status     string              `json:"status"`
+	Errors     map[string][]string `json:"errors"`
+}
+
+func (a APIError) Error() string {
+	if a.Message != "" {
+		return fmt.Sprintf("[status code: %d] %s", a.StatusCode, a.Message)
+	}
+
+	return fmt.Sprintf("[status code: %d] %s: %s", a.StatusCode, a.Status, formatErrors(a.Errors))
+}
+
+// formatErrors formats the validation errors (by field) in a stable order.
+func formatErrors(errs map[string][]string) string {
+	var fields []string
+	for field, messages := range errs {
+		fields = append(fields, fmt.Sprintf("%s: %s", field, strings.Join(messages, ", ")))
+	}
+
+	sort.Strings(fields)
+
+	return strings.Join(fields, "; ")
+}
+
+// Record a DNS record.
+type Record struct {
+	ID int `json:"id,omitempty"`
+	// Name the name of the record relative to the zone (e.g. "_acme-challenge", "_acme-challenge.sub").
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl,omitempty"`
+}
+
+// Response the response of the record operations.
+type Response struct {
+	Status string              `json:"status"`
+	Item   *Record             `json:"item"`
+	Errors map[string][]string `json:"errors"`
+}
+
+// Client the Websupport REST API client.
+type Client struct {
+	apiKey     string
+	secret     string
+	BaseURL    string
+	HTTPClient *http.Client
+	// now the time of the signatures of the requests.
+	now func() time.Time
+}
+
+// NewClient creates a new Client.
+func NewClient(apiKey, secret string) (*Client, error) {
+	if apiKey == "" || secret == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &Client{
+		apiKey:     apiKey,
+		secret:     secret,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{},
+		now:        time.Now,
+	}, nil
+}
+
+// AddRecord adds a record to a zone.
+// https://rest.websupport.sk/docs/v1.zone#post-record
+func (c *Client) AddRecord(domain string, record Record) (*Record, error) {
+	result := &Response{}
+	err := c.do(http.MethodPost, fmt.Sprintf("/v1/user/self/zone/%s/record", url.PathEscape(domain)), record, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Status != StatusSuccess || result.Item == nil {
+		return nil, fmt.Errorf("unexpected status: %s: %s", result.Status, formatErrors(result.Errors))
+	}
+
+	return result.Item, nil
+}
+
+// DeleteRecord deletes a record of a zone.
+// https://rest.websupport.sk/docs/v1.zone#delete-record
+func (c *Client) DeleteRecord(domain string, recordID int) error {
+	result := &Response{}
+	err := c.do(http.MethodDelete, fmt.Sprintf("/v1/user/self/zone/%s/record/%d", url.PathEscape(domain), recordID), nil, result)
+	if err != nil {
+		return err
+	}
+
+	if result.Status != StatusSuccess {
+		return fmt.Errorf("unexpected status: %s: %s", result.Status, formatErrors(result.Errors))
+	}
+
+	return nil
+}
+
+func (c *Client) do(method, uri string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(c.BaseURL, "/") + uri)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	c.sign(req, endpoint.EscapedPath(), c.now())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %v", err)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if errU := json.Unmarshal(raw, apiErr); errU != nil || (apiErr.Message == "" && len(apiErr.Errors) == 0) {
+			return fmt.Errorf("unexpected status code: [status code: %d] %s", resp.StatusCode, string(raw))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, result)
+}
+
+// sign adds the authentication headers:
+// the signature is the HMAC-SHA1 (hex) of the method, the path and the timestamp, separated by a space,
+// and is sent with the API key as basic authentication.
+// The timestamp is sent in the Date header (ISO 8601 basic format).
+// https://rest.websupport.sk/docs/index#auth
+func (c *Client) sign(req *http.Request, path string, now time.Time) {
+	req.Header.Set("Date", now.UTC().Format("20060102T150405Z"))
+	req.SetBasicAuth(c.apiKey, signature(c.secret, req.Method, path, now.Unix()))
+}
+
+func signature(secret, method, path string, timestamp int64) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	_, _ = mac.Write([]byte(fmt.Sprintf("%s %s %d", method, path, timestamp)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedTime the time of the signatures in the tests (2019-08-02T12:05:34Z).
+var fixedTime = time.Unix(1564747534, 0)
+
+func setupTest(t *testing.T, method, pattern, expectedSignature string, handler http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Date") != "20190802T120534Z" {
+			http.Error(rw, fmt.Sprintf("invalid date: %s", req.Header.Get("Date")), http.StatusBadRequest)
+			return
+		}
+
+		user, password, ok := req.BasicAuth()
+		if !ok || user != "key" || password != expectedSignature {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"code":401,"message":"Bad credentials"}`)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client, err := NewClient("key", "secret")
+	require.NoError(t, err)
+
+	client.BaseURL = server.URL
+	client.now = func() time.Time { return fixedTime }
+
+	return client, server.Close
+}
+
+func Test_signature(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		method   string
+		path     string
+		expected string
+	}{
+		{
+			desc:     "POST",
+			method:   http.MethodPost,
+			path:     "/v1/user/self/zone/example.com/record",
+			expected: "ec57b25624b4bdfd97de4e72ae204e19dd539ba5",
+		},
+		{
+			desc:     "DELETE",
+			method:   http.MethodDelete,
+			path:     "/v1/user/self/zone/example.com/record/123",
+			expected: "5faa6e285985e3dc2621ea3ee9599c3ad611206e",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, signature("secret", test.method, test.path, fixedTime.Unix()))
+		})
+	}
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/v1/user/self/zone/example.com/record", "ec57b25624b4bdfd97de4e72ae204e19dd539ba5", func(rw http.ResponseWriter, req *http.Request) {
+		record := Record{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Name: "_acme-challenge", Type: "TXT", Content: "txtTXTtxt", TTL: 600}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("invalid record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `{"status":"success","item":{"id":123,"type":"TXT","name":"_acme-challenge","content":"txtTXTtxt","ttl":600,"prio":null,"weight":null,"port":null,"zone":{"id":1,"name":"example.com","updateTime":1564747534}},"errors":{}}`)
+	})
+	defer tearDown()
+
+	record, err := client.AddRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Content: "txtTXTtxt", TTL: 600})
+	require.NoError(t, err)
+
+	expected := &Record{ID: 123, Name: "_acme-challenge", Type: "TXT", Content: "txtTXTtxt", TTL: 600}
+	assert.Equal(t, expected, record)
+}
+
+func TestClient_AddRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/v1/user/self/zone/example.com/record", "ec57b25624b4bdfd97de4e72ae204e19dd539ba5", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(rw, `{"status":"error","item":{"type":"TXT","name":"_acme-challenge","content":"","ttl":600},"errors":{"content":["Content is required"],"ttl":["TTL is too low"]}}`)
+	})
+	defer tearDown()
+
+	_, err := client.AddRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Content: "txtTXTtxt", TTL: 600})
+	require.EqualError(t, err, "[status code: 400] error: content: Content is required; ttl: TTL is too low")
+}
+
+func TestClient_AddRecord_unauthorized(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodPost, "/v1/user/self/zone/example.com/record", "ec57b25624b4bdfd97de4e72ae204e19dd539ba5", nil)
+	defer tearDown()
+
+	client.secret = "invalid"
+
+	_, err := client.AddRecord("example.com", Record{Name: "_acme-challenge", Type: "TXT", Content: "txtTXTtxt", TTL: 600})
+	require.EqualError(t, err, "[status code: 401] Bad credentials")
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/v1/user/self/zone/example.com/record/123", "5faa6e285985e3dc2621ea3ee9599c3ad611206e", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"status":"success","item":{"id":123,"type":"TXT","name":"_acme-challenge","content":"txtTXTtxt","ttl":600},"errors":{}}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", 123)
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, tearDown := setupTest(t, http.MethodDelete, "/v1/user/self/zone/example.com/record/123", "5faa6e285985e3dc2621ea3ee9599c3ad611206e", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(rw, `{"code":404,"message":"Record not found"}`)
+	})
+	defer tearDown()
+
+	err := client.DeleteRecord("example.com", 123)
+	require.EqualError(t, err, "[status code: 404] Record not found")
+}
@@ -2,7 +2,9 @@ package registration
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/vostronet/lego/acme"
 	"github.com/vostronet/lego/acme/api"
@@ -57,6 +59,10 @@ func (r *Registrar) Register(options RegisterOptions) (*Resource, error) {
 
 	account, err := r.core.Accounts.New(accMsg)
 	if err != nil {
+		if errA := checkSignatureAlgorithm(err); errA != nil {
+			return nil, errA
+		}
+
 		// FIXME seems impossible
 		errorDetails, ok := err.(acme.ProblemDetails)
 		if !ok || errorDetails.HTTPStatus != http.StatusConflict {
@@ -81,6 +87,10 @@ func (r *Registrar) RegisterWithExternalAccountBinding(options RegisterEABOption
 
 	account, err := r.core.Accounts.NewEAB(accMsg, options.Kid, options.HmacEncoded)
 	if err != nil {
+		if errA := checkSignatureAlgorithm(err); errA != nil {
+			return nil, errA
+		}
+
 		errorDetails, ok := err.(acme.ProblemDetails)
 		// FIXME seems impossible
 		if !ok || errorDetails.HTTPStatus != http.StatusConflict {
@@ -115,6 +125,36 @@ func (r *Registrar) QueryRegistration() (*Resource, error) {
 	}, nil
 }
 
+// UpdateRegistration updates the contacts of the account (e.g. to add an email address for the expiration notices).
+// The contacts are URLs (e.g. "mailto:foo@example.com"), an empty list removes all the contacts.
+func (r *Registrar) UpdateRegistration(contacts []string) (*Resource, error) {
+	if r == nil || r.user == nil {
+		return nil, errors.New("acme: cannot update the registration of a nil client or user")
+	}
+
+	reg := r.user.GetRegistration()
+	if reg == nil || reg.URI == "" {
+		return nil, errors.New("acme: cannot update the contacts of an unregistered account")
+	}
+
+	log.Infof("acme: Updating the contacts of the account %s", reg.URI)
+
+	account, err := r.core.Accounts.Update(reg.URI, contacts)
+	if err != nil {
+		if isContactRejected(err) {
+			return nil, fmt.Errorf("acme: the CA rejected the contacts [%s]: %v", strings.Join(contacts, ", "), err)
+		}
+
+		if _, ok := err.(*acme.ProblemDetails); ok {
+			return nil, fmt.Errorf("acme: the CA rejected the update of the contacts of the account: %v", err)
+		}
+
+		return nil, err
+	}
+
+	return &Resource{URI: reg.URI, Body: account}, nil
+}
+
 // DeleteRegistration deletes the client's user registration from the ACME server.
 func (r *Registrar) DeleteRegistration() error {
 	if r == nil || r.user == nil {
@@ -123,16 +163,22 @@ func (r *Registrar) DeleteRegistration() error {
 
 	log.Infof("acme: Deleting account for %s", r.user.GetEmail())
 
-	return r.core.Accounts.Deactivate(r.user.GetRegistration().URI)
+	err := r.core.Accounts.Deactivate(r.user.GetRegistration().URI)
+	if err != nil && isAccountDeactivated(err) {
+		log.Infof("acme: The account %s is already deactivated", r.user.GetRegistration().URI)
+		return nil
+	}
+
+	return err
 }
 
 // ResolveAccountByKey will attempt to look up an account using the given account key
 // and return its registration resource.
+// No account is created: if the account key is not registered, the error is an accountDoesNotExist error (see IsAccountNotFound).
 func (r *Registrar) ResolveAccountByKey() (*Resource, error) {
 	log.Infof("acme: Trying to resolve account by key")
 
-	accMsg := acme.Account{OnlyReturnExisting: true}
-	accountTransit, err := r.core.Accounts.New(accMsg)
+	accountTransit, err := r.core.Accounts.Lookup()
 	if err != nil {
 		return nil, err
 	}
@@ -144,3 +190,31 @@ func (r *Registrar) ResolveAccountByKey() (*Resource, error) {
 
 	return &Resource{URI: accountTransit.Location, Body: account}, nil
 }
+
+// IsAccountNotFound returns true if the CA has no account registered with the account key (see ResolveAccountByKey).
+func IsAccountNotFound(err error) bool {
+	problem, ok := err.(*acme.ProblemDetails)
+	return ok && problem.Type == acme.AccountDoesNotExistErr
+}
+
+// isContactRejected returns true if the CA rejected the contacts of an account (invalid or unsupported scheme).
+func isContactRejected(err error) bool {
+	problem, ok := err.(*acme.ProblemDetails)
+	return ok && (problem.Type == acme.InvalidContactErr || problem.Type == acme.UnsupportedContactErr)
+}
+
+// isAccountDeactivated returns true if the CA rejected a request because the account is deactivated.
+func isAccountDeactivated(err error) bool {
+	problem, ok := err.(*acme.ProblemDetails)
+	return ok && problem.Type == acme.UnauthorizedErr && strings.Contains(strings.ToLower(problem.Detail), acme.StatusDeactivated)
+}
+
+// checkSignatureAlgorithm returns a clear error if the CA rejected the signature algorithm of the account key.
+func checkSignatureAlgorithm(err error) error {
+	problem, ok := err.(*acme.ProblemDetails)
+	if !ok || problem.Type != acme.BadSignatureAlgorithmErr {
+		return nil
+	}
+
+	return fmt.Errorf("acme: the CA doesn't support the signature algorithm of the account key (e.g. Ed25519), use an RSA or ECDSA account key: %v", err)
+}
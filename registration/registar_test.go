@@ -1,8 +1,10 @@
 package registration
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -54,3 +56,182 @@ func TestRegistrar_ResolveAccountByKey(t *testing.T) {
 
 	assert.Equal(t, "valid", res.Body.Status, "Unexpected account status")
 }
+
+func TestRegistrar_ResolveAccountByKey_notFound(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/account", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"type":"urn:ietf:params:acme:error:accountDoesNotExist","detail":"No account exists with the provided key"}`))
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{},
+		privatekey: key,
+	}
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	_, err = registrar.ResolveAccountByKey()
+	require.Error(t, err)
+
+	assert.True(t, IsAccountNotFound(err))
+}
+
+func TestRegistrar_Register_badSignatureAlgorithm(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/account", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"type":"urn:ietf:params:acme:error:badSignatureAlgorithm","detail":"EdDSA is not supported"}`))
+	})
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{},
+		privatekey: key,
+	}
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	_, err = registrar.Register(RegisterOptions{TermsOfServiceAgreed: true})
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "the CA doesn't support the signature algorithm of the account key")
+}
+
+func TestRegistrar_DeleteRegistration_alreadyDeactivated(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"type":"urn:ietf:params:acme:error:unauthorized","detail":"Account is not valid, has status \"deactivated\""}`))
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{URI: apiURL + "/account/1"},
+		privatekey: key,
+	}
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	err = registrar.DeleteRegistration()
+	require.NoError(t, err)
+}
+
+func TestRegistrar_UpdateRegistration(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, _ *http.Request) {
+		err := tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusValid, Contact: []string{"mailto:foo@example.com"}})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{URI: apiURL + "/account/1"},
+		privatekey: key,
+	}
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	resource, err := registrar.UpdateRegistration([]string{"mailto:foo@example.com"})
+	require.NoError(t, err)
+
+	expected := &Resource{
+		URI:  apiURL + "/account/1",
+		Body: acme.Account{Status: acme.StatusValid, Contact: []string{"mailto:foo@example.com"}},
+	}
+	assert.Equal(t, expected, resource)
+}
+
+func TestRegistrar_UpdateRegistration_rejected(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		problem  string
+		expected string
+	}{
+		{
+			desc:     "unsupported contact",
+			problem:  `{"type":"urn:ietf:params:acme:error:unsupportedContact","status":400,"detail":"contact method \"tel\" is not supported"}`,
+			expected: `acme: the CA rejected the contacts [tel:+12025550123]: acme: error: 400 :: POST :: %s/account/1 :: urn:ietf:params:acme:error:unsupportedContact :: contact method "tel" is not supported, url: `,
+		},
+		{
+			desc:     "update not allowed",
+			problem:  `{"type":"urn:ietf:params:acme:error:malformed","status":400,"detail":"the contacts of the account cannot be changed"}`,
+			expected: `acme: the CA rejected the update of the contacts of the account: acme: error: 400 :: POST :: %s/account/1 :: urn:ietf:params:acme:error:malformed :: the contacts of the account cannot be changed, url: `,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			mux.HandleFunc("/account/1", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(test.problem))
+			})
+
+			key, err := rsa.GenerateKey(rand.Reader, 512)
+			require.NoError(t, err, "Could not generate test key")
+
+			user := mockUser{
+				email:      "test@test.com",
+				regres:     &Resource{URI: apiURL + "/account/1"},
+				privatekey: key,
+			}
+
+			core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", key)
+			require.NoError(t, err)
+
+			registrar := NewRegistrar(core, user)
+
+			_, err = registrar.UpdateRegistration([]string{"tel:+12025550123"})
+			require.EqualError(t, err, fmt.Sprintf(test.expected, apiURL))
+		})
+	}
+}
+
+func TestRegistrar_UpdateRegistration_notRegistered(t *testing.T) {
+	registrar := NewRegistrar(nil, mockUser{email: "test@test.com"})
+
+	_, err := registrar.UpdateRegistration([]string{"mailto:foo@example.com"})
+	require.EqualError(t, err, "acme: cannot update the contacts of an unregistered account")
+}
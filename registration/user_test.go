@@ -2,13 +2,12 @@ package registration
 
 import (
 	"crypto"
-	"crypto/rsa"
 )
 
 type mockUser struct {
 	email      string
 	regres     *Resource
-	privatekey *rsa.PrivateKey
+	privatekey crypto.PrivateKey
 }
 
 func (u mockUser) GetEmail() string                 { return u.email }